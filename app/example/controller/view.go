@@ -29,3 +29,10 @@ func (this *View) Index2(ctx *gin.Context) {
         "msg": "测试数据222",
     })
 }
+
+// 表单提交
+func (this *View) Submit(ctx *gin.Context) {
+    this.SuccessWithData(ctx, "example::view.submit-success", map[string]any{
+        "msg": ctx.PostForm("msg"),
+    })
+}
@@ -1,6 +1,9 @@
 package provider
 
 import (
+    "time"
+    "context"
+
     "github.com/gin-gonic/gin"
 
     "github.com/deatil/go-event/event"
@@ -61,6 +64,17 @@ func (this *ExampleServiceProvider) Schedule(s *schedule.Schedule) {
         // panic("errors")
     }).EveryMinute().WithName("cron111")
 
+    // 多台服务器只运行一次，上次未结束时跳过
+    s.AddContextFunc(func(ctx context.Context) error {
+        facade.Logger.Info("计划任务333运行中...")
+
+        return nil
+    }).EveryFiveMinutes().
+        WithName("cron333").
+        WithoutOverlapping().
+        OnOneServer().
+        WithTimeout(time.Minute)
+
     s.AddFunc(func() {
         facade.Logger.Info("计划任务222运行中...")
     }).EveryMinute().WithName("cron222")
@@ -114,6 +128,9 @@ func (this *ExampleServiceProvider) loadSetting() {
 
     // 视图
     this.LoadViewsFrom(toViewPath, "example")
+
+    // 语言包，可在 {i18n.path}/pkg/example 目录覆盖
+    this.LoadTranslationsFrom("{root}/app/example/resources/lang", "example")
 }
 
 /**
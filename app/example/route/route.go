@@ -3,6 +3,7 @@ package route
 import (
     "github.com/gin-gonic/gin"
 
+    "github.com/deatil/lakego-doak/lakego/router"
     "github.com/deatil/lakego-doak/lakego/facade"
 
     "app/example/controller"
@@ -24,10 +25,14 @@ func GinRoute(engine gin.IRouter) {
         })
     })
 
-    // 视图
+    // 视图，表单提交需验证 CSRF
     viewController := new(controller.View)
-    engine.GET("/example/view/index", viewController.Index)
-    engine.GET("/example/view/index2", viewController.Index2)
+    view := router.Group(engine, "/example/view", "csrf")
+    {
+        view.GET("/index", viewController.Index)
+        view.GET("/index2", viewController.Index2)
+        view.POST("/submit", viewController.Submit)
+    }
 
 }
 
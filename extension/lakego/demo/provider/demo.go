@@ -81,19 +81,18 @@ func (this *Demo) loadExtInfo() {
         },
         Version: "1.0.1",
         Adaptation: ">= 1.2.1",
+        // 权限规则，安装时添加，卸载时删除，启用禁用时同步状态
+        Rules: []map[string]any{
+            getRules(slug),
+        },
         Install: func() error {
             facade.Logger.Error("demo Install")
 
-            rules := getRules(slug)
-            extension.NewRule().Create(rules, "0")
-
             return nil
         },
         Uninstall: func() error {
             facade.Logger.Error("demo Uninstall")
 
-            extension.NewRule().Delete(slug)
-
             return nil
         },
         Upgrade: func() error {
@@ -104,15 +103,11 @@ func (this *Demo) loadExtInfo() {
         Enable: func() error {
             facade.Logger.Error("demo Enable")
 
-            extension.NewRule().Enable(slug)
-
             return nil
         },
         Disable: func() error {
             facade.Logger.Error("demo Disable")
 
-            extension.NewRule().Disable(slug)
-
             return nil
         },
         // 扩展启用后
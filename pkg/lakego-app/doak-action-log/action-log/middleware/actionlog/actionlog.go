@@ -1,29 +1,28 @@
 package actionlog
 
 import (
+    "context"
     "strconv"
     "encoding/json"
 
     "github.com/deatil/go-datebin/datebin"
-    "github.com/deatil/lakego-doak/lakego/gmq"
+    "github.com/deatil/lakego-doak/lakego/queue"
     "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/facade"
     "github.com/deatil/lakego-doak/lakego/http/request"
+    facadeQueue "github.com/deatil/lakego-doak/lakego/facade/queue"
 
     "github.com/deatil/lakego-doak-action-log/action-log/model"
 )
 
-var MQ *gmq.GMQ
+// 队列任务名称
+const JobName = "action-log"
 
 func init() {
-    MQ = gmq.NewGMQ()
-
     // 执行记录
-    MQ.Subscribe("action-log", func(value interface{}) {
-        f := value.(func())
-        f()
+    queue.Register(facadeQueue.Default, JobName, func(ctx context.Context, log model.ActionLog) error {
+        return model.NewDB().Create(&log).Error
     })
-
-    go MQ.Run()
 }
 
 /**
@@ -36,12 +35,11 @@ func Handler() router.HandlerFunc {
     return func(ctx *router.Context) {
         ctx.Next()
 
-        // 协程使用 ctx
-        newCtx := ctx.Copy()
-
-        go MQ.Publish("action-log", func() {
-            recordLog(newCtx)
-        })
+        // 请求结束后收集数据，通过队列写入
+        _, err := facadeQueue.Default.Dispatch(JobName, newLog(ctx))
+        if err != nil {
+            facade.Logger.Error("action-log: " + err.Error())
+        }
     }
 }
 
@@ -53,8 +51,8 @@ func filterPassword(data map[string]any, key string) map[string]any {
     return data
 }
 
-// 记录日志
-func recordLog(ctx *router.Context) {
+// 日志数据
+func newLog(ctx *router.Context) model.ActionLog {
     path := ctx.Request.URL.Path
     raw := ctx.Request.URL.RawQuery
 
@@ -90,8 +88,7 @@ func recordLog(ctx *router.Context) {
         name = "操作账号[" + adminId.(string) + "]"
     }
 
-    // 记录数据
-    model.NewDB().Create(&model.ActionLog{
+    return model.ActionLog{
         Name: name,
         Url: path,
         Method: method,
@@ -100,5 +97,5 @@ func recordLog(ctx *router.Context) {
         Time: int(datebin.NowTimestamp()),
         Ip: ip,
         Status: status,
-    })
+    }
 }
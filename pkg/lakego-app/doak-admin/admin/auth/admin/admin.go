@@ -146,7 +146,7 @@ func (this *Admin) GetProfile() map[string]any {
     profile := collection.Collect(this.Data).
         Only([]string{
             "id", "name", "nickname",
-            "email", "avatar", "introduce",
+            "email", "avatar", "introduce", "language",
             "last_active", "last_ip",
         }).
         ToMap()
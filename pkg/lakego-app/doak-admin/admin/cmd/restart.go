@@ -0,0 +1,47 @@
+package cmd
+
+import (
+    "github.com/deatil/lakego-doak/lakego/color"
+    "github.com/deatil/lakego-doak/lakego/command"
+    "github.com/deatil/lakego-doak/lakego/kernel/shutdown"
+)
+
+/**
+ * 重启 admin 系统服务，新进程接管监听后旧进程处理完请求再退出
+ *
+ * > ./main lakego-admin:restart [--pid=12345]
+ * > go run main.go lakego-admin:restart [--pid=12345]
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var RestartCmd = &command.Command{
+    Use:   "lakego-admin:restart",
+    Short: "重启 admin 系统服务",
+    Run: func(cmd *command.Command, args []string) {
+        Restart()
+    },
+}
+
+// 自定义 Pid
+var restartPid string
+
+func init() {
+    pf := RestartCmd.Flags()
+    pf.StringVarP(&restartPid, "pid", "p", "", "要重启的pid")
+}
+
+// 重启 admin 系统服务
+func Restart() {
+    color.Greenln("系统服务正在重启...")
+
+    pids, err := servicePids(restartPid)
+    if err != nil {
+        color.Redln(err.Error())
+        return
+    }
+
+    if err := shutdown.SignalRestart(pids[len(pids) - 1]); err != nil {
+        color.Redln(err.Error())
+    }
+}
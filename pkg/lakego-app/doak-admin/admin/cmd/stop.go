@@ -1,6 +1,7 @@
 package cmd
 
 import (
+    "errors"
     "strconv"
     "strings"
 
@@ -11,14 +12,15 @@ import (
     "github.com/deatil/lakego-doak/lakego/color"
     "github.com/deatil/lakego-doak/lakego/command"
     "github.com/deatil/lakego-doak/lakego/facade"
+    "github.com/deatil/lakego-doak/lakego/kernel/shutdown"
 )
 
 /**
  * 停止 admin 系统服务
  *
- * > ./main lakego-admin:stop [--pid=12345]
- * > main.exe lakego-admin:stop [--pid=12345]
- * > go run main.go lakego-admin:stop [--pid=12345]
+ * > ./main lakego-admin:stop [--pid=12345] [--force]
+ * > main.exe lakego-admin:stop [--pid=12345] [--force]
+ * > go run main.go lakego-admin:stop [--pid=12345] [--force]
  *
  * @create 2022-2-13
  * @author deatil
@@ -34,49 +36,65 @@ var StopCmd = &command.Command{
 // 自定义 Pid
 var stopPid string
 
+// 强制停止
+var stopForce bool
+
 func init() {
     pf := StopCmd.Flags()
     pf.StringVarP(&stopPid, "pid", "p", "", "要停止的pid")
+    pf.BoolVarP(&stopForce, "force", "f", false, "直接结束进程，不等待处理中的请求及任务完成")
 }
 
 // 停止 admin 系统服务
 func Stop() {
     color.Greenln("系统服务正在停止...")
 
-    if stopPid == "" {
+    pids, err := servicePids(stopPid)
+    if err != nil {
+        color.Redln(err.Error())
+        return
+    }
+
+    if stopForce {
+        for _, id := range pids {
+            if _, err := cmdTool.New().Kill(id); err != nil {
+                color.Redln(err.Error())
+            }
+        }
+
+        return
+    }
+
+    // 通知服务进程按顺序关闭
+    if err := shutdown.SignalStop(pids[len(pids) - 1]); err != nil {
+        color.Redln(err.Error())
+    }
+}
+
+// 服务进程 pid，最后一个为服务进程
+func servicePids(pid string) ([]int, error) {
+    if pid == "" {
         pidPath := facade.Config("admin").GetString("pid-path")
         location := path.FormatPath(pidPath)
 
         contents, err := filesystem.New().Get(location)
         if err != nil {
-            color.Redln(err.Error())
-
-            return
+            return nil, err
         }
 
-        pids := strings.Split(string(contents), ",")
-        if len(pids) == 0 {
-            color.Redln("pid 数据为空")
+        pid = string(contents)
+    }
 
-            return
+    pids := make([]int, 0)
+    for _, item := range strings.Split(pid, ",") {
+        if id, err := strconv.Atoi(strings.TrimSpace(item)); err == nil {
+            pids = append(pids, id)
         }
+    }
 
-        for _, pid := range pids {
-            id, err2 := strconv.Atoi(pid)
-            if err2 == nil {
-                _, err3 := cmdTool.New().Kill(id)
-                if err3 != nil {
-                    color.Redln(err3.Error())
-                }
-            }
-        }
-    } else {
-        id, err2 := strconv.Atoi(stopPid)
-        if err2 == nil {
-            _, err3 := cmdTool.New().Kill(id)
-            if err3 != nil {
-                color.Redln(err3.Error())
-            }
-        }
+    if len(pids) == 0 {
+        return nil, errors.New("pid 数据为空")
     }
+
+    return pids, nil
 }
@@ -53,10 +53,10 @@ type Admin struct {
 // @x-lakego {"slug": "lakego-admin.admin.index"}
 func (this *Admin) Index(ctx *router.Context) {
     // 授权数据
-    gadb := model.NewAuthGroupAccess()
+    gadb := model.NewAuthGroupAccess(ctx.Request.Context())
 
     // 模型
-    adminModel := model.NewAdmin().
+    adminModel := model.NewAdmin(ctx.Request.Context()).
         Scopes(scope.AdminWithAccess(ctx, gadb))
 
     // 排序
@@ -78,7 +78,7 @@ func (this *Admin) Index(ctx *router.Context) {
         searchword = "%" + searchword + "%"
 
         adminModel = adminModel.Where(
-            model.NewDB().
+            model.NewDB(ctx.Request.Context()).
                 Where("name LIKE ?", searchword).
                 Or("nickname LIKE ?", searchword).
                 Or("email LIKE ?", searchword),
@@ -174,10 +174,10 @@ func (this *Admin) Detail(ctx *router.Context) {
     var info = model.Admin{}
 
     // 授权数据
-    gadb := model.NewAuthGroupAccess()
+    gadb := model.NewAuthGroupAccess(ctx.Request.Context())
 
     // 模型
-    err := model.NewAdmin().
+    err := model.NewAdmin(ctx.Request.Context()).
         Scopes(scope.AdminWithAccess(ctx, gadb)).
         Where("id = ?", id).
         Preload("Groups").
@@ -239,10 +239,10 @@ func (this *Admin) Rules(ctx *router.Context) {
     var info = model.Admin{}
 
     // 授权数据
-    gadb := model.NewAuthGroupAccess()
+    gadb := model.NewAuthGroupAccess(ctx.Request.Context())
 
     // 模型
-    err := model.NewAdmin().
+    err := model.NewAdmin(ctx.Request.Context()).
         Scopes(scope.AdminWithAccess(ctx, gadb)).
         Where("id = ?", id).
         Preload("Groups").
@@ -285,7 +285,7 @@ func (this *Admin) Groups(ctx *router.Context) {
 
     list := make([]map[string]any, 0)
     if adminData.IsSuperAdministrator() {
-        err := model.NewAuthGroup().
+        err := model.NewAuthGroup(ctx.Request.Context()).
             Order("listorder ASC").
             Order("add_time ASC").
             Select([]string{
@@ -349,7 +349,7 @@ func (this *Admin) Create(ctx *router.Context) {
     post := make(map[string]any)
     this.ShouldBindJSON(ctx, &post)
 
-    validateErr := admin_validate.Create(post)
+    validateErr := admin_validate.Create(ctx, post)
     if validateErr != "" {
         this.Error(ctx, validateErr)
         return
@@ -362,7 +362,7 @@ func (this *Admin) Create(ctx *router.Context) {
 
     // 模型
     result := map[string]any{}
-    err := model.NewAdmin().
+    err := model.NewAdmin(ctx.Request.Context()).
         Where("name = ?", post["name"].(string)).
         Or("email = ?", post["email"].(string)).
         First(&result).
@@ -382,7 +382,7 @@ func (this *Admin) Create(ctx *router.Context) {
         AddIp: router.GetRequestIp(ctx),
     }
 
-    err2 := model.NewDB().
+    err2 := model.NewDB(ctx.Request.Context()).
         Create(&insertData).
         Error
     if err2 != nil {
@@ -390,7 +390,7 @@ func (this *Admin) Create(ctx *router.Context) {
         return
     }
 
-    model.NewDB().Create(&model.AuthGroupAccess{
+    model.NewDB(ctx.Request.Context()).Create(&model.AuthGroupAccess{
         AdminId: insertData.ID,
         GroupId: post["group_id"].(string),
     })
@@ -430,11 +430,11 @@ func (this *Admin) Update(ctx *router.Context) {
     }
 
     // 授权数据
-    gadb := model.NewAuthGroupAccess()
+    gadb := model.NewAuthGroupAccess(ctx.Request.Context())
 
     // 查询
     result := map[string]any{}
-    err := model.NewAdmin().
+    err := model.NewAdmin(ctx.Request.Context()).
         Scopes(scope.AdminWithAccess(ctx, gadb)).
         Where("id = ?", id).
         First(&result).
@@ -448,7 +448,7 @@ func (this *Admin) Update(ctx *router.Context) {
     post := make(map[string]any)
     this.ShouldBindJSON(ctx, &post)
 
-    validateErr := admin_validate.Update(post)
+    validateErr := admin_validate.Update(ctx, post)
     if validateErr != "" {
         this.Error(ctx, validateErr)
         return
@@ -460,11 +460,11 @@ func (this *Admin) Update(ctx *router.Context) {
     }
 
     // 链接db
-    db := model.NewDB()
+    db := model.NewDB(ctx.Request.Context())
 
     // 验证
     result2 := map[string]any{}
-    err2 := model.NewAdmin().
+    err2 := model.NewAdmin(ctx.Request.Context()).
         Where(db.Where("id != ?", id).Where("name = ?", post["name"].(string))).
         Or(db.Where("id != ?", id).Where("email = ?", post["email"].(string))).
         First(&result2).
@@ -474,7 +474,7 @@ func (this *Admin) Update(ctx *router.Context) {
         return
     }
 
-    err3 := model.NewAdmin().
+    err3 := model.NewAdmin(ctx.Request.Context()).
         Scopes(scope.AdminWithAccess(ctx, gadb)).
         Where("id = ?", id).
         Updates(map[string]any{
@@ -522,10 +522,10 @@ func (this *Admin) Delete(ctx *router.Context) {
     result := map[string]any{}
 
     // 授权数据
-    gadb := model.NewAuthGroupAccess()
+    gadb := model.NewAuthGroupAccess(ctx.Request.Context())
 
     // 模型
-    err := model.NewAdmin().
+    err := model.NewAdmin(ctx.Request.Context()).
         Scopes(scope.AdminWithAccess(ctx, gadb)).
         Where("id = ?", id).
         First(&result).
@@ -542,7 +542,7 @@ func (this *Admin) Delete(ctx *router.Context) {
     }
 
     // 删除
-    err2 := model.NewAdmin().
+    err2 := model.NewAdmin(ctx.Request.Context()).
         Scopes(scope.AdminWithAccess(ctx, gadb)).
         Delete(&model.Admin{
             ID: id,
@@ -582,11 +582,11 @@ func (this *Admin) UpdateAvatar(ctx *router.Context) {
     }
 
     // 授权数据
-    gadb := model.NewAuthGroupAccess()
+    gadb := model.NewAuthGroupAccess(ctx.Request.Context())
 
     // 查询
     result := map[string]any{}
-    err := model.NewAdmin().
+    err := model.NewAdmin(ctx.Request.Context()).
         Scopes(scope.AdminWithAccess(ctx, gadb)).
         Where("id = ?", id).
         First(&result).
@@ -600,13 +600,13 @@ func (this *Admin) UpdateAvatar(ctx *router.Context) {
     post := make(map[string]any)
     this.ShouldBindJSON(ctx, &post)
 
-    validateErr := admin_validate.UpdateAvatar(post)
+    validateErr := admin_validate.UpdateAvatar(ctx, post)
     if validateErr != "" {
         this.Error(ctx, validateErr)
         return
     }
 
-    err3 := model.NewAdmin().
+    err3 := model.NewAdmin(ctx.Request.Context()).
         Scopes(scope.AdminWithAccess(ctx, gadb)).
         Where("id = ?", id).
         Updates(map[string]any{
@@ -647,11 +647,11 @@ func (this *Admin) UpdatePasssword(ctx *router.Context) {
     }
 
     // 授权数据
-    gadb := model.NewAuthGroupAccess()
+    gadb := model.NewAuthGroupAccess(ctx.Request.Context())
 
     // 查询
     result := map[string]any{}
-    err := model.NewAdmin().
+    err := model.NewAdmin(ctx.Request.Context()).
         Scopes(scope.AdminWithAccess(ctx, gadb)).
         Where("id = ?", id).
         First(&result).
@@ -674,7 +674,7 @@ func (this *Admin) UpdatePasssword(ctx *router.Context) {
     // 生成密码
     pass, encrypt := auth_password.MakePassword(password)
 
-    err3 := model.NewAdmin().
+    err3 := model.NewAdmin(ctx.Request.Context()).
         Scopes(scope.AdminWithAccess(ctx, gadb)).
         Where("id = ?", id).
         Updates(map[string]any{
@@ -715,11 +715,11 @@ func (this *Admin) Enable(ctx *router.Context) {
     }
 
     // 授权数据
-    gadb := model.NewAuthGroupAccess()
+    gadb := model.NewAuthGroupAccess(ctx.Request.Context())
 
     // 查询
     result := map[string]any{}
-    err := model.NewAdmin().
+    err := model.NewAdmin(ctx.Request.Context()).
         Scopes(scope.AdminWithAccess(ctx, gadb)).
         Where("id = ?", id).
         First(&result).
@@ -738,7 +738,7 @@ func (this *Admin) Enable(ctx *router.Context) {
         return
     }
 
-    err2 := model.NewAdmin().
+    err2 := model.NewAdmin(ctx.Request.Context()).
         Scopes(scope.AdminWithAccess(ctx, gadb)).
         Where("id = ?", id).
         Updates(map[string]any{
@@ -778,11 +778,11 @@ func (this *Admin) Disable(ctx *router.Context) {
     }
 
     // 授权数据
-    gadb := model.NewAuthGroupAccess()
+    gadb := model.NewAuthGroupAccess(ctx.Request.Context())
 
     // 查询
     result := map[string]any{}
-    err := model.NewAdmin().
+    err := model.NewAdmin(ctx.Request.Context()).
         Scopes(scope.AdminWithAccess(ctx, gadb)).
         Where("id = ?", id).
         First(&result).
@@ -801,7 +801,7 @@ func (this *Admin) Disable(ctx *router.Context) {
         return
     }
 
-    err2 := model.NewAdmin().
+    err2 := model.NewAdmin(ctx.Request.Context()).
         Scopes(scope.AdminWithAccess(ctx, gadb)).
         Where("id = ?", id).
         Updates(map[string]any{
@@ -834,7 +834,7 @@ func (this *Admin) Logout(ctx *router.Context) {
         return
     }
 
-    c := facade.Cache
+    c := facade.Cache.WithContext(ctx.Request.Context())
 
     if c.Has(utils.MD5(refreshToken)) {
         this.Error(ctx, "refreshToken已失效")
@@ -868,7 +868,7 @@ func (this *Admin) Logout(ctx *router.Context) {
 
     c.Put(utils.MD5(refreshToken), "no", int64(refreshTokenExpiresIn))
 
-    model.NewAdmin().
+    model.NewAdmin(ctx.Request.Context()).
         Where("id = ?", refreshAdminid).
         Updates(map[string]any{
             "refresh_time": int(datebin.NowTimestamp()),
@@ -904,11 +904,11 @@ func (this *Admin) Access(ctx *router.Context) {
     }
 
     // 授权数据
-    gadb := model.NewAuthGroupAccess()
+    gadb := model.NewAuthGroupAccess(ctx.Request.Context())
 
     // 查询
     result := map[string]any{}
-    err := model.NewAdmin().
+    err := model.NewAdmin(ctx.Request.Context()).
         Scopes(scope.AdminWithAccess(ctx, gadb)).
         Where("id = ?", id).
         First(&result).
@@ -920,12 +920,12 @@ func (this *Admin) Access(ctx *router.Context) {
 
     // 旧数据
     accessResult := make([]map[string]any, 0)
-    model.NewAuthGroupAccess().
+    model.NewAuthGroupAccess(ctx.Request.Context()).
         Where("admin_id = ?", id).
         Find(&accessResult)
 
     // 模型
-    err = model.NewAuthGroupAccess().
+    err = model.NewAuthGroupAccess(ctx.Request.Context()).
         Where("admin_id = ?", id).
         Delete(&model.AuthGroupAccess{}).
         Error
@@ -980,7 +980,7 @@ func (this *Admin) Access(ctx *router.Context) {
             })
         }
 
-        model.NewDB().Create(&insertData)
+        model.NewDB(ctx.Request.Context()).Create(&insertData)
     }
 
     // ========
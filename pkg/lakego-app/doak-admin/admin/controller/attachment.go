@@ -1,15 +1,20 @@
 package controller
 
 import (
+    "time"
+    "net/http"
+
     "github.com/deatil/go-goch/goch"
     "github.com/deatil/go-events/events"
     "github.com/deatil/go-datebin/datebin"
     "github.com/deatil/lakego-filesystem/filesystem"
 
+    "github.com/deatil/lakego-doak/lakego/cache"
     "github.com/deatil/lakego-doak/lakego/router"
     "github.com/deatil/lakego-doak/lakego/random"
     "github.com/deatil/lakego-doak/lakego/facade"
     "github.com/deatil/lakego-doak/lakego/facade/storage"
+    lakegoStorage "github.com/deatil/lakego-doak/lakego/storage"
 
     "github.com/deatil/lakego-doak-admin/admin/model"
     "github.com/deatil/lakego-doak-admin/admin/support/url"
@@ -45,7 +50,7 @@ type Attachment struct {
 // @x-lakego {"slug": "lakego-admin.attachment.index","sort":"151"}
 func (this *Attachment) Index(ctx *router.Context) {
     // 附件模型
-    attachModel := model.NewAttachment()
+    attachModel := model.NewAttachment(ctx.Request.Context())
 
     // 排序
     order := ctx.DefaultQuery("order", "add_time__DESC")
@@ -66,7 +71,7 @@ func (this *Attachment) Index(ctx *router.Context) {
         searchword = "%" + searchword + "%"
 
         attachModel = attachModel.Where(
-            model.NewDB().
+            model.NewDB(ctx.Request.Context()).
                 Where("name LIKE ?", searchword).
                 Or("extension LIKE ?", searchword).
                 Or("disk LIKE ?", searchword),
@@ -155,7 +160,7 @@ func (this *Attachment) Detail(ctx *router.Context) {
     result := map[string]any{}
 
     // 附件模型
-    err := model.NewAttachment().
+    err := model.NewAttachment(ctx.Request.Context()).
         Where("id = ?", newId).
         First(&result).
         Error
@@ -195,7 +200,7 @@ func (this *Attachment) Delete(ctx *router.Context) {
     result := map[string]any{}
 
     // 附件模型
-    err := model.NewAttachment().
+    err := model.NewAttachment(ctx.Request.Context()).
         Where("id = ?", id).
         First(&result).
         Error
@@ -205,7 +210,7 @@ func (this *Attachment) Delete(ctx *router.Context) {
     }
 
     // 附件模型
-    err2 := model.NewAttachment().
+    err2 := model.NewAttachment(ctx.Request.Context()).
         Delete(&model.Attachment{
             ID: id,
         }).
@@ -217,6 +222,7 @@ func (this *Attachment) Delete(ctx *router.Context) {
 
     // 删除具体文件
     storage.NewWithDisk(result["disk"].(string)).
+        WithContext(ctx.Request.Context()).
         Delete(result["path"].(string))
 
     // 数据输出
@@ -244,7 +250,7 @@ func (this *Attachment) Enable(ctx *router.Context) {
     result := map[string]any{}
 
     // 附件模型
-    err := model.NewAttachment().
+    err := model.NewAttachment(ctx.Request.Context()).
         Where("id = ?", id).
         First(&result).
         Error
@@ -258,7 +264,7 @@ func (this *Attachment) Enable(ctx *router.Context) {
         return
     }
 
-    err2 := model.NewAttachment().
+    err2 := model.NewAttachment(ctx.Request.Context()).
         Where("id = ?", id).
         Updates(map[string]any{
             "status": 1,
@@ -294,7 +300,7 @@ func (this *Attachment) Disable(ctx *router.Context) {
     result := map[string]any{}
 
     // 附件模型
-    err := model.NewAttachment().
+    err := model.NewAttachment(ctx.Request.Context()).
         Where("id = ?", id).
         First(&result).
         Error
@@ -308,7 +314,7 @@ func (this *Attachment) Disable(ctx *router.Context) {
         return
     }
 
-    err2 := model.NewAttachment().
+    err2 := model.NewAttachment(ctx.Request.Context()).
         Where("id = ?", id).
         Updates(map[string]any{
             "status": 0,
@@ -344,7 +350,7 @@ func (this *Attachment) DownloadCode(ctx *router.Context) {
     result := map[string]any{}
 
     // 附件模型
-    err := model.NewAttachment().
+    err := model.NewAttachment(ctx.Request.Context()).
         Where("id = ?", id).
         First(&result).
         Error
@@ -353,16 +359,81 @@ func (this *Attachment) DownloadCode(ctx *router.Context) {
         return
     }
 
+    disk, diskOk := result["disk"].(string)
+    path, pathOk := result["path"].(string)
+    name, nameOk := result["name"].(string)
+    if !diskOk || !pathOk || !nameOk {
+        this.Error(ctx, "文件信息错误")
+        return
+    }
+
     // 添加到缓存
     code := utils.MD5(goch.ToString(datebin.NowTimestamp()) + random.String(10))
-    facade.Cache.Put(code, result["id"].(string), 300)
+    facade.Cache.WithContext(ctx.Request.Context()).Put(code, result["id"].(string), 300)
+
+    // 签名临时链接，磁盘未配置签名时为空
+    temporaryUrl, _ := storage.NewWithDisk(disk).
+        WithContext(ctx.Request.Context()).
+        TemporaryUrl(path, 300 * time.Second, lakegoStorage.TemporaryUrlOptions{
+            Filename:    name,
+            Disposition: "attachment",
+        })
 
     // 数据输出
     this.SuccessWithData(ctx, "获取成功", router.H{
         "code": code,
+        "url":  temporaryUrl,
     })
 }
 
+// 临时链接下载
+// @Summary 临时链接下载
+// @Description 临时链接下载，链接由磁盘 TemporaryUrl 生成
+// @Tags 附件
+// @Accept  application/json
+// @Produce application/octet-stream
+// @Param disk      query string true "磁盘"
+// @Param path      query string true "文件路径"
+// @Param expires   query string true "过期时间"
+// @Param signature query string true "签名"
+// @Success 200 {string} string ""
+// @Router /attachment/temporary [get]
+// @x-lakego {"slug": "lakego-admin.attachment.temporary","sort":"158"}
+func (this *Attachment) Temporary(ctx *router.Context) {
+    data, err := storage.VerifyTemporaryUrl(ctx.Request.URL.Query(), router.GetRequestIp(ctx))
+    if err != nil {
+        ctx.String(http.StatusForbidden, "链接无效或已过期")
+        ctx.Abort()
+        return
+    }
+
+    fs := storage.NewWithDisk(data.Disk).WithContext(ctx.Request.Context())
+    if !fs.Exists(data.Path) {
+        ctx.String(http.StatusNotFound, "文件不存在")
+        ctx.Abort()
+        return
+    }
+
+    stream, err := fs.ReadStream(data.Path)
+    if err != nil {
+        ctx.String(http.StatusNotFound, "文件不存在")
+        ctx.Abort()
+        return
+    }
+    defer stream.Close()
+
+    modified := time.Time{}
+    if timestamp, err := fs.GetTimestamp(data.Path); err == nil {
+        modified = time.Unix(timestamp, 0)
+    }
+
+    if disposition := lakegoStorage.ContentDisposition(data.Disposition, data.Filename); disposition != "" {
+        ctx.Header("Content-Disposition", disposition)
+    }
+
+    http.ServeContent(ctx.Writer, ctx.Request, data.Path, modified, stream)
+}
+
 // 附件下载
 // @Summary 附件下载
 // @Description 附件下载
@@ -381,7 +452,7 @@ func (this *Attachment) Download(ctx *router.Context) {
         return
     }
 
-    fileId, _ := facade.Cache.Pull(code)
+    fileId, _ := cache.PullAs[string](facade.Cache.WithContext(ctx.Request.Context()), code)
     if fileId == "" {
         this.ReturnString(ctx, "文件ID错误")
         return
@@ -390,7 +461,7 @@ func (this *Attachment) Download(ctx *router.Context) {
     result := map[string]any{}
 
     // 附件模型
-    err := model.NewAttachment().
+    err := model.NewAttachment(ctx.Request.Context()).
         Where("id = ?", fileId).
         First(&result).
         Error
@@ -46,7 +46,7 @@ type AuthGroup struct {
 // @x-lakego {"slug": "lakego-admin.auth-group.index"}
 func (this *AuthGroup) Index(ctx *router.Context) {
     // 模型
-    groupModel := model.NewAuthGroup()
+    groupModel := model.NewAuthGroup(ctx.Request.Context())
 
     // 排序
     order := ctx.DefaultQuery("order", "add_time__DESC")
@@ -133,7 +133,7 @@ func (this *AuthGroup) Index(ctx *router.Context) {
 func (this *AuthGroup) IndexTree(ctx *router.Context) {
     list := make([]map[string]any, 0)
 
-    err := model.NewAuthGroup().
+    err := model.NewAuthGroup(ctx.Request.Context()).
         Order("listorder ASC").
         Order("add_time ASC").
         Find(&list).
@@ -206,7 +206,7 @@ func (this *AuthGroup) Detail(ctx *router.Context) {
     var info model.AuthGroup
 
     // 模型
-    err := model.NewAuthGroup().
+    err := model.NewAuthGroup(ctx.Request.Context()).
         Where("id = ?", id).
         Preload("RuleAccesses").
         First(&info).
@@ -271,7 +271,7 @@ func (this *AuthGroup) Create(ctx *router.Context) {
     post := make(map[string]any)
     this.ShouldBindJSON(ctx, &post)
 
-    validateErr := authGroupValidate.Create(post)
+    validateErr := authGroupValidate.Create(ctx, post)
     if validateErr != "" {
         this.Error(ctx, validateErr)
         return
@@ -296,7 +296,7 @@ func (this *AuthGroup) Create(ctx *router.Context) {
         AddIp: router.GetRequestIp(ctx),
     }
 
-    err2 := model.NewDB().
+    err2 := model.NewDB(ctx.Request.Context()).
         Create(&insertData).
         Error
     if err2 != nil {
@@ -334,7 +334,7 @@ func (this *AuthGroup) Update(ctx *router.Context) {
 
     // 查询
     result := map[string]any{}
-    err := model.NewAuthGroup().
+    err := model.NewAuthGroup(ctx.Request.Context()).
         Where("id = ?", id).
         First(&result).
         Error
@@ -347,7 +347,7 @@ func (this *AuthGroup) Update(ctx *router.Context) {
     post := make(map[string]any)
     this.ShouldBindJSON(ctx, &post)
 
-    validateErr := authGroupValidate.Update(post)
+    validateErr := authGroupValidate.Update(ctx, post)
     if validateErr != "" {
         this.Error(ctx, validateErr)
         return
@@ -362,7 +362,7 @@ func (this *AuthGroup) Update(ctx *router.Context) {
         status = 0
     }
 
-    err3 := model.NewAuthGroup().
+    err3 := model.NewAuthGroup(ctx.Request.Context()).
         Where("id = ?", id).
         Updates(map[string]any{
             "parentid": post["parentid"].(string),
@@ -402,7 +402,7 @@ func (this *AuthGroup) Delete(ctx *router.Context) {
 
     // 详情
     var info model.AuthGroup
-    err := model.NewAuthGroup().
+    err := model.NewAuthGroup(ctx.Request.Context()).
         Where("id = ?", id).
         First(&info).
         Error
@@ -413,7 +413,7 @@ func (this *AuthGroup) Delete(ctx *router.Context) {
 
     // 子级
     var total int64
-    err2 := model.NewAuthGroup().
+    err2 := model.NewAuthGroup(ctx.Request.Context()).
         Where("parentid = ?", id).
         Count(&total).
         Error
@@ -423,7 +423,7 @@ func (this *AuthGroup) Delete(ctx *router.Context) {
     }
 
     // 删除
-    err3 := model.NewAuthGroup().
+    err3 := model.NewAuthGroup(ctx.Request.Context()).
         Delete(&model.AuthGroup{
             ID: id,
         }).
@@ -457,7 +457,7 @@ func (this *AuthGroup) Listorder(ctx *router.Context) {
 
     // 查询
     result := map[string]any{}
-    err := model.NewAuthGroup().
+    err := model.NewAuthGroup(ctx.Request.Context()).
         Where("id = ?", id).
         First(&result).
         Error
@@ -478,7 +478,7 @@ func (this *AuthGroup) Listorder(ctx *router.Context) {
         listorder = 100
     }
 
-    err2 := model.NewAuthGroup().
+    err2 := model.NewAuthGroup(ctx.Request.Context()).
         Where("id = ?", id).
         Updates(map[string]any{
             "listorder": listorder,
@@ -512,7 +512,7 @@ func (this *AuthGroup) Enable(ctx *router.Context) {
 
     // 查询
     result := map[string]any{}
-    err := model.NewAuthGroup().
+    err := model.NewAuthGroup(ctx.Request.Context()).
         Where("id = ?", id).
         First(&result).
         Error
@@ -530,7 +530,7 @@ func (this *AuthGroup) Enable(ctx *router.Context) {
         return
     }
 
-    err2 := model.NewAuthGroup().
+    err2 := model.NewAuthGroup(ctx.Request.Context()).
         Where("id = ?", id).
         Updates(map[string]any{
             "status": 1,
@@ -564,7 +564,7 @@ func (this *AuthGroup) Disable(ctx *router.Context) {
 
     // 查询
     result := map[string]any{}
-    err := model.NewAuthGroup().
+    err := model.NewAuthGroup(ctx.Request.Context()).
         Where("id = ?", id).
         First(&result).
         Error
@@ -582,7 +582,7 @@ func (this *AuthGroup) Disable(ctx *router.Context) {
         return
     }
 
-    err2 := model.NewAuthGroup().
+    err2 := model.NewAuthGroup(ctx.Request.Context()).
         Where("id = ?", id).
         Updates(map[string]any{
             "status": 0,
@@ -617,7 +617,7 @@ func (this *AuthGroup) Access(ctx *router.Context) {
 
     // 查询
     groupInfo := new(model.AuthGroup)
-    err := model.NewAuthGroup().
+    err := model.NewAuthGroup(ctx.Request.Context()).
         Where("id = ?", id).
         Preload("Rules").
         First(&groupInfo).
@@ -630,7 +630,7 @@ func (this *AuthGroup) Access(ctx *router.Context) {
     result := model.FormatStructToMap(groupInfo)
 
     // 模型
-    err = model.NewAuthRuleAccess().
+    err = model.NewAuthRuleAccess(ctx.Request.Context()).
         Where("group_id = ?", id).
         Delete(&model.AuthRuleAccess{}).
         Error
@@ -664,7 +664,7 @@ func (this *AuthGroup) Access(ctx *router.Context) {
             })
         }
 
-        model.NewDB().Create(&insertData)
+        model.NewDB(ctx.Request.Context()).Create(&insertData)
     }
 
     // ========
@@ -708,7 +708,7 @@ func (this *AuthGroup) Access(ctx *router.Context) {
     }
 
     addResult := make([]map[string]any, 0)
-    model.NewAuthRule().
+    model.NewAuthRule(ctx.Request.Context()).
         Where("id in ?", adds).
         Find(&addResult)
 
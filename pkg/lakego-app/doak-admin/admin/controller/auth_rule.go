@@ -44,7 +44,7 @@ type AuthRule struct {
 // @x-lakego {"slug": "lakego-admin.auth-rule.index"}
 func (this *AuthRule) Index(ctx *router.Context) {
     // 模型
-    ruleModel := model.NewAuthRule()
+    ruleModel := model.NewAuthRule(ctx.Request.Context())
 
     // 排序
     order := ctx.DefaultQuery("order", "add_time__DESC")
@@ -141,7 +141,7 @@ func (this *AuthRule) Index(ctx *router.Context) {
 func (this *AuthRule) IndexTree(ctx *router.Context) {
     list := make([]map[string]any, 0)
 
-    err := model.NewAuthRule().
+    err := model.NewAuthRule(ctx.Request.Context()).
         Order("listorder ASC").
         Order("add_time ASC").
         Find(&list).
@@ -214,7 +214,7 @@ func (this *AuthRule) Detail(ctx *router.Context) {
     var info model.AuthRule
 
     // 模型
-    err := model.NewAuthRule().
+    err := model.NewAuthRule(ctx.Request.Context()).
         Where("id = ?", id).
         First(&info).
         Error
@@ -252,7 +252,7 @@ func (this *AuthRule) Create(ctx *router.Context) {
     post := make(map[string]any)
     this.ShouldBindJSON(ctx, &post)
 
-    validateErr := authRuleValidate.Create(post)
+    validateErr := authRuleValidate.Create(ctx, post)
     if validateErr != "" {
         this.Error(ctx, validateErr)
         return
@@ -288,7 +288,7 @@ func (this *AuthRule) Create(ctx *router.Context) {
         AddIp: router.GetRequestIp(ctx),
     }
 
-    err2 := model.NewDB().
+    err2 := model.NewDB(ctx.Request.Context()).
         Create(&insertData).
         Error
     if err2 != nil {
@@ -329,7 +329,7 @@ func (this *AuthRule) Update(ctx *router.Context) {
 
     // 查询
     result := map[string]any{}
-    err := model.NewAuthRule().
+    err := model.NewAuthRule(ctx.Request.Context()).
         Where("id = ?", id).
         First(&result).
         Error
@@ -342,7 +342,7 @@ func (this *AuthRule) Update(ctx *router.Context) {
     post := make(map[string]any)
     this.ShouldBindJSON(ctx, &post)
 
-    validateErr := authRuleValidate.Update(post)
+    validateErr := authRuleValidate.Update(ctx, post)
     if validateErr != "" {
         this.Error(ctx, validateErr)
         return
@@ -360,7 +360,7 @@ func (this *AuthRule) Update(ctx *router.Context) {
         status = 1
     }
 
-    err3 := model.NewAuthRule().
+    err3 := model.NewAuthRule(ctx.Request.Context()).
         Where("id = ?", id).
         Updates(map[string]any{
             "parentid": post["parentid"].(string),
@@ -403,7 +403,7 @@ func (this *AuthRule) Delete(ctx *router.Context) {
 
     // 详情
     var info model.AuthRule
-    err := model.NewAuthRule().
+    err := model.NewAuthRule(ctx.Request.Context()).
         Where("id = ?", id).
         First(&info).
         Error
@@ -414,7 +414,7 @@ func (this *AuthRule) Delete(ctx *router.Context) {
 
     // 子级
     var total int64
-    err2 := model.NewAuthRule().
+    err2 := model.NewAuthRule(ctx.Request.Context()).
         Where("parentid = ?", id).
         Count(&total).
         Error
@@ -424,7 +424,7 @@ func (this *AuthRule) Delete(ctx *router.Context) {
     }
 
     // 删除
-    err3 := model.NewAuthRule().
+    err3 := model.NewAuthRule(ctx.Request.Context()).
         Delete(&model.AuthRule{
             ID: id,
         }).
@@ -458,7 +458,7 @@ func (this *AuthRule) Listorder(ctx *router.Context) {
 
     // 查询
     result := map[string]any{}
-    err := model.NewAuthRule().
+    err := model.NewAuthRule(ctx.Request.Context()).
         Where("id = ?", id).
         First(&result).
         Error
@@ -479,7 +479,7 @@ func (this *AuthRule) Listorder(ctx *router.Context) {
         listorder = 100
     }
 
-    err2 := model.NewAuthRule().
+    err2 := model.NewAuthRule(ctx.Request.Context()).
         Where("id = ?", id).
         Updates(map[string]any{
             "listorder": listorder,
@@ -513,7 +513,7 @@ func (this *AuthRule) Enable(ctx *router.Context) {
 
     // 查询
     result := map[string]any{}
-    err := model.NewAuthRule().
+    err := model.NewAuthRule(ctx.Request.Context()).
         Where("id = ?", id).
         First(&result).
         Error
@@ -531,7 +531,7 @@ func (this *AuthRule) Enable(ctx *router.Context) {
         return
     }
 
-    err2 := model.NewAuthRule().
+    err2 := model.NewAuthRule(ctx.Request.Context()).
         Where("id = ?", id).
         Updates(map[string]any{
             "status": 1,
@@ -565,7 +565,7 @@ func (this *AuthRule) Disable(ctx *router.Context) {
 
     // 查询
     result := map[string]any{}
-    err := model.NewAuthRule().
+    err := model.NewAuthRule(ctx.Request.Context()).
         Where("id = ?", id).
         First(&result).
         Error
@@ -583,7 +583,7 @@ func (this *AuthRule) Disable(ctx *router.Context) {
         return
     }
 
-    err2 := model.NewAuthRule().
+    err2 := model.NewAuthRule(ctx.Request.Context()).
         Where("id = ?", id).
         Updates(map[string]any{
             "status": 0,
@@ -623,7 +623,7 @@ func (this *AuthRule) Clear(ctx *router.Context) {
     for _, id := range newIds {
         // 详情
         var info model.AuthRule
-        err := model.NewAuthRule().
+        err := model.NewAuthRule(ctx.Request.Context()).
             Where("id = ?", id).
             First(&info).
             Error
@@ -633,7 +633,7 @@ func (this *AuthRule) Clear(ctx *router.Context) {
 
         // 子级
         var total int64
-        err2 := model.NewAuthRule().
+        err2 := model.NewAuthRule(ctx.Request.Context()).
             Where("parentid = ?", id).
             Count(&total).
             Error
@@ -642,7 +642,7 @@ func (this *AuthRule) Clear(ctx *router.Context) {
         }
 
         // 删除
-        err3 := model.NewAuthRule().
+        err3 := model.NewAuthRule(ctx.Request.Context()).
             Delete(&model.AuthRule{
                 ID: id,
             }).
@@ -4,6 +4,7 @@ import (
     "github.com/deatil/go-events/events"
     "github.com/deatil/go-datebin/datebin"
 
+    "github.com/deatil/lakego-doak/lakego/cache"
     "github.com/deatil/lakego-doak/lakego/router"
     "github.com/deatil/lakego-doak/lakego/facade"
 
@@ -70,7 +71,7 @@ func (this *Passport) Login(ctx *router.Context) {
 
     events.DoAction("admin.passport-login.start", post)
 
-    validateErr := passport_validate.Login(post)
+    validateErr := passport_validate.Login(ctx, post)
     if validateErr != "" {
         this.Error(ctx, validateErr, code.LoginError)
         return
@@ -92,7 +93,7 @@ func (this *Passport) Login(ctx *router.Context) {
 
     // 用户信息
     admin := map[string]any{}
-    err := model.NewAdmin().
+    err := model.NewAdmin(ctx.Request.Context()).
         Where(&model.Admin{Name: name}).
         First(&admin).
         Error
@@ -146,7 +147,7 @@ func (this *Passport) Login(ctx *router.Context) {
     expiresIn := jwter.GetAccessExpiresIn()
 
     // 更新登录时间
-    model.NewAdmin().
+    model.NewAdmin(ctx.Request.Context()).
         Where("id = ?", adminid).
         Updates(map[string]any{
             "last_active": int(datebin.NowTimestamp()),
@@ -180,16 +181,14 @@ func (this *Passport) RefreshToken(ctx *router.Context) {
 
     events.DoAction("admin.passport-refreshtoken.start", post)
 
-    var refreshToken any
-    var ok bool
-
-    if refreshToken, ok = post["refresh_token"]; !ok {
+    refreshToken, ok := post["refresh_token"].(string)
+    if !ok || refreshToken == "" {
         this.Error(ctx, "refreshToken不能为空", code.JwtRefreshTokenFail)
         return
     }
 
-    refreshTokenPutTime, _ := facade.Cache.Get(utils.MD5(refreshToken.(string)))
-    refreshTokenPutTime = refreshTokenPutTime.(string)
+    // 已在黑名单中
+    refreshTokenPutTime, _ := cache.GetAs[string](facade.Cache.WithContext(ctx.Request.Context()), utils.MD5(refreshToken))
     if refreshTokenPutTime != "" {
         this.Error(ctx, "refreshToken已失效", code.JwtRefreshTokenFail)
         return
@@ -200,7 +199,7 @@ func (this *Passport) RefreshToken(ctx *router.Context) {
     jwter := auth.NewWithAud(aud)
 
     // 拿取数据
-    adminId := jwter.GetRefreshTokenData(refreshToken.(string), "id")
+    adminId := jwter.GetRefreshTokenData(refreshToken, "id")
     if adminId == "" {
         this.Error(ctx, "刷新Token失败", code.JwtRefreshTokenFail)
         return
@@ -251,18 +250,16 @@ func (this *Passport) Logout(ctx *router.Context) {
 
     events.DoAction("admin.passport-logout.start", post)
 
-    var refreshToken any
-    var ok bool
-
-    if refreshToken, ok = post["refresh_token"]; !ok {
+    refreshToken, ok := post["refresh_token"].(string)
+    if !ok || refreshToken == "" {
         this.Error(ctx, "refreshToken 不能为空", code.JwtRefreshTokenFail)
         return
     }
 
-    c := facade.Cache
+    c := facade.Cache.WithContext(ctx.Request.Context())
 
-    refreshTokenPutString, _ := c.Get(utils.MD5(refreshToken.(string)))
-    refreshTokenPutString = refreshTokenPutString.(string)
+    // 已在黑名单中
+    refreshTokenPutString, _ := cache.GetAs[string](c, utils.MD5(refreshToken))
     if refreshTokenPutString != "" {
         this.Error(ctx, "refreshToken 已失效", code.JwtRefreshTokenFail)
         return
@@ -273,7 +270,7 @@ func (this *Passport) Logout(ctx *router.Context) {
     jwter := auth.NewWithAud(aud)
 
     // 拿取数据
-    claims, claimsErr := jwter.GetRefreshTokenClaims(refreshToken.(string))
+    claims, claimsErr := jwter.GetRefreshTokenClaims(refreshToken)
     if claimsErr != nil {
         this.Error(ctx, "refreshToken 已失效", code.JwtRefreshTokenFail)
         return
@@ -298,7 +295,7 @@ func (this *Passport) Logout(ctx *router.Context) {
 
     // 加入黑名单
     c.Put(utils.MD5(accessToken.(string)), "no", int64(refreshTokenExpiresIn))
-    c.Put(utils.MD5(refreshToken.(string)), "no", int64(refreshTokenExpiresIn))
+    c.Put(utils.MD5(refreshToken), "no", int64(refreshTokenExpiresIn))
 
     events.DoAction("admin.passport-logout.end", adminId)
 
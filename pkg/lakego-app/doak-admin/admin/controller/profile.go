@@ -3,7 +3,9 @@ package controller
 import (
     "github.com/deatil/go-events/events"
 
+    "github.com/deatil/lakego-doak/lakego/i18n"
     "github.com/deatil/lakego-doak/lakego/router"
+    locale_middleware "github.com/deatil/lakego-doak/lakego/middleware/locale"
 
     "github.com/deatil/lakego-doak-admin/admin/model"
     "github.com/deatil/lakego-doak-admin/admin/auth/admin"
@@ -62,7 +64,7 @@ func (this *Profile) Update(ctx *router.Context) {
     this.ShouldBindJSON(ctx, &post)
 
     // 检测
-    validateErr := profile_validate.Update(post)
+    validateErr := profile_validate.Update(ctx, post)
     if validateErr != "" {
         this.Error(ctx, validateErr)
         return
@@ -77,7 +79,7 @@ func (this *Profile) Update(ctx *router.Context) {
 
     adminid := adminInfo.(*admin.Admin).GetId()
 
-    err := model.NewAdmin().
+    err := model.NewAdmin(ctx.Request.Context()).
         Where("id = ?", adminid).
         Updates(map[string]any{
             "nickname": post["nickname"].(string),
@@ -113,7 +115,7 @@ func (this *Profile) UpdateAvatar(ctx *router.Context) {
     this.ShouldBindJSON(ctx, &post)
 
     // 检测
-    validateErr := profile_validate.UpdateAvatar(post)
+    validateErr := profile_validate.UpdateAvatar(ctx, post)
     if validateErr != "" {
         this.Error(ctx, validateErr)
         return
@@ -128,7 +130,7 @@ func (this *Profile) UpdateAvatar(ctx *router.Context) {
 
     adminid := adminInfo.(*admin.Admin).GetId()
 
-    err := model.NewAdmin().
+    err := model.NewAdmin(ctx.Request.Context()).
         Where("id = ?", adminid).
         Updates(map[string]any{
             "avatar": post["avatar"].(string),
@@ -145,6 +147,65 @@ func (this *Profile) UpdateAvatar(ctx *router.Context) {
     this.Success(ctx, "修改头像成功")
 }
 
+// 修改语言
+// @Summary 修改个人语言
+// @Description 修改个人语言，提示信息按该语言返回
+// @Tags 个人信息
+// @Accept  application/json
+// @Produce application/json
+// @Param language formData string true "语言，如 zh-CN、en"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /profile/language [patch]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.profile.language"}
+func (this *Profile) UpdateLanguage(ctx *router.Context) {
+    // 接收数据
+    post := make(map[string]any)
+    this.ShouldBindJSON(ctx, &post)
+
+    // 检测
+    validateErr := profile_validate.UpdateLanguage(ctx, post)
+    if validateErr != "" {
+        this.Error(ctx, validateErr)
+        return
+    }
+
+    // 只能使用已有语言包的语言
+    language := i18n.Canonical(post["language"].(string))
+    if !i18n.Default().HasLocale(language) {
+        this.Error(ctx, "语言不存在")
+        return
+    }
+
+    // 当前账号信息
+    adminInfo, ok := ctx.Get("admin")
+    if !ok {
+        this.Error(ctx, "修改语言失败")
+        return
+    }
+
+    adminid := adminInfo.(*admin.Admin).GetId()
+
+    err := model.NewAdmin(ctx.Request.Context()).
+        Where("id = ?", adminid).
+        Updates(map[string]any{
+            "language": language,
+        }).
+        Error
+    if err != nil {
+        this.Error(ctx, "修改语言失败")
+        return
+    }
+
+    // 事件
+    events.DoAction("admin.profile.update-language-after", adminid)
+
+    // 当前请求使用新语言
+    locale_middleware.Set(ctx, language)
+
+    this.Success(ctx, "修改语言成功")
+}
+
 // 修改密码
 // @Summary 修改密码
 // @Description 修改密码
@@ -164,7 +225,7 @@ func (this *Profile) UpdatePasssword(ctx *router.Context) {
     this.ShouldBindJSON(ctx, &post)
 
     // 检测
-    validateErr := profile_validate.UpdatePasssword(post)
+    validateErr := profile_validate.UpdatePasssword(ctx, post)
     if validateErr != "" {
         this.Error(ctx, validateErr)
         return
@@ -202,7 +263,7 @@ func (this *Profile) UpdatePasssword(ctx *router.Context) {
     // 生成密码
     pass, encrypt := auth_password.MakePassword(newpassword)
 
-    err := model.NewAdmin().
+    err := model.NewAdmin(ctx.Request.Context()).
         Where("id = ?", adminid).
         Updates(map[string]any{
             "password": pass,
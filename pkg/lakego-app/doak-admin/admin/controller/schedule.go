@@ -0,0 +1,307 @@
+package controller
+
+import (
+    "time"
+
+    "github.com/deatil/go-goch/goch"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/schedule"
+
+    adminSchedule "github.com/deatil/lakego-doak-admin/admin/schedule"
+)
+
+/**
+ * 计划任务
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Schedule struct {
+    Base
+}
+
+// 计划任务列表
+// @Summary 计划任务列表
+// @Description 计划任务列表
+// @Tags 计划任务
+// @Accept  application/json
+// @Produce application/json
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /schedule [get]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.schedule.index","sort":"171"}
+func (this *Schedule) Index(ctx *router.Context) {
+    jobs := adminSchedule.Default()
+    if jobs == nil {
+        this.Error(ctx, "计划任务未启用")
+        return
+    }
+
+    s := jobs.GetSchedule()
+
+    list := make([]router.H, 0)
+    for _, entry := range s.Entries() {
+        list = append(list, this.formatEntry(s, jobs, entry))
+    }
+
+    this.SuccessWithData(ctx, "获取成功", router.H{
+        "running": s.IsRunning(),
+        "total":   len(list),
+        "list":    list,
+    })
+}
+
+// 计划任务运行记录
+// @Summary 计划任务运行记录
+// @Description 计划任务运行记录
+// @Tags 计划任务
+// @Accept  application/json
+// @Produce application/json
+// @Param name  query string false "任务名称，为空时返回全部任务的记录"
+// @Param limit query string false "数量，默认20，最大200"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /schedule/runs [get]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.schedule.runs","sort":"172"}
+func (this *Schedule) Runs(ctx *router.Context) {
+    jobs := adminSchedule.Default()
+    if jobs == nil {
+        this.Error(ctx, "计划任务未启用")
+        return
+    }
+
+    history := jobs.GetSchedule().GetHistory()
+    if history == nil {
+        this.Error(ctx, "运行记录未启用")
+        return
+    }
+
+    limit := goch.ToInt(ctx.DefaultQuery("limit", "20"))
+    if limit < 1 || limit > 200 {
+        limit = 20
+    }
+
+    runs, err := history.Recent(ctx.DefaultQuery("name", ""), limit)
+    if err != nil {
+        this.Error(ctx, "获取失败")
+        return
+    }
+
+    list := make([]router.H, 0, len(runs))
+    for _, run := range runs {
+        list = append(list, router.H{
+            "name":     run.Name,
+            "spec":     run.Spec,
+            "start":    run.Start.Unix(),
+            "end":      run.End.Unix(),
+            "duration": run.Duration.Milliseconds(),
+            "outcome":  run.Outcome,
+            "error":    run.Error,
+            "host":     run.Host,
+        })
+    }
+
+    this.SuccessWithData(ctx, "获取成功", router.H{
+        "limit": limit,
+        "list":  list,
+    })
+}
+
+// 计划任务暂停
+// @Summary 计划任务暂停
+// @Description 计划任务暂停
+// @Tags 计划任务
+// @Accept  application/json
+// @Produce application/json
+// @Param name formData string true "任务名称"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /schedule/pause [patch]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.schedule.pause","sort":"173"}
+func (this *Schedule) Pause(ctx *router.Context) {
+    s, name, ok := this.entryName(ctx)
+    if !ok {
+        return
+    }
+
+    if s.IsPaused(name) {
+        this.Error(ctx, "任务已暂停")
+        return
+    }
+
+    if err := s.Pause(name); err != nil {
+        this.Error(ctx, "暂停失败")
+        return
+    }
+
+    this.Success(ctx, "暂停成功")
+}
+
+// 计划任务恢复
+// @Summary 计划任务恢复
+// @Description 计划任务恢复
+// @Tags 计划任务
+// @Accept  application/json
+// @Produce application/json
+// @Param name formData string true "任务名称"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /schedule/resume [patch]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.schedule.resume","sort":"174"}
+func (this *Schedule) Resume(ctx *router.Context) {
+    s, name, ok := this.entryName(ctx)
+    if !ok {
+        return
+    }
+
+    if !s.IsPaused(name) {
+        this.Error(ctx, "任务未暂停")
+        return
+    }
+
+    if err := s.Resume(name); err != nil {
+        this.Error(ctx, "恢复失败")
+        return
+    }
+
+    this.Success(ctx, "恢复成功")
+}
+
+// 计划任务立即运行
+// @Summary 计划任务立即运行
+// @Description 计划任务立即运行，在当前服务中运行，不等待运行结束
+// @Tags 计划任务
+// @Accept  application/json
+// @Produce application/json
+// @Param name formData string true "任务名称"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /schedule/run [post]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.schedule.run","sort":"175"}
+func (this *Schedule) Run(ctx *router.Context) {
+    s, name, ok := this.entryName(ctx)
+    if !ok {
+        return
+    }
+
+    if err := s.RunNow(name); err != nil {
+        this.Error(ctx, "任务运行失败")
+        return
+    }
+
+    this.Success(ctx, "任务已开始运行")
+}
+
+// 可执行的脚本列表
+// @Summary 可执行的脚本列表
+// @Description 可执行的脚本列表
+// @Tags 计划任务
+// @Accept  application/json
+// @Produce application/json
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /schedule/commands [get]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.schedule.commands","sort":"176"}
+func (this *Schedule) Commands(ctx *router.Context) {
+    jobs := adminSchedule.Default()
+    if jobs == nil {
+        this.Error(ctx, "计划任务未启用")
+        return
+    }
+
+    this.SuccessWithData(ctx, "获取成功", router.H{
+        "list": jobs.Commands(),
+    })
+}
+
+// 获取请求的任务名称
+func (this *Schedule) entryName(ctx *router.Context) (*schedule.Schedule, string, bool) {
+    jobs := adminSchedule.Default()
+    if jobs == nil {
+        this.Error(ctx, "计划任务未启用")
+        return nil, "", false
+    }
+
+    // 接收数据
+    post := make(map[string]any)
+    this.ShouldBindJSON(ctx, &post)
+
+    name := goch.ToString(post["name"])
+    if name == "" {
+        this.Error(ctx, "任务名称不能为空")
+        return nil, "", false
+    }
+
+    s := jobs.GetSchedule()
+    if _, ok := s.FindEntry(name); !ok {
+        this.Error(ctx, "任务不存在")
+        return nil, "", false
+    }
+
+    return s, name, true
+}
+
+// 格式化任务数据
+func (this *Schedule) formatEntry(s *schedule.Schedule, jobs *adminSchedule.Jobs, entry *schedule.Entry) router.H {
+    name := entry.DisplayName()
+
+    source := "code"
+    if jobs.IsJob(entry.Name) {
+        source = "database"
+    }
+
+    paused := entry.Name != "" && s.IsPaused(entry.Name)
+
+    var next, prev time.Time
+
+    // 当前服务运行中时使用计划任务数据
+    if cronEntry := s.CronEntry(entry.Name); entry.Name != "" && cronEntry.Valid() {
+        next = cronEntry.Next
+        prev = cronEntry.Prev
+    } else if !paused {
+        if runs, err := s.NextRuns(entry, time.Now(), 1); err == nil && len(runs) > 0 {
+            next = runs[0]
+        }
+    }
+
+    var lastRun router.H
+    if history := s.GetHistory(); history != nil {
+        if runs, err := history.Recent(name, 1); err == nil && len(runs) > 0 {
+            lastRun = router.H{
+                "start":    runs[0].Start.Unix(),
+                "duration": runs[0].Duration.Milliseconds(),
+                "outcome":  runs[0].Outcome,
+                "error":    runs[0].Error,
+                "host":     runs[0].Host,
+            }
+
+            if prev.IsZero() {
+                prev = runs[0].Start
+            }
+        }
+    }
+
+    return router.H{
+        "name":                name,
+        "spec":                entry.Spec,
+        "source":              source,
+        "controllable":        entry.Name != "",
+        "paused":              paused,
+        "without_overlapping": entry.SkipOverlapping,
+        "one_server":          entry.OneServer,
+        "timeout":             int64(entry.Timeout / time.Second),
+        "next_time":           this.unixTime(next),
+        "prev_time":           this.unixTime(prev),
+        "last_run":            lastRun,
+    }
+}
+
+// 时间戳，零值时返回 0
+func (this *Schedule) unixTime(t time.Time) int64 {
+    if t.IsZero() {
+        return 0
+    }
+
+    return t.Unix()
+}
@@ -0,0 +1,504 @@
+package controller
+
+import (
+    "errors"
+
+    "github.com/deatil/go-goch/goch"
+    "github.com/deatil/go-datebin/datebin"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/schedule"
+    "github.com/deatil/lakego-doak/lakego/facade"
+
+    "github.com/deatil/lakego-doak-admin/admin/model"
+    "github.com/deatil/lakego-doak-admin/admin/support/utils"
+    adminSchedule "github.com/deatil/lakego-doak-admin/admin/schedule"
+    scheduleJobValidate "github.com/deatil/lakego-doak-admin/admin/validate/schedulejob"
+)
+
+// 计划时间解析
+var scheduleJobParser = schedule.NewParser(
+    schedule.Second | schedule.Minute | schedule.Hour |
+    schedule.Dom | schedule.Month | schedule.Dow | schedule.Descriptor,
+)
+
+/**
+ * 数据库计划任务
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type ScheduleJob struct {
+    Base
+}
+
+// 数据库计划任务列表
+// @Summary 数据库计划任务列表
+// @Description 数据库计划任务列表
+// @Tags 计划任务
+// @Accept  application/json
+// @Produce application/json
+// @Param order      query string false "排序，示例：id__DESC"
+// @Param searchword query string false "搜索关键字"
+// @Param status     query string false "状态"
+// @Param start      query string false "开始数据量"
+// @Param limit      query string false "每页数量"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /schedule/job [get]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.schedule-job.index","sort":"181"}
+func (this *ScheduleJob) Index(ctx *router.Context) {
+    jobModel := model.NewScheduleJob(ctx.Request.Context())
+
+    // 排序
+    order := ctx.DefaultQuery("order", "add_time__DESC")
+    orders := this.FormatOrderBy(order)
+    if orders[0] == "" ||
+        (orders[0] != "id" &&
+        orders[0] != "name" &&
+        orders[0] != "update_time" &&
+        orders[0] != "add_time") {
+        orders[0] = "add_time"
+    }
+
+    jobModel = jobModel.Order(orders[0] + " " + orders[1])
+
+    // 搜索条件
+    searchword := ctx.DefaultQuery("searchword", "")
+    if searchword != "" {
+        searchword = "%" + searchword + "%"
+
+        jobModel = jobModel.Where(
+            model.NewDB(ctx.Request.Context()).
+                Where("name LIKE ?", searchword).
+                Or("command LIKE ?", searchword).
+                Or("description LIKE ?", searchword),
+        )
+    }
+
+    status := this.SwitchStatus(ctx.DefaultQuery("status", ""))
+    if status != -1 {
+        jobModel = jobModel.Where("status = ?", status)
+    }
+
+    // 分页相关
+    start := ctx.DefaultQuery("start", "0")
+    limit := ctx.DefaultQuery("limit", "10")
+
+    newStart := goch.ToInt(start)
+    newLimit := goch.ToInt(limit)
+
+    jobModel = jobModel.
+        Offset(newStart).
+        Limit(newLimit)
+
+    list := make([]map[string]any, 0)
+
+    // 列表
+    jobModel = jobModel.Find(&list)
+
+    var total int64
+
+    // 总数
+    err := jobModel.Offset(-1).Limit(-1).Count(&total).Error
+    if err != nil {
+        this.Error(ctx, "获取失败")
+        return
+    }
+
+    // 数据输出
+    this.SuccessWithData(ctx, "获取成功", router.H{
+        "start": start,
+        "limit": limit,
+        "total": total,
+        "list":  list,
+    })
+}
+
+// 数据库计划任务详情
+// @Summary 数据库计划任务详情
+// @Description 数据库计划任务详情
+// @Tags 计划任务
+// @Accept  application/json
+// @Produce application/json
+// @Param id path string true "任务ID"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /schedule/job/{id} [get]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.schedule-job.detail","sort":"182"}
+func (this *ScheduleJob) Detail(ctx *router.Context) {
+    id := ctx.Param("id")
+    if id == "" {
+        this.Error(ctx, "ID不能为空")
+        return
+    }
+
+    var info model.ScheduleJob
+
+    err := model.NewScheduleJob(ctx.Request.Context()).
+        Where("id = ?", id).
+        First(&info).
+        Error
+    if err != nil {
+        this.Error(ctx, "信息不存在")
+        return
+    }
+
+    this.SuccessWithData(ctx, "获取成功", model.FormatStructToMap(&info))
+}
+
+// 数据库计划任务添加
+// @Summary 数据库计划任务添加
+// @Description 数据库计划任务添加
+// @Tags 计划任务
+// @Accept  application/json
+// @Produce application/json
+// @Param name                formData string true  "任务名称"
+// @Param spec                formData string true  "计划时间，带秒，示例：0 */5 * * * *"
+// @Param command             formData string true  "执行的脚本"
+// @Param args                formData string false "脚本参数，空格分隔，支持引号"
+// @Param without_overlapping formData string false "不重叠运行"
+// @Param one_server          formData string false "只在一台服务器运行"
+// @Param timeout             formData string false "超时时间，单位秒"
+// @Param description         formData string false "描述"
+// @Param status              formData string true  "状态"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /schedule/job [post]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.schedule-job.create","sort":"183"}
+func (this *ScheduleJob) Create(ctx *router.Context) {
+    // 接收数据
+    post := make(map[string]any)
+    this.ShouldBindJSON(ctx, &post)
+
+    validateErr := scheduleJobValidate.Create(ctx, post)
+    if validateErr != "" {
+        this.Error(ctx, validateErr)
+        return
+    }
+
+    data, errMsg := this.formatData(ctx, post, "")
+    if errMsg != "" {
+        this.Error(ctx, errMsg)
+        return
+    }
+
+    insertData := model.ScheduleJob{
+        Name: data["name"].(string),
+        Spec: data["spec"].(string),
+        Command: data["command"].(string),
+        Args: data["args"].(string),
+        WithoutOverlapping: data["without_overlapping"].(int),
+        OneServer: data["one_server"].(int),
+        Timeout: data["timeout"].(int),
+        Description: data["description"].(string),
+        Status: data["status"].(int),
+        AddTime: int(datebin.NowTimestamp()),
+        AddIp: router.GetRequestIp(ctx),
+    }
+
+    err := model.NewDB(ctx.Request.Context()).
+        Create(&insertData).
+        Error
+    if err != nil {
+        this.Error(ctx, "信息添加失败")
+        return
+    }
+
+    this.sync()
+
+    this.SuccessWithData(ctx, "信息添加成功", router.H{
+        "id": insertData.ID,
+    })
+}
+
+// 数据库计划任务更新
+// @Summary 数据库计划任务更新
+// @Description 数据库计划任务更新
+// @Tags 计划任务
+// @Accept  application/json
+// @Produce application/json
+// @Param id                  path     string true  "任务ID"
+// @Param name                formData string true  "任务名称"
+// @Param spec                formData string true  "计划时间，带秒，示例：0 */5 * * * *"
+// @Param command             formData string true  "执行的脚本"
+// @Param args                formData string false "脚本参数，空格分隔，支持引号"
+// @Param without_overlapping formData string false "不重叠运行"
+// @Param one_server          formData string false "只在一台服务器运行"
+// @Param timeout             formData string false "超时时间，单位秒"
+// @Param description         formData string false "描述"
+// @Param status              formData string true  "状态"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /schedule/job/{id} [put]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.schedule-job.update","sort":"184"}
+func (this *ScheduleJob) Update(ctx *router.Context) {
+    id := ctx.Param("id")
+    if id == "" {
+        this.Error(ctx, "ID不能为空")
+        return
+    }
+
+    // 查询
+    var info model.ScheduleJob
+    err := model.NewScheduleJob(ctx.Request.Context()).
+        Where("id = ?", id).
+        First(&info).
+        Error
+    if err != nil {
+        this.Error(ctx, "信息不存在")
+        return
+    }
+
+    // 接收数据
+    post := make(map[string]any)
+    this.ShouldBindJSON(ctx, &post)
+
+    validateErr := scheduleJobValidate.Update(ctx, post)
+    if validateErr != "" {
+        this.Error(ctx, validateErr)
+        return
+    }
+
+    data, errMsg := this.formatData(ctx, post, id)
+    if errMsg != "" {
+        this.Error(ctx, errMsg)
+        return
+    }
+
+    data["update_time"] = int(datebin.NowTimestamp())
+    data["update_ip"] = router.GetRequestIp(ctx)
+
+    err2 := model.NewScheduleJob(ctx.Request.Context()).
+        Where("id = ?", id).
+        Updates(data).
+        Error
+    if err2 != nil {
+        this.Error(ctx, "信息修改失败")
+        return
+    }
+
+    // 改名后清除原名称的暂停状态
+    if info.Name != data["name"].(string) {
+        this.forgetPaused(info.Name)
+    }
+
+    this.sync()
+
+    this.Success(ctx, "信息修改成功")
+}
+
+// 数据库计划任务删除
+// @Summary 数据库计划任务删除
+// @Description 数据库计划任务删除
+// @Tags 计划任务
+// @Accept  application/json
+// @Produce application/json
+// @Param id path string true "任务ID"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /schedule/job/{id} [delete]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.schedule-job.delete","sort":"185"}
+func (this *ScheduleJob) Delete(ctx *router.Context) {
+    id := ctx.Param("id")
+    if id == "" {
+        this.Error(ctx, "ID不能为空")
+        return
+    }
+
+    var info model.ScheduleJob
+    err := model.NewScheduleJob(ctx.Request.Context()).
+        Where("id = ?", id).
+        First(&info).
+        Error
+    if err != nil {
+        this.Error(ctx, "信息不存在")
+        return
+    }
+
+    err2 := model.NewScheduleJob(ctx.Request.Context()).
+        Delete(&model.ScheduleJob{
+            ID: id,
+        }).
+        Error
+    if err2 != nil {
+        this.Error(ctx, "信息删除失败")
+        return
+    }
+
+    this.forgetPaused(info.Name)
+    this.sync()
+
+    this.Success(ctx, "信息删除成功")
+}
+
+// 数据库计划任务启用
+// @Summary 数据库计划任务启用
+// @Description 数据库计划任务启用
+// @Tags 计划任务
+// @Accept  application/json
+// @Produce application/json
+// @Param id path string true "任务ID"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /schedule/job/{id}/enable [patch]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.schedule-job.enable","sort":"186"}
+func (this *ScheduleJob) Enable(ctx *router.Context) {
+    this.changeStatus(ctx, 1)
+}
+
+// 数据库计划任务禁用
+// @Summary 数据库计划任务禁用
+// @Description 数据库计划任务禁用
+// @Tags 计划任务
+// @Accept  application/json
+// @Produce application/json
+// @Param id path string true "任务ID"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /schedule/job/{id}/disable [patch]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.schedule-job.disable","sort":"187"}
+func (this *ScheduleJob) Disable(ctx *router.Context) {
+    this.changeStatus(ctx, 0)
+}
+
+// 修改状态
+func (this *ScheduleJob) changeStatus(ctx *router.Context, status int) {
+    id := ctx.Param("id")
+    if id == "" {
+        this.Error(ctx, "ID不能为空")
+        return
+    }
+
+    var info model.ScheduleJob
+    err := model.NewScheduleJob(ctx.Request.Context()).
+        Where("id = ?", id).
+        First(&info).
+        Error
+    if err != nil {
+        this.Error(ctx, "信息不存在")
+        return
+    }
+
+    action := "禁用"
+    if status == 1 {
+        action = "启用"
+    }
+
+    if info.Status == status {
+        this.Error(ctx, "信息已" + action)
+        return
+    }
+
+    err2 := model.NewScheduleJob(ctx.Request.Context()).
+        Where("id = ?", id).
+        Updates(map[string]any{
+            "status": status,
+            "update_time": int(datebin.NowTimestamp()),
+            "update_ip": router.GetRequestIp(ctx),
+        }).
+        Error
+    if err2 != nil {
+        this.Error(ctx, action + "失败")
+        return
+    }
+
+    this.sync()
+
+    this.Success(ctx, action + "成功")
+}
+
+// 检测并格式化提交的数据
+func (this *ScheduleJob) formatData(ctx *router.Context, post map[string]any, id string) (map[string]any, string) {
+    name := goch.ToString(post["name"])
+    spec := goch.ToString(post["spec"])
+    command := goch.ToString(post["command"])
+    args := goch.ToString(post["args"])
+
+    if _, err := scheduleJobParser.Parse(spec); err != nil {
+        return nil, "计划时间格式错误"
+    }
+
+    jobs := adminSchedule.Default()
+    if jobs == nil {
+        return nil, "计划任务未启用"
+    }
+
+    if err := jobs.CheckCommand(command); err != nil {
+        if errors.Is(err, adminSchedule.ErrCommandForbidden) {
+            return nil, "执行脚本不在允许列表中"
+        }
+
+        return nil, "执行脚本不存在或不能使用"
+    }
+
+    if _, err := utils.SplitArgs(args); err != nil {
+        return nil, "脚本参数格式错误"
+    }
+
+    // 名称不能重复
+    var total int64
+    query := model.NewScheduleJob(ctx.Request.Context()).Where("name = ?", name)
+    if id != "" {
+        query = query.Where("id != ?", id)
+    }
+
+    if err := query.Count(&total).Error; err != nil || total > 0 {
+        return nil, "任务名称已存在"
+    }
+
+    // 名称不能与代码中的任务相同
+    if _, ok := jobs.GetSchedule().FindEntry(name); ok && !jobs.IsJob(name) {
+        return nil, "任务名称已被使用"
+    }
+
+    timeout := goch.ToInt(post["timeout"])
+    if timeout < 0 {
+        timeout = 0
+    }
+
+    return map[string]any{
+        "name": name,
+        "spec": spec,
+        "command": command,
+        "args": args,
+        "without_overlapping": this.formatBool(post["without_overlapping"]),
+        "one_server": this.formatBool(post["one_server"]),
+        "timeout": timeout,
+        "description": goch.ToString(post["description"]),
+        "status": this.formatBool(post["status"]),
+    }, ""
+}
+
+// 格式化开关
+func (this *ScheduleJob) formatBool(value any) int {
+    if goch.ToInt(value) == 1 {
+        return 1
+    }
+
+    return 0
+}
+
+// 清除暂停状态
+func (this *ScheduleJob) forgetPaused(name string) {
+    jobs := adminSchedule.Default()
+    if jobs == nil {
+        return
+    }
+
+    if state := jobs.GetSchedule().GetState(); state != nil {
+        state.Resume(name)
+    }
+}
+
+// 同步到计划任务
+func (this *ScheduleJob) sync() {
+    jobs := adminSchedule.Default()
+    if jobs == nil {
+        return
+    }
+
+    if err := jobs.Sync(); err != nil {
+        facade.Logger.Error("schedule job sync: " + err.Error())
+    }
+}
@@ -2,11 +2,15 @@ package controller
 
 import (
     "os"
+    "sort"
     "runtime"
+    "strings"
 
+    "github.com/deatil/go-goch/goch"
     "github.com/deatil/go-datebin/datebin"
     "github.com/deatil/lakego-doak/lakego/router"
     "github.com/deatil/lakego-doak/lakego/facade"
+    "github.com/deatil/lakego-doak/lakego/logger/ring"
 
     "github.com/deatil/lakego-doak-admin/admin/auth/admin"
 )
@@ -87,3 +91,59 @@ func (this *System) Rules(ctx *router.Context) {
         "list": rules,
     })
 }
+
+// 内存日志
+// @Summary 内存日志
+// @Description 查看日志 multi 驱动 memory 输出中的最近日志
+// @Tags 系统
+// @Accept application/json
+// @Produce application/json
+// @Param buffer query string false "缓冲名称，默认 default"
+// @Param level  query string false "等级，多个用英文逗号分隔"
+// @Param limit  query string false "数量，默认100，最大500"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /system/logs [get]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.system.logs"}
+func (this *System) Logs(ctx *router.Context) {
+    names := ring.Names()
+    sort.Strings(names)
+
+    name := ctx.DefaultQuery("buffer", "default")
+
+    buffer, ok := ring.Get(name)
+    if !ok {
+        this.Error(ctx, "日志缓冲不存在")
+        return
+    }
+
+    limit := goch.ToInt(ctx.DefaultQuery("limit", "100"))
+    if limit < 1 || limit > 500 {
+        limit = 100
+    }
+
+    levels := make([]string, 0)
+    for _, level := range strings.Split(ctx.DefaultQuery("level", ""), ",") {
+        level = strings.TrimSpace(level)
+        if level != "" {
+            levels = append(levels, level)
+        }
+    }
+
+    list := make([]router.H, 0)
+    for _, entry := range buffer.Recent(limit, levels...) {
+        list = append(list, router.H{
+            "time":    entry.Time.Unix(),
+            "level":   entry.Level,
+            "message": entry.Message,
+            "fields":  entry.Fields,
+        })
+    }
+
+    this.SuccessWithData(ctx, "获取成功", router.H{
+        "buffers": names,
+        "buffer":  name,
+        "total":   buffer.Len(),
+        "list":    list,
+    })
+}
@@ -4,6 +4,7 @@ import (
     "strconv"
 
     "github.com/deatil/go-datebin/datebin"
+    "github.com/deatil/lakego-doak/lakego/i18n"
     "github.com/deatil/lakego-doak/lakego/router"
     "github.com/deatil/lakego-doak/lakego/facade"
     "github.com/deatil/lakego-doak/lakego/facade/upload"
@@ -55,7 +56,9 @@ func (this *Upload) File(ctx *router.Context) {
 
     file, err := ctx.FormFile(conf.GetString("Upload.Field"))
     if err != nil {
-        this.Error(ctx, "上传文件失败，原因：" + err.Error())
+        this.Error(ctx, i18n.Trans(ctx, "admin::上传文件失败，原因：:reason", i18n.Params{
+            "reason": err.Error(),
+        }))
         return
     }
 
@@ -90,8 +93,8 @@ func (this *Upload) File(ctx *router.Context) {
     storager := up.GetStorage()
 
     // 模型
-    adminModel := model.NewAdmin()
-    attachmentModel := model.NewAttachment()
+    adminModel := model.NewAdmin(ctx.Request.Context())
+    attachmentModel := model.NewAttachment(ctx.Request.Context())
 
     attach := map[string]any{}
     attachErr := attachmentModel.
@@ -157,7 +160,7 @@ func (this *Upload) File(ctx *router.Context) {
         AddTime: int(datebin.NowTimestamp()),
         AddIp: router.GetRequestIp(ctx),
     }
-    addError := model.NewDB().
+    addError := model.NewDB(ctx.Request.Context()).
         Model(&adminer).
         Association("Attachments").
         Append(attachData)
@@ -3,8 +3,10 @@ package authorization
 import (
     "strings"
 
+    "github.com/deatil/lakego-doak/lakego/i18n"
     "github.com/deatil/lakego-doak/lakego/router"
     "github.com/deatil/lakego-doak/lakego/facade"
+    locale_middleware "github.com/deatil/lakego-doak/lakego/middleware/locale"
 
     "github.com/deatil/lakego-doak-admin/admin/auth/auth"
     "github.com/deatil/lakego-doak-admin/admin/auth/admin"
@@ -62,7 +64,7 @@ func jwtCheck(ctx *router.Context) bool {
 
     // 用户信息
     adminInfo := new(model.Admin)
-    modelErr := model.NewDB().
+    modelErr := model.NewDB(ctx.Request.Context()).
         Where(&model.Admin{ID: userId}).
         Preload("Groups").
         First(&adminInfo).
@@ -96,6 +98,11 @@ func jwtCheck(ctx *router.Context) bool {
     ctx.Set("access_token", accessToken)
     ctx.Set("admin", adminer)
 
+    // 账号设置的语言优先于请求的语言
+    if adminInfo.Language != "" {
+        locale_middleware.Set(ctx, i18n.Default().Match(adminInfo.Language))
+    }
+
     return true
 }
 
@@ -107,6 +114,7 @@ func shouldPassThrough(ctx *router.Context) bool {
         "POST:passport/login",
         "PUT:passport/refresh-token",
         "GET:attachment/download/*",
+        "GET:attachment/temporary",
     }
 
     // 自定义
@@ -0,0 +1,66 @@
+package idempotency
+
+import (
+    "sync"
+    "errors"
+    "net/http"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/facade"
+    facade_cache "github.com/deatil/lakego-doak/lakego/facade/cache"
+    "github.com/deatil/lakego-doak/lakego/middleware/idempotency"
+
+    "github.com/deatil/lakego-doak-admin/admin/support/response"
+    "github.com/deatil/lakego-doak-admin/admin/support/http/code"
+)
+
+/**
+ * 重复提交处理，重试的请求返回首次的响应
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Handler() router.HandlerFunc {
+    var once sync.Once
+    var handler router.HandlerFunc
+
+    return func(ctx *router.Context) {
+        // 配置在服务启动后读取
+        once.Do(func() {
+            handler = newHandler()
+        })
+
+        handler(ctx)
+    }
+}
+
+func newHandler() router.HandlerFunc {
+    conf := facade.Config("admin")
+
+    c := facade.Cache
+    if name := conf.GetString("idempotency.cache"); name != "" {
+        c = facade_cache.Cache(name)
+    }
+
+    return idempotency.Handler(c, idempotency.Options{
+        TTL:             conf.GetDuration("idempotency.ttl"),
+        LockTTL:         conf.GetDuration("idempotency.lock-ttl"),
+        MaxBodySize:     conf.GetInt64("idempotency.max-body-size"),
+        MaxResponseSize: conf.GetInt64("idempotency.max-response-size"),
+        OnConflict: func(ctx *router.Context, err error) {
+            status, msg := http.StatusConflict, "请求正在处理，请勿重复提交"
+
+            switch {
+                case errors.Is(err, idempotency.ErrMismatch):
+                    msg = "Idempotency-Key 已被其他请求使用"
+                case errors.Is(err, idempotency.ErrInvalidKey):
+                    status, msg = http.StatusBadRequest, "Idempotency-Key 格式错误"
+            }
+
+            response.ReturnJsonWithAbort(ctx, false, code.StatusError, msg, router.H{}, status)
+        },
+        OnError: func(ctx *router.Context, err error) {
+            facade.Logger.Error("idempotency: " + err.Error())
+        },
+    })
+}
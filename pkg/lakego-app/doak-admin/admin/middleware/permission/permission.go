@@ -100,6 +100,7 @@ func shouldPassThrough(ctx *router.Context) bool {
         "DELETE:passport/logout",
         "PUT:passport/refresh-token",
         "GET:attachment/download/*",
+        "GET:attachment/temporary",
     }
 
     // 自定义
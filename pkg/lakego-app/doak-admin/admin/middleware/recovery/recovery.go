@@ -101,7 +101,7 @@ func Handler() router.HandlerFunc {
                 }
 
                 // 记录日志
-                facade.Logger.Error(logData)
+                facade.Logger.WithContext(ctx).Error(logData)
 
                 if brokenPipe {
                     responseData(ctx, "服务器内部异常", responsedata)
@@ -1,6 +1,8 @@
 package model
 
 import (
+    "context"
+
     "gorm.io/gorm"
 
     "github.com/deatil/lakego-doak/lakego/uuid"
@@ -15,6 +17,7 @@ type Admin struct {
     Email        string `gorm:"column:email;type:varchar(100);" json:"email"`
     Avatar       string `gorm:"column:avatar;type:char(36);" json:"avatar"`
     Introduce    string `gorm:"column:introduce;type:mediumtext;" json:"introduce"`
+    Language     string `gorm:"column:language;type:varchar(20);" json:"language"`
     IsRoot       int    `gorm:"column:is_root;type:tinyint(1);" json:"is_root"`
     Status       int    `gorm:"column:status;not null;type:tinyint(1);" json:"status"`
     RefreshTime  int    `gorm:"column:refresh_time;type:int(10);" json:"refresh_time"`
@@ -37,7 +40,7 @@ func (this *Admin) BeforeCreate(tx *gorm.DB) error {
     return nil
 }
 
-func NewAdmin() *gorm.DB {
-    return NewDB().Model(&Admin{})
+func NewAdmin(ctx ...context.Context) *gorm.DB {
+    return NewDB(ctx...).Model(&Admin{})
 }
 
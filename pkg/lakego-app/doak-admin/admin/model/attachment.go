@@ -1,6 +1,8 @@
 package model
 
 import (
+    "context"
+
     "gorm.io/gorm"
 
     "github.com/deatil/lakego-doak/lakego/uuid"
@@ -34,8 +36,8 @@ func (this *Attachment) BeforeCreate(tx *gorm.DB) error {
     return nil
 }
 
-func NewAttachment() *gorm.DB {
-    return NewDB().Model(&Attachment{})
+func NewAttachment(ctx ...context.Context) *gorm.DB {
+    return NewDB(ctx...).Model(&Attachment{})
 }
 
 // 附件链接
@@ -1,6 +1,8 @@
 package model
 
 import (
+    "context"
+
     "gorm.io/gorm"
 
     "github.com/deatil/lakego-doak/lakego/uuid"
@@ -32,6 +34,6 @@ func (this *AuthGroup) BeforeCreate(tx *gorm.DB) error {
     return nil
 }
 
-func NewAuthGroup() *gorm.DB {
-    return NewDB().Model(&AuthGroup{})
+func NewAuthGroup(ctx ...context.Context) *gorm.DB {
+    return NewDB(ctx...).Model(&AuthGroup{})
 }
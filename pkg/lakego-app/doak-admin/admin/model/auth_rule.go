@@ -1,6 +1,8 @@
 package model
 
 import (
+    "context"
+
     "gorm.io/gorm"
 
     "github.com/deatil/lakego-doak/lakego/uuid"
@@ -31,6 +33,6 @@ func (this *AuthRule) BeforeCreate(tx *gorm.DB) error {
     return nil
 }
 
-func NewAuthRule() *gorm.DB {
-    return NewDB().Model(&AuthRule{})
+func NewAuthRule(ctx ...context.Context) *gorm.DB {
+    return NewDB(ctx...).Model(&AuthRule{})
 }
@@ -1,6 +1,7 @@
 package model
 
 import (
+    "context"
     "encoding/json"
 
     "gorm.io/gorm"
@@ -14,8 +15,12 @@ type (
     Session = gorm.Session
 )
 
-// 创建一个 db 连接
-func NewDB() *gorm.DB {
+// 创建一个 db 连接，传入请求的上下文时记录链路
+func NewDB(ctx ...context.Context) *gorm.DB {
+    if len(ctx) > 0 && ctx[0] != nil {
+        return facade.DB.WithContext(ctx[0])
+    }
+
     return facade.DB
 }
 
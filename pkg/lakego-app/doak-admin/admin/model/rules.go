@@ -1,6 +1,8 @@
 package model
 
 import (
+    "context"
+
     "gorm.io/gorm"
 
     "github.com/deatil/lakego-doak/lakego/uuid"
@@ -24,8 +26,8 @@ func (this *Rules) BeforeCreate(db *gorm.DB) error {
     return nil
 }
 
-func NewRules() *gorm.DB {
-    return NewDB().Model(&Rules{})
+func NewRules(ctx ...context.Context) *gorm.DB {
+    return NewDB(ctx...).Model(&Rules{})
 }
 
 // 清空数据
@@ -0,0 +1,37 @@
+package model
+
+import (
+    "context"
+
+    "gorm.io/gorm"
+
+    "github.com/deatil/lakego-doak/lakego/uuid"
+)
+
+// 计划任务
+type ScheduleJob struct {
+    ID                 string `gorm:"column:id;size:36;not null;index;" json:"id"`
+    Name               string `gorm:"column:name;size:100;not null;" json:"name"`
+    Spec               string `gorm:"column:spec;size:100;not null;" json:"spec"`
+    Command            string `gorm:"column:command;size:100;not null;" json:"command"`
+    Args               string `gorm:"column:args;size:500;" json:"args"`
+    WithoutOverlapping int    `gorm:"column:without_overlapping;size:1;" json:"without_overlapping"`
+    OneServer          int    `gorm:"column:one_server;size:1;" json:"one_server"`
+    Timeout            int    `gorm:"column:timeout;size:10;" json:"timeout"`
+    Description        string `gorm:"column:description;size:255;" json:"description"`
+    Status             int    `gorm:"column:status;not null;size:1;" json:"status"`
+    UpdateTime         int    `gorm:"column:update_time;size:10;" json:"update_time"`
+    UpdateIp           string `gorm:"column:update_ip;size:50;" json:"update_ip"`
+    AddTime            int    `gorm:"column:add_time;size:10;" json:"add_time"`
+    AddIp              string `gorm:"column:add_ip;size:50;" json:"add_ip"`
+}
+
+func (this *ScheduleJob) BeforeCreate(tx *gorm.DB) error {
+    this.ID = uuid.ToUUIDString()
+
+    return nil
+}
+
+func NewScheduleJob(ctx ...context.Context) *gorm.DB {
+    return NewDB(ctx...).Model(&ScheduleJob{})
+}
@@ -3,6 +3,7 @@ package provider
 import (
     "os"
     "fmt"
+    "net/http"
 
     "github.com/deatil/go-events/events"
     "github.com/deatil/go-datebin/datebin"
@@ -10,7 +11,9 @@ import (
     "github.com/deatil/lakego-doak/lakego/router"
     "github.com/deatil/lakego-doak/lakego/provider"
     "github.com/deatil/lakego-doak/lakego/facade"
+    "github.com/deatil/lakego-doak/lakego/schedule"
     path_tool "github.com/deatil/lakego-doak/lakego/path"
+    ratelimit_middleware "github.com/deatil/lakego-doak/lakego/middleware/ratelimit"
 
     "github.com/deatil/lakego-doak-admin/admin/support/url"
     "github.com/deatil/lakego-doak-admin/admin/support/time"
@@ -23,6 +26,7 @@ import (
     "github.com/deatil/lakego-doak-admin/admin/middleware/cors"
     "github.com/deatil/lakego-doak-admin/admin/middleware/permission"
     "github.com/deatil/lakego-doak-admin/admin/middleware/admincheck"
+    "github.com/deatil/lakego-doak-admin/admin/middleware/idempotency"
 
     // 路由
     admin_route "github.com/deatil/lakego-doak-admin/admin/route"
@@ -32,6 +36,9 @@ import (
 
     // 事件
     "github.com/deatil/lakego-doak-admin/admin/listener"
+
+    // 计划任务
+    admin_schedule "github.com/deatil/lakego-doak-admin/admin/schedule"
 )
 
 // 全局中间件
@@ -53,6 +60,9 @@ var routeMiddlewares = map[string]router.HandlerFunc{
 
     // 超级管理员检测
     "lakego-admin.admin-check": admincheck.Handler(),
+
+    // 重复提交处理
+    "lakego-admin.idempotency": idempotency.Handler(),
 }
 
 // 中间件分组
@@ -61,6 +71,7 @@ var middlewareGroups = map[string][]string{
     "lakego-admin": {
         "lakego-admin.auth",
         "lakego-admin.permission",
+        "lakego-admin.idempotency",
     },
 
     // 超级管理员检测
@@ -93,6 +104,9 @@ func (this *Admin) Boot() {
     // 推送配置
     this.publishConfig()
 
+    // 语言包
+    this.loadTranslations()
+
     // 记录 pid 信息
     this.putSock()
 
@@ -100,6 +114,27 @@ func (this *Admin) Boot() {
     this.loadEvents()
 }
 
+// 计划任务
+func (this *Admin) Schedule(s *schedule.Schedule) {
+    jobs := admin_schedule.New(s, this.App.GetRootCmd()).
+        WithAllowCommands(facade.Config("schedule").GetStringSlice("allow-commands"))
+    admin_schedule.SetDefault(jobs)
+
+    // 导入数据库计划任务
+    if err := jobs.Sync(); err != nil {
+        facade.Logger.Error("schedule job sync: " + err.Error())
+    }
+
+    // 定时同步其他服务修改的任务
+    s.AddFunc(func() {
+        if err := jobs.Sync(); err != nil {
+            facade.Logger.Error("schedule job sync: " + err.Error())
+        }
+    }).
+        WithName(admin_schedule.SyncName).
+        EveryMinute()
+}
+
 // 设置时区
 func (this *Admin) initTimezone() {
     tz := facade.Config("admin").GetString("timezone")
@@ -135,6 +170,9 @@ func (this *Admin) loadCommand() {
 
     // 停止 admin 系统服务
     this.AddCommand(cmd.StopCmd)
+
+    // 重启 admin 系统服务
+    this.AddCommand(cmd.RestartCmd)
 }
 
 // 导入路由
@@ -193,6 +231,11 @@ func (this *Admin) loadMiddleware() {
             router.PushMiddlewareToGroup(groupName, middleware)
         }
     }
+
+    // 限流响应
+    ratelimit_middleware.SetLimitedHandler(func(ctx *router.Context) {
+        response.ReturnJsonWithAbort(ctx, false, code.StatusTooMany, "请求过于频繁，请稍后再试", router.H{}, http.StatusTooManyRequests)
+    })
 }
 
 // 推送配置
@@ -208,8 +251,17 @@ func (this *Admin) publishConfig() {
     }, "admin-config")
 }
 
-// 记录 pid 信息
+// 导入语言包，应用中的 lang/pkg/admin 目录可覆盖
+func (this *Admin) loadTranslations() {
+    this.LoadTranslationsFrom("{root}/pkg/lakego-app/doak-admin/resources/lang", "admin")
+}
+
+// 记录 pid 信息，脚本运行时不覆盖服务的 pid
 func (this *Admin) putSock() {
+    if this.App != nil && this.App.RunningInConsole() {
+        return
+    }
+
     pidPath := facade.Config("admin").GetString("pid-path")
     file := path_tool.FormatPath(pidPath)
 
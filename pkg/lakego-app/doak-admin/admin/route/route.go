@@ -12,8 +12,10 @@ import (
 func Route(engine router.IRouter) {
     // 登陆
     passportController := new(controller.Passport)
-    engine.GET("/passport/captcha", passportController.Captcha)
-    engine.POST("/passport/login", passportController.Login)
+    router.Group(engine, "", "ratelimit:admin-captcha").
+        GET("/passport/captcha", passportController.Captcha)
+    router.Group(engine, "", "ratelimit:admin-login").
+        POST("/passport/login", passportController.Login)
     engine.PUT("/passport/refresh-token", passportController.RefreshToken)
     engine.DELETE("/passport/logout", passportController.Logout)
 
@@ -22,12 +24,14 @@ func Route(engine router.IRouter) {
     engine.GET("/profile", profileController.Index)
     engine.PUT("/profile", profileController.Update)
     engine.PATCH("/profile/avatar", profileController.UpdateAvatar)
+    engine.PATCH("/profile/language", profileController.UpdateLanguage)
     engine.PATCH("/profile/password", profileController.UpdatePasssword)
     engine.GET("/profile/rules", profileController.Rules)
 
     // 上传
     uploadController := new(controller.Upload)
-    engine.POST("/upload/file", uploadController.File)
+    router.Group(engine, "", "ratelimit:admin-upload").
+        POST("/upload/file", uploadController.File)
 
     // 附件
     attachmentController := new(controller.Attachment)
@@ -38,6 +42,7 @@ func Route(engine router.IRouter) {
     engine.DELETE("/attachment/:id", attachmentController.Delete)
     engine.GET("/attachment/downcode/:id", attachmentController.DownloadCode)
     engine.GET("/attachment/download/:code", attachmentController.Download)
+    engine.GET("/attachment/temporary", attachmentController.Temporary)
 
     // 管理员
     adminController := new(controller.Admin)
@@ -93,4 +98,27 @@ func AdminRoute(engine router.IRouter) {
     engine.PATCH("/auth/group/:id/enable", authGroupController.Enable)
     engine.PATCH("/auth/group/:id/disable", authGroupController.Disable)
     engine.PATCH("/auth/group/:id/access", authGroupController.Access)
+
+    // 计划任务
+    scheduleController := new(controller.Schedule)
+    engine.GET("/schedule", scheduleController.Index)
+    engine.GET("/schedule/runs", scheduleController.Runs)
+    engine.GET("/schedule/commands", scheduleController.Commands)
+    engine.PATCH("/schedule/pause", scheduleController.Pause)
+    engine.PATCH("/schedule/resume", scheduleController.Resume)
+    engine.POST("/schedule/run", scheduleController.Run)
+
+    // 数据库计划任务
+    scheduleJobController := new(controller.ScheduleJob)
+    engine.GET("/schedule/job", scheduleJobController.Index)
+    engine.GET("/schedule/job/:id", scheduleJobController.Detail)
+    engine.POST("/schedule/job", scheduleJobController.Create)
+    engine.PUT("/schedule/job/:id", scheduleJobController.Update)
+    engine.DELETE("/schedule/job/:id", scheduleJobController.Delete)
+    engine.PATCH("/schedule/job/:id/enable", scheduleJobController.Enable)
+    engine.PATCH("/schedule/job/:id/disable", scheduleJobController.Disable)
+
+    // 内存日志
+    systemController := new(controller.System)
+    engine.GET("/system/logs", systemController.Logs)
 }
@@ -0,0 +1,248 @@
+package schedule
+
+import (
+    "fmt"
+    "sync"
+    "time"
+    "bytes"
+    "errors"
+    "context"
+    "os/exec"
+
+    "github.com/deatil/lakego-doak/lakego/command"
+    "github.com/deatil/lakego-doak/lakego/facade"
+    "github.com/deatil/lakego-doak/lakego/schedule"
+
+    "github.com/deatil/lakego-doak-admin/admin/model"
+    "github.com/deatil/lakego-doak-admin/admin/support/utils"
+)
+
+// 同步任务名称
+const SyncName = "lakego-admin:schedule-job-sync"
+
+// 输出保留的最大长度
+const maxOutput = 2048
+
+var (
+    // 脚本不存在
+    ErrCommandNotFound = errors.New("schedule: command not found")
+
+    // 脚本不在允许列表中
+    ErrCommandForbidden = errors.New("schedule: command is not allowed")
+)
+
+/**
+ * 数据库计划任务，执行已注册的脚本
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Jobs struct {
+    // 锁定
+    mu sync.Mutex
+
+    // 计划任务
+    schedule *schedule.Schedule
+
+    // 根脚本
+    rootCmd *command.Command
+
+    // 允许执行的脚本
+    allowCommands []string
+
+    // 已加载的任务，任务名称 => 更新标识
+    loaded map[string]string
+}
+
+// 构造函数
+func New(s *schedule.Schedule, rootCmd *command.Command) *Jobs {
+    return &Jobs{
+        schedule: s,
+        rootCmd:  rootCmd,
+        loaded:   make(map[string]string),
+    }
+}
+
+// 设置允许执行的脚本，未设置时不能执行任何脚本
+func (this *Jobs) WithAllowCommands(commands []string) *Jobs {
+    this.allowCommands = commands
+
+    return this
+}
+
+// 默认
+var defaultJobs *Jobs
+
+// 设置默认
+func SetDefault(jobs *Jobs) {
+    defaultJobs = jobs
+}
+
+// 获取默认
+func Default() *Jobs {
+    return defaultJobs
+}
+
+// 计划任务
+func (this *Jobs) GetSchedule() *schedule.Schedule {
+    return this.schedule
+}
+
+// 是否为数据库任务
+func (this *Jobs) IsJob(name string) bool {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    _, ok := this.loaded[name]
+
+    return ok
+}
+
+// 同步数据库任务到计划任务
+func (this *Jobs) Sync() error {
+    // 未安装数据表时跳过
+    if !model.NewDB().Migrator().HasTable(&model.ScheduleJob{}) {
+        return nil
+    }
+
+    var jobs []model.ScheduleJob
+
+    err := model.NewScheduleJob().
+        Where("status = ?", 1).
+        Find(&jobs).
+        Error
+    if err != nil {
+        return err
+    }
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    current := make(map[string]bool)
+    for _, job := range jobs {
+        current[job.Name] = true
+
+        version := fmt.Sprintf("%s:%d", job.ID, job.UpdateTime)
+        if old, ok := this.loaded[job.Name]; ok && old == version {
+            continue
+        }
+
+        // 名称被代码中的任务使用
+        if _, ok := this.loaded[job.Name]; !ok {
+            if _, exists := this.schedule.FindEntry(job.Name); exists {
+                facade.Logger.Error("schedule job " + job.Name + " conflicts with an existing entry")
+                continue
+            }
+        }
+
+        this.schedule.RemoveEntry(job.Name)
+        this.schedule.AddEntry(this.Entry(job))
+
+        this.loaded[job.Name] = version
+    }
+
+    // 已删除或禁用的任务
+    for name := range this.loaded {
+        if !current[name] {
+            this.schedule.RemoveEntry(name)
+            delete(this.loaded, name)
+        }
+    }
+
+    return nil
+}
+
+// 生成计划任务数据
+func (this *Jobs) Entry(job model.ScheduleJob) *schedule.Entry {
+    entry := schedule.NewEntry().
+        WithName(job.Name).
+        WithSpec(job.Spec).
+        AddContextFunc(func(ctx context.Context) error {
+            return this.Run(ctx, job.Command, job.Args)
+        })
+
+    if job.WithoutOverlapping == 1 {
+        entry.WithoutOverlapping()
+    }
+
+    if job.OneServer == 1 {
+        entry.OnOneServer()
+    }
+
+    if job.Timeout > 0 {
+        entry.WithTimeout(time.Duration(job.Timeout) * time.Second)
+    }
+
+    return entry
+}
+
+// 执行脚本
+func (this *Jobs) Run(ctx context.Context, name string, args string) error {
+    if err := this.CheckCommand(name); err != nil {
+        return err
+    }
+
+    params, err := utils.SplitArgs(args)
+    if err != nil {
+        return err
+    }
+
+    cmd := exec.CommandContext(ctx, command.CommandLookPath(), append([]string{name}, params...)...)
+
+    var out bytes.Buffer
+    cmd.Stdout = &out
+    cmd.Stderr = &out
+
+    if err := cmd.Run(); err != nil {
+        output := out.String()
+        if len(output) > maxOutput {
+            output = output[len(output) - maxOutput:]
+        }
+
+        return fmt.Errorf("%w: %s", err, output)
+    }
+
+    return nil
+}
+
+// 检测脚本是否可用，只能执行允许列表中已注册的脚本
+func (this *Jobs) CheckCommand(name string) error {
+    allowed := false
+    for _, allow := range this.allowCommands {
+        if name == allow {
+            allowed = true
+            break
+        }
+    }
+
+    if !allowed {
+        return ErrCommandForbidden
+    }
+
+    for _, cmd := range this.rootCmd.Commands() {
+        if cmd.Name() == name {
+            return nil
+        }
+    }
+
+    return ErrCommandNotFound
+}
+
+// 可用的脚本列表
+func (this *Jobs) Commands() []map[string]string {
+    list := make([]map[string]string, 0)
+
+    for _, cmd := range this.rootCmd.Commands() {
+        if this.CheckCommand(cmd.Name()) != nil {
+            continue
+        }
+
+        list = append(list, map[string]string{
+            "name":    cmd.Name(),
+            "short":   cmd.Short,
+            "example": cmd.Example,
+        })
+    }
+
+    return list
+}
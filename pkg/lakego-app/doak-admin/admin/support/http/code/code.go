@@ -5,6 +5,7 @@ const (
     // 常用业务状态码
     StatusSuccess   int = 0
     StatusError     int = 1
+    StatusTooMany   int = 99996
     StatusException int = 99997
     StatusUnknown   int = 99998
     StatusInvalid   int = 99999
@@ -0,0 +1,149 @@
+package response
+
+import (
+    "os"
+    "strconv"
+    "strings"
+    "testing"
+    "go/ast"
+    "go/token"
+    "go/parser"
+    "path/filepath"
+
+    "github.com/deatil/lakego-doak/lakego/i18n"
+)
+
+// 源码目录
+const adminDir = "../.."
+
+// 英文语言包
+const enFile = "../../../resources/lang/en.yml"
+
+// 响应方法，第二个参数为提示信息
+var responseMethods = map[string]bool{
+    "Error":           true,
+    "ErrorWithData":   true,
+    "Success":         true,
+    "SuccessWithData": true,
+}
+
+// 检测 admin 源码中的提示信息是否都有英文翻译
+func Test_EnglishCatalogue(t *testing.T) {
+    translator := i18n.New()
+    if err := translator.LoadFile("admin", enFile); err != nil {
+        t.Fatal(err)
+    }
+
+    messages := make(map[string]string)
+
+    err := filepath.Walk(adminDir, func(path string, info os.FileInfo, err error) error {
+        if err != nil {
+            return err
+        }
+
+        if info.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+            return nil
+        }
+
+        fset := token.NewFileSet()
+        file, err := parser.ParseFile(fset, path, nil, 0)
+        if err != nil {
+            return err
+        }
+
+        ast.Inspect(file, func(node ast.Node) bool {
+            switch n := node.(type) {
+                case *ast.CallExpr:
+                    for _, msg := range responseMessages(n) {
+                        messages[msg] = fset.Position(n.Pos()).String()
+                    }
+                case *ast.CompositeLit:
+                    for _, msg := range validateMessages(n) {
+                        messages[msg] = fset.Position(n.Pos()).String()
+                    }
+            }
+
+            return true
+        })
+
+        return nil
+    })
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if len(messages) == 0 {
+        t.Fatal("no messages found")
+    }
+
+    for msg, pos := range messages {
+        key := msg
+        if !strings.Contains(key, i18n.NamespaceSeparator) {
+            key = "admin" + i18n.NamespaceSeparator + key
+        }
+
+        if !translator.Has("en", key) {
+            t.Errorf("%s: missing en translation for %q", pos, msg)
+        }
+    }
+}
+
+// this.Error(ctx, "...") 及 response.Error(ctx, "...") 的提示信息
+func responseMessages(call *ast.CallExpr) []string {
+    sel, ok := call.Fun.(*ast.SelectorExpr)
+    if !ok || !responseMethods[sel.Sel.Name] || len(call.Args) < 2 {
+        return nil
+    }
+
+    if msg, ok := stringLit(call.Args[1]); ok && msg != "" {
+        return []string{msg}
+    }
+
+    return nil
+}
+
+// 验证器 map[string]string{"field.tag": "..."} 的提示信息，:field 替换为字段名
+func validateMessages(lit *ast.CompositeLit) []string {
+    typ, ok := lit.Type.(*ast.MapType)
+    if !ok || !isIdent(typ.Key, "string") || !isIdent(typ.Value, "string") {
+        return nil
+    }
+
+    var msgs []string
+    for _, elt := range lit.Elts {
+        kv, ok := elt.(*ast.KeyValueExpr)
+        if !ok {
+            continue
+        }
+
+        key, ok1 := stringLit(kv.Key)
+        msg, ok2 := stringLit(kv.Value)
+        if !ok1 || !ok2 || !strings.Contains(key, ".") {
+            continue
+        }
+
+        field := key[:strings.Index(key, ".")]
+        msgs = append(msgs, strings.Replace(msg, ":field", field, -1))
+    }
+
+    return msgs
+}
+
+func stringLit(expr ast.Expr) (string, bool) {
+    lit, ok := expr.(*ast.BasicLit)
+    if !ok || lit.Kind != token.STRING {
+        return "", false
+    }
+
+    str, err := strconv.Unquote(lit.Value)
+    if err != nil {
+        return "", false
+    }
+
+    return str, true
+}
+
+func isIdent(expr ast.Expr, name string) bool {
+    ident, ok := expr.(*ast.Ident)
+    return ok && ident.Name == name
+}
@@ -1,8 +1,12 @@
 package response
 
 import (
+    "strings"
+
+    "github.com/deatil/lakego-doak/lakego/i18n"
     "github.com/deatil/lakego-doak/lakego/router"
     "github.com/deatil/lakego-doak/lakego/http/response"
+    "github.com/deatil/lakego-doak/lakego/middleware/requestid"
 
     "github.com/deatil/lakego-doak-admin/admin/support/http/code"
 )
@@ -18,6 +22,9 @@ type JSONResult struct {
     Code    int    `json:"code"`
     Message string `json:"message"`
     Data    any    `json:"data"`
+
+    // 请求 ID，错误时返回，用于关联日志
+    RequestID string `json:"request_id,omitempty"`
 }
 
 // 默认
@@ -86,12 +93,7 @@ func (this *Response) ReturnJson(
         resp.WithHttpCode(httpCode[0])
     }
 
-    resp.ReturnJson(JSONResult{
-        Success: success,
-        Code:    dataCode,
-        Message: msg,
-        Data:    data,
-    })
+    resp.ReturnJson(this.result(ctx, success, dataCode, msg, data))
 }
 
 /**
@@ -111,14 +113,44 @@ func (this *Response) ReturnJsonWithAbort(
         resp.WithHttpCode(httpCode[0])
     }
 
-    resp.ReturnJson(JSONResult{
+    resp.ReturnJson(this.result(ctx, success, dataCode, msg, data))
+
+    resp.Abort()
+}
+
+// 响应数据，提示信息按当前语言翻译，错误时带上请求 ID
+func (this *Response) result(
+    ctx *router.Context,
+    success bool,
+    dataCode int,
+    msg string,
+    data any,
+) JSONResult {
+    result := JSONResult{
         Success: success,
         Code:    dataCode,
-        Message: msg,
+        Message: this.trans(ctx, msg),
         Data:    data,
-    })
+    }
 
-    resp.Abort()
+    if !success {
+        result.RequestID = requestid.Get(ctx)
+    }
+
+    return result
+}
+
+// 翻译提示信息，不带命名空间时使用 admin 语言包，以提示信息为键名
+func (this *Response) trans(ctx *router.Context, msg string) string {
+    if msg == "" {
+        return msg
+    }
+
+    if strings.Contains(msg, i18n.NamespaceSeparator) {
+        return i18n.Trans(ctx, msg)
+    }
+
+    return i18n.Trans(ctx, "admin" + i18n.NamespaceSeparator + msg)
 }
 
 // 错误暂停
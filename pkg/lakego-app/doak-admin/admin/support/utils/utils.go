@@ -1,6 +1,9 @@
 package utils
 
 import (
+    "errors"
+    "strings"
+
     "github.com/deatil/go-hash/hash"
 
     "github.com/deatil/lakego-doak/lakego/array"
@@ -21,3 +24,55 @@ func FormatAccess[T constraints.Ordered](olds, news []T) (adds, deletes []T) {
 
     return
 }
+
+// 参数未闭合的引号
+var ErrUnclosedQuote = errors.New("utils: unclosed quote in args")
+
+// 按空格拆分脚本参数，支持单引号、双引号及反斜杠转义
+func SplitArgs(data string) ([]string, error) {
+    args := make([]string, 0)
+
+    var current strings.Builder
+    var quote rune
+    inArg := false
+    escaped := false
+
+    for _, r := range data {
+        switch {
+            case escaped:
+                current.WriteRune(r)
+                escaped = false
+            case r == '\\' && quote != '\'':
+                escaped = true
+                inArg = true
+            case quote != 0:
+                if r == quote {
+                    quote = 0
+                } else {
+                    current.WriteRune(r)
+                }
+            case r == '"' || r == '\'':
+                quote = r
+                inArg = true
+            case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+                if inArg {
+                    args = append(args, current.String())
+                    current.Reset()
+                    inArg = false
+                }
+            default:
+                current.WriteRune(r)
+                inArg = true
+        }
+    }
+
+    if quote != 0 || escaped {
+        return nil, ErrUnclosedQuote
+    }
+
+    if inArg {
+        args = append(args, current.String())
+    }
+
+    return args, nil
+}
@@ -26,3 +26,34 @@ func Test_FormatAccess(t *testing.T) {
         eq([]string{"test"}, deletes, "FormatAccess deletes")
     }
 }
+
+func Test_SplitArgs(t *testing.T) {
+    eq := AssertEqualT(t)
+
+    {
+        args, err := SplitArgs(`--name=test  -f "a b" 'c "d"' e\ f`)
+
+        eq(err, nil, "SplitArgs err")
+        eq(args, []string{"--name=test", "-f", "a b", `c "d"`, "e f"}, "SplitArgs")
+    }
+
+    {
+        args, err := SplitArgs("  ")
+
+        eq(err, nil, "SplitArgs empty err")
+        eq(args, []string{}, "SplitArgs empty")
+    }
+
+    {
+        args, err := SplitArgs(`--id ""`)
+
+        eq(err, nil, "SplitArgs empty quote err")
+        eq(args, []string{"--id", ""}, "SplitArgs empty quote")
+    }
+
+    {
+        _, err := SplitArgs(`"abc`)
+
+        eq(err, ErrUnclosedQuote, "SplitArgs unclosed")
+    }
+}
@@ -1,11 +1,12 @@
 package admin
 
 import (
+    "github.com/deatil/lakego-doak/lakego/router"
     "github.com/deatil/lakego-doak/lakego/validate"
 )
 
 // 创建验证
-func Create(data map[string]any) string {
+func Create(ctx *router.Context, data map[string]any) string {
     // 规则
     rules := map[string]any{
         "group_id": "required,len=36",
@@ -35,7 +36,7 @@ func Create(data map[string]any) string {
         "status.required": "状态选项不能为空",
     }
 
-    ok, err := validate.ValidateMapError(data, rules, messages)
+    ok, err := validate.WithContext(ctx).ValidateMapError(data, rules, messages)
     if ok {
         return ""
     }
@@ -44,7 +45,7 @@ func Create(data map[string]any) string {
 }
 
 // 编辑验证
-func Update(data map[string]any) string {
+func Update(ctx *router.Context, data map[string]any) string {
     // 规则
     rules := map[string]any{
         "name": "required,min=2,max=20",
@@ -71,7 +72,7 @@ func Update(data map[string]any) string {
         "status.required": "状态选项不能为空",
     }
 
-    ok, err := validate.ValidateMapError(data, rules, messages)
+    ok, err := validate.WithContext(ctx).ValidateMapError(data, rules, messages)
     if ok {
         return ""
     }
@@ -80,7 +81,7 @@ func Update(data map[string]any) string {
 }
 
 // 修改头像
-func UpdateAvatar(data map[string]any) string {
+func UpdateAvatar(ctx *router.Context, data map[string]any) string {
     // 规则
     rules := map[string]any{
         "avatar": "required,len=36",
@@ -92,7 +93,7 @@ func UpdateAvatar(data map[string]any) string {
         "avatar.len": "头像数据错误",
     }
 
-    ok, err := validate.ValidateMapError(data, rules, messages)
+    ok, err := validate.WithContext(ctx).ValidateMapError(data, rules, messages)
     if ok {
         return ""
     }
@@ -1,11 +1,12 @@
 package authrule
 
 import (
+    "github.com/deatil/lakego-doak/lakego/router"
     "github.com/deatil/lakego-doak/lakego/validate"
 )
 
 // 创建验证
-func Create(data map[string]any) string {
+func Create(ctx *router.Context, data map[string]any) string {
     // 规则
     rules := map[string]any{
         "parentid": "required",
@@ -29,7 +30,7 @@ func Create(data map[string]any) string {
         "status.required": "状态选项不能为空",
     }
 
-    ok, err := validate.ValidateMapError(data, rules, messages)
+    ok, err := validate.WithContext(ctx).ValidateMapError(data, rules, messages)
     if ok {
         return ""
     }
@@ -38,7 +39,7 @@ func Create(data map[string]any) string {
 }
 
 // 编辑验证
-func Update(data map[string]any) string {
+func Update(ctx *router.Context, data map[string]any) string {
     // 规则
     rules := map[string]any{
         "parentid": "required",
@@ -62,7 +63,7 @@ func Update(data map[string]any) string {
         "status.required": "状态选项不能为空",
     }
 
-    ok, err := validate.ValidateMapError(data, rules, messages)
+    ok, err := validate.WithContext(ctx).ValidateMapError(data, rules, messages)
     if ok {
         return ""
     }
@@ -1,6 +1,7 @@
 package passport
 
 import (
+    "github.com/deatil/lakego-doak/lakego/router"
     "github.com/deatil/lakego-doak/lakego/validate"
 )
 
@@ -10,9 +11,9 @@ user := map[string]any{
     "password": "6b4ee75684079f24bb6331d6b4abbb57",
     "captcha": "wert",
 }
-Login(user)
+Login(ctx, user)
 */
-func Login(data map[string]any) string {
+func Login(ctx *router.Context, data map[string]any) string {
     // 规则
     rules := map[string]any{
         "name": "required",
@@ -29,7 +30,7 @@ func Login(data map[string]any) string {
         "captcha.len": ":field 字段为4位长度",
     }
 
-    ok, err := validate.ValidateMapError(data, rules, messages)
+    ok, err := validate.WithContext(ctx).ValidateMapError(data, rules, messages)
     if ok {
         return ""
     }
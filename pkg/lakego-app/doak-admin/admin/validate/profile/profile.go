@@ -1,11 +1,12 @@
 package passport
 
 import (
+    "github.com/deatil/lakego-doak/lakego/router"
     "github.com/deatil/lakego-doak/lakego/validate"
 )
 
 // 账号信息更新
-func Update(data map[string]any) string {
+func Update(ctx *router.Context, data map[string]any) string {
     // 规则
     rules := map[string]any{
         "nickname": "required,max=150",
@@ -24,7 +25,7 @@ func Update(data map[string]any) string {
         "introduce.max": "简介字数超过了限制",
     }
 
-    ok, err := validate.ValidateMapError(data, rules, messages)
+    ok, err := validate.WithContext(ctx).ValidateMapError(data, rules, messages)
     if ok {
         return ""
     }
@@ -33,7 +34,7 @@ func Update(data map[string]any) string {
 }
 
 // 更新头像
-func UpdateAvatar(data map[string]any) string {
+func UpdateAvatar(ctx *router.Context, data map[string]any) string {
     // 规则
     rules := map[string]any{
         "avatar": "required,len=36",
@@ -45,7 +46,28 @@ func UpdateAvatar(data map[string]any) string {
         "avatar.len": "头像数据错误",
     }
 
-    ok, err := validate.ValidateMapError(data, rules, messages)
+    ok, err := validate.WithContext(ctx).ValidateMapError(data, rules, messages)
+    if ok {
+        return ""
+    }
+
+    return err
+}
+
+// 修改语言
+func UpdateLanguage(ctx *router.Context, data map[string]any) string {
+    // 规则
+    rules := map[string]any{
+        "language": "required,max=20",
+    }
+
+    // 错误提示
+    messages := map[string]string{
+        "language.required": "语言不能为空",
+        "language.max": "语言不存在",
+    }
+
+    ok, err := validate.WithContext(ctx).ValidateMapError(data, rules, messages)
     if ok {
         return ""
     }
@@ -54,7 +76,7 @@ func UpdateAvatar(data map[string]any) string {
 }
 
 // 修改密码
-func UpdatePasssword(data map[string]any) string {
+func UpdatePasssword(ctx *router.Context, data map[string]any) string {
     // 规则
     rules := map[string]any{
         "oldpassword": "required,len=32",
@@ -72,7 +94,7 @@ func UpdatePasssword(data map[string]any) string {
         "newpassword_confirm.len": "确认密码错误",
     }
 
-    ok, err := validate.ValidateMapError(data, rules, messages)
+    ok, err := validate.WithContext(ctx).ValidateMapError(data, rules, messages)
     if ok {
         return ""
     }
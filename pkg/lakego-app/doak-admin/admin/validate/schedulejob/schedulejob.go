@@ -0,0 +1,64 @@
+package schedulejob
+
+import (
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/validate"
+)
+
+// 创建验证
+func Create(ctx *router.Context, data map[string]any) string {
+    // 规则
+    rules := map[string]any{
+        "name": "required,max=100",
+        "spec": "required,max=100",
+        "command": "required,max=100",
+        "status": "required",
+    }
+
+    // 错误提示
+    messages := map[string]string{
+        "name.required": "任务名称不能为空",
+        "name.max": "任务名称最大字符需要100个",
+        "spec.required": "计划时间不能为空",
+        "spec.max": "计划时间最大字符需要100个",
+        "command.required": "执行脚本不能为空",
+        "command.max": "执行脚本最大字符需要100个",
+        "status.required": "状态选项不能为空",
+    }
+
+    ok, err := validate.WithContext(ctx).ValidateMapError(data, rules, messages)
+    if ok {
+        return ""
+    }
+
+    return err
+}
+
+// 编辑验证
+func Update(ctx *router.Context, data map[string]any) string {
+    // 规则
+    rules := map[string]any{
+        "name": "required,max=100",
+        "spec": "required,max=100",
+        "command": "required,max=100",
+        "status": "required",
+    }
+
+    // 错误提示
+    messages := map[string]string{
+        "name.required": "任务名称不能为空",
+        "name.max": "任务名称最大字符需要100个",
+        "spec.required": "计划时间不能为空",
+        "spec.max": "计划时间最大字符需要100个",
+        "command.required": "执行脚本不能为空",
+        "command.max": "执行脚本最大字符需要100个",
+        "status.required": "状态选项不能为空",
+    }
+
+    ok, err := validate.WithContext(ctx).ValidateMapError(data, rules, messages)
+    if ok {
+        return ""
+    }
+
+    return err
+}
@@ -0,0 +1,174 @@
+package archive
+
+import (
+    "io"
+    "os"
+    "bytes"
+    "errors"
+    "strings"
+    "archive/zip"
+    "path/filepath"
+    "encoding/base64"
+)
+
+// 签名文件后缀
+const SignatureExt = ".sig"
+
+/**
+ * 签名扩展包
+ *
+ * 扩展包为 zip 文件，根目录需包含 manifest.json，
+ * 签名为对整个 zip 文件的分离签名，以 base64 格式
+ * 保存在同目录的 {file}.sig 文件中
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Archive struct {
+    // 清单
+    Manifest Manifest
+
+    // 验证通过的公钥名称
+    Signer string
+
+    reader *zip.Reader
+}
+
+// 打开扩展包
+func Open(file string, verifier *Verifier) (*Archive, error) {
+    data, err := os.ReadFile(file)
+    if err != nil {
+        return nil, err
+    }
+
+    sig, err := os.ReadFile(file + SignatureExt)
+    if err != nil {
+        return nil, errors.New("扩展包签名文件不存在")
+    }
+
+    return Load(data, sig, verifier)
+}
+
+// 从数据加载扩展包
+func Load(data []byte, sig []byte, verifier *Verifier) (*Archive, error) {
+    signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sig)))
+    if err != nil {
+        return nil, errors.New("扩展包签名格式错误")
+    }
+
+    signer, err := verifier.Verify(data, signature)
+    if err != nil {
+        return nil, err
+    }
+
+    reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+    if err != nil {
+        return nil, err
+    }
+
+    manifestData, err := readFile(reader, ManifestFile)
+    if err != nil {
+        return nil, errors.New("扩展包清单文件不存在")
+    }
+
+    manifest, err := ParseManifest(manifestData)
+    if err != nil {
+        return nil, err
+    }
+
+    return &Archive{
+        Manifest: manifest,
+        Signer:   signer,
+        reader:   reader,
+    }, nil
+}
+
+// 文件列表
+func (this *Archive) Files() []string {
+    files := make([]string, 0, len(this.reader.File))
+    for _, f := range this.reader.File {
+        if !f.FileInfo().IsDir() {
+            files = append(files, f.Name)
+        }
+    }
+
+    return files
+}
+
+// 读取文件
+func (this *Archive) ReadFile(name string) ([]byte, error) {
+    return readFile(this.reader, name)
+}
+
+// 解压到目录，清单文件不解压
+func (this *Archive) Extract(dir string) error {
+    dir, err := filepath.Abs(dir)
+    if err != nil {
+        return err
+    }
+
+    for _, f := range this.reader.File {
+        if f.Name == ManifestFile {
+            continue
+        }
+
+        target := filepath.Join(dir, filepath.FromSlash(f.Name))
+
+        // 防止路径穿越
+        if target != dir && !strings.HasPrefix(target, dir + string(os.PathSeparator)) {
+            return errors.New("扩展包文件路径错误: " + f.Name)
+        }
+
+        if f.FileInfo().IsDir() {
+            if err := os.MkdirAll(target, 0755); err != nil {
+                return err
+            }
+
+            continue
+        }
+
+        if err := extractFile(f, target); err != nil {
+            return err
+        }
+    }
+
+    return nil
+}
+
+func extractFile(f *zip.File, target string) error {
+    if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+        return err
+    }
+
+    rc, err := f.Open()
+    if err != nil {
+        return err
+    }
+    defer rc.Close()
+
+    out, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+    if err != nil {
+        return err
+    }
+    defer out.Close()
+
+    _, err = io.Copy(out, rc)
+
+    return err
+}
+
+func readFile(reader *zip.Reader, name string) ([]byte, error) {
+    for _, f := range reader.File {
+        if f.Name == name {
+            rc, err := f.Open()
+            if err != nil {
+                return nil, err
+            }
+            defer rc.Close()
+
+            return io.ReadAll(rc)
+        }
+    }
+
+    return nil, os.ErrNotExist
+}
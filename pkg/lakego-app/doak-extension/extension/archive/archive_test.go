@@ -0,0 +1,115 @@
+package archive
+
+import (
+    "bytes"
+    "testing"
+    "reflect"
+    "archive/zip"
+    "encoding/base64"
+
+    "github.com/deatil/go-cryptobin/cryptobin/eddsa"
+)
+
+func AssertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func makeZip(t *testing.T, files map[string]string) []byte {
+    buf := new(bytes.Buffer)
+
+    w := zip.NewWriter(buf)
+    for name, content := range files {
+        f, err := w.Create(name)
+        if err != nil {
+            t.Fatal(err)
+        }
+
+        f.Write([]byte(content))
+    }
+
+    if err := w.Close(); err != nil {
+        t.Fatal(err)
+    }
+
+    return buf.Bytes()
+}
+
+func Test_Load(t *testing.T) {
+    eq := AssertEqualT(t)
+
+    key := eddsa.GenerateKey()
+    pub := key.CreatePublicKey().ToKeyBytes()
+
+    data := makeZip(t, map[string]string{
+        ManifestFile: `{"name":"lakego.demo","version":"1.0.1","require":{"lakego.base":"^1.0"}}`,
+        "resources/view/index.html": "demo",
+    })
+
+    sig := key.WithData(data).Sign().ToBase64String()
+
+    verifier := NewVerifier(TrustedKey{
+        Name: "lakego",
+        Type: "ed25519",
+        Key:  pub,
+    })
+
+    pkg, err := Load(data, []byte(sig), verifier)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    eq(pkg.Signer, "lakego", "Load Signer")
+    eq(pkg.Manifest.Name, "lakego.demo", "Load Manifest Name")
+    eq(pkg.Manifest.Require, map[string]string{"lakego.base": "^1.0"}, "Load Manifest Require")
+
+    content, _ := pkg.ReadFile("resources/view/index.html")
+    eq(string(content), "demo", "Load ReadFile")
+
+    // 篡改内容
+    tampered := append([]byte{}, data...)
+    tampered[len(tampered)/2] ^= 0xff
+
+    _, err = Load(tampered, []byte(sig), verifier)
+    eq(err, ErrInvalidSignature, "Load tampered")
+
+    // 不可信公钥
+    other := eddsa.GenerateKey().CreatePublicKey().ToKeyBytes()
+    _, err = Load(data, []byte(sig), NewVerifier(TrustedKey{Name: "other", Type: "ed25519", Key: other}))
+    eq(err, ErrInvalidSignature, "Load untrusted")
+
+    sig2 := base64.StdEncoding.EncodeToString([]byte("bad"))
+    _, err = Load(data, []byte(sig2), verifier)
+    eq(err, ErrInvalidSignature, "Load bad sig")
+}
+
+func Test_Extract(t *testing.T) {
+    key := eddsa.GenerateKey()
+    pub := key.CreatePublicKey().ToKeyBytes()
+
+    data := makeZip(t, map[string]string{
+        ManifestFile: `{"name":"lakego.demo","version":"1.0.1"}`,
+        "../evil.txt": "evil",
+    })
+
+    sig := key.WithData(data).Sign().ToBase64String()
+
+    pkg, err := Load(data, []byte(sig), NewVerifier(TrustedKey{Name: "lakego", Type: "ed25519", Key: pub}))
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if err := pkg.Extract(t.TempDir()); err == nil {
+        t.Error("Failed Extract: path traversal should be refused")
+    }
+}
+
+func Test_ParseManifest(t *testing.T) {
+    _, err := ParseManifest([]byte(`{"name":"../demo","version":"1.0.0"}`))
+    if err == nil {
+        t.Error("Failed ParseManifest: bad name should be refused")
+    }
+}
@@ -0,0 +1,63 @@
+package archive
+
+import (
+    "errors"
+    "regexp"
+    "encoding/json"
+)
+
+// 清单文件名
+const ManifestFile = "manifest.json"
+
+// 扩展名称格式，比如 lakego.log-viewer
+var nameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_\-]+(\.[a-zA-Z0-9_\-]+)+$`)
+
+// 扩展包清单
+type Manifest struct {
+    // 名称, 比如 lakego.log-viewer
+    Name string `json:"name"`
+
+    // 扩展名称
+    Title string `json:"title"`
+
+    // 扩展描述
+    Description string `json:"description"`
+
+    // 版本号
+    Version string `json:"version"`
+
+    // 适配系统版本
+    Adaptation string `json:"adaptation"`
+
+    // 依赖扩展
+    Require map[string]string `json:"require"`
+}
+
+// 解析清单
+func ParseManifest(data []byte) (Manifest, error) {
+    var manifest Manifest
+
+    if err := json.Unmarshal(data, &manifest); err != nil {
+        return manifest, err
+    }
+
+    if manifest.Name == "" || manifest.Version == "" {
+        return manifest, errors.New("扩展包清单信息不完整")
+    }
+
+    if !nameRegexp.MatchString(manifest.Name) {
+        return manifest, errors.New("扩展包名称格式错误")
+    }
+
+    return manifest, nil
+}
+
+// 转为 JSON
+func (this Manifest) ToJSON() []byte {
+    data, err := json.Marshal(this)
+    if err != nil {
+        return nil
+    }
+
+    return data
+}
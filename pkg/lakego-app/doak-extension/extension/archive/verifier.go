@@ -0,0 +1,100 @@
+package archive
+
+import (
+    "errors"
+    "strings"
+
+    "github.com/deatil/go-cryptobin/cryptobin/rsa"
+    "github.com/deatil/go-cryptobin/cryptobin/sm2"
+    "github.com/deatil/go-cryptobin/cryptobin/eddsa"
+)
+
+var (
+    // 签名验证失败
+    ErrInvalidSignature = errors.New("扩展包签名验证失败")
+
+    // 没有可信公钥
+    ErrNoTrustedKey = errors.New("没有配置可信公钥")
+)
+
+// 可信公钥
+type TrustedKey struct {
+    // 名称
+    Name string
+
+    // 类型: rsa | ed25519 | sm2
+    Type string
+
+    // PEM 格式公钥
+    Key []byte
+}
+
+/**
+ * 扩展包签名验证
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Verifier struct {
+    keys []TrustedKey
+}
+
+// 构造函数
+func NewVerifier(keys ...TrustedKey) *Verifier {
+    return &Verifier{
+        keys: keys,
+    }
+}
+
+// 添加可信公钥
+func (this *Verifier) AddKey(key TrustedKey) *Verifier {
+    this.keys = append(this.keys, key)
+
+    return this
+}
+
+// 验证签名，返回验证通过的公钥名称
+func (this *Verifier) Verify(data []byte, sig []byte) (string, error) {
+    if len(this.keys) == 0 {
+        return "", ErrNoTrustedKey
+    }
+
+    for _, key := range this.keys {
+        ok, err := VerifyWithKey(key, data, sig)
+        if err == nil && ok {
+            return key.Name, nil
+        }
+    }
+
+    return "", ErrInvalidSignature
+}
+
+// 使用单个公钥验证
+func VerifyWithKey(key TrustedKey, data []byte, sig []byte) (bool, error) {
+    switch strings.ToLower(key.Type) {
+        case "rsa":
+            obj := rsa.New().
+                FromPublicKey(key.Key).
+                SetSignHash("SHA256").
+                FromBytes(sig).
+                Verify(data)
+
+            return obj.ToVerify(), obj.Error()
+        case "ed25519", "eddsa":
+            obj := eddsa.New().
+                FromPublicKey(key.Key).
+                FromBytes(sig).
+                Verify(data)
+
+            return obj.ToVerify(), obj.Error()
+        case "sm2":
+            obj := sm2.New().
+                FromPublicKey(key.Key).
+                FromBytes(sig).
+                Verify(data)
+
+            return obj.ToVerify(), obj.Error()
+    }
+
+    return false, errors.New("不支持的公钥类型: " + key.Type)
+}
@@ -23,6 +23,7 @@ import (
  * > go run main.go lakego-admin:extension --action=enable --name=lakego.demo
  * > go run main.go lakego-admin:extension --action=disable --name=lakego.demo
  * > go run main.go lakego-admin:extension --action=sort --name=lakego.demo --sort=105
+ * > go run main.go lakego-admin:extension --action=import --file=./lakego.demo-1.0.1.zip
  *
  * @create 2023-7-3
  * @author deatil
@@ -43,12 +44,14 @@ var ExtensionCmd = &command.Command{
 var action string
 var name string
 var sort int
+var file string
 
 func init() {
     pf := ExtensionCmd.Flags()
     pf.StringVarP(&action, "action", "a", "", "操作类型")
     pf.StringVarP(&name, "name", "n", "", "扩展名称")
     pf.IntVarP(&sort, "sort", "s", 100, "扩展排序值")
+    pf.StringVarP(&file, "file", "f", "", "签名扩展包文件")
 
     command.MarkFlagRequired(pf, "action")
 }
@@ -119,6 +122,12 @@ func ExtensionCtl() {
                 fmt.Println("更新扩展排序成功")
                 return
             }
+        case "import":
+            err = newExtension.Import(file)
+            if err == nil {
+                fmt.Println("导入扩展包成功")
+                return
+            }
     }
 
     fmt.Println(err.Error())
@@ -48,6 +48,19 @@ type Extension struct {
     // map[string]string{'lakego.log-viewer' => '1.0.*'}
     Require map[string]string `json:"require"`
 
+    // 数据库迁移，更新时只执行新版本的迁移
+    Migrations []Migration `json:"-"`
+
+    // 默认配置文件，配置名称 => 源文件
+    // map[string]string{"demo": "{root}/extension/lakego/demo/config/demo.yml"}
+    Config map[string]string `json:"-"`
+
+    // 权限规则，每项需包含 slug，可包含 children
+    Rules []map[string]any `json:"-"`
+
+    // 静态资源，源路径 => 目标路径
+    Assets map[string]string `json:"-"`
+
     // 安装后
     Install func() error `json:"-"`
 
@@ -0,0 +1,245 @@
+package extension
+
+import (
+    "os"
+    "fmt"
+    "path/filepath"
+
+    "gorm.io/gorm"
+
+    "github.com/deatil/lakego-doak/lakego/path"
+    "github.com/deatil/lakego-doak/lakego/publish"
+
+    "github.com/deatil/lakego-doak-extension/extension/model"
+    "github.com/deatil/lakego-doak-extension/extension/lifecycle"
+)
+
+// 数据库迁移
+type Migration = lifecycle.Migration
+
+// 记录扩展数据
+type RecordFunc = func(tx *gorm.DB) error
+
+// 静态资源推送分组
+func AssetsGroup(name string) string {
+    return "extension-" + name
+}
+
+// 配置推送分组
+func ConfigGroup(name string) string {
+    return "extension-" + name + "-config"
+}
+
+// 注册推送，可用 lakego:publish --tag=[group] 单独推送
+func (this *Manager) registerPublish(ext Extension) {
+    if len(ext.Assets) > 0 {
+        assets := make(map[string]string)
+        for from, to := range ext.Assets {
+            assets[path.FormatPath(from)] = path.FormatPath(to)
+        }
+
+        publish.Instance().Publish(this, assets, AssetsGroup(ext.Name))
+    }
+
+    if len(ext.Config) > 0 {
+        publish.Instance().Publish(this, configPaths(ext), ConfigGroup(ext.Name))
+    }
+}
+
+// 配置文件推送路径
+func configPaths(ext Extension) map[string]string {
+    paths := make(map[string]string)
+    for name, from := range ext.Config {
+        from = path.FormatPath(from)
+
+        paths[from] = path.ConfigPath("/" + name + filepath.Ext(from))
+    }
+
+    return paths
+}
+
+// 安装扩展，迁移、规则、记录和安装回调在同一事务中执行
+// 失败时回滚事务，按倒序执行已执行迁移的 Down，并恢复推送的文件
+func (this *Manager) RunInstall(ext Extension, record RecordFunc) error {
+    return this.runLifecycle(func(tx *gorm.DB, p *lifecycle.Publisher) error {
+        return migrate(tx, lifecycle.Pending(ext.Migrations, ""), func() error {
+            if err := lifecycle.CreateRules(NewRule().WithDB(tx), ext.Rules); err != nil {
+                return err
+            }
+
+            if err := publishExtension(p, ext); err != nil {
+                return err
+            }
+
+            if err := record(tx); err != nil {
+                return err
+            }
+
+            if ext.Install != nil {
+                return ext.Install()
+            }
+
+            return nil
+        })
+    })
+}
+
+// 更新扩展，只执行已安装版本之后的迁移
+func (this *Manager) RunUpgrade(ext Extension, from string, record RecordFunc) error {
+    return this.runLifecycle(func(tx *gorm.DB, p *lifecycle.Publisher) error {
+        return migrate(tx, lifecycle.Pending(ext.Migrations, from), func() error {
+            // 只添加新增的规则
+            if err := lifecycle.CreateRules(NewRule().WithDB(tx), ext.Rules); err != nil {
+                return err
+            }
+
+            if err := publishExtension(p, ext); err != nil {
+                return err
+            }
+
+            if err := record(tx); err != nil {
+                return err
+            }
+
+            if ext.Upgrade != nil {
+                return ext.Upgrade()
+            }
+
+            return nil
+        })
+    })
+}
+
+// 卸载扩展，按倒序回滚已安装版本及之前的迁移，配置文件保留
+func (this *Manager) RunUninstall(ext Extension, installed string, record RecordFunc) error {
+    err := this.runLifecycle(func(tx *gorm.DB, p *lifecycle.Publisher) error {
+        migrations := lifecycle.SortMigrations(ext.Migrations)
+        for i := len(migrations) - 1; i >= 0; i-- {
+            m := migrations[i]
+            if m.Down == nil || lifecycle.VersionAfter(m.Version, installed) {
+                continue
+            }
+
+            if err := m.Down(tx); err != nil {
+                return fmt.Errorf("回滚迁移[%s]失败: %w", m.Version, err)
+            }
+        }
+
+        rule := NewRule().WithDB(tx)
+        for _, r := range ext.Rules {
+            rule.Delete(lifecycle.RuleSlug(r))
+        }
+
+        if err := record(tx); err != nil {
+            return err
+        }
+
+        if ext.Uninstall != nil {
+            return ext.Uninstall()
+        }
+
+        return nil
+    })
+    if err != nil {
+        return err
+    }
+
+    // 数据库提交后再删除静态资源
+    for _, to := range ext.Assets {
+        os.RemoveAll(path.FormatPath(to))
+    }
+
+    return nil
+}
+
+// 启用扩展
+func (this *Manager) RunEnable(ext Extension, record RecordFunc) error {
+    return this.runLifecycle(func(tx *gorm.DB, p *lifecycle.Publisher) error {
+        rule := NewRule().WithDB(tx)
+        for _, r := range ext.Rules {
+            rule.Enable(lifecycle.RuleSlug(r))
+        }
+
+        if err := record(tx); err != nil {
+            return err
+        }
+
+        if ext.Enable != nil {
+            return ext.Enable()
+        }
+
+        return nil
+    })
+}
+
+// 禁用扩展
+func (this *Manager) RunDisable(ext Extension, record RecordFunc) error {
+    return this.runLifecycle(func(tx *gorm.DB, p *lifecycle.Publisher) error {
+        rule := NewRule().WithDB(tx)
+        for _, r := range ext.Rules {
+            rule.Disable(lifecycle.RuleSlug(r))
+        }
+
+        if err := record(tx); err != nil {
+            return err
+        }
+
+        if ext.Disable != nil {
+            return ext.Disable()
+        }
+
+        return nil
+    })
+}
+
+// 在事务中执行，失败时恢复推送的文件，成功时删除备份
+func (this *Manager) runLifecycle(fn func(*gorm.DB, *lifecycle.Publisher) error) error {
+    p := lifecycle.NewPublisher()
+
+    err := model.NewDB().Transaction(func(tx *gorm.DB) error {
+        return fn(tx, p)
+    })
+    if err != nil {
+        p.Rollback()
+        return err
+    }
+
+    p.Commit()
+
+    return nil
+}
+
+// 执行迁移后执行 fn，任一步失败时回滚已执行的迁移
+func migrate(tx *gorm.DB, migrations []Migration, fn func() error) error {
+    ran, err := lifecycle.Migrate(tx, migrations)
+    if err == nil {
+        err = fn()
+    }
+
+    if err != nil {
+        if rollbackErr := lifecycle.Rollback(tx, ran); rollbackErr != nil {
+            return fmt.Errorf("%w; %s", err, rollbackErr.Error())
+        }
+
+        return err
+    }
+
+    return nil
+}
+
+// 推送静态资源和配置，静态资源覆盖，配置文件已存在时跳过
+func publishExtension(p *lifecycle.Publisher, ext Extension) error {
+    for from, to := range publish.Instance().PathsToPublish("", AssetsGroup(ext.Name)) {
+        if err := p.Copy(from, to, true); err != nil {
+            return err
+        }
+    }
+
+    for from, to := range publish.Instance().PathsToPublish("", ConfigGroup(ext.Name)) {
+        if err := p.Copy(from, to, false); err != nil {
+            return err
+        }
+    }
+
+    return nil
+}
@@ -55,6 +55,8 @@ func (this *Manager) Extend(ext Extension) *Manager {
 
     if ext.Name != "" {
         this.extensions[ext.Name] = ext
+
+        this.registerPublish(ext)
     }
 
     return this
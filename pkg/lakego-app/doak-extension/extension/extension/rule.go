@@ -3,6 +3,8 @@ package extension
 import (
     "strings"
 
+    "gorm.io/gorm"
+
     "github.com/deatil/go-goch/goch"
     "github.com/deatil/go-tree/tree"
     "github.com/deatil/go-datebin/datebin"
@@ -13,7 +15,10 @@ import (
 )
 
 // 规则
-type Rule struct {}
+type Rule struct {
+    // 数据库，为空时使用默认连接
+    db *gorm.DB
+}
 
 // 初始化
 func NewRule() *Rule {
@@ -22,6 +27,41 @@ func NewRule() *Rule {
     return r
 }
 
+// 设置数据库，用于在事务中操作
+func (this *Rule) WithDB(db *gorm.DB) *Rule {
+    this.db = db
+
+    return this
+}
+
+// 规则模型
+func (this *Rule) newAuthRule() *gorm.DB {
+    if this.db != nil {
+        return this.db.Model(&model.AuthRule{})
+    }
+
+    return model.NewAuthRule()
+}
+
+// 数据库
+func (this *Rule) newDB() *gorm.DB {
+    if this.db != nil {
+        return this.db
+    }
+
+    return model.NewDB()
+}
+
+// 判断规则是否存在
+func (this *Rule) Exists(slug string) bool {
+    var count int64
+    this.newAuthRule().
+        Where("slug = ?", slug).
+        Count(&count)
+
+    return count > 0
+}
+
 // 创建
 func (this *Rule) Create(data map[string]any, parentId string) bool {
     if len(data) == 0 {
@@ -31,7 +71,7 @@ func (this *Rule) Create(data map[string]any, parentId string) bool {
     lastOrder := 0
 
     var info model.AuthRule
-    err := model.NewAuthRule().
+    err := this.newAuthRule().
         Order("listorder DESC").
         First(&info).
         Error
@@ -54,14 +94,18 @@ func (this *Rule) Create(data map[string]any, parentId string) bool {
         AddIp:       "0.0.0.0",
     }
 
-    err2 := model.NewDB().
+    err2 := this.newDB().
         Create(&insertData).
         Error
-    if err2 == nil {
-        children := res.Value("children").ToSlice()
-        for _, child := range children {
-            if r, ok := child.(map[string]any); ok {
-                this.Create(r, insertData.ID)
+    if err2 != nil {
+        return false
+    }
+
+    children := res.Value("children").ToSlice()
+    for _, child := range children {
+        if r, ok := child.(map[string]any); ok {
+            if !this.Create(r, insertData.ID) {
+                return false
             }
         }
     }
@@ -76,7 +120,7 @@ func (this *Rule) Delete(slug string) bool {
         return false
     }
 
-    model.NewAuthRule().
+    this.newAuthRule().
         Where("id IN ?", ids).
         Delete(&model.AuthRule{})
 
@@ -90,7 +134,7 @@ func (this *Rule) Enable(slug string) bool {
         return false
     }
 
-    model.NewAuthRule().
+    this.newAuthRule().
         Where("id IN ?", ids).
         Updates(map[string]any{
             "status": 1,
@@ -106,7 +150,7 @@ func (this *Rule) Disable(slug string) bool {
         return false
     }
 
-    model.NewAuthRule().
+    this.newAuthRule().
         Where("id IN ?", ids).
         Updates(map[string]any{
             "status": 0,
@@ -127,14 +171,14 @@ func (this *Rule) Export(slug string) []map[string]any {
     var info model.AuthRule
 
     // 模型
-    err := model.NewAuthRule().
+    err := this.newAuthRule().
         Where("slug = ?", slug).
         First(&info).
         Error
     if err == nil {
         rules := make([]map[string]any, 0)
 
-        model.NewAuthRule().
+        this.newAuthRule().
             Where("id IN ?", ids).
             Order("listorder ASC").
             Find(&rules)
@@ -152,12 +196,12 @@ func (this *Rule) GetAuthRuleIdsBySlug(slug string) []string {
     ids := make([]string, 0)
 
     rules := make([]map[string]any, 0)
-    model.NewAuthRule().
+    this.newAuthRule().
         Where("slug = ?", slug).
         Find(&rules)
 
     ruleList := make([]map[string]any, 0)
-    model.NewAuthRule().
+    this.newAuthRule().
         Order("listorder ASC").
         Select("id", "parentid", "slug").
         Find(&ruleList)
@@ -0,0 +1,201 @@
+package lifecycle
+
+import (
+    "os"
+    "errors"
+    "testing"
+    "reflect"
+    "path/filepath"
+
+    "gorm.io/gorm"
+)
+
+func AssertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func versions(migrations []Migration) []string {
+    list := make([]string, 0, len(migrations))
+    for _, m := range migrations {
+        list = append(list, m.Version)
+    }
+
+    return list
+}
+
+func Test_VersionAfter(t *testing.T) {
+    eq := AssertEqualT(t)
+
+    eq(VersionAfter("1.0.1", "1.0.0"), true, "patch")
+    eq(VersionAfter("1.10.0", "1.9.0"), true, "numeric compare")
+    eq(VersionAfter("1.0.0", "1.0.0"), false, "equal")
+    eq(VersionAfter("1.0.0", "1.1.0"), false, "older")
+    eq(VersionAfter("1.0.0", ""), true, "empty from")
+}
+
+func Test_Pending(t *testing.T) {
+    eq := AssertEqualT(t)
+
+    up := func(tx *gorm.DB) error {
+        return nil
+    }
+
+    migrations := []Migration{
+        {Version: "1.10.0", Up: up},
+        {Version: "1.0.0", Up: up},
+        {Version: "1.2.0", Up: up},
+        {Version: "1.1.0"},
+        {Version: "1.0.1", Up: up},
+    }
+
+    eq(versions(SortMigrations(migrations)), []string{"1.0.0", "1.0.1", "1.1.0", "1.2.0", "1.10.0"}, "SortMigrations")
+
+    eq(versions(Pending(migrations, "")), []string{"1.0.0", "1.0.1", "1.2.0", "1.10.0"}, "install")
+    eq(versions(Pending(migrations, "1.0.1")), []string{"1.2.0", "1.10.0"}, "upgrade from 1.0.1")
+    eq(versions(Pending(migrations, "1.10.0")), []string{}, "upgrade from latest")
+}
+
+func Test_MigrateRollback(t *testing.T) {
+    eq := AssertEqualT(t)
+
+    calls := make([]string, 0)
+    migration := func(ver string, fail bool) Migration {
+        return Migration{
+            Version: ver,
+            Up: func(tx *gorm.DB) error {
+                calls = append(calls, "up " + ver)
+                if fail {
+                    return errors.New("fail")
+                }
+
+                return nil
+            },
+            Down: func(tx *gorm.DB) error {
+                calls = append(calls, "down " + ver)
+                return nil
+            },
+        }
+    }
+
+    ran, err := Migrate(nil, []Migration{
+        migration("1.0.0", false),
+        migration("1.1.0", false),
+        migration("1.2.0", true),
+        migration("1.3.0", false),
+    })
+    eq(err != nil, true, "Migrate error")
+    eq(versions(ran), []string{"1.0.0", "1.1.0"}, "Migrate ran")
+
+    eq(Rollback(nil, ran), nil, "Rollback")
+    eq(calls, []string{
+        "up 1.0.0", "up 1.1.0", "up 1.2.0",
+        "down 1.1.0", "down 1.0.0",
+    }, "Rollback order")
+
+    failDown := Migration{
+        Version: "2.0.0",
+        Down: func(tx *gorm.DB) error {
+            return errors.New("down fail")
+        },
+    }
+    eq(Rollback(nil, []Migration{failDown}) != nil, true, "Rollback error")
+}
+
+type ruleStore struct {
+    rules   map[string]bool
+    created []string
+    fail    string
+}
+
+func (this *ruleStore) Exists(slug string) bool {
+    return this.rules[slug]
+}
+
+func (this *ruleStore) Create(data map[string]any, parentId string) bool {
+    slug := RuleSlug(data)
+    if slug == this.fail {
+        return false
+    }
+
+    this.rules[slug] = true
+    this.created = append(this.created, slug)
+
+    return true
+}
+
+func Test_CreateRules(t *testing.T) {
+    eq := AssertEqualT(t)
+
+    store := &ruleStore{
+        rules: map[string]bool{"demo.index": true},
+    }
+
+    err := CreateRules(store, []map[string]any{
+        {"slug": "demo.index"},
+        {"slug": "demo.create"},
+    })
+    eq(err, nil, "CreateRules")
+    eq(store.created, []string{"demo.create"}, "skip existing")
+
+    err = CreateRules(store, []map[string]any{
+        {"title": "no slug"},
+    })
+    eq(err != nil, true, "missing slug")
+
+    store.fail = "demo.delete"
+    err = CreateRules(store, []map[string]any{
+        {"slug": "demo.delete"},
+    })
+    eq(err != nil, true, "create fail")
+}
+
+func Test_PublisherRollback(t *testing.T) {
+    eq := AssertEqualT(t)
+
+    dir := t.TempDir()
+
+    read := func(file string) string {
+        data, _ := os.ReadFile(file)
+        return string(data)
+    }
+
+    src := filepath.Join(dir, "src")
+    os.MkdirAll(filepath.Join(src, "assets"), 0755)
+    os.WriteFile(filepath.Join(src, "assets", "app.js"), []byte("new js"), 0644)
+    os.WriteFile(filepath.Join(src, "config.yml"), []byte("new config"), 0644)
+
+    dst := filepath.Join(dir, "dst")
+    os.MkdirAll(filepath.Join(dst, "assets"), 0755)
+    os.WriteFile(filepath.Join(dst, "assets", "app.js"), []byte("old js"), 0644)
+    os.WriteFile(filepath.Join(dst, "assets", "custom.css"), []byte("custom"), 0644)
+
+    p := NewPublisher()
+    eq(p.Copy(filepath.Join(src, "assets"), filepath.Join(dst, "assets"), true), nil, "copy assets")
+    eq(p.Copy(filepath.Join(src, "config.yml"), filepath.Join(dst, "config.yml"), false), nil, "copy config")
+    eq(read(filepath.Join(dst, "assets", "app.js")), "new js", "overwritten")
+    eq(read(filepath.Join(dst, "config.yml")), "new config", "created")
+
+    p.Rollback()
+
+    eq(read(filepath.Join(dst, "assets", "app.js")), "old js", "restored")
+    eq(read(filepath.Join(dst, "assets", "custom.css")), "custom", "kept")
+
+    _, err := os.Stat(filepath.Join(dst, "config.yml"))
+    eq(os.IsNotExist(err), true, "created removed")
+
+    entries, _ := os.ReadDir(dst)
+    eq(len(entries), 1, "backup removed")
+
+    p = NewPublisher()
+    eq(p.Copy(filepath.Join(src, "assets"), filepath.Join(dst, "assets"), true), nil, "copy again")
+    p.Commit()
+
+    eq(read(filepath.Join(dst, "assets", "app.js")), "new js", "committed")
+
+    entries, _ = os.ReadDir(dst)
+    eq(len(entries), 1, "commit removes backup")
+}
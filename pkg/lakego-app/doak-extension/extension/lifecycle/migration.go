@@ -0,0 +1,102 @@
+package lifecycle
+
+import (
+    "fmt"
+    "sort"
+    "errors"
+    "strings"
+
+    "gorm.io/gorm"
+
+    "github.com/deatil/lakego-doak-extension/extension/version"
+)
+
+/**
+ * 数据库迁移
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Migration struct {
+    // 版本号
+    Version string
+
+    // 执行
+    Up func(tx *gorm.DB) error
+
+    // 回滚
+    Down func(tx *gorm.DB) error
+}
+
+// 按版本排序迁移
+func SortMigrations(migrations []Migration) []Migration {
+    sorted := make([]Migration, len(migrations))
+    copy(sorted, migrations)
+
+    sort.SliceStable(sorted, func(i, j int) bool {
+        return VersionAfter(sorted[j].Version, sorted[i].Version)
+    })
+
+    return sorted
+}
+
+// 判断版本 a 是否大于 b，b 为空时为 true
+func VersionAfter(a, b string) bool {
+    if b == "" {
+        return true
+    }
+
+    return version.VersionCheck(a, "> " + b) == nil
+}
+
+// 需要执行的迁移，按版本排序，只包含 from 之后的版本，from 为空时为全部
+func Pending(migrations []Migration, from string) []Migration {
+    pending := make([]Migration, 0)
+    for _, m := range SortMigrations(migrations) {
+        if m.Up == nil || !VersionAfter(m.Version, from) {
+            continue
+        }
+
+        pending = append(pending, m)
+    }
+
+    return pending
+}
+
+// 依次执行迁移，返回执行成功的迁移
+func Migrate(tx *gorm.DB, migrations []Migration) ([]Migration, error) {
+    ran := make([]Migration, 0, len(migrations))
+
+    for _, m := range migrations {
+        if err := m.Up(tx); err != nil {
+            return ran, fmt.Errorf("执行迁移[%s]失败: %w", m.Version, err)
+        }
+
+        ran = append(ran, m)
+    }
+
+    return ran, nil
+}
+
+// 按倒序执行已执行迁移的 Down
+// MySQL 等数据库的 DDL 会隐式提交，事务回滚不能撤销已执行的迁移
+func Rollback(tx *gorm.DB, ran []Migration) error {
+    errs := make([]string, 0)
+
+    for i := len(ran) - 1; i >= 0; i-- {
+        m := ran[i]
+        if m.Down == nil {
+            continue
+        }
+
+        if err := m.Down(tx); err != nil {
+            errs = append(errs, fmt.Sprintf("回滚迁移[%s]失败: %s", m.Version, err.Error()))
+        }
+    }
+
+    if len(errs) > 0 {
+        return errors.New(strings.Join(errs, "; "))
+    }
+
+    return nil
+}
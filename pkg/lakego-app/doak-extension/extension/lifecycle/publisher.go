@@ -0,0 +1,136 @@
+package lifecycle
+
+import (
+    "os"
+    "fmt"
+    "time"
+    "path/filepath"
+
+    "github.com/deatil/lakego-filesystem/filesystem"
+)
+
+/**
+ * 文件推送
+ *
+ * 记录新建的文件，覆盖已有文件前先备份到同目录，
+ * 失败时删除新建文件并恢复备份
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Publisher struct {
+    // 新建的文件
+    created []string
+
+    // 覆盖的文件，目标路径 => 备份路径
+    backups map[string]string
+
+    // 备份顺序
+    order []string
+}
+
+// 构造函数
+func NewPublisher() *Publisher {
+    return &Publisher{
+        backups: make(map[string]string),
+    }
+}
+
+// 复制文件或目录，force 为 false 时目标已存在则跳过
+func (this *Publisher) Copy(from, to string, force bool) error {
+    fs := filesystem.New()
+
+    exists := fs.Exists(to)
+    if exists && !force {
+        return nil
+    }
+
+    if !fs.IsDirectory(from) && !fs.IsFile(from) {
+        return fmt.Errorf("推送文件[%s]不存在", from)
+    }
+
+    if err := os.MkdirAll(filepath.Dir(to), os.ModePerm); err != nil {
+        return err
+    }
+
+    if !exists {
+        this.created = append(this.created, to)
+    } else if !this.isCreated(to) {
+        if err := this.backup(to); err != nil {
+            return fmt.Errorf("备份文件[%s]失败: %w", to, err)
+        }
+    }
+
+    return copyPath(from, to)
+}
+
+// 回滚，删除新建的文件并恢复覆盖的文件
+func (this *Publisher) Rollback() {
+    for _, p := range this.created {
+        os.RemoveAll(p)
+    }
+
+    for i := len(this.order) - 1; i >= 0; i-- {
+        to := this.order[i]
+
+        os.RemoveAll(to)
+        os.Rename(this.backups[to], to)
+    }
+
+    this.reset()
+}
+
+// 完成，删除备份
+func (this *Publisher) Commit() {
+    for _, backup := range this.backups {
+        os.RemoveAll(backup)
+    }
+
+    this.reset()
+}
+
+// 备份已有文件，同一目标只备份第一次覆盖前的内容
+func (this *Publisher) backup(to string) error {
+    if _, ok := this.backups[to]; ok {
+        return nil
+    }
+
+    backup := fmt.Sprintf("%s.bak-%d", to, time.Now().UnixNano())
+    if err := copyPath(to, backup); err != nil {
+        os.RemoveAll(backup)
+        return err
+    }
+
+    this.backups[to] = backup
+    this.order = append(this.order, to)
+
+    return nil
+}
+
+// 是否为本次新建的文件
+func (this *Publisher) isCreated(to string) bool {
+    for _, p := range this.created {
+        if p == to {
+            return true
+        }
+    }
+
+    return false
+}
+
+func (this *Publisher) reset() {
+    this.created = nil
+    this.backups = make(map[string]string)
+    this.order = nil
+}
+
+// 复制文件或目录
+func copyPath(from, to string) error {
+    fs := filesystem.New()
+
+    if fs.IsDirectory(from) {
+        return fs.CopyDirectory(from, to)
+    }
+
+    return fs.Copy(from, to)
+}
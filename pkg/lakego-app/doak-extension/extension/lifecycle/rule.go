@@ -0,0 +1,41 @@
+package lifecycle
+
+import (
+    "fmt"
+    "errors"
+)
+
+// 规则存储
+type RuleStore interface {
+    // 判断规则是否存在
+    Exists(slug string) bool
+
+    // 创建规则
+    Create(data map[string]any, parentId string) bool
+}
+
+// 创建规则，已存在的规则跳过
+func CreateRules(store RuleStore, rules []map[string]any) error {
+    for _, r := range rules {
+        slug := RuleSlug(r)
+        if slug == "" {
+            return errors.New("扩展规则缺少 slug")
+        }
+
+        if store.Exists(slug) {
+            continue
+        }
+
+        if !store.Create(r, "0") {
+            return fmt.Errorf("创建规则[%s]失败", slug)
+        }
+    }
+
+    return nil
+}
+
+// 规则标识
+func RuleSlug(rule map[string]any) string {
+    slug, _ := rule["slug"].(string)
+    return slug
+}
@@ -0,0 +1,330 @@
+package resolver
+
+import (
+    "fmt"
+    "sort"
+    "errors"
+    "strings"
+
+    "github.com/deatil/lakego-doak-extension/extension/version"
+)
+
+var (
+    // 扩展不存在
+    ErrNotFound = errors.New("extension not found")
+)
+
+// 循环依赖错误
+type CycleError struct {
+    Path []string
+}
+
+func (this *CycleError) Error() string {
+    return fmt.Sprintf("扩展存在循环依赖: %s", strings.Join(this.Path, " -> "))
+}
+
+// 版本冲突错误
+type ConflictError struct {
+    // 被依赖扩展
+    Name string
+
+    // 扩展版本
+    Version string
+
+    // 依赖方
+    RequiredBy string
+
+    // 依赖版本约束
+    Constraint string
+}
+
+func (this *ConflictError) Error() string {
+    return fmt.Sprintf(
+        "扩展[%s]版本[%s]不满足[%s]的依赖要求[%s]",
+        this.Name, this.Version, this.RequiredBy, this.Constraint,
+    )
+}
+
+// 依赖不存在错误
+type MissingError struct {
+    Name       string
+    RequiredBy string
+}
+
+func (this *MissingError) Error() string {
+    return fmt.Sprintf("扩展[%s]依赖的扩展[%s]不存在", this.RequiredBy, this.Name)
+}
+
+// 被依赖错误
+type DependentError struct {
+    Name       string
+    Dependents []string
+}
+
+func (this *DependentError) Error() string {
+    return fmt.Sprintf("扩展[%s]被扩展[%s]依赖", this.Name, strings.Join(this.Dependents, ", "))
+}
+
+// 扩展节点
+type Node struct {
+    // 名称
+    Name string
+
+    // 版本
+    Version string
+
+    // 依赖
+    Require map[string]string
+}
+
+/**
+ * 扩展依赖解析
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Resolver struct {
+    // 可用扩展，即已注册的扩展
+    available map[string]Node
+
+    // 已安装扩展
+    installed map[string]Node
+}
+
+// 构造函数
+func New() *Resolver {
+    return &Resolver{
+        available: make(map[string]Node),
+        installed: make(map[string]Node),
+    }
+}
+
+// 添加可用扩展
+func (this *Resolver) WithAvailable(nodes ...Node) *Resolver {
+    for _, node := range nodes {
+        this.available[node.Name] = node
+    }
+
+    return this
+}
+
+// 添加已安装扩展
+func (this *Resolver) WithInstalled(nodes ...Node) *Resolver {
+    for _, node := range nodes {
+        this.installed[node.Name] = node
+    }
+
+    return this
+}
+
+// 安装顺序，依赖在前，已安装的扩展不包含在内
+func (this *Resolver) ResolveInstall(name string) ([]string, error) {
+    if _, ok := this.available[name]; !ok {
+        return nil, ErrNotFound
+    }
+
+    order, err := this.resolve(name, false)
+    if err != nil {
+        return nil, err
+    }
+
+    result := make([]string, 0, len(order))
+    for _, n := range order {
+        if _, ok := this.installed[n]; !ok {
+            result = append(result, n)
+        }
+    }
+
+    return result, nil
+}
+
+// 更新顺序，需更新的依赖在前
+func (this *Resolver) ResolveUpgrade(name string) ([]string, error) {
+    node, ok := this.available[name]
+    if !ok {
+        return nil, ErrNotFound
+    }
+
+    // 新版本需满足已安装扩展的依赖要求
+    for _, dep := range this.installed {
+        if constraint, ok := dep.Require[name]; ok && dep.Name != name {
+            if err := version.VersionCheck(node.Version, constraint); err != nil {
+                return nil, &ConflictError{
+                    Name:       name,
+                    Version:    node.Version,
+                    RequiredBy: dep.Name,
+                    Constraint: constraint,
+                }
+            }
+        }
+    }
+
+    order, err := this.resolve(name, true)
+    if err != nil {
+        return nil, err
+    }
+
+    result := make([]string, 0, len(order))
+    for _, n := range order {
+        installed, isInstalled := this.installed[n]
+        if !isInstalled || n == name || installed.Version != this.available[n].Version {
+            result = append(result, n)
+        }
+    }
+
+    return result, nil
+}
+
+// 依赖该扩展的已安装扩展
+func (this *Resolver) Dependents(name string) []string {
+    dependents := make([]string, 0)
+
+    for _, node := range this.installed {
+        if _, ok := node.Require[name]; ok && node.Name != name {
+            dependents = append(dependents, node.Name)
+        }
+    }
+
+    sort.Strings(dependents)
+
+    return dependents
+}
+
+// 检测是否可卸载
+func (this *Resolver) CheckUninstall(name string) error {
+    dependents := this.Dependents(name)
+    if len(dependents) > 0 {
+        return &DependentError{
+            Name:       name,
+            Dependents: dependents,
+        }
+    }
+
+    return nil
+}
+
+// 深度优先解析，upgrade 时优先使用可用扩展的版本
+func (this *Resolver) resolve(name string, upgrade bool) ([]string, error) {
+    order := make([]string, 0)
+
+    // 0 未访问，1 访问中，2 已完成
+    state := make(map[string]int)
+    path := make([]string, 0)
+
+    var visit func(string) error
+    visit = func(n string) error {
+        switch state[n] {
+            case 1:
+                start := 0
+                for i, p := range path {
+                    if p == n {
+                        start = i
+                        break
+                    }
+                }
+
+                cycle := append(append([]string{}, path[start:]...), n)
+                return &CycleError{Path: cycle}
+            case 2:
+                return nil
+        }
+
+        state[n] = 1
+        path = append(path, n)
+
+        node := this.pick(n, upgrade)
+
+        requires := make([]string, 0, len(node.Require))
+        for req := range node.Require {
+            requires = append(requires, req)
+        }
+
+        sort.Strings(requires)
+
+        for _, req := range requires {
+            constraint := node.Require[req]
+
+            dep, ok := this.lookup(req, upgrade)
+            if !ok {
+                return &MissingError{
+                    Name:       req,
+                    RequiredBy: n,
+                }
+            }
+
+            if err := version.VersionCheck(dep.Version, constraint); err != nil {
+                return &ConflictError{
+                    Name:       req,
+                    Version:    dep.Version,
+                    RequiredBy: n,
+                    Constraint: constraint,
+                }
+            }
+
+            if err := this.checkRequiredBy(req, dep.Version, n); err != nil {
+                return err
+            }
+
+            if err := visit(req); err != nil {
+                return err
+            }
+        }
+
+        path = path[:len(path)-1]
+        state[n] = 2
+        order = append(order, n)
+
+        return nil
+    }
+
+    if err := visit(name); err != nil {
+        return nil, err
+    }
+
+    return order, nil
+}
+
+// 检测已安装的其他扩展对该依赖的版本要求
+func (this *Resolver) checkRequiredBy(name, ver, except string) error {
+    for _, node := range this.installed {
+        if node.Name == except || node.Name == name {
+            continue
+        }
+
+        if constraint, ok := node.Require[name]; ok {
+            if err := version.VersionCheck(ver, constraint); err != nil {
+                return &ConflictError{
+                    Name:       name,
+                    Version:    ver,
+                    RequiredBy: node.Name,
+                    Constraint: constraint,
+                }
+            }
+        }
+    }
+
+    return nil
+}
+
+// 选取解析用节点
+func (this *Resolver) pick(name string, upgrade bool) Node {
+    node, _ := this.lookup(name, upgrade)
+
+    return node
+}
+
+// 查找扩展，安装时已安装版本优先
+func (this *Resolver) lookup(name string, upgrade bool) (Node, bool) {
+    installed, isInstalled := this.installed[name]
+    available, isAvailable := this.available[name]
+
+    if isInstalled && (!upgrade || !isAvailable) {
+        return installed, true
+    }
+
+    if isAvailable {
+        return available, true
+    }
+
+    return Node{}, false
+}
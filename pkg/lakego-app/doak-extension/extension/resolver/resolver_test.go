@@ -0,0 +1,136 @@
+package resolver
+
+import (
+    "testing"
+    "reflect"
+)
+
+func AssertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func Test_ResolveInstall(t *testing.T) {
+    eq := AssertEqualT(t)
+
+    r := New().
+        WithAvailable(
+            Node{Name: "a.app", Version: "1.0.0", Require: map[string]string{"a.lib": "^1.0", "a.base": ">= 1.1"}},
+            Node{Name: "a.lib", Version: "1.2.0", Require: map[string]string{"a.base": "1.*"}},
+            Node{Name: "a.base", Version: "1.1.0"},
+        )
+
+    order, err := r.ResolveInstall("a.app")
+    eq(err, nil, "ResolveInstall err")
+    eq(order, []string{"a.base", "a.lib", "a.app"}, "ResolveInstall order")
+
+    r.WithInstalled(Node{Name: "a.base", Version: "1.1.0"})
+
+    order, err = r.ResolveInstall("a.app")
+    eq(err, nil, "ResolveInstall installed err")
+    eq(order, []string{"a.lib", "a.app"}, "ResolveInstall installed order")
+
+    _, err = r.ResolveInstall("a.none")
+    eq(err, ErrNotFound, "ResolveInstall not found")
+}
+
+func Test_ResolveCycle(t *testing.T) {
+    r := New().
+        WithAvailable(
+            Node{Name: "a.x", Version: "1.0.0", Require: map[string]string{"a.y": "*"}},
+            Node{Name: "a.y", Version: "1.0.0", Require: map[string]string{"a.z": "*"}},
+            Node{Name: "a.z", Version: "1.0.0", Require: map[string]string{"a.x": "*"}},
+        )
+
+    _, err := r.ResolveInstall("a.x")
+
+    cycle, ok := err.(*CycleError)
+    if !ok {
+        t.Fatalf("Failed ResolveCycle: got %v", err)
+    }
+
+    AssertEqualT(t)(cycle.Path, []string{"a.x", "a.y", "a.z", "a.x"}, "ResolveCycle path")
+}
+
+func Test_ResolveConflict(t *testing.T) {
+    eq := AssertEqualT(t)
+
+    // 依赖版本不满足
+    r := New().
+        WithAvailable(
+            Node{Name: "a.app", Version: "1.0.0", Require: map[string]string{"a.lib": "^2.0"}},
+            Node{Name: "a.lib", Version: "1.2.0"},
+        )
+
+    _, err := r.ResolveInstall("a.app")
+    _, ok := err.(*ConflictError)
+    eq(ok, true, "ResolveConflict version")
+
+    // 已安装扩展要求的版本冲突
+    r = New().
+        WithAvailable(
+            Node{Name: "a.app", Version: "1.0.0", Require: map[string]string{"a.lib": "*"}},
+            Node{Name: "a.lib", Version: "2.0.0"},
+        ).
+        WithInstalled(
+            Node{Name: "a.other", Version: "1.0.0", Require: map[string]string{"a.lib": "^1.0"}},
+            Node{Name: "a.lib", Version: "1.0.0"},
+        )
+
+    _, err = r.ResolveUpgrade("a.lib")
+    conflict, ok := err.(*ConflictError)
+    eq(ok, true, "ResolveConflict upgrade")
+    if ok {
+        eq(conflict.RequiredBy, "a.other", "ResolveConflict upgrade RequiredBy")
+    }
+
+    // 依赖不存在
+    r = New().
+        WithAvailable(
+            Node{Name: "a.app", Version: "1.0.0", Require: map[string]string{"a.none": "*"}},
+        )
+
+    _, err = r.ResolveInstall("a.app")
+    _, ok = err.(*MissingError)
+    eq(ok, true, "ResolveConflict missing")
+}
+
+func Test_ResolveUpgrade(t *testing.T) {
+    eq := AssertEqualT(t)
+
+    r := New().
+        WithAvailable(
+            Node{Name: "a.app", Version: "1.1.0", Require: map[string]string{"a.lib": "^1.2"}},
+            Node{Name: "a.lib", Version: "1.2.0"},
+            Node{Name: "a.base", Version: "1.0.0"},
+        ).
+        WithInstalled(
+            Node{Name: "a.app", Version: "1.0.0", Require: map[string]string{"a.lib": "^1.0"}},
+            Node{Name: "a.lib", Version: "1.0.0"},
+            Node{Name: "a.base", Version: "1.0.0"},
+        )
+
+    order, err := r.ResolveUpgrade("a.app")
+    eq(err, nil, "ResolveUpgrade err")
+    eq(order, []string{"a.lib", "a.app"}, "ResolveUpgrade order")
+}
+
+func Test_CheckUninstall(t *testing.T) {
+    eq := AssertEqualT(t)
+
+    r := New().
+        WithInstalled(
+            Node{Name: "a.app", Version: "1.0.0", Require: map[string]string{"a.lib": "*"}},
+            Node{Name: "a.tool", Version: "1.0.0", Require: map[string]string{"a.lib": "*"}},
+            Node{Name: "a.lib", Version: "1.0.0"},
+        )
+
+    eq(r.Dependents("a.lib"), []string{"a.app", "a.tool"}, "Dependents")
+    eq(r.CheckUninstall("a.app"), nil, "CheckUninstall free")
+
+    _, ok := r.CheckUninstall("a.lib").(*DependentError)
+    eq(ok, true, "CheckUninstall depended")
+}
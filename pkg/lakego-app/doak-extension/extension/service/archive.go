@@ -0,0 +1,195 @@
+package service
+
+import (
+    "os"
+    "fmt"
+    "errors"
+    "time"
+    "strings"
+    "path/filepath"
+
+    "github.com/deatil/go-goch/goch"
+
+    "github.com/deatil/lakego-doak/lakego/path"
+    "github.com/deatil/lakego-doak/lakego/facade"
+
+    "github.com/deatil/lakego-doak-extension/extension/model"
+    "github.com/deatil/lakego-doak-extension/extension/archive"
+    "github.com/deatil/lakego-doak-extension/extension/extension"
+)
+
+// 从配置生成签名验证
+func NewVerifier() (*archive.Verifier, error) {
+    verifier := archive.NewVerifier()
+
+    keys := facade.Config("extension").GetStringMap("trusted-keys")
+    for name, conf := range keys {
+        keyConf, ok := conf.(map[string]any)
+        if !ok {
+            continue
+        }
+
+        keyType := goch.ToString(keyConf["type"])
+        keyFile := path.FormatPath(goch.ToString(keyConf["key"]))
+
+        key, err := os.ReadFile(keyFile)
+        if err != nil {
+            return nil, errors.New(fmt.Sprintf("可信公钥[%s]读取失败", name))
+        }
+
+        verifier.AddKey(archive.TrustedKey{
+            Name: name,
+            Type: keyType,
+            Key:  key,
+        })
+    }
+
+    return verifier, nil
+}
+
+// 导入签名扩展包，验证签名和清单后解压资源文件并安装或更新扩展
+// 扩展代码需已编译注册，扩展包版本和依赖需和注册信息一致
+// 资源文件先解压到临时目录，安装失败时恢复原扩展目录
+func (this *Extension) Import(file string) error {
+    if file == "" {
+        return errors.New("扩展包不能为空")
+    }
+
+    verifier, err := NewVerifier()
+    if err != nil {
+        return err
+    }
+
+    pkg, err := archive.Open(path.FormatPath(file), verifier)
+    if err != nil {
+        return err
+    }
+
+    manifest := pkg.Manifest
+
+    if err := checkManifest(manifest); err != nil {
+        return err
+    }
+
+    installed := model.IsInstallExtension(manifest.Name)
+
+    // 解压前检测依赖，避免依赖不满足时改动扩展目录
+    if installed {
+        _, err = NewResolver().ResolveUpgrade(manifest.Name)
+    } else {
+        _, err = NewResolver().ResolveInstall(manifest.Name)
+    }
+    if err != nil {
+        return err
+    }
+
+    target := ExtensionPath(manifest.Name)
+    if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+        return err
+    }
+
+    // 临时目录和扩展目录在同一目录下，保证可以直接重命名
+    tmpDir, err := os.MkdirTemp(filepath.Dir(target), "." + filepath.Base(target) + "-import-")
+    if err != nil {
+        return err
+    }
+    defer os.RemoveAll(tmpDir)
+
+    if err := pkg.Extract(tmpDir); err != nil {
+        return errors.New("解压扩展包失败: " + err.Error())
+    }
+
+    restore, err := replaceDir(tmpDir, target)
+    if err != nil {
+        return errors.New("替换扩展目录失败: " + err.Error())
+    }
+
+    if installed {
+        err = this.Upgrade(manifest.Name)
+    } else {
+        err = this.Inatll(manifest.Name)
+    }
+
+    restore(err != nil)
+
+    return err
+}
+
+// 检测清单和已注册扩展信息是否一致
+func checkManifest(manifest archive.Manifest) error {
+    info := extension.GetManager().GetExtension(manifest.Name)
+    if info.Name == "" {
+        return errors.New(fmt.Sprintf("扩展[%s]没有注册", manifest.Name))
+    }
+
+    if info.Version != manifest.Version {
+        return errors.New(fmt.Sprintf("扩展包版本[%s]与注册版本[%s]不一致", manifest.Version, info.Version))
+    }
+
+    if !sameRequire(manifest.Require, info.Require) {
+        return errors.New(fmt.Sprintf("扩展包[%s]依赖与注册依赖不一致", manifest.Name))
+    }
+
+    return nil
+}
+
+// 依赖是否一致
+func sameRequire(a, b map[string]string) bool {
+    if len(a) != len(b) {
+        return false
+    }
+
+    for name, ver := range a {
+        other, ok := b[name]
+        if !ok || strings.TrimSpace(other) != strings.TrimSpace(ver) {
+            return false
+        }
+    }
+
+    return true
+}
+
+// 用 from 目录替换 to 目录，原目录先重命名备份
+// 返回的函数在 rollback 为 true 时恢复原目录，否则删除备份
+func replaceDir(from, to string) (func(rollback bool), error) {
+    backup := ""
+    if _, err := os.Stat(to); err == nil {
+        backup = to + fmt.Sprintf(".bak-%d", time.Now().UnixNano())
+        if err := os.Rename(to, backup); err != nil {
+            return nil, err
+        }
+    }
+
+    if err := os.Rename(from, to); err != nil {
+        if backup != "" {
+            os.Rename(backup, to)
+        }
+
+        return nil, err
+    }
+
+    return func(rollback bool) {
+        if !rollback {
+            if backup != "" {
+                os.RemoveAll(backup)
+            }
+
+            return
+        }
+
+        os.RemoveAll(to)
+        if backup != "" {
+            os.Rename(backup, to)
+        }
+    }, nil
+}
+
+// 扩展目录，比如 lakego.demo 对应 {extension}/lakego/demo
+func ExtensionPath(name string) string {
+    root := facade.Config("extension").GetString("path")
+    if root == "" {
+        root = "{root}/extension"
+    }
+
+    return filepath.Join(path.FormatPath(root), filepath.FromSlash(strings.ReplaceAll(name, ".", "/")))
+}
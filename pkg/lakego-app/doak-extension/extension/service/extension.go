@@ -4,6 +4,8 @@ import (
     "fmt"
     "errors"
 
+    "gorm.io/gorm"
+
     "github.com/deatil/go-goch/goch"
     "github.com/deatil/go-datebin/datebin"
 
@@ -14,6 +16,7 @@ import (
 
     "github.com/deatil/lakego-doak-extension/extension/model"
     "github.com/deatil/lakego-doak-extension/extension/version"
+    "github.com/deatil/lakego-doak-extension/extension/resolver"
     "github.com/deatil/lakego-doak-extension/extension/extension"
 )
 
@@ -82,12 +85,40 @@ func (this *Extension) Local() []map[string]any {
     return newExts
 }
 
-// 安装扩展
+// 安装扩展，未安装的依赖扩展按依赖顺序先安装
 func (this *Extension) Inatll(name string) error {
     if name == "" {
         return errors.New("扩展不能为空")
     }
 
+    if model.IsInstallExtension(name) {
+        return errors.New("扩展已经安装")
+    }
+
+    order, err := NewResolver().ResolveInstall(name)
+    if err != nil {
+        if err == resolver.ErrNotFound {
+            return errors.New("扩展不存在")
+        }
+
+        return err
+    }
+
+    for _, n := range order {
+        if err := this.install(n); err != nil {
+            if n != name {
+                return errors.New(fmt.Sprintf("安装依赖扩展[%s]失败: %s", n, err.Error()))
+            }
+
+            return err
+        }
+    }
+
+    return nil
+}
+
+// 安装单个扩展
+func (this *Extension) install(name string) error {
     extManager := extension.GetManager()
 
     info := extManager.GetExtension(name)
@@ -106,7 +137,7 @@ func (this *Extension) Inatll(name string) error {
         return errors.New(fmt.Sprintf("扩展[%s]适配系统版本[%s]错误", info.Adaptation, adminVersion))
     }
 
-    if ok, err := CheckExtensionRequire(info.Require); !ok {
+    if ok, err := CheckExtensionRequireByName(name); !ok {
         return err
     }
 
@@ -133,16 +164,12 @@ func (this *Extension) Inatll(name string) error {
         AddIp: ip,
     }
 
-    err = model.NewDB().
-        Create(&insertData).
-        Error
+    // 迁移、规则、资源和记录在同一事务中执行
+    err = extManager.RunInstall(info, func(tx *gorm.DB) error {
+        return tx.Create(&insertData).Error
+    })
     if err != nil {
-        return errors.New("安装扩展失败")
-    }
-
-    // 执行方法
-    if info.Install != nil {
-        info.Install()
+        return errors.New("安装扩展失败: " + err.Error())
     }
 
     return nil
@@ -158,32 +185,81 @@ func (this *Extension) Uninstall(name string) error {
         return errors.New("扩展没有被安装或者请先禁用扩展")
     }
 
+    // 被其他已安装扩展依赖时不能卸载
+    if err := NewResolver().CheckUninstall(name); err != nil {
+        return err
+    }
+
+    installInfo := model.GetExtension(name)
+    if installInfo.ID == "" {
+        return errors.New("扩展没有被安装")
+    }
+
     // 删除
-    err := model.NewExtension().
-        Where("name = ?", name).
-        Delete(&model.Extension{}).
-        Error
-    if err != nil {
-        return errors.New("卸载扩展失败")
+    remove := func(tx *gorm.DB) error {
+        return tx.Where("name = ?", name).
+            Delete(&model.Extension{}).
+            Error
     }
 
-    info := extension.GetManager().GetExtension(name)
+    var err error
+
+    extManager := extension.GetManager()
+
+    info := extManager.GetExtension(name)
     if info.Name != "" {
-        // 执行方法
-        if info.Uninstall != nil {
-            info.Uninstall()
-        }
+        err = extManager.RunUninstall(info, installInfo.Version, remove)
+    } else {
+        err = remove(model.NewDB())
+    }
+
+    if err != nil {
+        return errors.New("卸载扩展失败: " + err.Error())
     }
 
     return nil
 }
 
-// 更新扩展
+// 更新扩展，依赖扩展按依赖顺序先安装或更新
 func (this *Extension) Upgrade(name string) error {
     if name == "" {
         return errors.New("扩展不能为空")
     }
 
+    if !model.IsInstallExtension(name) {
+        return errors.New("扩展没有被安装")
+    }
+
+    order, err := NewResolver().ResolveUpgrade(name)
+    if err != nil {
+        if err == resolver.ErrNotFound {
+            return errors.New("扩展不存在")
+        }
+
+        return err
+    }
+
+    for _, n := range order {
+        if model.IsInstallExtension(n) {
+            err = this.upgrade(n)
+        } else {
+            err = this.install(n)
+        }
+
+        if err != nil {
+            if n != name {
+                return errors.New(fmt.Sprintf("更新依赖扩展[%s]失败: %s", n, err.Error()))
+            }
+
+            return err
+        }
+    }
+
+    return nil
+}
+
+// 更新单个扩展
+func (this *Extension) upgrade(name string) error {
     installInfo := model.GetExtension(name)
     if installInfo.ID == "" {
         return errors.New("扩展没有被安装")
@@ -217,33 +293,27 @@ func (this *Extension) Upgrade(name string) error {
         return errors.New(fmt.Sprintf("扩展[%s]升级到版本[%s]错误", installInfo.Version, info.Version))
     }
 
-    if ok, err := CheckExtensionRequire(info.Require); !ok {
-        return err
-    }
-
     ip := "0.0.0.0"
     if this.Ctx != nil {
         ip = router.GetRequestIp(this.Ctx)
     }
 
-    err = model.NewExtension().
-        Where("name = ?", name).
-        Updates(map[string]any{
-            "title": info.Title,
-            "version": info.Version,
-            "adaptation": info.Adaptation,
-            "info": string(info.ToJSON()),
-            "update_time": int(datebin.NowTimestamp()),
-            "update_ip": ip,
-        }).
-        Error
+    // 只执行已安装版本之后的迁移
+    err = extManager.RunUpgrade(info, installInfo.Version, func(tx *gorm.DB) error {
+        return tx.Model(&model.Extension{}).
+            Where("name = ?", name).
+            Updates(map[string]any{
+                "title": info.Title,
+                "version": info.Version,
+                "adaptation": info.Adaptation,
+                "info": string(info.ToJSON()),
+                "update_time": int(datebin.NowTimestamp()),
+                "update_ip": ip,
+            }).
+            Error
+    })
     if err != nil {
-        return errors.New("更新扩展失败")
-    }
-
-    // 执行方法
-    if info.Upgrade != nil {
-        info.Upgrade()
+        return errors.New("更新扩展失败: " + err.Error())
     }
 
     return nil
@@ -264,19 +334,16 @@ func (this *Extension) Enable(name string) error {
         return errors.New("扩展已经启用")
     }
 
-    err := model.NewExtension().
-        Where("name = ?", name).
-        Updates(map[string]any{
-            "status": 1,
-        }).
-        Error
+    err := extension.GetManager().RunEnable(info, func(tx *gorm.DB) error {
+        return tx.Model(&model.Extension{}).
+            Where("name = ?", name).
+            Updates(map[string]any{
+                "status": 1,
+            }).
+            Error
+    })
     if err != nil {
-        return errors.New("启用扩展失败")
-    }
-
-    // 执行方法
-    if info.Enable != nil {
-        info.Enable()
+        return errors.New("启用扩展失败: " + err.Error())
     }
 
     return nil
@@ -297,19 +364,16 @@ func (this *Extension) Disable(name string) error {
         return errors.New("扩展已经禁用")
     }
 
-    err := model.NewExtension().
-        Where("name = ?", name).
-        Updates(map[string]any{
-            "status": 0,
-        }).
-        Error
+    err := extension.GetManager().RunDisable(info, func(tx *gorm.DB) error {
+        return tx.Model(&model.Extension{}).
+            Where("name = ?", name).
+            Updates(map[string]any{
+                "status": 0,
+            }).
+            Error
+    })
     if err != nil {
-        return errors.New("禁用扩展失败")
-    }
-
-    // 执行方法
-    if info.Disable != nil {
-        info.Disable()
+        return errors.New("禁用扩展失败: " + err.Error())
     }
 
     return nil
@@ -3,11 +3,42 @@ package service
 import (
     "fmt"
     "errors"
+    "encoding/json"
+
+    "github.com/deatil/go-goch/goch"
 
     "github.com/deatil/lakego-doak-extension/extension/model"
     "github.com/deatil/lakego-doak-extension/extension/version"
+    "github.com/deatil/lakego-doak-extension/extension/resolver"
+    "github.com/deatil/lakego-doak-extension/extension/extension"
 )
 
+// 生成依赖解析器，可用扩展为已注册扩展，已安装扩展从数据库读取
+func NewResolver() *resolver.Resolver {
+    r := resolver.New()
+
+    for _, ext := range extension.GetManager().GetExtensions() {
+        r.WithAvailable(resolver.Node{
+            Name:    ext.Name,
+            Version: ext.Version,
+            Require: ext.Require,
+        })
+    }
+
+    for _, ext := range model.GetAllExtensions() {
+        var info extension.Extension
+        json.Unmarshal([]byte(goch.ToString(ext["info"])), &info)
+
+        r.WithInstalled(resolver.Node{
+            Name:    goch.ToString(ext["name"]),
+            Version: goch.ToString(ext["version"]),
+            Require: info.Require,
+        })
+    }
+
+    return r
+}
+
 // 检测依赖
 func CheckExtensionRequire(requires map[string]string) (bool, error) {
     if len(requires) == 0 {
@@ -43,3 +74,22 @@ func CheckExtensionRequire(requires map[string]string) (bool, error) {
 
     return true, nil
 }
+
+// 按依赖解析检测扩展依赖，依赖需已安装且版本符合
+func CheckExtensionRequireByName(name string) (bool, error) {
+    order, err := NewResolver().ResolveInstall(name)
+    if err != nil {
+        return false, err
+    }
+
+    for _, n := range order {
+        if n != name {
+            return false, &resolver.MissingError{
+                Name:       n,
+                RequiredBy: name,
+            }
+        }
+    }
+
+    return true, nil
+}
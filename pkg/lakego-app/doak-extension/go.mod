@@ -3,9 +3,12 @@ module github.com/deatil/lakego-doak-extension
 go 1.18
 
 require (
+	github.com/deatil/go-cryptobin v1.0.2042
 	github.com/deatil/go-hash v0.0.3
 	github.com/deatil/go-goch v0.0.3
 	github.com/deatil/lakego-doak v0.0.3
 	github.com/deatil/lakego-doak-admin v0.0.3
+	github.com/deatil/lakego-filesystem v1.0.1007
+	gorm.io/gorm v1.24.6
 	github.com/Masterminds/semver/v3 v3.2.1 // indirect
 )
@@ -0,0 +1,303 @@
+package cached
+
+import (
+    "io"
+    "bytes"
+    "encoding/json"
+    "time"
+
+    "github.com/deatil/go-filesystem/filesystem/interfaces"
+)
+
+/**
+ * 缓存存储接口
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Store interface {
+    // 获取，不存在时返回错误
+    Get(key string) (any, error)
+
+    // 存储
+    Put(key string, value any, ttl time.Duration) error
+
+    // 删除
+    Forget(key string) (bool, error)
+}
+
+// 缓存类型
+const (
+    keyHas        = "has"
+    keyMetadata   = "metadata"
+    keyMimetype   = "mimetype"
+    keyVisibility = "visibility"
+)
+
+// 需要清除的缓存类型
+var cacheKinds = []string{
+    keyHas,
+    keyMetadata,
+    keyMimetype,
+    keyVisibility,
+}
+
+/**
+ * 文件信息缓存适配器 / Metadata cache adapter
+ * 包装其他适配器，缓存文件是否存在、文件信息、类型及权限
+ * 通过本适配器的写入操作会清除对应缓存
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Cached struct {
+    // 被包装的适配器
+    interfaces.Adapter
+
+    // 缓存
+    store Store
+
+    // 缓存前缀
+    prefix string
+
+    // 缓存时间
+    ttl time.Duration
+}
+
+// 文件信息缓存适配器
+func New(adapter interfaces.Adapter, store Store, prefix string, ttl time.Duration) *Cached {
+    return &Cached{
+        Adapter: adapter,
+        store:   store,
+        prefix:  prefix,
+        ttl:     ttl,
+    }
+}
+
+// 被包装的适配器
+func (this *Cached) GetAdapter() interfaces.Adapter {
+    return this.Adapter
+}
+
+// 判断是否存在
+func (this *Cached) Has(path string) bool {
+    var has bool
+    if this.get(keyHas, path, &has) {
+        return has
+    }
+
+    has = this.Adapter.Has(path)
+    this.put(keyHas, path, has)
+
+    return has
+}
+
+// 上传
+func (this *Cached) Write(path string, contents []byte, conf interfaces.Config) (map[string]any, error) {
+    defer this.forget(path)
+
+    return this.Adapter.Write(path, contents, conf)
+}
+
+// 上传 Stream 文件类型
+func (this *Cached) WriteStream(path string, stream io.Reader, conf interfaces.Config) (map[string]any, error) {
+    defer this.forget(path)
+
+    return this.Adapter.WriteStream(path, stream, conf)
+}
+
+// 更新
+func (this *Cached) Update(path string, contents []byte, conf interfaces.Config) (map[string]any, error) {
+    defer this.forget(path)
+
+    return this.Adapter.Update(path, contents, conf)
+}
+
+// 更新
+func (this *Cached) UpdateStream(path string, stream io.Reader, conf interfaces.Config) (map[string]any, error) {
+    defer this.forget(path)
+
+    return this.Adapter.UpdateStream(path, stream, conf)
+}
+
+// 重命名
+func (this *Cached) Rename(path string, newpath string) error {
+    defer this.forget(path, newpath)
+
+    return this.Adapter.Rename(path, newpath)
+}
+
+// 复制
+func (this *Cached) Copy(path string, newpath string) error {
+    defer this.forget(newpath)
+
+    return this.Adapter.Copy(path, newpath)
+}
+
+// 删除
+func (this *Cached) Delete(path string) error {
+    defer this.forget(path)
+
+    return this.Adapter.Delete(path)
+}
+
+// 删除文件夹
+// 删除前列出文件夹内容以清除缓存
+func (this *Cached) DeleteDir(dirname string) error {
+    paths := []string{dirname}
+    if list, err := this.Adapter.ListContents(dirname, true); err == nil {
+        for _, item := range list {
+            if path, ok := item["path"].(string); ok {
+                paths = append(paths, path)
+            }
+        }
+    }
+
+    defer this.forget(paths...)
+
+    return this.Adapter.DeleteDir(dirname)
+}
+
+// 创建文件夹
+func (this *Cached) CreateDir(dirname string, conf interfaces.Config) (map[string]string, error) {
+    defer this.forget(dirname)
+
+    return this.Adapter.CreateDir(dirname, conf)
+}
+
+// 文件信息
+func (this *Cached) GetMetadata(path string) (map[string]any, error) {
+    return this.getAny(keyMetadata, path, this.Adapter.GetMetadata)
+}
+
+// 文件大小
+func (this *Cached) GetSize(path string) (map[string]any, error) {
+    return this.getAny(keyMetadata, path, this.Adapter.GetMetadata)
+}
+
+// 文件类型
+func (this *Cached) GetMimetype(path string) (map[string]any, error) {
+    return this.getAny(keyMimetype, path, this.Adapter.GetMimetype)
+}
+
+// 获取时间戳
+func (this *Cached) GetTimestamp(path string) (map[string]any, error) {
+    return this.getAny(keyMetadata, path, this.Adapter.GetMetadata)
+}
+
+// 获取文件的权限
+func (this *Cached) GetVisibility(path string) (map[string]string, error) {
+    var result map[string]string
+    if this.get(keyVisibility, path, &result) {
+        return result, nil
+    }
+
+    result, err := this.Adapter.GetVisibility(path)
+    if err != nil {
+        return nil, err
+    }
+
+    this.put(keyVisibility, path, result)
+
+    return result, nil
+}
+
+// 设置文件的权限
+func (this *Cached) SetVisibility(path string, visibility string) (map[string]string, error) {
+    defer this.forget(path)
+
+    return this.Adapter.SetVisibility(path, visibility)
+}
+
+// 清除缓存
+func (this *Cached) Forget(paths ...string) {
+    this.forget(paths...)
+}
+
+// 获取文件信息缓存
+func (this *Cached) getAny(kind string, path string, fn func(string) (map[string]any, error)) (map[string]any, error) {
+    var result map[string]any
+    if this.get(kind, path, &result) {
+        return normalize(result), nil
+    }
+
+    result, err := fn(path)
+    if err != nil {
+        return nil, err
+    }
+
+    this.put(kind, path, result)
+
+    return result, nil
+}
+
+// 读取缓存，数据使用 JSON 存储以兼容不同的缓存驱动
+func (this *Cached) get(kind string, path string, dst any) bool {
+    data, err := this.store.Get(this.key(kind, path))
+    if err != nil || data == nil {
+        return false
+    }
+
+    var raw []byte
+    switch v := data.(type) {
+        case string:
+            raw = []byte(v)
+        case []byte:
+            raw = v
+        default:
+            return false
+    }
+
+    decoder := json.NewDecoder(bytes.NewReader(raw))
+    decoder.UseNumber()
+
+    return decoder.Decode(dst) == nil
+}
+
+// 写入缓存
+func (this *Cached) put(kind string, path string, value any) {
+    data, err := json.Marshal(value)
+    if err != nil {
+        return
+    }
+
+    this.store.Put(this.key(kind, path), string(data), this.ttl)
+}
+
+// 清除文件缓存
+func (this *Cached) forget(paths ...string) {
+    for _, path := range paths {
+        for _, kind := range cacheKinds {
+            this.store.Forget(this.key(kind, path))
+        }
+    }
+}
+
+// 缓存 key
+func (this *Cached) key(kind string, path string) string {
+    return this.prefix + kind + ":" + normalizePath(path)
+}
+
+// 路径格式化
+func normalizePath(path string) string {
+    for len(path) > 0 && path[0] == '/' {
+        path = path[1:]
+    }
+
+    return path
+}
+
+// 恢复 JSON 解码后的数字类型
+func normalize(result map[string]any) map[string]any {
+    for k, v := range result {
+        if num, ok := v.(json.Number); ok {
+            if i, err := num.Int64(); err == nil {
+                result[k] = i
+            } else if f, err := num.Float64(); err == nil {
+                result[k] = f
+            }
+        }
+    }
+
+    return result
+}
@@ -0,0 +1,161 @@
+package cached
+
+import (
+    "sync"
+    "errors"
+    "reflect"
+    "testing"
+    "time"
+
+    "github.com/deatil/go-filesystem/filesystem/config"
+    "github.com/deatil/go-filesystem/filesystem/interfaces"
+    memory_adapter "github.com/deatil/go-filesystem/filesystem/adapter/memory"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func assertErrorT(t *testing.T) func(error, string) {
+    return func(err error, msg string) {
+        if err != nil {
+            t.Errorf("Failed %s: error: %+v", msg, err)
+        }
+    }
+}
+
+// 测试缓存
+type mapStore struct {
+    mu   sync.Mutex
+    data map[string]any
+}
+
+func newMapStore() *mapStore {
+    return &mapStore{
+        data: make(map[string]any),
+    }
+}
+
+func (this *mapStore) Get(key string) (any, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if v, ok := this.data[key]; ok {
+        return v, nil
+    }
+
+    return nil, errors.New("miss")
+}
+
+func (this *mapStore) Put(key string, value any, ttl time.Duration) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.data[key] = value
+
+    return nil
+}
+
+func (this *mapStore) Forget(key string) (bool, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    delete(this.data, key)
+
+    return true, nil
+}
+
+// 统计调用次数
+type countAdapter struct {
+    interfaces.Adapter
+
+    calls map[string]int
+}
+
+func (this *countAdapter) Has(path string) bool {
+    this.calls["Has"]++
+
+    return this.Adapter.Has(path)
+}
+
+func (this *countAdapter) GetMetadata(path string) (map[string]any, error) {
+    this.calls["GetMetadata"]++
+
+    return this.Adapter.GetMetadata(path)
+}
+
+func (this *countAdapter) GetVisibility(path string) (map[string]string, error) {
+    this.calls["GetVisibility"]++
+
+    return this.Adapter.GetVisibility(path)
+}
+
+func Test_Cached(t *testing.T) {
+    assertEqual := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    conf := config.New(map[string]any{})
+
+    counter := &countAdapter{
+        Adapter: memory_adapter.New(),
+        calls:   make(map[string]int),
+    }
+
+    store := newMapStore()
+    fs := New(counter, store, "fs:", time.Minute)
+
+    assertEqual(fs.Has("a.txt"), false, "Has before write")
+    assertEqual(fs.Has("a.txt"), false, "Has cached")
+    assertEqual(counter.calls["Has"], 1, "Has calls")
+
+    _, err := fs.Write("a.txt", []byte("aaa"), conf)
+    assertError(err, "Write")
+
+    assertEqual(fs.Has("a.txt"), true, "Has after write")
+    assertEqual(counter.calls["Has"], 2, "Has calls after write")
+
+    meta, err := fs.GetMetadata("a.txt")
+    assertError(err, "GetMetadata")
+
+    cached, err := fs.GetMetadata("a.txt")
+    assertError(err, "GetMetadata cached")
+    assertEqual(cached, meta, "GetMetadata cached data")
+    assertEqual(cached["size"], int64(3), "GetMetadata cached size")
+
+    size, _ := fs.GetSize("a.txt")
+    assertEqual(size["size"], int64(3), "GetSize cached")
+    assertEqual(counter.calls["GetMetadata"], 1, "GetMetadata calls")
+
+    vis, _ := fs.GetVisibility("a.txt")
+    vis2, _ := fs.GetVisibility("a.txt")
+    assertEqual(vis2, vis, "GetVisibility cached")
+    assertEqual(counter.calls["GetVisibility"], 1, "GetVisibility calls")
+
+    // 写入后清除缓存
+    fs.Update("a.txt", []byte("aaaaa"), conf)
+    meta, _ = fs.GetMetadata("a.txt")
+    assertEqual(meta["size"], int64(5), "GetMetadata after Update")
+    assertEqual(counter.calls["GetMetadata"], 2, "GetMetadata calls after Update")
+
+    fs.SetVisibility("a.txt", "private")
+    vis, _ = fs.GetVisibility("a.txt")
+    assertEqual(vis["visibility"], "private", "GetVisibility after SetVisibility")
+
+    assertError(fs.Rename("a.txt", "dir/b.txt"), "Rename")
+    assertEqual(fs.Has("a.txt"), false, "Has after Rename")
+    assertEqual(fs.Has("dir/b.txt"), true, "Has new after Rename")
+
+    assertError(fs.DeleteDir("dir"), "DeleteDir")
+    assertEqual(fs.Has("dir/b.txt"), false, "Has after DeleteDir")
+
+    // 不存在的文件不缓存错误
+    _, err = fs.GetMetadata("no.txt")
+    if err == nil {
+        t.Error("GetMetadata not exists should fail")
+    }
+    assertEqual(len(store.data), 2, "store keys")
+}
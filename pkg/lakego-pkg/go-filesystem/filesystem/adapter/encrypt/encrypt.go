@@ -0,0 +1,406 @@
+package encrypt
+
+import (
+    "io"
+    "os"
+    "bytes"
+    "errors"
+    "net/http"
+    "crypto/aes"
+    "crypto/cipher"
+
+    "golang.org/x/crypto/chacha20poly1305"
+    "github.com/deatil/go-cryptobin/cipher/sm4"
+
+    "github.com/deatil/go-filesystem/filesystem/adapter"
+    "github.com/deatil/go-filesystem/filesystem/interfaces"
+)
+
+// 加密方式
+const (
+    AesGCM           = "aes-gcm"
+    Sm4GCM           = "sm4-gcm"
+    Chacha20Poly1305 = "chacha20-poly1305"
+)
+
+const (
+    // 默认分块大小
+    DefaultChunkSize = 64 * 1024
+
+    // 最大分块大小
+    MaxChunkSize = 16 * 1024 * 1024
+)
+
+// 加密方式对应的标识，写入文件头部
+var cipherIds = map[string]byte{
+    AesGCM:           1,
+    Sm4GCM:           2,
+    Chacha20Poly1305: 3,
+}
+
+// 加密方式
+var cipherAEADs = map[string]func([]byte) (cipher.AEAD, error){
+    AesGCM: func(key []byte) (cipher.AEAD, error) {
+        block, err := aes.NewCipher(key)
+        if err != nil {
+            return nil, err
+        }
+
+        return cipher.NewGCM(block)
+    },
+    Sm4GCM: func(key []byte) (cipher.AEAD, error) {
+        block, err := sm4.NewCipher(key)
+        if err != nil {
+            return nil, err
+        }
+
+        return cipher.NewGCM(block)
+    },
+    Chacha20Poly1305: chacha20poly1305.New,
+}
+
+/**
+ * 加密配置
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Config struct {
+    // 加密方式，默认为 aes-gcm
+    Cipher string
+
+    // 密钥，aes-gcm 为 16/24/32 字节，sm4-gcm 为 16 字节，
+    // chacha20-poly1305 为 32 字节
+    Key []byte
+
+    // 分块大小，默认为 64KB
+    // 分块大小会写入文件头部，有加密文件后不能修改，
+    // 读取分块大小不一致的文件时返回 ErrChunkSizeMismatch
+    ChunkSize int
+}
+
+/**
+ * 加密适配器 / Encrypt adapter
+ * 包装其他适配器，数据分块加密后存储，数据流读写不会整体加载文件
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Encrypt struct {
+    // 被包装的适配器
+    interfaces.Adapter
+
+    // 加密
+    aead cipher.AEAD
+
+    // 加密方式标识
+    cipherId byte
+
+    // 分块大小
+    chunkSize int
+}
+
+// 加密适配器
+func New(adapter interfaces.Adapter, conf Config) (*Encrypt, error) {
+    if conf.Cipher == "" {
+        conf.Cipher = AesGCM
+    }
+
+    newAEAD, ok := cipherAEADs[conf.Cipher]
+    if !ok {
+        return nil, errors.New("go-filesystem: encrypt cipher " + conf.Cipher + " not support")
+    }
+
+    aead, err := newAEAD(conf.Key)
+    if err != nil {
+        return nil, errors.New("go-filesystem: encrypt key invalid, error: " + err.Error())
+    }
+
+    if conf.ChunkSize <= 0 {
+        conf.ChunkSize = DefaultChunkSize
+    }
+    if conf.ChunkSize > MaxChunkSize {
+        return nil, errors.New("go-filesystem: encrypt chunk size too large")
+    }
+
+    return &Encrypt{
+        Adapter:   adapter,
+        aead:      aead,
+        cipherId:  cipherIds[conf.Cipher],
+        chunkSize: conf.ChunkSize,
+    }, nil
+}
+
+// 被包装的适配器
+func (this *Encrypt) GetAdapter() interfaces.Adapter {
+    return this.Adapter
+}
+
+// 加密数据
+func (this *Encrypt) Encrypt(contents []byte) ([]byte, error) {
+    var buf bytes.Buffer
+    buf.Grow(int(encryptedSize(this.aead, this.chunkSize, int64(len(contents)))))
+
+    w, err := newEncryptWriter(&buf, this.aead, this.cipherId, this.chunkSize)
+    if err != nil {
+        return nil, err
+    }
+
+    if _, err := w.Write(contents); err != nil {
+        return nil, err
+    }
+
+    if err := w.Close(); err != nil {
+        return nil, err
+    }
+
+    return buf.Bytes(), nil
+}
+
+// 解密数据
+func (this *Encrypt) Decrypt(data []byte) ([]byte, error) {
+    r, err := this.NewDecryptReader(bytes.NewReader(data))
+    if err != nil {
+        return nil, err
+    }
+
+    return io.ReadAll(r)
+}
+
+// 加密写入，Close 后写入完成，不关闭 w
+func (this *Encrypt) NewEncryptWriter(w io.Writer) (io.WriteCloser, error) {
+    return newEncryptWriter(w, this.aead, this.cipherId, this.chunkSize)
+}
+
+// 解密读取
+func (this *Encrypt) NewDecryptReader(r io.Reader) (io.Reader, error) {
+    return newDecryptReader(r, this.aead, this.cipherId, this.chunkSize)
+}
+
+// 上传
+func (this *Encrypt) Write(path string, contents []byte, conf interfaces.Config) (map[string]any, error) {
+    data, err := this.Encrypt(contents)
+    if err != nil {
+        return nil, err
+    }
+
+    result, err := this.Adapter.Write(path, data, conf)
+    if err != nil {
+        return nil, err
+    }
+
+    return this.plainResult(result, contents), nil
+}
+
+// 上传 Stream 文件类型
+// 通过管道边加密边写入
+func (this *Encrypt) WriteStream(path string, stream io.Reader, conf interfaces.Config) (map[string]any, error) {
+    return this.writeStream(path, stream, conf, this.Adapter.WriteStream)
+}
+
+// 更新
+func (this *Encrypt) Update(path string, contents []byte, conf interfaces.Config) (map[string]any, error) {
+    data, err := this.Encrypt(contents)
+    if err != nil {
+        return nil, err
+    }
+
+    result, err := this.Adapter.Update(path, data, conf)
+    if err != nil {
+        return nil, err
+    }
+
+    return this.plainResult(result, contents), nil
+}
+
+// 更新
+func (this *Encrypt) UpdateStream(path string, stream io.Reader, conf interfaces.Config) (map[string]any, error) {
+    return this.writeStream(path, stream, conf, this.Adapter.UpdateStream)
+}
+
+// 读取
+func (this *Encrypt) Read(path string) (map[string]any, error) {
+    result, err := this.Adapter.Read(path)
+    if err != nil {
+        return nil, err
+    }
+
+    data, _ := result["contents"].([]byte)
+
+    contents, err := this.Decrypt(data)
+    if err != nil {
+        return nil, err
+    }
+
+    result["contents"] = contents
+
+    return result, nil
+}
+
+// 读取成文件流
+// 解密数据写入临时文件，打开文件需要手动关闭
+func (this *Encrypt) ReadStream(path string) (map[string]any, error) {
+    result, err := this.Adapter.ReadStream(path)
+    if err != nil {
+        return nil, err
+    }
+
+    source, ok := result["stream"].(*os.File)
+    if !ok {
+        return nil, errors.New("go-filesystem: encrypt read stream fail")
+    }
+    defer source.Close()
+
+    stream, err := adapter.TempStream(func(w io.Writer) error {
+        r, err := this.NewDecryptReader(source)
+        if err != nil {
+            return err
+        }
+
+        _, err = io.Copy(w, r)
+        return err
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    result["stream"] = stream
+
+    return result, nil
+}
+
+// 列出内容
+func (this *Encrypt) ListContents(directory string, recursive ...bool) ([]map[string]any, error) {
+    list, err := this.Adapter.ListContents(directory, recursive...)
+    if err != nil {
+        return nil, err
+    }
+
+    for _, item := range list {
+        this.plainSize(item)
+    }
+
+    return list, nil
+}
+
+// 文件信息
+func (this *Encrypt) GetMetadata(path string) (map[string]any, error) {
+    result, err := this.Adapter.GetMetadata(path)
+    if err != nil {
+        return nil, err
+    }
+
+    return this.plainSize(result), nil
+}
+
+// 文件大小
+func (this *Encrypt) GetSize(path string) (map[string]any, error) {
+    result, err := this.Adapter.GetSize(path)
+    if err != nil {
+        return nil, err
+    }
+
+    return this.plainSize(result), nil
+}
+
+// 文件类型，根据解密后的第一块数据判断
+func (this *Encrypt) GetMimetype(path string) (map[string]any, error) {
+    result, err := this.Adapter.ReadStream(path)
+    if err != nil {
+        return nil, err
+    }
+
+    source, ok := result["stream"].(*os.File)
+    if !ok {
+        return nil, errors.New("go-filesystem: encrypt read stream fail")
+    }
+    defer source.Close()
+
+    r, err := this.NewDecryptReader(source)
+    if err != nil {
+        return nil, err
+    }
+
+    head := make([]byte, 512)
+    n, err := io.ReadFull(r, head)
+    if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+        return nil, err
+    }
+
+    return map[string]any{
+        "path":     path,
+        "type":     "file",
+        "mimetype": http.DetectContentType(head[:n]),
+    }, nil
+}
+
+// 数据流写入
+func (this *Encrypt) writeStream(
+    path string,
+    stream io.Reader,
+    conf interfaces.Config,
+    write func(string, io.Reader, interfaces.Config) (map[string]any, error),
+) (map[string]any, error) {
+    pr, pw := io.Pipe()
+
+    var size int64
+    done := make(chan struct{})
+
+    go func() {
+        defer close(done)
+
+        w, err := this.NewEncryptWriter(pw)
+        if err != nil {
+            pw.CloseWithError(err)
+            return
+        }
+
+        size, err = io.Copy(w, stream)
+        if err == nil {
+            err = w.Close()
+        }
+
+        pw.CloseWithError(err)
+    }()
+
+    result, err := write(path, pr, conf)
+
+    // 写入失败时结束加密协程
+    pr.CloseWithError(io.ErrClosedPipe)
+    <-done
+
+    if err != nil {
+        return nil, err
+    }
+
+    if _, ok := result["size"]; ok {
+        result["size"] = size
+    }
+
+    return result, nil
+}
+
+// 返回结果使用明文
+func (this *Encrypt) plainResult(result map[string]any, contents []byte) map[string]any {
+    if _, ok := result["contents"]; ok {
+        result["contents"] = contents
+    }
+    if _, ok := result["size"]; ok {
+        result["size"] = int64(len(contents))
+    }
+
+    return result
+}
+
+// 文件大小转换为明文大小，分块大小使用配置的值
+func (this *Encrypt) plainSize(result map[string]any) map[string]any {
+    if result["type"] == "dir" {
+        return result
+    }
+
+    if size, ok := result["size"].(int64); ok {
+        result["size"] = decryptedSize(this.aead, this.chunkSize, size)
+    }
+
+    return result
+}
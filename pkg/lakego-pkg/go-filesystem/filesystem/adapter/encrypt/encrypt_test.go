@@ -0,0 +1,297 @@
+package encrypt
+
+import (
+    "io"
+    "os"
+    "bytes"
+    "errors"
+    "reflect"
+    "testing"
+    "crypto/rand"
+    "path/filepath"
+
+    "github.com/deatil/go-filesystem/filesystem"
+    "github.com/deatil/go-filesystem/filesystem/config"
+    local_adapter "github.com/deatil/go-filesystem/filesystem/adapter/local"
+    memory_adapter "github.com/deatil/go-filesystem/filesystem/adapter/memory"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func assertErrorT(t *testing.T) func(error, string) {
+    return func(err error, msg string) {
+        if err != nil {
+            t.Errorf("Failed %s: error: %+v", msg, err)
+        }
+    }
+}
+
+func emptyConf() config.Config {
+    return config.New(map[string]any{})
+}
+
+func randomBytes(n int) []byte {
+    data := make([]byte, n)
+    rand.Read(data)
+
+    return data
+}
+
+var testCiphers = []struct {
+    name string
+    key  []byte
+}{
+    {AesGCM, bytes.Repeat([]byte("k"), 32)},
+    {Sm4GCM, bytes.Repeat([]byte("k"), 16)},
+    {Chacha20Poly1305, bytes.Repeat([]byte("k"), 32)},
+}
+
+func Test_EncryptDecrypt(t *testing.T) {
+    assertEqual := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    chunkSize := 16
+
+    for _, c := range testCiphers {
+        fs, err := New(memory_adapter.New(), Config{
+            Cipher:    c.name,
+            Key:       c.key,
+            ChunkSize: chunkSize,
+        })
+        assertError(err, "New " + c.name)
+
+        for _, size := range []int{0, 1, 15, 16, 17, 48, 100} {
+            data := randomBytes(size)
+
+            encrypted, err := fs.Encrypt(data)
+            assertError(err, "Encrypt " + c.name)
+            assertEqual(int64(len(encrypted)), encryptedSize(fs.aead, chunkSize, int64(size)), "encryptedSize " + c.name)
+            assertEqual(decryptedSize(fs.aead, chunkSize, int64(len(encrypted))), int64(size), "decryptedSize " + c.name)
+
+            decrypted, err := fs.Decrypt(encrypted)
+            assertError(err, "Decrypt " + c.name)
+            assertEqual(bytes.Equal(decrypted, data), true, "Decrypt data " + c.name)
+        }
+    }
+}
+
+func Test_Tamper(t *testing.T) {
+    assertEqual := assertEqualT(t)
+
+    fs, _ := New(memory_adapter.New(), Config{
+        Key:       bytes.Repeat([]byte("k"), 16),
+        ChunkSize: 16,
+    })
+
+    data := randomBytes(40)
+    encrypted, _ := fs.Encrypt(data)
+
+    // 修改数据
+    changed := append([]byte{}, encrypted...)
+    changed[len(changed) - 1] ^= 1
+    _, err := fs.Decrypt(changed)
+    assertEqual(err, ErrAuthFailed, "Decrypt changed")
+
+    // 截断最后一块
+    sealed := 16 + fs.aead.Overhead()
+    truncated := encrypted[:headerLen(fs.aead) + 2 * sealed]
+    _, err = fs.Decrypt(truncated)
+    assertEqual(err, ErrAuthFailed, "Decrypt truncated")
+
+    // 修改头部
+    header := append([]byte{}, encrypted...)
+    header[headerFixedLen] ^= 1
+    _, err = fs.Decrypt(header)
+    assertEqual(err, ErrAuthFailed, "Decrypt header changed")
+
+    // 错误的密钥
+    other, _ := New(memory_adapter.New(), Config{
+        Key:       bytes.Repeat([]byte("o"), 16),
+        ChunkSize: 16,
+    })
+    _, err = other.Decrypt(encrypted)
+    assertEqual(err, ErrAuthFailed, "Decrypt other key")
+
+    // 不同的加密方式
+    chacha, _ := New(memory_adapter.New(), Config{
+        Cipher: Chacha20Poly1305,
+        Key:    bytes.Repeat([]byte("k"), 32),
+    })
+    _, err = chacha.Decrypt(encrypted)
+    assertEqual(err, ErrInvalidFormat, "Decrypt other cipher")
+
+    _, err = fs.Decrypt([]byte("plain"))
+    assertEqual(err, ErrInvalidFormat, "Decrypt plain")
+}
+
+func Test_ChunkSizeMismatch(t *testing.T) {
+    assertEqual := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    root := t.TempDir()
+    key := bytes.Repeat([]byte("k"), 16)
+
+    fs, _ := New(local_adapter.New(root), Config{
+        Key:       key,
+        ChunkSize: 16,
+    })
+
+    data := randomBytes(100)
+    _, err := fs.Write("a.bin", data, emptyConf())
+    assertError(err, "Write")
+
+    // 修改分块大小后不能读取已有文件
+    other, _ := New(local_adapter.New(root), Config{
+        Key:       key,
+        ChunkSize: 32,
+    })
+
+    _, err = other.Read("a.bin")
+    assertEqual(err, ErrChunkSizeMismatch, "Read other chunk size")
+
+    _, err = other.ReadStream("a.bin")
+    assertEqual(err, ErrChunkSizeMismatch, "ReadStream other chunk size")
+
+    _, err = other.GetMimetype("a.bin")
+    assertEqual(err, ErrChunkSizeMismatch, "GetMimetype other chunk size")
+
+    // 分块大小一致时明文大小正确
+    size, err := fs.GetSize("a.bin")
+    assertError(err, "GetSize")
+    assertEqual(size["size"], int64(len(data)), "GetSize")
+}
+
+func Test_New(t *testing.T) {
+    _, err := New(memory_adapter.New(), Config{
+        Cipher: "des",
+        Key:    bytes.Repeat([]byte("k"), 16),
+    })
+    if err == nil {
+        t.Error("New with unsupported cipher should fail")
+    }
+
+    _, err = New(memory_adapter.New(), Config{
+        Cipher: Sm4GCM,
+        Key:    bytes.Repeat([]byte("k"), 32),
+    })
+    if err == nil {
+        t.Error("New with bad key should fail")
+    }
+}
+
+func Test_Adapter(t *testing.T) {
+    assertEqual := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    root := t.TempDir()
+
+    fs, err := New(local_adapter.New(root), Config{
+        Cipher:    Sm4GCM,
+        Key:       bytes.Repeat([]byte("k"), 16),
+        ChunkSize: 1024,
+    })
+    assertError(err, "New")
+
+    data := []byte("<html><body>encrypted</body></html>")
+
+    res, err := fs.Write("a/page.html", data, emptyConf())
+    assertError(err, "Write")
+    assertEqual(res["size"], int64(len(data)), "Write size")
+
+    // 磁盘上为密文
+    local, _ := os.ReadFile(filepath.Join(root, "a/page.html"))
+    assertEqual(bytes.Contains(local, []byte("encrypted")), false, "Write encrypted")
+
+    read, err := fs.Read("a/page.html")
+    assertError(err, "Read")
+    assertEqual(read["contents"], data, "Read")
+
+    meta, err := fs.GetMetadata("a/page.html")
+    assertError(err, "GetMetadata")
+    assertEqual(meta["size"], int64(len(data)), "GetMetadata size")
+
+    size, err := fs.GetSize("a/page.html")
+    assertError(err, "GetSize")
+    assertEqual(size["size"], int64(len(data)), "GetSize")
+
+    mime, err := fs.GetMimetype("a/page.html")
+    assertError(err, "GetMimetype")
+    assertEqual(mime["mimetype"], "text/html; charset=utf-8", "GetMimetype")
+
+    list, err := fs.ListContents("a")
+    assertError(err, "ListContents")
+    assertEqual(list[0]["size"], int64(len(data)), "ListContents size")
+}
+
+func Test_Stream(t *testing.T) {
+    assertEqual := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    fs, _ := New(local_adapter.New(t.TempDir()), Config{
+        Cipher:    Chacha20Poly1305,
+        Key:       bytes.Repeat([]byte("k"), 32),
+        ChunkSize: 4096,
+    })
+
+    data := randomBytes(4096 * 5 + 123)
+
+    _, err := fs.WriteStream("big.bin", bytes.NewReader(data), emptyConf())
+    assertError(err, "WriteStream")
+
+    stream, err := fs.ReadStream("big.bin")
+    assertError(err, "ReadStream")
+
+    f := stream["stream"].(*os.File)
+    streamData, _ := io.ReadAll(f)
+    f.Close()
+    assertEqual(bytes.Equal(streamData, data), true, "ReadStream")
+
+    meta, _ := fs.GetMetadata("big.bin")
+    assertEqual(meta["size"], int64(len(data)), "GetMetadata size")
+
+    // 读取出错时返回错误
+    failed := io.MultiReader(bytes.NewReader(data[:100]), errorReader{})
+    _, err = fs.UpdateStream("big.bin", failed, emptyConf())
+    if err == nil {
+        t.Error("UpdateStream with error reader should fail")
+    }
+}
+
+func Test_MountManager(t *testing.T) {
+    assertEqual := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    encrypted, _ := New(memory_adapter.New(), Config{
+        Key: bytes.Repeat([]byte("k"), 32),
+    })
+
+    manager := filesystem.NewMountManager(map[string]any{
+        "local":  filesystem.New(local_adapter.New(t.TempDir())),
+        "secure": filesystem.New(encrypted),
+    })
+
+    _, err := manager.Write("local://from.txt", []byte("mount-data"))
+    assertError(err, "Write")
+
+    _, err = manager.Copy("local://from.txt", "secure://to.txt")
+    assertError(err, "Copy to secure")
+
+    _, err = manager.Copy("secure://to.txt", "local://back.txt")
+    assertError(err, "Copy to local")
+
+    data, err := manager.Read("local://back.txt")
+    assertError(err, "Read")
+    assertEqual(data, []byte("mount-data"), "Read local")
+}
+
+type errorReader struct{}
+
+func (errorReader) Read([]byte) (int, error) {
+    return 0, errors.New("read fail")
+}
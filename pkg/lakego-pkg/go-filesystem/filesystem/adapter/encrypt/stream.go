@@ -0,0 +1,278 @@
+package encrypt
+
+import (
+    "io"
+    "bufio"
+    "errors"
+    "crypto/rand"
+    "crypto/cipher"
+    "encoding/binary"
+)
+
+/**
+ * 分块加密格式
+ *
+ * 头部: magic(4) | version(1) | cipher(1) | chunkSize(4) | noncePrefix(nonceSize-5)
+ * 数据: 每块明文 chunkSize 字节单独加密，最后一块可以更短或为空
+ * nonce: noncePrefix | counter(4) | last(1)，头部作为附加数据参与认证
+ * 最后一块 last 为 1，可防止截断及块顺序被修改
+ */
+
+const (
+    // 文件标识
+    streamMagic = "LGFE"
+
+    // 格式版本
+    streamVersion = 1
+
+    // 固定头部长度，不含 nonce 前缀
+    headerFixedLen = 10
+)
+
+var (
+    // 数据格式错误
+    ErrInvalidFormat = errors.New("go-filesystem: encrypt data format invalid")
+
+    // 数据认证失败
+    ErrAuthFailed = errors.New("go-filesystem: encrypt data authentication failed")
+
+    // 文件分块大小与配置不一致
+    ErrChunkSizeMismatch = errors.New("go-filesystem: encrypt chunk size mismatch")
+)
+
+// 头部长度
+func headerLen(aead cipher.AEAD) int {
+    return headerFixedLen + aead.NonceSize() - 5
+}
+
+// 加密后大小
+func encryptedSize(aead cipher.AEAD, chunkSize int, size int64) int64 {
+    chunks := size / int64(chunkSize) + 1
+    if size > 0 && size % int64(chunkSize) == 0 {
+        chunks--
+    }
+
+    return int64(headerLen(aead)) + size + chunks * int64(aead.Overhead())
+}
+
+// 解密后大小
+func decryptedSize(aead cipher.AEAD, chunkSize int, size int64) int64 {
+    body := size - int64(headerLen(aead))
+    if body < int64(aead.Overhead()) {
+        return 0
+    }
+
+    sealed := int64(chunkSize + aead.Overhead())
+
+    chunks := (body + sealed - 1) / sealed
+
+    return body - chunks * int64(aead.Overhead())
+}
+
+// 分块 nonce
+func chunkNonce(prefix []byte, counter uint32, last bool) []byte {
+    nonce := make([]byte, len(prefix) + 5)
+    copy(nonce, prefix)
+
+    binary.BigEndian.PutUint32(nonce[len(prefix):], counter)
+    if last {
+        nonce[len(nonce) - 1] = 1
+    }
+
+    return nonce
+}
+
+/**
+ * 加密写入
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type encryptWriter struct {
+    w         io.Writer
+    aead      cipher.AEAD
+    header    []byte
+    prefix    []byte
+    buf       []byte
+    chunkSize int
+    counter   uint32
+    started   bool
+    closed    bool
+}
+
+// 加密写入，Close 时写入最后一块，不关闭 w
+func newEncryptWriter(w io.Writer, aead cipher.AEAD, cipherId byte, chunkSize int) (*encryptWriter, error) {
+    header := make([]byte, headerLen(aead))
+    copy(header, streamMagic)
+    header[4] = streamVersion
+    header[5] = cipherId
+    binary.BigEndian.PutUint32(header[6:], uint32(chunkSize))
+
+    if _, err := io.ReadFull(rand.Reader, header[headerFixedLen:]); err != nil {
+        return nil, err
+    }
+
+    return &encryptWriter{
+        w:         w,
+        aead:      aead,
+        header:    header,
+        prefix:    header[headerFixedLen:],
+        buf:       make([]byte, 0, chunkSize),
+        chunkSize: chunkSize,
+    }, nil
+}
+
+func (this *encryptWriter) Write(p []byte) (int, error) {
+    if this.closed {
+        return 0, errors.New("go-filesystem: encrypt writer closed")
+    }
+
+    n := 0
+    for len(p) > 0 {
+        // 缓存已满且还有数据时，缓存不是最后一块
+        if len(this.buf) == this.chunkSize {
+            if err := this.flush(false); err != nil {
+                return n, err
+            }
+        }
+
+        size := copy(this.buf[len(this.buf):this.chunkSize], p)
+        this.buf = this.buf[:len(this.buf) + size]
+
+        p = p[size:]
+        n += size
+    }
+
+    return n, nil
+}
+
+// 写入最后一块
+func (this *encryptWriter) Close() error {
+    if this.closed {
+        return nil
+    }
+
+    this.closed = true
+
+    return this.flush(true)
+}
+
+// 加密并写入缓存的数据
+func (this *encryptWriter) flush(last bool) error {
+    if !this.started {
+        if _, err := this.w.Write(this.header); err != nil {
+            return err
+        }
+
+        this.started = true
+    }
+
+    nonce := chunkNonce(this.prefix, this.counter, last)
+    sealed := this.aead.Seal(nil, nonce, this.buf, this.header)
+
+    if _, err := this.w.Write(sealed); err != nil {
+        return err
+    }
+
+    this.counter++
+    this.buf = this.buf[:0]
+
+    return nil
+}
+
+/**
+ * 解密读取
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type decryptReader struct {
+    r        *bufio.Reader
+    aead     cipher.AEAD
+    header   []byte
+    prefix   []byte
+    sealed   []byte
+    plain    []byte
+    counter  uint32
+    finished bool
+}
+
+// 解密读取，读取时校验头部
+// 头部分块大小需与配置一致，否则按配置计算的明文大小会出错
+func newDecryptReader(r io.Reader, aead cipher.AEAD, cipherId byte, chunkSize int) (*decryptReader, error) {
+    header := make([]byte, headerLen(aead))
+    if _, err := io.ReadFull(r, header); err != nil {
+        return nil, ErrInvalidFormat
+    }
+
+    if string(header[:4]) != streamMagic ||
+        header[4] != streamVersion ||
+        header[5] != cipherId {
+        return nil, ErrInvalidFormat
+    }
+
+    size := int(binary.BigEndian.Uint32(header[6:]))
+    if size <= 0 || size > MaxChunkSize {
+        return nil, ErrInvalidFormat
+    }
+
+    if size != chunkSize {
+        return nil, ErrChunkSizeMismatch
+    }
+
+    return &decryptReader{
+        r:      bufio.NewReader(r),
+        aead:   aead,
+        header: header,
+        prefix: header[headerFixedLen:],
+        sealed: make([]byte, chunkSize + aead.Overhead()),
+    }, nil
+}
+
+func (this *decryptReader) Read(p []byte) (int, error) {
+    for len(this.plain) == 0 {
+        if this.finished {
+            return 0, io.EOF
+        }
+
+        if err := this.next(); err != nil {
+            return 0, err
+        }
+    }
+
+    n := copy(p, this.plain)
+    this.plain = this.plain[n:]
+
+    return n, nil
+}
+
+// 读取并解密下一块
+func (this *decryptReader) next() error {
+    n, err := io.ReadFull(this.r, this.sealed)
+    if err == io.ErrUnexpectedEOF || err == io.EOF {
+        // 不足一整块时为最后一块
+        this.finished = true
+    } else if err != nil {
+        return err
+    } else if _, err := this.r.Peek(1); err == io.EOF {
+        this.finished = true
+    } else if err != nil {
+        return err
+    }
+
+    if n < this.aead.Overhead() {
+        return io.ErrUnexpectedEOF
+    }
+
+    nonce := chunkNonce(this.prefix, this.counter, this.finished)
+
+    plain, err := this.aead.Open(this.sealed[:0], nonce, this.sealed[:n], this.header)
+    if err != nil {
+        return ErrAuthFailed
+    }
+
+    this.counter++
+    this.plain = plain
+
+    return nil
+}
@@ -0,0 +1,496 @@
+package ftp
+
+import (
+    "io"
+    "fmt"
+    "net"
+    "errors"
+    "strings"
+    "strconv"
+    "net/textproto"
+    "time"
+)
+
+// 目录项
+type entry struct {
+    name  string
+    isDir bool
+    size  int64
+    mtime time.Time
+
+    // 权限，为 0 时服务端未返回
+    perm uint32
+}
+
+/**
+ * FTP 客户端，只实现适配器需要的命令
+ * 使用被动模式传输数据
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type conn struct {
+    text *textproto.Conn
+    host string
+
+    timeout time.Duration
+
+    // 服务端支持 MLSD
+    mlsd bool
+}
+
+// 连接并登录
+func dial(addr string, username string, password string, timeout time.Duration) (*conn, error) {
+    raw, err := net.DialTimeout("tcp", addr, timeout)
+    if err != nil {
+        return nil, err
+    }
+
+    host, _, _ := net.SplitHostPort(addr)
+
+    c := &conn{
+        text:    textproto.NewConn(raw),
+        host:    host,
+        timeout: timeout,
+    }
+
+    if _, _, err := c.text.ReadResponse(220); err != nil {
+        c.text.Close()
+        return nil, err
+    }
+
+    if err := c.login(username, password); err != nil {
+        c.text.Close()
+        return nil, err
+    }
+
+    return c, nil
+}
+
+// 登录
+func (this *conn) login(username string, password string) error {
+    code, _, err := this.cmd(0, "USER %s", username)
+    if err != nil {
+        return err
+    }
+
+    switch code {
+        case 230:
+        case 331:
+            if _, _, err := this.cmd(230, "PASS %s", password); err != nil {
+                return err
+            }
+        default:
+            return &textproto.Error{Code: code, Msg: "login fail"}
+    }
+
+    if _, _, err := this.cmd(200, "TYPE I"); err != nil {
+        return err
+    }
+
+    // 检测是否支持 MLSD
+    if _, msg, err := this.cmd(211, "FEAT"); err == nil {
+        for _, line := range strings.Split(msg, "\n") {
+            if strings.EqualFold(strings.TrimSpace(line), "MLSD") ||
+                strings.HasPrefix(strings.ToUpper(strings.TrimSpace(line)), "MLST") {
+                this.mlsd = true
+            }
+        }
+    }
+
+    return nil
+}
+
+// 关闭
+func (this *conn) Close() error {
+    this.cmd(0, "QUIT")
+
+    return this.text.Close()
+}
+
+// 检测连接
+func (this *conn) Noop() error {
+    _, _, err := this.cmd(200, "NOOP")
+    return err
+}
+
+// 上传
+func (this *conn) Stor(path string, r io.Reader) (int64, error) {
+    data, err := this.transfer("STOR %s", path)
+    if err != nil {
+        return 0, err
+    }
+
+    size, copyErr := io.Copy(data, r)
+    data.Close()
+
+    if _, _, err := this.text.ReadResponse(2); err != nil {
+        return size, err
+    }
+
+    return size, copyErr
+}
+
+// 下载
+// limit 大于 0 时只读取前 limit 字节
+func (this *conn) Retr(path string, w io.Writer, limit int64) (int64, error) {
+    data, err := this.transfer("RETR %s", path)
+    if err != nil {
+        return 0, err
+    }
+
+    var src io.Reader = data
+    if limit > 0 {
+        src = io.LimitReader(data, limit)
+    }
+
+    size, copyErr := io.Copy(w, src)
+    data.Close()
+
+    // 提前关闭时服务端可能返回 426
+    expect := 2
+    if limit > 0 {
+        expect = 0
+    }
+
+    if _, _, err := this.text.ReadResponse(expect); err != nil {
+        return size, err
+    }
+
+    return size, copyErr
+}
+
+// 列出文件夹
+func (this *conn) List(dir string) ([]entry, error) {
+    command := "LIST -a %s"
+    if this.mlsd {
+        command = "MLSD %s"
+    }
+
+    data, err := this.transfer(command, dir)
+    if err != nil {
+        return nil, err
+    }
+
+    contents, readErr := io.ReadAll(data)
+    data.Close()
+
+    if _, _, err := this.text.ReadResponse(2); err != nil {
+        return nil, err
+    }
+
+    if readErr != nil {
+        return nil, readErr
+    }
+
+    var entries []entry
+    for _, line := range strings.Split(string(contents), "\n") {
+        line = strings.TrimRight(line, "\r")
+        if line == "" {
+            continue
+        }
+
+        var e entry
+        var ok bool
+        if this.mlsd {
+            e, ok = parseMLSD(line)
+        } else {
+            e, ok = parseList(line)
+        }
+
+        if ok && e.name != "." && e.name != ".." {
+            entries = append(entries, e)
+        }
+    }
+
+    return entries, nil
+}
+
+// 创建文件夹
+func (this *conn) Mkdir(path string) error {
+    _, _, err := this.cmd(257, "MKD %s", path)
+    return err
+}
+
+// 删除空文件夹
+func (this *conn) Rmdir(path string) error {
+    _, _, err := this.cmd(250, "RMD %s", path)
+    return err
+}
+
+// 删除文件
+func (this *conn) Delete(path string) error {
+    _, _, err := this.cmd(250, "DELE %s", path)
+    return err
+}
+
+// 重命名
+func (this *conn) Rename(from string, to string) error {
+    if _, _, err := this.cmd(350, "RNFR %s", from); err != nil {
+        return err
+    }
+
+    _, _, err := this.cmd(250, "RNTO %s", to)
+    return err
+}
+
+// 设置权限
+func (this *conn) Chmod(path string, perm uint32) error {
+    _, _, err := this.cmd(200, "SITE CHMOD %o %s", perm, path)
+    return err
+}
+
+// 发送命令
+func (this *conn) cmd(expect int, format string, args ...any) (int, string, error) {
+    id, err := this.text.Cmd(format, args...)
+    if err != nil {
+        return 0, "", err
+    }
+
+    this.text.StartResponse(id)
+    defer this.text.EndResponse(id)
+
+    return this.text.ReadResponse(expect)
+}
+
+// 打开数据连接并发送命令
+func (this *conn) transfer(format string, args ...any) (net.Conn, error) {
+    data, err := this.openData()
+    if err != nil {
+        return nil, err
+    }
+
+    if _, _, err := this.cmd(1, format, args...); err != nil {
+        data.Close()
+        return nil, err
+    }
+
+    return data, nil
+}
+
+// 打开被动模式数据连接
+// 优先使用 EPSV，不支持时使用 PASV
+func (this *conn) openData() (net.Conn, error) {
+    port, err := this.epsv()
+    if err != nil {
+        port, err = this.pasv()
+        if err != nil {
+            return nil, err
+        }
+    }
+
+    // 使用控制连接的地址，避免 NAT 环境返回内网地址
+    addr := net.JoinHostPort(this.host, strconv.Itoa(port))
+
+    return net.DialTimeout("tcp", addr, this.timeout)
+}
+
+func (this *conn) epsv() (int, error) {
+    _, msg, err := this.cmd(229, "EPSV")
+    if err != nil {
+        return 0, err
+    }
+
+    return parseEpsv(msg)
+}
+
+func (this *conn) pasv() (int, error) {
+    _, msg, err := this.cmd(227, "PASV")
+    if err != nil {
+        return 0, err
+    }
+
+    return parsePasv(msg)
+}
+
+// 解析 EPSV 响应中的端口
+// Entering Extended Passive Mode (|||6446|)
+func parseEpsv(msg string) (int, error) {
+    start := strings.Index(msg, "(")
+    end := strings.LastIndex(msg, ")")
+    if start < 0 || end <= start {
+        return 0, errors.New("go-filesystem: ftp invalid EPSV response")
+    }
+
+    fields := strings.Split(msg[start+1:end], "|")
+    if len(fields) != 5 {
+        return 0, errors.New("go-filesystem: ftp invalid EPSV response")
+    }
+
+    port, err := strconv.Atoi(fields[3])
+    if err != nil || port < 1 || port > 65535 {
+        return 0, errors.New("go-filesystem: ftp invalid EPSV response")
+    }
+
+    return port, nil
+}
+
+// 解析 PASV 响应中的端口，地址使用控制连接的地址
+// Entering Passive Mode (192,168,1,2,25,46)
+func parsePasv(msg string) (int, error) {
+    start := strings.Index(msg, "(")
+    end := strings.LastIndex(msg, ")")
+    if start < 0 || end <= start {
+        return 0, errors.New("go-filesystem: ftp invalid PASV response")
+    }
+
+    fields := strings.Split(msg[start+1:end], ",")
+    if len(fields) != 6 {
+        return 0, errors.New("go-filesystem: ftp invalid PASV response")
+    }
+
+    p1, err1 := strconv.Atoi(strings.TrimSpace(fields[4]))
+    p2, err2 := strconv.Atoi(strings.TrimSpace(fields[5]))
+    if err1 != nil || err2 != nil || p1 < 0 || p1 > 255 || p2 < 0 || p2 > 255 {
+        return 0, errors.New("go-filesystem: ftp invalid PASV response")
+    }
+
+    port := p1 * 256 + p2
+    if port < 1 {
+        return 0, errors.New("go-filesystem: ftp invalid PASV response")
+    }
+
+    return port, nil
+}
+
+// 解析 MLSD 数据
+// type=file;size=123;modify=20230101120000;unix.mode=0644; name
+func parseMLSD(line string) (entry, bool) {
+    facts, name, ok := strings.Cut(line, " ")
+    if !ok {
+        return entry{}, false
+    }
+
+    e := entry{
+        name: name,
+    }
+
+    for _, fact := range strings.Split(facts, ";") {
+        key, value, ok := strings.Cut(fact, "=")
+        if !ok {
+            continue
+        }
+
+        switch strings.ToLower(key) {
+            case "type":
+                value = strings.ToLower(value)
+                if value == "cdir" || value == "pdir" {
+                    return entry{}, false
+                }
+
+                e.isDir = value == "dir"
+            case "size":
+                e.size, _ = strconv.ParseInt(value, 10, 64)
+            case "modify":
+                e.mtime, _ = time.Parse("20060102150405", value[:min(len(value), 14)])
+            case "unix.mode":
+                perm, _ := strconv.ParseUint(value, 8, 32)
+                e.perm = uint32(perm) & 0777
+        }
+    }
+
+    if !validName(e.name) {
+        return entry{}, false
+    }
+
+    if e.size < 0 {
+        e.size = 0
+    }
+
+    return e, true
+}
+
+// 解析 LIST 数据，unix 格式
+// -rw-r--r--   1 owner group  1234 Jan  1 12:00 name
+func parseList(line string) (entry, bool) {
+    fields := strings.Fields(line)
+    if len(fields) < 9 || len(fields[0]) < 10 {
+        return entry{}, false
+    }
+
+    mode := fields[0]
+
+    e := entry{
+        isDir: mode[0] == 'd',
+        perm:  parsePermString(mode[1:10]),
+    }
+
+    e.size, _ = strconv.ParseInt(fields[4], 10, 64)
+
+    stamp := strings.Join(fields[5:8], " ")
+    if strings.Contains(fields[7], ":") {
+        if t, err := time.Parse("Jan _2 15:04", stamp); err == nil {
+            now := time.Now().UTC()
+
+            t = t.AddDate(now.Year(), 0, 0)
+            if t.After(now.AddDate(0, 0, 1)) {
+                t = t.AddDate(-1, 0, 0)
+            }
+
+            e.mtime = t
+        }
+    } else {
+        e.mtime, _ = time.Parse("Jan _2 2006", stamp)
+    }
+
+    // 文件名可能包含空格
+    name := line
+    for i := 0; i < 8; i++ {
+        name = strings.TrimLeft(name, " ")
+        if idx := strings.Index(name, " "); idx >= 0 {
+            name = name[idx:]
+        }
+    }
+    e.name = strings.TrimLeft(name, " ")
+
+    // 链接文件
+    if mode[0] == 'l' {
+        e.name, _, _ = strings.Cut(e.name, " -> ")
+    }
+
+    if !validName(e.name) {
+        return entry{}, false
+    }
+
+    if e.size < 0 {
+        e.size = 0
+    }
+
+    return e, true
+}
+
+// 服务端返回的文件名，不能为空或包含路径分隔符，避免遍历到其他目录
+func validName(name string) bool {
+    return name != "" && !strings.ContainsAny(name, "/\\\x00")
+}
+
+// 解析权限字符
+func parsePermString(s string) uint32 {
+    var perm uint32
+    for i, c := range s {
+        if c != '-' {
+            perm |= 1 << uint(8 - i)
+        }
+    }
+
+    return perm
+}
+
+func min(a, b int) int {
+    if a < b {
+        return a
+    }
+
+    return b
+}
+
+// 是否为服务端返回的错误
+func isProtocolError(err error) bool {
+    var protoErr *textproto.Error
+    return errors.As(err, &protoErr)
+}
+
+// 错误信息
+func wrapError(action string, err error) error {
+    return fmt.Errorf("go-filesystem: ftp %s fail, error: %w", action, err)
+}
@@ -0,0 +1,636 @@
+package ftp
+
+import (
+    "io"
+    "fmt"
+    "net"
+    "bytes"
+    "errors"
+    "strings"
+    "strconv"
+    "net/http"
+    "path"
+    "time"
+
+    "github.com/deatil/go-filesystem/filesystem/adapter"
+    "github.com/deatil/go-filesystem/filesystem/adapter/pool"
+    "github.com/deatil/go-filesystem/filesystem/interfaces"
+)
+
+// 权限列表
+var permissionMap map[string]map[string]uint32 = map[string]map[string]uint32{
+    "file": {
+        "public":  0644,
+        "private": 0600,
+    },
+    "dir": {
+        "public":  0755,
+        "private": 0700,
+    },
+}
+
+// 文件不存在
+var ErrNotExist = errors.New("go-filesystem: ftp file not exists")
+
+/**
+ * FTP 配置
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Config struct {
+    // 地址
+    Host string
+    Port int
+
+    // 账号
+    Username string
+    Password string
+
+    // 根目录
+    Root string
+
+    // 连接超时时间
+    Timeout time.Duration
+
+    // 连接池
+    MaxOpen     int
+    MaxIdle     int
+    IdleTimeout time.Duration
+}
+
+/**
+ * FTP 适配器 / Ftp adapter
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Ftp struct {
+    // 默认适配器基类
+    adapter.Adapter
+
+    // 配置
+    config Config
+
+    // 连接池
+    pool *pool.Pool[*conn]
+}
+
+// FTP 适配器
+func New(conf Config) (*Ftp, error) {
+    if conf.Host == "" {
+        return nil, errors.New("go-filesystem: ftp host is empty")
+    }
+
+    if conf.Port == 0 {
+        conf.Port = 21
+    }
+
+    if conf.Username == "" {
+        conf.Username = "anonymous"
+    }
+
+    if conf.Timeout == 0 {
+        conf.Timeout = 30 * time.Second
+    }
+
+    addr := net.JoinHostPort(conf.Host, strconv.Itoa(conf.Port))
+
+    fs := &Ftp{
+        config: conf,
+    }
+
+    fs.pool = pool.New(pool.Config[*conn]{
+        Factory: func() (*conn, error) {
+            c, err := dial(addr, conf.Username, conf.Password, conf.Timeout)
+            if err != nil {
+                return nil, wrapError("connect", err)
+            }
+
+            return c, nil
+        },
+        Close: func(c *conn) error {
+            return c.Close()
+        },
+        Ping: func(c *conn) bool {
+            return c.Noop() == nil
+        },
+        MaxOpen:     conf.MaxOpen,
+        MaxIdle:     conf.MaxIdle,
+        IdleTimeout: conf.IdleTimeout,
+    })
+
+    root := conf.Root
+    if root == "" {
+        root = "/"
+    }
+
+    fs.SetPathPrefix(root)
+
+    return fs, nil
+}
+
+// 关闭连接池
+func (this *Ftp) Close() error {
+    return this.pool.Close()
+}
+
+// 判断是否存在
+func (this *Ftp) Has(path string) bool {
+    location := this.ApplyPathPrefix(path)
+
+    err := this.do(func(c *conn) error {
+        _, err := this.stat(c, location)
+        return err
+    })
+
+    return err == nil
+}
+
+// 上传
+func (this *Ftp) Write(path string, contents []byte, conf interfaces.Config) (map[string]any, error) {
+    result, err := this.WriteStream(path, bytes.NewReader(contents), conf)
+    if err != nil {
+        return nil, err
+    }
+
+    result["contents"] = contents
+
+    return result, nil
+}
+
+// 上传 Stream 文件类型
+func (this *Ftp) WriteStream(path string, stream io.Reader, conf interfaces.Config) (map[string]any, error) {
+    location := this.ApplyPathPrefix(path)
+
+    var size int64
+    err := this.do(func(c *conn) error {
+        if err := this.ensureDirectory(c, parentDir(location)); err != nil {
+            return err
+        }
+
+        var err error
+        size, err = c.Stor(location, stream)
+        if err != nil {
+            return wrapError("upload", err)
+        }
+
+        if visibility := conf.Get("visibility"); visibility != nil {
+            return c.Chmod(location, permissionMap["file"][this.formatVisibility(visibility)])
+        }
+
+        return nil
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    result := map[string]any{
+        "type": "file",
+        "size": size,
+        "path": path,
+    }
+
+    if visibility := conf.Get("visibility"); visibility != nil {
+        result["visibility"] = this.formatVisibility(visibility)
+    }
+
+    return result, nil
+}
+
+// 更新
+func (this *Ftp) Update(path string, contents []byte, conf interfaces.Config) (map[string]any, error) {
+    return this.Write(path, contents, conf)
+}
+
+// 更新
+func (this *Ftp) UpdateStream(path string, stream io.Reader, conf interfaces.Config) (map[string]any, error) {
+    return this.WriteStream(path, stream, conf)
+}
+
+// 读取
+func (this *Ftp) Read(path string) (map[string]any, error) {
+    location := this.ApplyPathPrefix(path)
+
+    var buf bytes.Buffer
+    err := this.do(func(c *conn) error {
+        _, err := c.Retr(location, &buf, 0)
+        return err
+    })
+    if err != nil {
+        return nil, wrapError("read", err)
+    }
+
+    return map[string]any{
+        "type":     "file",
+        "path":     path,
+        "contents": buf.Bytes(),
+    }, nil
+}
+
+// 读取成文件流
+// 数据先写入临时文件，打开文件需要手动关闭
+func (this *Ftp) ReadStream(path string) (map[string]any, error) {
+    location := this.ApplyPathPrefix(path)
+
+    stream, err := adapter.TempStream(func(w io.Writer) error {
+        return this.do(func(c *conn) error {
+            _, err := c.Retr(location, w, 0)
+            return err
+        })
+    })
+    if err != nil {
+        return nil, wrapError("read", err)
+    }
+
+    return map[string]any{
+        "type":   "file",
+        "path":   path,
+        "stream": stream,
+    }, nil
+}
+
+// 重命名
+func (this *Ftp) Rename(path string, newpath string) error {
+    location := this.ApplyPathPrefix(path)
+    destination := this.ApplyPathPrefix(newpath)
+
+    return this.do(func(c *conn) error {
+        if err := this.ensureDirectory(c, parentDir(destination)); err != nil {
+            return err
+        }
+
+        if err := c.Rename(location, destination); err != nil {
+            return wrapError("rename", err)
+        }
+
+        return nil
+    })
+}
+
+// 复制
+// 协议不支持服务端复制，数据经本地中转
+func (this *Ftp) Copy(path string, newpath string) error {
+    location := this.ApplyPathPrefix(path)
+    destination := this.ApplyPathPrefix(newpath)
+
+    return this.do(func(c *conn) error {
+        info, err := this.stat(c, location)
+        if err != nil {
+            return err
+        }
+
+        if info.isDir {
+            return fmt.Errorf("go-filesystem: %s not right file", path)
+        }
+
+        var buf bytes.Buffer
+        if _, err := c.Retr(location, &buf, 0); err != nil {
+            return wrapError("copy", err)
+        }
+
+        if err := this.ensureDirectory(c, parentDir(destination)); err != nil {
+            return err
+        }
+
+        if _, err := c.Stor(destination, &buf); err != nil {
+            return wrapError("copy", err)
+        }
+
+        if info.perm != 0 {
+            c.Chmod(destination, info.perm)
+        }
+
+        return nil
+    })
+}
+
+// 删除
+func (this *Ftp) Delete(path string) error {
+    location := this.ApplyPathPrefix(path)
+
+    return this.do(func(c *conn) error {
+        if err := c.Delete(location); err != nil {
+            return errors.New("go-filesystem: file delete fail, error: " + err.Error())
+        }
+
+        return nil
+    })
+}
+
+// 删除文件夹
+func (this *Ftp) DeleteDir(dirname string) error {
+    location := this.ApplyPathPrefix(dirname)
+
+    return this.do(func(c *conn) error {
+        return this.removeAll(c, strings.TrimSuffix(location, "/"))
+    })
+}
+
+// 创建文件夹
+func (this *Ftp) CreateDir(dirname string, conf interfaces.Config) (map[string]string, error) {
+    location := strings.TrimSuffix(this.ApplyPathPrefix(dirname), "/")
+
+    visibility := this.formatVisibility(conf.Get("visibility", "public"))
+
+    err := this.do(func(c *conn) error {
+        if err := this.ensureDirectory(c, location); err != nil {
+            return err
+        }
+
+        return c.Chmod(location, permissionMap["dir"][visibility])
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    return map[string]string{
+        "path": dirname,
+        "type": "dir",
+    }, nil
+}
+
+// 列出内容
+func (this *Ftp) ListContents(directory string, recursive ...bool) ([]map[string]any, error) {
+    location := strings.TrimSuffix(this.ApplyPathPrefix(directory), "/")
+    if location == "" {
+        location = "/"
+    }
+
+    isRecursive := len(recursive) > 0 && recursive[0]
+
+    var result []map[string]any
+    err := this.do(func(c *conn) error {
+        return this.walk(c, location, isRecursive, func(pathname string, e entry) {
+            result = append(result, this.normalizeFileInfo(pathname, e))
+        })
+    })
+    if err != nil {
+        if isProtocolError(err) {
+            return []map[string]any{}, nil
+        }
+
+        return nil, err
+    }
+
+    return result, nil
+}
+
+// 文件信息
+func (this *Ftp) GetMetadata(path string) (map[string]any, error) {
+    location := this.ApplyPathPrefix(path)
+
+    var info entry
+    err := this.do(func(c *conn) error {
+        var err error
+        info, err = this.stat(c, location)
+        return err
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    return this.normalizeFileInfo(location, info), nil
+}
+
+func (this *Ftp) GetSize(path string) (map[string]any, error) {
+    return this.GetMetadata(path)
+}
+
+func (this *Ftp) GetMimetype(path string) (map[string]any, error) {
+    location := this.ApplyPathPrefix(path)
+
+    var buf bytes.Buffer
+    err := this.do(func(c *conn) error {
+        _, err := c.Retr(location, &buf, 512)
+        return err
+    })
+    if err != nil {
+        return nil, wrapError("read", err)
+    }
+
+    return map[string]any{
+        "path":     path,
+        "type":     "file",
+        "mimetype": http.DetectContentType(buf.Bytes()),
+    }, nil
+}
+
+func (this *Ftp) GetTimestamp(path string) (map[string]any, error) {
+    return this.GetMetadata(path)
+}
+
+// 获取文件的权限
+// 服务端未返回权限时为 public
+func (this *Ftp) GetVisibility(path string) (map[string]string, error) {
+    location := this.ApplyPathPrefix(path)
+
+    var info entry
+    err := this.do(func(c *conn) error {
+        var err error
+        info, err = this.stat(c, location)
+        return err
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    if info.perm == 0 {
+        return map[string]string{
+            "path":       path,
+            "visibility": "public",
+        }, nil
+    }
+
+    pathType := "file"
+    if info.isDir {
+        pathType = "dir"
+    }
+
+    for visibility, visibilityPermissions := range permissionMap[pathType] {
+        if visibilityPermissions == info.perm {
+            return map[string]string{
+                "path":       path,
+                "visibility": visibility,
+            }, nil
+        }
+    }
+
+    return map[string]string{
+        "path":       path,
+        "visibility": fmt.Sprintf("%o", info.perm),
+    }, nil
+}
+
+// 设置文件的权限
+func (this *Ftp) SetVisibility(path string, visibility string) (map[string]string, error) {
+    location := this.ApplyPathPrefix(path)
+
+    visibility = this.formatVisibility(visibility)
+
+    err := this.do(func(c *conn) error {
+        info, err := this.stat(c, location)
+        if err != nil {
+            return err
+        }
+
+        pathType := "file"
+        if info.isDir {
+            pathType = "dir"
+        }
+
+        return c.Chmod(location, permissionMap[pathType][visibility])
+    })
+    if err != nil {
+        return nil, errors.New("go-filesystem: set permission fail, error: " + err.Error())
+    }
+
+    return map[string]string{
+        "path":       path,
+        "visibility": visibility,
+    }, nil
+}
+
+// 使用连接池执行
+// 服务端返回错误码时连接仍可用，其他错误丢弃连接
+func (this *Ftp) do(fn func(*conn) error) error {
+    return this.pool.Do(fn, func(err error) bool {
+        return !isProtocolError(err) && !errors.Is(err, ErrNotExist)
+    })
+}
+
+// 权限
+func (this *Ftp) formatVisibility(visibility any) string {
+    if v, _ := visibility.(string); v == "private" {
+        return "private"
+    }
+
+    return "public"
+}
+
+// 文件信息，从上级目录列表中查找
+func (this *Ftp) stat(c *conn, location string) (entry, error) {
+    location = strings.TrimSuffix(location, "/")
+    if location == "" {
+        return entry{name: "/", isDir: true}, nil
+    }
+
+    entries, err := c.List(parentDir(location))
+    if err != nil {
+        if isProtocolError(err) {
+            return entry{}, ErrNotExist
+        }
+
+        return entry{}, err
+    }
+
+    name := path.Base(location)
+    for _, e := range entries {
+        if e.name == name {
+            return e, nil
+        }
+    }
+
+    return entry{}, ErrNotExist
+}
+
+// 确认文件夹，逐级创建
+func (this *Ftp) ensureDirectory(c *conn, dir string) error {
+    dir = strings.TrimSuffix(dir, "/")
+    if dir == "" || dir == "." {
+        return nil
+    }
+
+    info, err := this.stat(c, dir)
+    if err == nil {
+        if !info.isDir {
+            return fmt.Errorf("go-filesystem: %s is not dir", dir)
+        }
+
+        return nil
+    } else if !errors.Is(err, ErrNotExist) {
+        return err
+    }
+
+    if err := this.ensureDirectory(c, parentDir(dir)); err != nil {
+        return err
+    }
+
+    if err := c.Mkdir(dir); err != nil {
+        return wrapError("mkdir", err)
+    }
+
+    return nil
+}
+
+// 遍历文件夹
+func (this *Ftp) walk(c *conn, dir string, recursive bool, fn func(string, entry)) error {
+    entries, err := c.List(dir)
+    if err != nil {
+        return err
+    }
+
+    for _, e := range entries {
+        pathname := strings.TrimSuffix(dir, "/") + "/" + e.name
+
+        fn(pathname, e)
+
+        if recursive && e.isDir {
+            if err := this.walk(c, pathname, recursive, fn); err != nil {
+                return err
+            }
+        }
+    }
+
+    return nil
+}
+
+// 递归删除
+func (this *Ftp) removeAll(c *conn, dir string) error {
+    entries, err := c.List(dir)
+    if err != nil {
+        return wrapError("delete dir", err)
+    }
+
+    for _, e := range entries {
+        pathname := dir + "/" + e.name
+
+        if e.isDir {
+            err = this.removeAll(c, pathname)
+        } else {
+            err = c.Delete(pathname)
+        }
+
+        if err != nil {
+            return wrapError("delete dir", err)
+        }
+    }
+
+    if err := c.Rmdir(dir); err != nil {
+        return wrapError("delete dir", err)
+    }
+
+    return nil
+}
+
+// 格式化文件信息
+func (this *Ftp) normalizeFileInfo(pathname string, e entry) map[string]any {
+    normalized := map[string]any{
+        "type":      "file",
+        "path":      strings.Trim(this.RemovePathPrefix(pathname), "/"),
+        "timestamp": e.mtime.Unix(),
+    }
+
+    if e.isDir {
+        normalized["type"] = "dir"
+    } else {
+        normalized["size"] = e.size
+    }
+
+    return normalized
+}
+
+// 上级目录
+func parentDir(location string) string {
+    return path.Dir(strings.TrimSuffix(location, "/"))
+}
@@ -0,0 +1,232 @@
+package ftp
+
+import (
+    "io"
+    "os"
+    "sort"
+    "reflect"
+    "testing"
+    "path/filepath"
+    "time"
+
+    "github.com/deatil/go-filesystem/filesystem"
+    "github.com/deatil/go-filesystem/filesystem/config"
+    local_adapter "github.com/deatil/go-filesystem/filesystem/adapter/local"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func assertErrorT(t *testing.T) func(error, string) {
+    return func(err error, msg string) {
+        if err != nil {
+            t.Errorf("Failed %s: error: %+v", msg, err)
+        }
+    }
+}
+
+func newTestFtp(t *testing.T, root string, mlsd bool) (*Ftp, *fakeServer) {
+    server := newFakeServer(t, mlsd)
+
+    fs, err := New(Config{
+        Host:     "127.0.0.1",
+        Port:     server.port(),
+        Username: server.user,
+        Password: server.pass,
+        Root:     "/" + root,
+        Timeout:  5 * time.Second,
+        MaxOpen:  2,
+    })
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    t.Cleanup(func() {
+        fs.Close()
+    })
+
+    return fs, server
+}
+
+func emptyConf() config.Config {
+    return config.New(map[string]any{})
+}
+
+func Test_WriteAndRead(t *testing.T) {
+    for _, mlsd := range []bool{true, false} {
+        assertEqual := assertEqualT(t)
+        assertError := assertErrorT(t)
+
+        fs, server := newTestFtp(t, "files", mlsd)
+
+        res, err := fs.Write("a/b/test file.txt", []byte("ftp-data"), emptyConf())
+        assertError(err, "Write")
+        assertEqual(res["size"], int64(8), "Write size")
+
+        local, _ := os.ReadFile(filepath.Join(server.root, "files/a/b/test file.txt"))
+        assertEqual(local, []byte("ftp-data"), "Write local")
+
+        assertEqual(fs.Has("a/b/test file.txt"), true, "Has")
+        assertEqual(fs.Has("a/b"), true, "Has dir")
+        assertEqual(fs.Has("a/b/no.txt"), false, "Has not")
+
+        read, err := fs.Read("a/b/test file.txt")
+        assertError(err, "Read")
+        assertEqual(read["contents"], []byte("ftp-data"), "Read")
+
+        stream, err := fs.ReadStream("a/b/test file.txt")
+        assertError(err, "ReadStream")
+
+        f := stream["stream"].(*os.File)
+        streamData, _ := io.ReadAll(f)
+        f.Close()
+        assertEqual(streamData, []byte("ftp-data"), "ReadStream")
+
+        meta, err := fs.GetMetadata("a/b/test file.txt")
+        assertError(err, "GetMetadata")
+        assertEqual(meta["size"], int64(8), "GetMetadata size")
+        assertEqual(meta["type"], "file", "GetMetadata type")
+
+        _, err = fs.Read("no.txt")
+        if err == nil {
+            t.Error("Read not exists should fail")
+        }
+
+        // 出错后连接仍可复用
+        assertEqual(fs.Has("a/b/test file.txt"), true, "Has after error")
+        assertEqual(server.connCount() <= 2, true, "pool conns")
+    }
+}
+
+func Test_ListAndDelete(t *testing.T) {
+    for _, mlsd := range []bool{true, false} {
+        assertEqual := assertEqualT(t)
+        assertError := assertErrorT(t)
+
+        fs, _ := newTestFtp(t, "", mlsd)
+
+        conf := emptyConf()
+        fs.Write("a.txt", []byte("a"), conf)
+        fs.Write("sub/b.txt", []byte("bb"), conf)
+        fs.Write("sub/deep/c.txt", []byte("ccc"), conf)
+
+        list, err := fs.ListContents("")
+        assertError(err, "ListContents")
+        assertEqual(paths(list), []string{"dir:sub", "file:a.txt"}, "ListContents")
+
+        list, err = fs.ListContents("sub", true)
+        assertError(err, "ListContents recursive")
+        assertEqual(paths(list), []string{"dir:sub/deep", "file:sub/b.txt", "file:sub/deep/c.txt"}, "ListContents recursive")
+
+        for _, item := range list {
+            if item["path"] == "sub/deep/c.txt" {
+                assertEqual(item["size"], int64(3), "ListContents size")
+            }
+        }
+
+        assertError(fs.Copy("sub/b.txt", "copy/b.txt"), "Copy")
+        assertError(fs.Rename("copy/b.txt", "moved.txt"), "Rename")
+
+        read, _ := fs.Read("moved.txt")
+        assertEqual(read["contents"], []byte("bb"), "Rename data")
+
+        assertError(fs.DeleteDir("sub"), "DeleteDir")
+        assertEqual(fs.Has("sub"), false, "DeleteDir")
+
+        assertError(fs.Delete("a.txt"), "Delete")
+        assertEqual(fs.Has("a.txt"), false, "Delete")
+    }
+}
+
+func Test_Visibility(t *testing.T) {
+    for _, mlsd := range []bool{true, false} {
+        assertEqual := assertEqualT(t)
+        assertError := assertErrorT(t)
+
+        fs, server := newTestFtp(t, "", mlsd)
+
+        _, err := fs.Write("private.txt", []byte("p"), config.New(map[string]any{
+            "visibility": "private",
+        }))
+        assertError(err, "Write")
+
+        info, _ := os.Stat(filepath.Join(server.root, "private.txt"))
+        assertEqual(info.Mode().Perm(), os.FileMode(0600), "Write private perm")
+
+        res, err := fs.GetVisibility("private.txt")
+        assertError(err, "GetVisibility")
+        assertEqual(res["visibility"], "private", "GetVisibility")
+
+        _, err = fs.SetVisibility("private.txt", "public")
+        assertError(err, "SetVisibility")
+
+        res, _ = fs.GetVisibility("private.txt")
+        assertEqual(res["visibility"], "public", "SetVisibility")
+    }
+}
+
+func Test_MountManager(t *testing.T) {
+    assertEqual := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    fs, _ := newTestFtp(t, "", true)
+
+    manager := filesystem.NewMountManager(map[string]any{
+        "local": filesystem.New(local_adapter.New(t.TempDir())),
+        "ftp":   filesystem.New(fs),
+    })
+
+    _, err := manager.Write("local://from.txt", []byte("mount-data"))
+    assertError(err, "Write")
+
+    _, err = manager.Copy("local://from.txt", "ftp://to/to.txt")
+    assertError(err, "Copy to ftp")
+
+    data, err := manager.Read("ftp://to/to.txt")
+    assertError(err, "Read")
+    assertEqual(data, []byte("mount-data"), "Read ftp")
+}
+
+func Test_ParseList(t *testing.T) {
+    assertEqual := assertEqualT(t)
+
+    e, ok := parseList("-rw-r-----   1 owner group     1234 Jan  5  2023 my file.txt")
+    assertEqual(ok, true, "parseList ok")
+    assertEqual(e.name, "my file.txt", "parseList name")
+    assertEqual(e.size, int64(1234), "parseList size")
+    assertEqual(e.perm, uint32(0640), "parseList perm")
+    assertEqual(e.mtime, time.Date(2023, 1, 5, 0, 0, 0, 0, time.UTC), "parseList mtime")
+
+    e, ok = parseList("drwxr-xr-x   2 owner group     4096 Mar 10 12:30 dir")
+    assertEqual(ok, true, "parseList dir ok")
+    assertEqual(e.isDir, true, "parseList dir")
+    assertEqual(e.perm, uint32(0755), "parseList dir perm")
+
+    _, ok = parseList("total 8")
+    assertEqual(ok, false, "parseList total")
+
+    e, ok = parseMLSD("type=file;size=10;modify=20240102030405.123;UNIX.mode=0600; a b.txt")
+    assertEqual(ok, true, "parseMLSD ok")
+    assertEqual(e.name, "a b.txt", "parseMLSD name")
+    assertEqual(e.perm, uint32(0600), "parseMLSD perm")
+    assertEqual(e.mtime, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), "parseMLSD mtime")
+
+    _, ok = parseMLSD("type=cdir;modify=20240102030405; .")
+    assertEqual(ok, false, "parseMLSD cdir")
+}
+
+func paths(list []map[string]any) []string {
+    res := make([]string, 0, len(list))
+    for _, item := range list {
+        res = append(res, item["type"].(string) + ":" + item["path"].(string))
+    }
+
+    sort.Strings(res)
+
+    return res
+}
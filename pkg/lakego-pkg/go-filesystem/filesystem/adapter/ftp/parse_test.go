@@ -0,0 +1,97 @@
+package ftp
+
+import (
+    "strings"
+    "testing"
+)
+
+func Test_ParsePassive(t *testing.T) {
+    assertEqual := assertEqualT(t)
+
+    port, err := parseEpsv("Entering Extended Passive Mode (|||6446|)")
+    assertEqual(err, nil, "parseEpsv error")
+    assertEqual(port, 6446, "parseEpsv")
+
+    _, err = parseEpsv("Entering Extended Passive Mode (|||70000|)")
+    assertEqual(err != nil, true, "parseEpsv out of range")
+
+    port, err = parsePasv("Entering Passive Mode (192,168,1,2,25,46)")
+    assertEqual(err, nil, "parsePasv error")
+    assertEqual(port, 25 * 256 + 46, "parsePasv")
+
+    _, err = parsePasv("Entering Passive Mode (192,168,1,2,256,46)")
+    assertEqual(err != nil, true, "parsePasv out of range")
+
+    _, ok := parseList("-rw-r--r--   1 owner group     12 Jan  5  2023 ../etc/passwd")
+    assertEqual(ok, false, "parseList path name")
+
+    _, ok = parseMLSD("type=file;size=-1; a/b")
+    assertEqual(ok, false, "parseMLSD path name")
+
+    e, ok := parseMLSD("type=file;size=-1;unix.mode=0100644; a")
+    assertEqual(ok, true, "parseMLSD ok")
+    assertEqual(e.size, int64(0), "parseMLSD negative size")
+    assertEqual(e.perm, uint32(0644), "parseMLSD mode bits")
+}
+
+func Fuzz_ParseList(f *testing.F) {
+    f.Add("-rw-r-----   1 owner group     1234 Jan  5  2023 my file.txt")
+    f.Add("drwxr-xr-x   2 owner group     4096 Mar 10 12:30 dir")
+    f.Add("lrwxrwxrwx   1 owner group        7 Mar 10 12:30 link -> target")
+    f.Add("total 8")
+
+    f.Fuzz(func(t *testing.T, line string) {
+        e, ok := parseList(line)
+        if !ok {
+            return
+        }
+
+        checkEntry(t, e)
+    })
+}
+
+func Fuzz_ParseMLSD(f *testing.F) {
+    f.Add("type=file;size=10;modify=20240102030405.123;UNIX.mode=0600; a b.txt")
+    f.Add("type=dir;modify=20240102030405; dir")
+    f.Add("type=cdir;modify=20240102030405; .")
+
+    f.Fuzz(func(t *testing.T, line string) {
+        e, ok := parseMLSD(line)
+        if !ok {
+            return
+        }
+
+        checkEntry(t, e)
+    })
+}
+
+func Fuzz_ParsePassive(f *testing.F) {
+    f.Add("Entering Extended Passive Mode (|||6446|)")
+    f.Add("Entering Passive Mode (192,168,1,2,25,46)")
+    f.Add("()")
+
+    f.Fuzz(func(t *testing.T, msg string) {
+        if port, err := parseEpsv(msg); err == nil && (port < 1 || port > 65535) {
+            t.Errorf("parseEpsv port out of range: %d", port)
+        }
+
+        if port, err := parsePasv(msg); err == nil && (port < 1 || port > 65535) {
+            t.Errorf("parsePasv port out of range: %d", port)
+        }
+    })
+}
+
+// 解析结果不能包含路径或无效的值
+func checkEntry(t *testing.T, e entry) {
+    if e.name == "" || strings.ContainsAny(e.name, "/\\\x00") {
+        t.Errorf("invalid name: %q", e.name)
+    }
+
+    if e.size < 0 {
+        t.Errorf("negative size: %d", e.size)
+    }
+
+    if e.perm > 0777 {
+        t.Errorf("invalid perm: %o", e.perm)
+    }
+}
@@ -0,0 +1,283 @@
+package ftp
+
+import (
+    "io"
+    "os"
+    "fmt"
+    "net"
+    "sync"
+    "bufio"
+    "strings"
+    "strconv"
+    "testing"
+    "path/filepath"
+)
+
+// 测试用 FTP 服务，文件保存在临时目录
+type fakeServer struct {
+    root     string
+    addr     string
+    user     string
+    pass     string
+    mlsd     bool
+    listener net.Listener
+
+    mu    sync.Mutex
+    conns int
+}
+
+func newFakeServer(t *testing.T, mlsd bool) *fakeServer {
+    listener, err := net.Listen("tcp", "127.0.0.1:0")
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    server := &fakeServer{
+        root:     t.TempDir(),
+        addr:     listener.Addr().String(),
+        user:     "test",
+        pass:     "pass123",
+        mlsd:     mlsd,
+        listener: listener,
+    }
+
+    go func() {
+        for {
+            conn, err := listener.Accept()
+            if err != nil {
+                return
+            }
+
+            server.mu.Lock()
+            server.conns++
+            server.mu.Unlock()
+
+            go server.serve(conn)
+        }
+    }()
+
+    t.Cleanup(func() {
+        listener.Close()
+    })
+
+    return server
+}
+
+func (this *fakeServer) port() int {
+    _, port, _ := net.SplitHostPort(this.addr)
+    p, _ := strconv.Atoi(port)
+    return p
+}
+
+func (this *fakeServer) connCount() int {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    return this.conns
+}
+
+func (this *fakeServer) local(name string) string {
+    return filepath.Join(this.root, filepath.FromSlash(filepath.Clean("/" + name)))
+}
+
+func (this *fakeServer) serve(conn net.Conn) {
+    defer conn.Close()
+
+    r := bufio.NewReader(conn)
+    reply := func(format string, args ...any) {
+        fmt.Fprintf(conn, format + "\r\n", args...)
+    }
+
+    reply("220 fake ftp ready")
+
+    var passive net.Listener
+    var renameFrom string
+    logged := false
+
+    // 等待数据连接
+    data := func() net.Conn {
+        if passive == nil {
+            reply("425 use PASV first")
+            return nil
+        }
+
+        c, err := passive.Accept()
+        passive.Close()
+        passive = nil
+        if err != nil {
+            reply("425 can't open data connection")
+            return nil
+        }
+
+        return c
+    }
+
+    for {
+        line, err := r.ReadString('\n')
+        if err != nil {
+            return
+        }
+
+        line = strings.TrimRight(line, "\r\n")
+        cmd, arg, _ := strings.Cut(line, " ")
+        cmd = strings.ToUpper(cmd)
+
+        if !logged && cmd != "USER" && cmd != "PASS" && cmd != "QUIT" {
+            reply("530 not logged in")
+            continue
+        }
+
+        switch cmd {
+            case "USER":
+                if arg != this.user {
+                    reply("530 unknown user")
+                    continue
+                }
+                reply("331 password required")
+            case "PASS":
+                if arg != this.pass {
+                    reply("530 login incorrect")
+                    continue
+                }
+                logged = true
+                reply("230 logged in")
+            case "TYPE", "NOOP":
+                reply("200 ok")
+            case "FEAT":
+                if this.mlsd {
+                    reply("211-Features:\r\n MLSD\r\n MLST type*;size*;modify*;unix.mode*;\r\n211 End")
+                } else {
+                    reply("211-Features:\r\n SIZE\r\n211 End")
+                }
+            case "EPSV":
+                l, _ := net.Listen("tcp", "127.0.0.1:0")
+                passive = l
+                _, port, _ := net.SplitHostPort(l.Addr().String())
+                reply("229 Entering Extended Passive Mode (|||%s|)", port)
+            case "STOR":
+                c := data()
+                if c == nil {
+                    continue
+                }
+                f, err := os.Create(this.local(arg))
+                if err != nil {
+                    c.Close()
+                    reply("550 %s", err.Error())
+                    continue
+                }
+                reply("150 ok to send data")
+                io.Copy(f, c)
+                c.Close()
+                f.Close()
+                reply("226 transfer complete")
+            case "RETR":
+                c := data()
+                if c == nil {
+                    continue
+                }
+                f, err := os.Open(this.local(arg))
+                if err != nil {
+                    c.Close()
+                    reply("550 %s", err.Error())
+                    continue
+                }
+                reply("150 opening data connection")
+                _, err = io.Copy(c, f)
+                c.Close()
+                f.Close()
+                if err != nil {
+                    reply("426 transfer aborted")
+                } else {
+                    reply("226 transfer complete")
+                }
+            case "MLSD", "LIST":
+                dir := strings.TrimPrefix(arg, "-a ")
+                c := data()
+                if c == nil {
+                    continue
+                }
+                entries, err := os.ReadDir(this.local(dir))
+                if err != nil {
+                    c.Close()
+                    reply("550 %s", err.Error())
+                    continue
+                }
+                reply("150 listing")
+                for _, e := range entries {
+                    info, _ := e.Info()
+                    if cmd == "MLSD" {
+                        typ := "file"
+                        if info.IsDir() {
+                            typ = "dir"
+                        }
+                        fmt.Fprintf(c, "type=%s;size=%d;modify=%s;unix.mode=0%o; %s\r\n",
+                            typ, info.Size(), info.ModTime().UTC().Format("20060102150405"),
+                            info.Mode().Perm(), info.Name())
+                    } else {
+                        mode := info.Mode().Perm().String()
+                        if info.IsDir() {
+                            mode = "d" + mode[1:]
+                        }
+                        fmt.Fprintf(c, "%s   1 owner group %8d %s %s\r\n",
+                            mode, info.Size(), info.ModTime().Format("Jan _2 15:04"), info.Name())
+                    }
+                }
+                c.Close()
+                reply("226 transfer complete")
+            case "DELE":
+                info, err := os.Stat(this.local(arg))
+                if err == nil && info.IsDir() {
+                    reply("550 is a directory")
+                    continue
+                }
+                if err := os.Remove(this.local(arg)); err != nil {
+                    reply("550 %s", err.Error())
+                    continue
+                }
+                reply("250 deleted")
+            case "MKD":
+                if err := os.Mkdir(this.local(arg), 0755); err != nil {
+                    reply("550 %s", err.Error())
+                    continue
+                }
+                reply("257 \"%s\" created", arg)
+            case "RMD":
+                if err := os.Remove(this.local(arg)); err != nil {
+                    reply("550 %s", err.Error())
+                    continue
+                }
+                reply("250 removed")
+            case "RNFR":
+                if _, err := os.Stat(this.local(arg)); err != nil {
+                    reply("550 %s", err.Error())
+                    continue
+                }
+                renameFrom = arg
+                reply("350 ready for RNTO")
+            case "RNTO":
+                if err := os.Rename(this.local(renameFrom), this.local(arg)); err != nil {
+                    reply("550 %s", err.Error())
+                    continue
+                }
+                reply("250 renamed")
+            case "SITE":
+                sub, rest, _ := strings.Cut(arg, " ")
+                if strings.ToUpper(sub) != "CHMOD" {
+                    reply("500 unknown site command")
+                    continue
+                }
+                mode, name, _ := strings.Cut(rest, " ")
+                perm, _ := strconv.ParseUint(mode, 8, 32)
+                if err := os.Chmod(this.local(name), os.FileMode(perm)); err != nil {
+                    reply("550 %s", err.Error())
+                    continue
+                }
+                reply("200 chmod ok")
+            case "QUIT":
+                reply("221 bye")
+                return
+            default:
+                reply("502 not implemented")
+        }
+    }
+}
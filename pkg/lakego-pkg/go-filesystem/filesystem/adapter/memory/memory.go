@@ -0,0 +1,515 @@
+package memory
+
+import (
+    "io"
+    "os"
+    "fmt"
+    "sort"
+    "sync"
+    "strings"
+    "net/http"
+    "path"
+    "time"
+
+    "github.com/deatil/go-filesystem/filesystem/adapter"
+    "github.com/deatil/go-filesystem/filesystem/interfaces"
+)
+
+// 文件
+type file struct {
+    contents   []byte
+    visibility string
+    timestamp  int64
+}
+
+// 文件夹
+type dir struct {
+    visibility string
+    timestamp  int64
+}
+
+/**
+ * 内存适配器 / Memory adapter
+ * 数据只保存在当前进程，可用于测试或临时数据
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Memory struct {
+    // 默认适配器基类
+    adapter.Adapter
+
+    // 锁
+    mu sync.RWMutex
+
+    // 文件列表
+    files map[string]*file
+
+    // 文件夹列表
+    dirs map[string]*dir
+
+    // 当前时间，测试时可替换
+    now func() time.Time
+}
+
+// 内存适配器
+func New() *Memory {
+    return &Memory{
+        files: make(map[string]*file),
+        dirs:  make(map[string]*dir),
+        now:   time.Now,
+    }
+}
+
+// 判断是否存在
+func (this *Memory) Has(path string) bool {
+    location := this.location(path)
+
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    if location == "" {
+        return true
+    }
+
+    if _, ok := this.files[location]; ok {
+        return true
+    }
+
+    _, ok := this.dirs[location]
+    return ok
+}
+
+// 上传
+func (this *Memory) Write(path string, contents []byte, conf interfaces.Config) (map[string]any, error) {
+    location := this.location(path)
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if _, ok := this.dirs[location]; ok || location == "" {
+        return nil, fmt.Errorf("go-filesystem: %s is dir", path)
+    }
+
+    if err := this.ensureDirectory(parentDir(location)); err != nil {
+        return nil, err
+    }
+
+    visibility := "public"
+    if old, ok := this.files[location]; ok {
+        visibility = old.visibility
+    }
+    if v, ok := conf.Get("visibility").(string); ok {
+        visibility = formatVisibility(v)
+    }
+
+    this.files[location] = &file{
+        contents:   append([]byte{}, contents...),
+        visibility: visibility,
+        timestamp:  this.now().Unix(),
+    }
+
+    result := map[string]any{
+        "type":     "file",
+        "size":     int64(len(contents)),
+        "path":     path,
+        "contents": contents,
+    }
+
+    if conf.Get("visibility") != nil {
+        result["visibility"] = visibility
+    }
+
+    return result, nil
+}
+
+// 上传 Stream 文件类型
+func (this *Memory) WriteStream(path string, stream io.Reader, conf interfaces.Config) (map[string]any, error) {
+    contents, err := io.ReadAll(stream)
+    if err != nil {
+        return nil, fmt.Errorf("go-filesystem: write stream fail, error: %w", err)
+    }
+
+    result, err := this.Write(path, contents, conf)
+    if err != nil {
+        return nil, err
+    }
+
+    delete(result, "contents")
+
+    return result, nil
+}
+
+// 更新
+func (this *Memory) Update(path string, contents []byte, conf interfaces.Config) (map[string]any, error) {
+    return this.Write(path, contents, conf)
+}
+
+// 更新
+func (this *Memory) UpdateStream(path string, stream io.Reader, conf interfaces.Config) (map[string]any, error) {
+    return this.WriteStream(path, stream, conf)
+}
+
+// 读取
+func (this *Memory) Read(path string) (map[string]any, error) {
+    f, err := this.file(path)
+    if err != nil {
+        return nil, err
+    }
+
+    return map[string]any{
+        "type":     "file",
+        "path":     path,
+        "contents": append([]byte{}, f.contents...),
+    }, nil
+}
+
+// 读取成文件流
+// 数据先写入临时文件，打开文件需要手动关闭
+func (this *Memory) ReadStream(path string) (map[string]any, error) {
+    f, err := this.file(path)
+    if err != nil {
+        return nil, err
+    }
+
+    stream, err := adapter.TempStream(func(w io.Writer) error {
+        _, err := w.Write(f.contents)
+        return err
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    return map[string]any{
+        "type":   "file",
+        "path":   path,
+        "stream": stream,
+    }, nil
+}
+
+// 重命名
+func (this *Memory) Rename(path string, newpath string) error {
+    location := this.location(path)
+    destination := this.location(newpath)
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    f, ok := this.files[location]
+    if !ok {
+        return notExist(path)
+    }
+
+    if err := this.ensureDirectory(parentDir(destination)); err != nil {
+        return err
+    }
+
+    delete(this.files, location)
+    this.files[destination] = f
+
+    return nil
+}
+
+// 复制
+func (this *Memory) Copy(path string, newpath string) error {
+    location := this.location(path)
+    destination := this.location(newpath)
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    f, ok := this.files[location]
+    if !ok {
+        return notExist(path)
+    }
+
+    if err := this.ensureDirectory(parentDir(destination)); err != nil {
+        return err
+    }
+
+    this.files[destination] = &file{
+        contents:   append([]byte{}, f.contents...),
+        visibility: f.visibility,
+        timestamp:  this.now().Unix(),
+    }
+
+    return nil
+}
+
+// 删除
+func (this *Memory) Delete(path string) error {
+    location := this.location(path)
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if _, ok := this.files[location]; !ok {
+        return notExist(path)
+    }
+
+    delete(this.files, location)
+
+    return nil
+}
+
+// 删除文件夹
+func (this *Memory) DeleteDir(dirname string) error {
+    location := this.location(dirname)
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if _, ok := this.dirs[location]; !ok {
+        return notExist(dirname)
+    }
+
+    prefix := location + "/"
+    for name := range this.files {
+        if strings.HasPrefix(name, prefix) {
+            delete(this.files, name)
+        }
+    }
+
+    for name := range this.dirs {
+        if name == location || strings.HasPrefix(name, prefix) {
+            delete(this.dirs, name)
+        }
+    }
+
+    return nil
+}
+
+// 创建文件夹
+func (this *Memory) CreateDir(dirname string, conf interfaces.Config) (map[string]string, error) {
+    location := this.location(dirname)
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if err := this.ensureDirectory(location); err != nil {
+        return nil, err
+    }
+
+    if v, ok := conf.Get("visibility").(string); ok && location != "" {
+        this.dirs[location].visibility = formatVisibility(v)
+    }
+
+    return map[string]string{
+        "path": dirname,
+        "type": "dir",
+    }, nil
+}
+
+// 列出内容
+func (this *Memory) ListContents(directory string, recursive ...bool) ([]map[string]any, error) {
+    location := this.location(directory)
+
+    isRecursive := len(recursive) > 0 && recursive[0]
+
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    prefix := ""
+    if location != "" {
+        prefix = location + "/"
+    }
+
+    match := func(name string) bool {
+        if !strings.HasPrefix(name, prefix) || name == location {
+            return false
+        }
+
+        return isRecursive || !strings.Contains(strings.TrimPrefix(name, prefix), "/")
+    }
+
+    var result []map[string]any
+    for name, d := range this.dirs {
+        if match(name) {
+            result = append(result, map[string]any{
+                "type":      "dir",
+                "path":      name,
+                "timestamp": d.timestamp,
+            })
+        }
+    }
+
+    for name, f := range this.files {
+        if match(name) {
+            result = append(result, map[string]any{
+                "type":      "file",
+                "path":      name,
+                "timestamp": f.timestamp,
+                "size":      int64(len(f.contents)),
+            })
+        }
+    }
+
+    sort.Slice(result, func(i, j int) bool {
+        return result[i]["path"].(string) < result[j]["path"].(string)
+    })
+
+    return result, nil
+}
+
+// 文件信息
+func (this *Memory) GetMetadata(path string) (map[string]any, error) {
+    location := this.location(path)
+
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    if f, ok := this.files[location]; ok {
+        return map[string]any{
+            "type":      "file",
+            "path":      location,
+            "timestamp": f.timestamp,
+            "size":      int64(len(f.contents)),
+        }, nil
+    }
+
+    if d, ok := this.dirs[location]; ok {
+        return map[string]any{
+            "type":      "dir",
+            "path":      location,
+            "timestamp": d.timestamp,
+        }, nil
+    }
+
+    return nil, notExist(path)
+}
+
+func (this *Memory) GetSize(path string) (map[string]any, error) {
+    return this.GetMetadata(path)
+}
+
+func (this *Memory) GetMimetype(path string) (map[string]any, error) {
+    f, err := this.file(path)
+    if err != nil {
+        return nil, err
+    }
+
+    return map[string]any{
+        "path":     path,
+        "type":     "file",
+        "mimetype": http.DetectContentType(f.contents),
+    }, nil
+}
+
+func (this *Memory) GetTimestamp(path string) (map[string]any, error) {
+    return this.GetMetadata(path)
+}
+
+// 获取文件的权限
+func (this *Memory) GetVisibility(path string) (map[string]string, error) {
+    location := this.location(path)
+
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    visibility := ""
+    if f, ok := this.files[location]; ok {
+        visibility = f.visibility
+    } else if d, ok := this.dirs[location]; ok {
+        visibility = d.visibility
+    } else {
+        return nil, notExist(path)
+    }
+
+    return map[string]string{
+        "path":       path,
+        "visibility": visibility,
+    }, nil
+}
+
+// 设置文件的权限
+func (this *Memory) SetVisibility(path string, visibility string) (map[string]string, error) {
+    location := this.location(path)
+
+    visibility = formatVisibility(visibility)
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if f, ok := this.files[location]; ok {
+        f.visibility = visibility
+    } else if d, ok := this.dirs[location]; ok {
+        d.visibility = visibility
+    } else {
+        return nil, notExist(path)
+    }
+
+    return map[string]string{
+        "path":       path,
+        "visibility": visibility,
+    }, nil
+}
+
+// 获取文件
+func (this *Memory) file(path string) (*file, error) {
+    location := this.location(path)
+
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    f, ok := this.files[location]
+    if !ok {
+        return nil, notExist(path)
+    }
+
+    return f, nil
+}
+
+// 逐级创建文件夹，需在加锁后调用
+func (this *Memory) ensureDirectory(location string) error {
+    if location == "" {
+        return nil
+    }
+
+    if _, ok := this.dirs[location]; ok {
+        return nil
+    }
+
+    if _, ok := this.files[location]; ok {
+        return fmt.Errorf("go-filesystem: %s is not dir", location)
+    }
+
+    if err := this.ensureDirectory(parentDir(location)); err != nil {
+        return err
+    }
+
+    this.dirs[location] = &dir{
+        visibility: "public",
+        timestamp:  this.now().Unix(),
+    }
+
+    return nil
+}
+
+// 存储路径
+func (this *Memory) location(p string) string {
+    return strings.Trim(path.Clean("/" + p), "/")
+}
+
+// 上级目录
+func parentDir(location string) string {
+    parent := path.Dir(location)
+    if parent == "." || parent == "/" {
+        return ""
+    }
+
+    return parent
+}
+
+// 权限
+func formatVisibility(visibility string) string {
+    if visibility != "private" {
+        return "public"
+    }
+
+    return visibility
+}
+
+// 文件不存在
+func notExist(path string) error {
+    return fmt.Errorf("go-filesystem: %s %w", path, os.ErrNotExist)
+}
@@ -0,0 +1,220 @@
+package memory
+
+import (
+    "io"
+    "os"
+    "sync"
+    "errors"
+    "strconv"
+    "reflect"
+    "testing"
+    "path/filepath"
+
+    "github.com/deatil/go-filesystem/filesystem"
+    "github.com/deatil/go-filesystem/filesystem/config"
+    zip_adapter "github.com/deatil/go-filesystem/filesystem/adapter/zip"
+    local_adapter "github.com/deatil/go-filesystem/filesystem/adapter/local"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func assertErrorT(t *testing.T) func(error, string) {
+    return func(err error, msg string) {
+        if err != nil {
+            t.Errorf("Failed %s: error: %+v", msg, err)
+        }
+    }
+}
+
+func emptyConf() config.Config {
+    return config.New(map[string]any{})
+}
+
+func Test_WriteAndRead(t *testing.T) {
+    assertEqual := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    fs := New()
+
+    res, err := fs.Write("/a/b/test.txt", []byte("memory-data"), emptyConf())
+    assertError(err, "Write")
+    assertEqual(res["size"], int64(11), "Write size")
+
+    assertEqual(fs.Has("a/b/test.txt"), true, "Has")
+    assertEqual(fs.Has("a/b"), true, "Has dir")
+    assertEqual(fs.Has("a/b/no.txt"), false, "Has not")
+
+    read, err := fs.Read("a/b/test.txt")
+    assertError(err, "Read")
+    assertEqual(read["contents"], []byte("memory-data"), "Read")
+
+    stream, err := fs.ReadStream("a/b/test.txt")
+    assertError(err, "ReadStream")
+
+    f := stream["stream"].(*os.File)
+    streamData, _ := io.ReadAll(f)
+    f.Close()
+    assertEqual(streamData, []byte("memory-data"), "ReadStream")
+
+    mime, err := fs.GetMimetype("a/b/test.txt")
+    assertError(err, "GetMimetype")
+    assertEqual(mime["mimetype"], "text/plain; charset=utf-8", "GetMimetype")
+
+    _, err = fs.Read("no.txt")
+    assertEqual(errors.Is(err, os.ErrNotExist), true, "Read not exists")
+
+    _, err = fs.Write("a/b", []byte("x"), emptyConf())
+    if err == nil {
+        t.Error("Write to dir should fail")
+    }
+
+    _, err = fs.Write("a/b/test.txt/c.txt", []byte("x"), emptyConf())
+    if err == nil {
+        t.Error("Write under file should fail")
+    }
+}
+
+func Test_ListAndDelete(t *testing.T) {
+    assertEqual := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    fs := New()
+
+    conf := emptyConf()
+    fs.Write("a.txt", []byte("a"), conf)
+    fs.Write("sub/b.txt", []byte("bb"), conf)
+    fs.Write("sub/deep/c.txt", []byte("ccc"), conf)
+    fs.CreateDir("empty", conf)
+
+    list, err := fs.ListContents("")
+    assertError(err, "ListContents")
+    assertEqual(paths(list), []string{"file:a.txt", "dir:empty", "dir:sub"}, "ListContents")
+
+    list, err = fs.ListContents("sub", true)
+    assertError(err, "ListContents recursive")
+    assertEqual(paths(list), []string{"file:sub/b.txt", "dir:sub/deep", "file:sub/deep/c.txt"}, "ListContents recursive")
+    assertEqual(list[2]["size"], int64(3), "ListContents size")
+
+    assertError(fs.Copy("sub/b.txt", "copy/b.txt"), "Copy")
+    assertError(fs.Rename("copy/b.txt", "moved.txt"), "Rename")
+
+    read, _ := fs.Read("moved.txt")
+    assertEqual(read["contents"], []byte("bb"), "Rename data")
+    assertEqual(fs.Has("copy/b.txt"), false, "Rename source")
+
+    assertError(fs.DeleteDir("sub"), "DeleteDir")
+    assertEqual(fs.Has("sub"), false, "DeleteDir")
+    assertEqual(fs.Has("sub/deep/c.txt"), false, "DeleteDir children")
+
+    assertError(fs.Delete("a.txt"), "Delete")
+    assertEqual(fs.Has("a.txt"), false, "Delete")
+
+    err = fs.Delete("a.txt")
+    assertEqual(errors.Is(err, os.ErrNotExist), true, "Delete not exists")
+}
+
+func Test_Visibility(t *testing.T) {
+    assertEqual := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    fs := New()
+
+    _, err := fs.Write("private.txt", []byte("p"), config.New(map[string]any{
+        "visibility": "private",
+    }))
+    assertError(err, "Write")
+
+    res, err := fs.GetVisibility("private.txt")
+    assertError(err, "GetVisibility")
+    assertEqual(res["visibility"], "private", "GetVisibility")
+
+    // 更新时保留原有权限
+    fs.Update("private.txt", []byte("p2"), emptyConf())
+    res, _ = fs.GetVisibility("private.txt")
+    assertEqual(res["visibility"], "private", "Update keep visibility")
+
+    _, err = fs.SetVisibility("private.txt", "public")
+    assertError(err, "SetVisibility")
+
+    res, _ = fs.GetVisibility("private.txt")
+    assertEqual(res["visibility"], "public", "SetVisibility")
+}
+
+func Test_Concurrent(t *testing.T) {
+    assertEqual := assertEqualT(t)
+
+    fs := New()
+
+    var wg sync.WaitGroup
+    for i := 0; i < 20; i++ {
+        wg.Add(1)
+        go func(i int) {
+            defer wg.Done()
+
+            name := "dir/" + strconv.Itoa(i % 4) + "/" + strconv.Itoa(i) + ".txt"
+            fs.Write(name, []byte(name), emptyConf())
+            fs.Read(name)
+            fs.ListContents("dir", true)
+        }(i)
+    }
+
+    wg.Wait()
+
+    list, _ := fs.ListContents("dir", true)
+    assertEqual(len(list), 24, "Concurrent")
+}
+
+func Test_MountManager(t *testing.T) {
+    assertEqual := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    zipPath := filepath.Join(t.TempDir(), "export.zip")
+    archive := zip_adapter.New(zipPath)
+
+    manager := filesystem.NewMountManager(map[string]any{
+        "local":  filesystem.New(local_adapter.New(t.TempDir())),
+        "memory": filesystem.New(New()),
+        "zip":    filesystem.New(archive),
+    })
+
+    _, err := manager.Write("local://from.txt", []byte("mount-data"))
+    assertError(err, "Write")
+
+    _, err = manager.Copy("local://from.txt", "memory://to/to.txt")
+    assertError(err, "Copy to memory")
+
+    _, err = manager.Copy("memory://to/to.txt", "zip://export/to.txt")
+    assertError(err, "Copy to zip")
+
+    _, err = manager.Copy("zip://export/to.txt", "local://back.txt")
+    assertError(err, "Copy to local")
+
+    data, err := manager.Read("local://back.txt")
+    assertError(err, "Read")
+    assertEqual(data, []byte("mount-data"), "Read local")
+
+    assertError(archive.Close(), "Close zip")
+
+    reopened, err := zip_adapter.Open(zipPath)
+    assertError(err, "Open zip")
+    defer reopened.Close()
+
+    read, err := reopened.Read("export/to.txt")
+    assertError(err, "Read zip")
+    assertEqual(read["contents"], []byte("mount-data"), "Read zip")
+}
+
+func paths(list []map[string]any) []string {
+    res := make([]string, 0, len(list))
+    for _, item := range list {
+        res = append(res, item["type"].(string) + ":" + item["path"].(string))
+    }
+
+    return res
+}
@@ -0,0 +1,201 @@
+package pool
+
+import (
+    "sync"
+    "errors"
+    "time"
+)
+
+// 连接池已关闭
+var ErrClosed = errors.New("go-filesystem: pool is closed")
+
+/**
+ * 连接池配置
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Config[T any] struct {
+    // 创建连接
+    Factory func() (T, error)
+
+    // 关闭连接
+    Close func(T) error
+
+    // 取出空闲连接时检测，返回 false 时关闭该连接
+    Ping func(T) bool
+
+    // 最大连接数，小于 1 时为 1
+    MaxOpen int
+
+    // 最大空闲连接数，小于 1 时等于 MaxOpen
+    MaxIdle int
+
+    // 空闲超时时间，为 0 时不超时
+    IdleTimeout time.Duration
+}
+
+// 空闲连接
+type idleConn[T any] struct {
+    conn T
+    time time.Time
+}
+
+/**
+ * 连接池 / Pool
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Pool[T any] struct {
+    mu   sync.Mutex
+    conf Config[T]
+
+    // 空闲连接
+    idle []idleConn[T]
+
+    // 连接令牌，限制最大连接数
+    sem chan struct{}
+
+    closed bool
+}
+
+// 连接池
+func New[T any](conf Config[T]) *Pool[T] {
+    if conf.MaxOpen < 1 {
+        conf.MaxOpen = 1
+    }
+
+    if conf.MaxIdle < 1 || conf.MaxIdle > conf.MaxOpen {
+        conf.MaxIdle = conf.MaxOpen
+    }
+
+    return &Pool[T]{
+        conf: conf,
+        sem:  make(chan struct{}, conf.MaxOpen),
+    }
+}
+
+// 获取连接，连接数达到上限时等待
+func (this *Pool[T]) Get() (T, error) {
+    var zero T
+
+    this.sem <- struct{}{}
+
+    for {
+        this.mu.Lock()
+        if this.closed {
+            this.mu.Unlock()
+            <-this.sem
+            return zero, ErrClosed
+        }
+
+        if len(this.idle) == 0 {
+            this.mu.Unlock()
+            break
+        }
+
+        // 后进先出，优先使用最近的连接
+        item := this.idle[len(this.idle) - 1]
+        this.idle = this.idle[:len(this.idle) - 1]
+        this.mu.Unlock()
+
+        if this.expired(item) ||
+            (this.conf.Ping != nil && !this.conf.Ping(item.conn)) {
+            this.closeConn(item.conn)
+            continue
+        }
+
+        return item.conn, nil
+    }
+
+    conn, err := this.conf.Factory()
+    if err != nil {
+        <-this.sem
+        return zero, err
+    }
+
+    return conn, nil
+}
+
+// 归还连接
+func (this *Pool[T]) Put(conn T) {
+    this.mu.Lock()
+
+    if this.closed || len(this.idle) >= this.conf.MaxIdle {
+        this.mu.Unlock()
+        this.closeConn(conn)
+        <-this.sem
+        return
+    }
+
+    this.idle = append(this.idle, idleConn[T]{
+        conn: conn,
+        time: time.Now(),
+    })
+    this.mu.Unlock()
+
+    <-this.sem
+}
+
+// 丢弃出错的连接
+func (this *Pool[T]) Discard(conn T) {
+    this.closeConn(conn)
+    <-this.sem
+}
+
+// 使用连接执行
+// broken 判断错误是否为连接错误，连接错误时丢弃该连接
+func (this *Pool[T]) Do(fn func(T) error, broken func(error) bool) error {
+    conn, err := this.Get()
+    if err != nil {
+        return err
+    }
+
+    err = fn(conn)
+    if err != nil && broken != nil && broken(err) {
+        this.Discard(conn)
+        return err
+    }
+
+    this.Put(conn)
+
+    return err
+}
+
+// 空闲连接数量
+func (this *Pool[T]) IdleLen() int {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    return len(this.idle)
+}
+
+// 关闭连接池
+func (this *Pool[T]) Close() error {
+    this.mu.Lock()
+    idle := this.idle
+    this.idle = nil
+    this.closed = true
+    this.mu.Unlock()
+
+    for _, item := range idle {
+        this.closeConn(item.conn)
+    }
+
+    return nil
+}
+
+func (this *Pool[T]) expired(item idleConn[T]) bool {
+    if this.conf.IdleTimeout <= 0 {
+        return false
+    }
+
+    return time.Since(item.time) > this.conf.IdleTimeout
+}
+
+func (this *Pool[T]) closeConn(conn T) {
+    if this.conf.Close != nil {
+        this.conf.Close(conn)
+    }
+}
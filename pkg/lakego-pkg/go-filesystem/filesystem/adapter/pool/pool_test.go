@@ -0,0 +1,123 @@
+package pool
+
+import (
+    "sync"
+    "errors"
+    "reflect"
+    "testing"
+    "time"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+type testConn struct {
+    id     int
+    closed bool
+}
+
+func newTestPool(maxOpen int, idleTimeout time.Duration) (*Pool[*testConn], *int) {
+    created := 0
+    var mu sync.Mutex
+
+    p := New(Config[*testConn]{
+        Factory: func() (*testConn, error) {
+            mu.Lock()
+            defer mu.Unlock()
+
+            created++
+            return &testConn{id: created}, nil
+        },
+        Close: func(c *testConn) error {
+            c.closed = true
+            return nil
+        },
+        MaxOpen:     maxOpen,
+        IdleTimeout: idleTimeout,
+    })
+
+    return p, &created
+}
+
+func Test_Reuse(t *testing.T) {
+    assertEqual := assertEqualT(t)
+
+    p, created := newTestPool(2, 0)
+
+    c1, _ := p.Get()
+    p.Put(c1)
+
+    c2, _ := p.Get()
+    assertEqual(c2.id, c1.id, "reuse")
+    assertEqual(*created, 1, "created")
+
+    p.Discard(c2)
+    assertEqual(c2.closed, true, "discard")
+
+    c3, _ := p.Get()
+    assertEqual(c3.id, 2, "new after discard")
+    p.Put(c3)
+
+    p.Close()
+    assertEqual(c3.closed, true, "close idle")
+
+    _, err := p.Get()
+    assertEqual(err, ErrClosed, "closed")
+}
+
+func Test_MaxOpen(t *testing.T) {
+    assertEqual := assertEqualT(t)
+
+    p, _ := newTestPool(1, 0)
+
+    c1, _ := p.Get()
+
+    got := make(chan *testConn)
+    go func() {
+        c, _ := p.Get()
+        got <- c
+    }()
+
+    select {
+        case <-got:
+            t.Fatal("Get should wait when pool is full")
+        case <-time.After(50 * time.Millisecond):
+    }
+
+    p.Put(c1)
+
+    c2 := <-got
+    assertEqual(c2.id, c1.id, "wait and reuse")
+}
+
+func Test_IdleTimeoutAndDo(t *testing.T) {
+    assertEqual := assertEqualT(t)
+
+    p, created := newTestPool(1, time.Millisecond)
+
+    c1, _ := p.Get()
+    p.Put(c1)
+
+    time.Sleep(5 * time.Millisecond)
+
+    c2, _ := p.Get()
+    assertEqual(c1.closed, true, "expired closed")
+    assertEqual(c2.id, 2, "expired new")
+    p.Put(c2)
+
+    broken := errors.New("broken")
+    err := p.Do(func(c *testConn) error {
+        return broken
+    }, func(err error) bool {
+        return err == broken
+    })
+    assertEqual(err, broken, "Do error")
+    assertEqual(c2.closed, true, "Do discard broken")
+    assertEqual(p.IdleLen(), 0, "Do idle")
+    assertEqual(*created, 2, "created")
+}
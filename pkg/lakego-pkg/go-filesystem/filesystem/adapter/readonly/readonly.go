@@ -0,0 +1,85 @@
+package readonly
+
+import (
+    "io"
+    "errors"
+
+    "github.com/deatil/go-filesystem/filesystem/interfaces"
+)
+
+// 只读错误
+var ErrReadOnly = errors.New("go-filesystem: adapter is read-only")
+
+/**
+ * 只读适配器 / Read-only adapter
+ * 包装其他适配器，所有写入操作返回 ErrReadOnly
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type ReadOnly struct {
+    // 被包装的适配器
+    interfaces.Adapter
+}
+
+// 只读适配器
+func New(adapter interfaces.Adapter) *ReadOnly {
+    return &ReadOnly{
+        Adapter: adapter,
+    }
+}
+
+// 被包装的适配器
+func (this *ReadOnly) GetAdapter() interfaces.Adapter {
+    return this.Adapter
+}
+
+// 上传
+func (this *ReadOnly) Write(path string, contents []byte, conf interfaces.Config) (map[string]any, error) {
+    return nil, ErrReadOnly
+}
+
+// 上传 Stream 文件类型
+func (this *ReadOnly) WriteStream(path string, stream io.Reader, conf interfaces.Config) (map[string]any, error) {
+    return nil, ErrReadOnly
+}
+
+// 更新
+func (this *ReadOnly) Update(path string, contents []byte, conf interfaces.Config) (map[string]any, error) {
+    return nil, ErrReadOnly
+}
+
+// 更新
+func (this *ReadOnly) UpdateStream(path string, stream io.Reader, conf interfaces.Config) (map[string]any, error) {
+    return nil, ErrReadOnly
+}
+
+// 重命名
+func (this *ReadOnly) Rename(path string, newpath string) error {
+    return ErrReadOnly
+}
+
+// 复制
+func (this *ReadOnly) Copy(path string, newpath string) error {
+    return ErrReadOnly
+}
+
+// 删除
+func (this *ReadOnly) Delete(path string) error {
+    return ErrReadOnly
+}
+
+// 删除文件夹
+func (this *ReadOnly) DeleteDir(dirname string) error {
+    return ErrReadOnly
+}
+
+// 创建文件夹
+func (this *ReadOnly) CreateDir(dirname string, conf interfaces.Config) (map[string]string, error) {
+    return nil, ErrReadOnly
+}
+
+// 设置文件的权限
+func (this *ReadOnly) SetVisibility(path string, visibility string) (map[string]string, error) {
+    return nil, ErrReadOnly
+}
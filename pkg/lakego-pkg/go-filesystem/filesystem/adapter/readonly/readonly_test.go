@@ -0,0 +1,71 @@
+package readonly
+
+import (
+    "bytes"
+    "reflect"
+    "testing"
+
+    "github.com/deatil/go-filesystem/filesystem"
+    "github.com/deatil/go-filesystem/filesystem/config"
+    memory_adapter "github.com/deatil/go-filesystem/filesystem/adapter/memory"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func Test_ReadOnly(t *testing.T) {
+    assertEqual := assertEqualT(t)
+
+    conf := config.New(map[string]any{})
+
+    memory := memory_adapter.New()
+    memory.Write("a.txt", []byte("data"), conf)
+
+    fs := New(memory)
+
+    assertEqual(fs.Has("a.txt"), true, "Has")
+
+    read, err := fs.Read("a.txt")
+    assertEqual(err, nil, "Read")
+    assertEqual(read["contents"], []byte("data"), "Read")
+
+    _, err = fs.Write("b.txt", []byte("b"), conf)
+    assertEqual(err, ErrReadOnly, "Write")
+
+    _, err = fs.WriteStream("b.txt", bytes.NewReader([]byte("b")), conf)
+    assertEqual(err, ErrReadOnly, "WriteStream")
+
+    _, err = fs.Update("a.txt", []byte("b"), conf)
+    assertEqual(err, ErrReadOnly, "Update")
+
+    _, err = fs.UpdateStream("a.txt", bytes.NewReader([]byte("b")), conf)
+    assertEqual(err, ErrReadOnly, "UpdateStream")
+
+    assertEqual(fs.Rename("a.txt", "c.txt"), ErrReadOnly, "Rename")
+    assertEqual(fs.Copy("a.txt", "c.txt"), ErrReadOnly, "Copy")
+    assertEqual(fs.Delete("a.txt"), ErrReadOnly, "Delete")
+    assertEqual(fs.DeleteDir("dir"), ErrReadOnly, "DeleteDir")
+
+    _, err = fs.CreateDir("dir", conf)
+    assertEqual(err, ErrReadOnly, "CreateDir")
+
+    _, err = fs.SetVisibility("a.txt", "private")
+    assertEqual(err, ErrReadOnly, "SetVisibility")
+
+    assertEqual(memory.Has("b.txt"), false, "not written")
+
+    // 通过文件管理器使用
+    disk := filesystem.New(fs)
+
+    ok, err := disk.Put("a.txt", []byte("x"))
+    assertEqual(ok, false, "Filesystem Put")
+    assertEqual(err, ErrReadOnly, "Filesystem Put error")
+
+    data, _ := disk.Read("a.txt")
+    assertEqual(data, []byte("data"), "Filesystem Read")
+}
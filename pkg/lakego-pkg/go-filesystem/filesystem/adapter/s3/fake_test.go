@@ -0,0 +1,394 @@
+package s3
+
+import (
+    "io"
+    "fmt"
+    "sort"
+    "sync"
+    "bytes"
+    "strings"
+    "strconv"
+    "net/url"
+    "net/http"
+    "crypto/md5"
+    "encoding/hex"
+    "encoding/xml"
+    "encoding/base64"
+    "time"
+)
+
+// 测试用 S3 服务
+type fakeObject struct {
+    data        []byte
+    contentType string
+    acl         string
+    modified    time.Time
+}
+
+type fakeUpload struct {
+    key         string
+    contentType string
+    acl         string
+    parts       map[int][]byte
+}
+
+type fakeS3 struct {
+    mu      sync.Mutex
+    bucket  string
+    signer  signer
+    objects map[string]*fakeObject
+    uploads map[string]*fakeUpload
+    nextId  int
+
+    // 请求记录
+    requests []string
+}
+
+func newFakeS3(bucket string, s signer) *fakeS3 {
+    return &fakeS3{
+        bucket:  bucket,
+        signer:  s,
+        objects: map[string]*fakeObject{},
+        uploads: map[string]*fakeUpload{},
+    }
+}
+
+func (this *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    body, _ := io.ReadAll(r.Body)
+
+    if !this.verify(r, body) {
+        this.error(w, 403, "SignatureDoesNotMatch", "")
+        return
+    }
+
+    path := strings.TrimPrefix(r.URL.Path, "/")
+    bucket, key, _ := strings.Cut(path, "/")
+    if bucket != this.bucket {
+        this.error(w, 404, "NoSuchBucket", "")
+        return
+    }
+
+    query := r.URL.Query()
+    this.requests = append(this.requests, r.Method + " " + key + "?" + r.URL.RawQuery)
+
+    switch {
+        case key == "" && r.Method == http.MethodGet && query.Get("list-type") == "2":
+            this.list(w, query)
+        case key == "" && r.Method == http.MethodPost && query.Has("delete"):
+            this.deleteObjects(w, r, body)
+        case query.Has("acl") && r.Method == http.MethodGet:
+            this.getAcl(w, key)
+        case query.Has("acl") && r.Method == http.MethodPut:
+            obj, ok := this.objects[key]
+            if !ok {
+                this.error(w, 404, "NoSuchKey", key)
+                return
+            }
+            obj.acl = r.Header.Get("X-Amz-Acl")
+        case query.Has("uploads") && r.Method == http.MethodPost:
+            this.nextId++
+            id := "upload-" + strconv.Itoa(this.nextId)
+            this.uploads[id] = &fakeUpload{
+                key:         key,
+                contentType: r.Header.Get("Content-Type"),
+                acl:         r.Header.Get("X-Amz-Acl"),
+                parts:       map[int][]byte{},
+            }
+            this.xml(w, initiateMultipartUploadResult{
+                Bucket:   bucket,
+                Key:      key,
+                UploadId: id,
+            })
+        case query.Has("uploadId"):
+            this.multipart(w, r, key, query, body)
+        case r.Method == http.MethodPut && r.Header.Get("X-Amz-Copy-Source") != "":
+            source, _ := url.PathUnescape(r.Header.Get("X-Amz-Copy-Source"))
+            source = strings.TrimPrefix(source, "/" + this.bucket + "/")
+            obj, ok := this.objects[source]
+            if !ok {
+                this.error(w, 404, "NoSuchKey", source)
+                return
+            }
+            acl := r.Header.Get("X-Amz-Acl")
+            if acl == "" {
+                acl = "private"
+            }
+            this.objects[key] = &fakeObject{
+                data:        append([]byte{}, obj.data...),
+                contentType: obj.contentType,
+                acl:         acl,
+                modified:    time.Now(),
+            }
+            fmt.Fprint(w, "<CopyObjectResult></CopyObjectResult>")
+        case r.Method == http.MethodPut:
+            if md := r.Header.Get("Content-MD5"); md != "" {
+                sum := md5.Sum(body)
+                if md != base64.StdEncoding.EncodeToString(sum[:]) {
+                    this.error(w, 400, "BadDigest", key)
+                    return
+                }
+            }
+            acl := r.Header.Get("X-Amz-Acl")
+            if acl == "" {
+                acl = "private"
+            }
+            this.objects[key] = &fakeObject{
+                data:        body,
+                contentType: r.Header.Get("Content-Type"),
+                acl:         acl,
+                modified:    time.Now(),
+            }
+            sum := md5.Sum(body)
+            w.Header().Set("ETag", `"` + hex.EncodeToString(sum[:]) + `"`)
+        case r.Method == http.MethodGet || r.Method == http.MethodHead:
+            obj, ok := this.objects[key]
+            if !ok {
+                this.error(w, 404, "NoSuchKey", key)
+                return
+            }
+            w.Header().Set("Content-Type", obj.contentType)
+            w.Header().Set("Content-Length", strconv.Itoa(len(obj.data)))
+            w.Header().Set("Last-Modified", obj.modified.UTC().Format(http.TimeFormat))
+            if r.Method == http.MethodGet {
+                w.Write(obj.data)
+            }
+        case r.Method == http.MethodDelete:
+            delete(this.objects, key)
+            w.WriteHeader(204)
+        default:
+            this.error(w, 405, "MethodNotAllowed", key)
+    }
+}
+
+// 校验签名
+func (this *fakeS3) verify(r *http.Request, body []byte) bool {
+    query := r.URL.Query()
+
+    // 预签名链接
+    if sig := query.Get("X-Amz-Signature"); sig != "" {
+        t, err := time.Parse(amzDateFormat, query.Get("X-Amz-Date"))
+        if err != nil {
+            return false
+        }
+
+        expires, _ := strconv.Atoi(query.Get("X-Amz-Expires"))
+        if time.Now().After(t.Add(time.Duration(expires) * time.Second)) {
+            return false
+        }
+
+        u := *r.URL
+        u.Host = r.Host
+        query.Del("X-Amz-Signature")
+        for k := range query {
+            if strings.HasPrefix(k, "X-Amz-") {
+                query.Del(k)
+            }
+        }
+        u.RawQuery = query.Encode()
+
+        signed, _ := url.Parse(this.signer.Presign(r.Method, &u, time.Duration(expires) * time.Second, t))
+        return signed.Query().Get("X-Amz-Signature") == sig
+    }
+
+    auth := r.Header.Get("Authorization")
+    if auth == "" {
+        return false
+    }
+
+    if hashHex(body) != r.Header.Get("X-Amz-Content-Sha256") {
+        return false
+    }
+
+    t, err := time.Parse(amzDateFormat, r.Header.Get("X-Amz-Date"))
+    if err != nil {
+        return false
+    }
+
+    // 只使用签名过的头信息重新签名
+    _, signedPart, _ := strings.Cut(auth, "SignedHeaders=")
+    signedHeaders, _, _ := strings.Cut(signedPart, ",")
+
+    req, _ := http.NewRequest(r.Method, "http://" + r.Host + r.URL.RequestURI(), nil)
+    for _, name := range strings.Split(signedHeaders, ";") {
+        if name != "host" {
+            req.Header.Set(name, r.Header.Get(name))
+        }
+    }
+
+    this.signer.Sign(req, r.Header.Get("X-Amz-Content-Sha256"), t)
+
+    return req.Header.Get("Authorization") == auth
+}
+
+func (this *fakeS3) list(w http.ResponseWriter, query url.Values) {
+    prefix := query.Get("prefix")
+    delimiter := query.Get("delimiter")
+    maxKeys, _ := strconv.Atoi(query.Get("max-keys"))
+    if maxKeys == 0 {
+        maxKeys = 1000
+    }
+
+    keys := make([]string, 0, len(this.objects))
+    for key := range this.objects {
+        if strings.HasPrefix(key, prefix) {
+            keys = append(keys, key)
+        }
+    }
+    sort.Strings(keys)
+
+    start := query.Get("continuation-token")
+
+    result := listBucketResult{
+        Name:      this.bucket,
+        Prefix:    prefix,
+        MaxKeys:   maxKeys,
+        Delimiter: delimiter,
+    }
+
+    seen := map[string]bool{}
+    for _, key := range keys {
+        if start != "" && key <= start {
+            continue
+        }
+
+        if result.KeyCount >= maxKeys {
+            result.IsTruncated = true
+            break
+        }
+
+        if delimiter != "" {
+            rest := strings.TrimPrefix(key, prefix)
+            if i := strings.Index(rest, delimiter); i >= 0 {
+                dir := prefix + rest[:i+1]
+                if !seen[dir] {
+                    seen[dir] = true
+                    result.CommonPrefixes = append(result.CommonPrefixes, commonPrefix{Prefix: dir})
+                    result.KeyCount++
+                    result.NextContinuationToken = key
+                }
+                continue
+            }
+        }
+
+        obj := this.objects[key]
+        result.Contents = append(result.Contents, listObject{
+            Key:          key,
+            LastModified: obj.modified.UTC(),
+            Size:         int64(len(obj.data)),
+        })
+        result.KeyCount++
+        result.NextContinuationToken = key
+    }
+
+    if !result.IsTruncated {
+        result.NextContinuationToken = ""
+    }
+
+    this.xml(w, result)
+}
+
+func (this *fakeS3) deleteObjects(w http.ResponseWriter, r *http.Request, body []byte) {
+    sum := md5.Sum(body)
+    if r.Header.Get("Content-MD5") != base64.StdEncoding.EncodeToString(sum[:]) {
+        this.error(w, 400, "BadDigest", "")
+        return
+    }
+
+    var req deleteRequest
+    if err := xml.Unmarshal(body, &req); err != nil {
+        this.error(w, 400, "MalformedXML", "")
+        return
+    }
+
+    for _, obj := range req.Objects {
+        delete(this.objects, obj.Key)
+    }
+
+    this.xml(w, deleteResult{})
+}
+
+func (this *fakeS3) getAcl(w http.ResponseWriter, key string) {
+    obj, ok := this.objects[key]
+    if !ok {
+        this.error(w, 404, "NoSuchKey", key)
+        return
+    }
+
+    var buf bytes.Buffer
+    buf.WriteString(`<AccessControlPolicy xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"><AccessControlList>`)
+    buf.WriteString(`<Grant><Grantee xsi:type="CanonicalUser"><ID>owner</ID></Grantee><Permission>FULL_CONTROL</Permission></Grant>`)
+    if obj.acl == "public-read" {
+        buf.WriteString(`<Grant><Grantee xsi:type="Group"><URI>` + allUsersURI + `</URI></Grantee><Permission>READ</Permission></Grant>`)
+    }
+    buf.WriteString(`</AccessControlList></AccessControlPolicy>`)
+
+    w.Write(buf.Bytes())
+}
+
+func (this *fakeS3) multipart(w http.ResponseWriter, r *http.Request, key string, query url.Values, body []byte) {
+    id := query.Get("uploadId")
+    upload, ok := this.uploads[id]
+    if !ok || upload.key != key {
+        this.error(w, 404, "NoSuchUpload", key)
+        return
+    }
+
+    switch r.Method {
+        case http.MethodPut:
+            number, _ := strconv.Atoi(query.Get("partNumber"))
+            upload.parts[number] = body
+            sum := md5.Sum(body)
+            w.Header().Set("ETag", `"` + hex.EncodeToString(sum[:]) + `"`)
+        case http.MethodPost:
+            var req completeMultipartUpload
+            if err := xml.Unmarshal(body, &req); err != nil {
+                this.error(w, 400, "MalformedXML", key)
+                return
+            }
+
+            var data []byte
+            for i, part := range req.Parts {
+                chunk, ok := upload.parts[part.PartNumber]
+                if !ok || part.PartNumber != i + 1 {
+                    this.error(w, 400, "InvalidPart", key)
+                    return
+                }
+
+                // 除最后一片外不能小于 5M
+                if i < len(req.Parts) - 1 && int64(len(chunk)) < MinPartSize {
+                    this.error(w, 400, "EntityTooSmall", key)
+                    return
+                }
+
+                data = append(data, chunk...)
+            }
+
+            this.objects[key] = &fakeObject{
+                data:        data,
+                contentType: upload.contentType,
+                acl:         upload.acl,
+                modified:    time.Now(),
+            }
+            delete(this.uploads, id)
+
+            fmt.Fprint(w, "<CompleteMultipartUploadResult><Key>" + key + "</Key></CompleteMultipartUploadResult>")
+        case http.MethodDelete:
+            delete(this.uploads, id)
+            w.WriteHeader(204)
+    }
+}
+
+func (this *fakeS3) xml(w http.ResponseWriter, v any) {
+    data, _ := xml.Marshal(v)
+    w.Header().Set("Content-Type", "application/xml")
+    w.Write(data)
+}
+
+func (this *fakeS3) error(w http.ResponseWriter, status int, code string, key string) {
+    w.Header().Set("Content-Type", "application/xml")
+    w.WriteHeader(status)
+    fmt.Fprintf(w, "<Error><Code>%s</Code><Message>%s</Message><Key>%s</Key></Error>", code, code, key)
+}
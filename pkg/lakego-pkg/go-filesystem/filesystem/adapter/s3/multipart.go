@@ -0,0 +1,149 @@
+package s3
+
+import (
+    "io"
+    "errors"
+    "strconv"
+    "net/url"
+    "net/http"
+    "encoding/xml"
+
+    "github.com/deatil/go-filesystem/filesystem/interfaces"
+)
+
+// 分片上传
+// 任一分片失败时取消上传，返回上传的总大小
+func (this *S3) multipartUpload(path string, stream io.Reader, conf interfaces.Config) (int64, error) {
+    location := this.ApplyPathPrefix(path)
+
+    uploadId, err := this.createMultipartUpload(path, location, conf)
+    if err != nil {
+        return 0, err
+    }
+
+    var size int64
+    var parts []completePart
+
+    buf := make([]byte, this.config.PartSize)
+    for number := 1; ; number++ {
+        n, readErr := io.ReadFull(stream, buf)
+        if readErr != nil && readErr != io.EOF && readErr != io.ErrUnexpectedEOF {
+            this.abortMultipartUpload(location, uploadId)
+            return 0, errors.New("go-filesystem: read stream fail, error: " + readErr.Error())
+        }
+
+        if n == 0 {
+            break
+        }
+
+        etag, err := this.uploadPart(location, uploadId, number, buf[:n])
+        if err != nil {
+            this.abortMultipartUpload(location, uploadId)
+            return 0, err
+        }
+
+        size += int64(n)
+        parts = append(parts, completePart{
+            PartNumber: number,
+            ETag:       etag,
+        })
+
+        if n < len(buf) {
+            break
+        }
+    }
+
+    if err := this.completeMultipartUpload(location, uploadId, parts); err != nil {
+        this.abortMultipartUpload(location, uploadId)
+        return 0, err
+    }
+
+    return size, nil
+}
+
+// 初始化分片上传
+func (this *S3) createMultipartUpload(path string, location string, conf interfaces.Config) (string, error) {
+    query := url.Values{}
+    query.Set("uploads", "")
+
+    // 分片上传不能计算完整数据，使用文件名判断类型
+    header := this.writeHeader(path, nil, conf)
+
+    resp, err := this.do(http.MethodPost, location, query, header, nil)
+    if err != nil {
+        return "", err
+    }
+    defer resp.Body.Close()
+
+    var result initiateMultipartUploadResult
+    if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+        return "", errors.New("go-filesystem: parse multipart upload fail, error: " + err.Error())
+    }
+
+    if result.UploadId == "" {
+        return "", errors.New("go-filesystem: multipart upload id is empty")
+    }
+
+    return result.UploadId, nil
+}
+
+// 上传分片
+func (this *S3) uploadPart(location string, uploadId string, number int, data []byte) (string, error) {
+    query := url.Values{}
+    query.Set("partNumber", strconv.Itoa(number))
+    query.Set("uploadId", uploadId)
+
+    resp, err := this.do(http.MethodPut, location, query, nil, data)
+    if err != nil {
+        return "", err
+    }
+    resp.Body.Close()
+
+    etag := resp.Header.Get("ETag")
+    if etag == "" {
+        return "", errors.New("go-filesystem: upload part " + strconv.Itoa(number) + " has no etag")
+    }
+
+    return etag, nil
+}
+
+// 完成分片上传
+func (this *S3) completeMultipartUpload(location string, uploadId string, parts []completePart) error {
+    query := url.Values{}
+    query.Set("uploadId", uploadId)
+
+    body, err := xml.Marshal(completeMultipartUpload{
+        Parts: parts,
+    })
+    if err != nil {
+        return err
+    }
+
+    header := http.Header{}
+    header.Set("Content-Type", "application/xml")
+
+    resp, err := this.do(http.MethodPost, location, query, header, body)
+    if err != nil {
+        return err
+    }
+    defer resp.Body.Close()
+
+    // 完成失败时也可能返回 200
+    data, _ := io.ReadAll(resp.Body)
+    if len(data) > 0 && xml.Unmarshal(data, &ResponseError{}) == nil {
+        return this.parseError(resp.StatusCode, data)
+    }
+
+    return nil
+}
+
+// 取消分片上传
+func (this *S3) abortMultipartUpload(location string, uploadId string) {
+    query := url.Values{}
+    query.Set("uploadId", uploadId)
+
+    resp, err := this.do(http.MethodDelete, location, query, nil, nil)
+    if err == nil {
+        resp.Body.Close()
+    }
+}
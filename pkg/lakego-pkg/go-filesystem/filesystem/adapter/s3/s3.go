@@ -0,0 +1,771 @@
+package s3
+
+import (
+    "io"
+    "mime"
+    "bytes"
+    "errors"
+    "strings"
+    "strconv"
+    "net/url"
+    "net/http"
+    "crypto/md5"
+    "encoding/xml"
+    "encoding/base64"
+    "path/filepath"
+    "time"
+
+    "github.com/deatil/go-filesystem/filesystem/adapter"
+    "github.com/deatil/go-filesystem/filesystem/interfaces"
+)
+
+const (
+    // 默认分片大小 8M，S3 要求最小为 5M
+    DefaultPartSize int64 = 8 << 20
+
+    // 最小分片大小
+    MinPartSize int64 = 5 << 20
+
+    // 公开访问的用户组
+    allUsersURI = "http://acs.amazonaws.com/groups/global/AllUsers"
+)
+
+// 权限列表
+var aclMap = map[string]string{
+    "public":  "public-read",
+    "private": "private",
+}
+
+/**
+ * S3 配置
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Config struct {
+    // 服务地址，为空时使用 AWS 官方地址
+    // 如 https://s3.amazonaws.com 或 http://127.0.0.1:9000
+    Endpoint string
+
+    // 区域
+    Region string
+
+    // 存储桶
+    Bucket string
+
+    // 账号
+    AccessKey string
+    SecretKey string
+
+    // 临时凭证
+    SessionToken string
+
+    // 存储前缀
+    Root string
+
+    // 使用路径形式访问存储桶
+    // 为 false 时使用 bucket.host 形式
+    UsePathStyle bool
+
+    // 分片上传的分片大小
+    PartSize int64
+
+    // 请求客户端
+    Client *http.Client
+}
+
+/**
+ * S3 兼容对象存储适配器 / S3 adapter
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type S3 struct {
+    // 默认适配器基类
+    adapter.Adapter
+
+    // 配置
+    config Config
+
+    // 服务地址
+    endpoint *url.URL
+
+    // 签名
+    signer signer
+
+    // 当前时间，测试时可替换
+    now func() time.Time
+}
+
+// S3 适配器
+func New(conf Config) (*S3, error) {
+    if conf.Bucket == "" {
+        return nil, errors.New("go-filesystem: s3 bucket is empty")
+    }
+
+    if conf.Region == "" {
+        conf.Region = "us-east-1"
+    }
+
+    if conf.Endpoint == "" {
+        conf.Endpoint = "https://s3." + conf.Region + ".amazonaws.com"
+    }
+
+    endpoint, err := url.Parse(strings.TrimSuffix(conf.Endpoint, "/"))
+    if err != nil || endpoint.Host == "" {
+        return nil, errors.New("go-filesystem: s3 endpoint is invalid")
+    }
+
+    if conf.PartSize == 0 {
+        conf.PartSize = DefaultPartSize
+    } else if conf.PartSize < MinPartSize {
+        conf.PartSize = MinPartSize
+    }
+
+    if conf.Client == nil {
+        conf.Client = http.DefaultClient
+    }
+
+    s3 := &S3{
+        config:   conf,
+        endpoint: endpoint,
+        signer:   signer{
+            accessKey:    conf.AccessKey,
+            secretKey:    conf.SecretKey,
+            sessionToken: conf.SessionToken,
+            region:       conf.Region,
+        },
+        now: time.Now,
+    }
+
+    s3.SetPathPrefix(strings.Trim(conf.Root, "/"))
+
+    return s3, nil
+}
+
+// 获取配置
+func (this *S3) GetConfig() Config {
+    return this.config
+}
+
+// 判断是否存在
+func (this *S3) Has(path string) bool {
+    location := this.ApplyPathPrefix(path)
+
+    resp, err := this.do(http.MethodHead, location, nil, nil, nil)
+    if err == nil {
+        resp.Body.Close()
+        return true
+    }
+
+    // 文件不存在时判断是否为目录
+    result, err := this.listObjects(strings.TrimSuffix(location, "/") + "/", "", "", 1)
+    if err != nil {
+        return false
+    }
+
+    return len(result.Contents) > 0 || len(result.CommonPrefixes) > 0
+}
+
+// 上传
+func (this *S3) Write(path string, contents []byte, conf interfaces.Config) (map[string]any, error) {
+    location := this.ApplyPathPrefix(path)
+
+    header := this.writeHeader(path, contents, conf)
+
+    resp, err := this.do(http.MethodPut, location, nil, header, contents)
+    if err != nil {
+        return nil, err
+    }
+    resp.Body.Close()
+
+    result := map[string]any{
+        "type":     "file",
+        "size":     int64(len(contents)),
+        "path":     path,
+        "contents": contents,
+        "mimetype": header.Get("Content-Type"),
+    }
+
+    if visibility, ok := conf.Get("visibility").(string); ok {
+        result["visibility"] = visibility
+    }
+
+    return result, nil
+}
+
+// 上传 Stream 文件类型
+// 数据超过分片大小时使用分片上传
+func (this *S3) WriteStream(path string, stream io.Reader, conf interfaces.Config) (map[string]any, error) {
+    first := make([]byte, this.config.PartSize)
+
+    n, err := io.ReadFull(stream, first)
+    if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+        return nil, errors.New("go-filesystem: read stream fail, error: " + err.Error())
+    }
+
+    // 数据不足一个分片时直接上传
+    if n < len(first) {
+        result, err := this.Write(path, first[:n], conf)
+        if err != nil {
+            return nil, err
+        }
+
+        delete(result, "contents")
+
+        return result, nil
+    }
+
+    size, err := this.multipartUpload(path, io.MultiReader(bytes.NewReader(first), stream), conf)
+    if err != nil {
+        return nil, err
+    }
+
+    result := map[string]any{
+        "type": "file",
+        "size": size,
+        "path": path,
+    }
+
+    if visibility, ok := conf.Get("visibility").(string); ok {
+        result["visibility"] = visibility
+    }
+
+    return result, nil
+}
+
+// 更新
+func (this *S3) Update(path string, contents []byte, conf interfaces.Config) (map[string]any, error) {
+    return this.Write(path, contents, conf)
+}
+
+// 更新
+func (this *S3) UpdateStream(path string, stream io.Reader, conf interfaces.Config) (map[string]any, error) {
+    return this.WriteStream(path, stream, conf)
+}
+
+// 读取
+func (this *S3) Read(path string) (map[string]any, error) {
+    location := this.ApplyPathPrefix(path)
+
+    resp, err := this.do(http.MethodGet, location, nil, nil, nil)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+
+    contents, err := io.ReadAll(resp.Body)
+    if err != nil {
+        return nil, errors.New("go-filesystem: exec io.ReadAll() fail, error: " + err.Error())
+    }
+
+    return map[string]any{
+        "type":     "file",
+        "path":     path,
+        "contents": contents,
+    }, nil
+}
+
+// 读取成文件流
+// 数据先写入临时文件，打开文件需要手动关闭
+func (this *S3) ReadStream(path string) (map[string]any, error) {
+    location := this.ApplyPathPrefix(path)
+
+    resp, err := this.do(http.MethodGet, location, nil, nil, nil)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+
+    stream, err := adapter.TempStream(func(w io.Writer) error {
+        if _, err := io.Copy(w, resp.Body); err != nil {
+            return errors.New("go-filesystem: read stream fail, error: " + err.Error())
+        }
+
+        return nil
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    return map[string]any{
+        "type":   "file",
+        "path":   path,
+        "stream": stream,
+    }, nil
+}
+
+// 重命名
+func (this *S3) Rename(path string, newpath string) error {
+    if err := this.Copy(path, newpath); err != nil {
+        return err
+    }
+
+    return this.Delete(path)
+}
+
+// 复制
+func (this *S3) Copy(path string, newpath string) error {
+    location := this.ApplyPathPrefix(path)
+    destination := this.ApplyPathPrefix(newpath)
+
+    header := http.Header{}
+    header.Set("X-Amz-Copy-Source", "/" + this.config.Bucket + "/" + uriEncode(location, false))
+
+    // 保留原文件权限，服务不支持 acl 时忽略
+    if visibility, err := this.GetVisibility(path); err == nil {
+        header.Set("X-Amz-Acl", aclMap[visibility["visibility"]])
+    }
+
+    resp, err := this.do(http.MethodPut, destination, nil, header, nil)
+    if err != nil {
+        return err
+    }
+    defer resp.Body.Close()
+
+    // 复制失败时也可能返回 200
+    body, _ := io.ReadAll(resp.Body)
+    if bytes.Contains(body, []byte("<Error>")) {
+        return this.parseError(resp.StatusCode, body)
+    }
+
+    return nil
+}
+
+// 删除
+func (this *S3) Delete(path string) error {
+    location := this.ApplyPathPrefix(path)
+
+    resp, err := this.do(http.MethodDelete, location, nil, nil, nil)
+    if err != nil {
+        return err
+    }
+    resp.Body.Close()
+
+    return nil
+}
+
+// 删除文件夹
+func (this *S3) DeleteDir(dirname string) error {
+    prefix := strings.TrimSuffix(this.ApplyPathPrefix(dirname), "/") + "/"
+
+    token := ""
+    for {
+        result, err := this.listObjects(prefix, "", token, 1000)
+        if err != nil {
+            return err
+        }
+
+        keys := make([]string, 0, len(result.Contents))
+        for _, object := range result.Contents {
+            keys = append(keys, object.Key)
+        }
+
+        if err := this.deleteObjects(keys); err != nil {
+            return err
+        }
+
+        if !result.IsTruncated {
+            break
+        }
+
+        token = result.NextContinuationToken
+    }
+
+    return nil
+}
+
+// 创建文件夹
+// 对象存储没有目录，写入以 / 结尾的空对象作为标识
+func (this *S3) CreateDir(dirname string, conf interfaces.Config) (map[string]string, error) {
+    location := strings.TrimSuffix(this.ApplyPathPrefix(dirname), "/") + "/"
+
+    header := http.Header{}
+    header.Set("Content-Type", "application/x-directory")
+
+    visibility, ok := conf.Get("visibility", "public").(string)
+    if !ok {
+        visibility = "public"
+    }
+
+    if acl, ok := aclMap[visibility]; ok {
+        header.Set("X-Amz-Acl", acl)
+    }
+
+    resp, err := this.do(http.MethodPut, location, nil, header, nil)
+    if err != nil {
+        return nil, err
+    }
+    resp.Body.Close()
+
+    return map[string]string{
+        "path": dirname,
+        "type": "dir",
+    }, nil
+}
+
+// 列出内容
+func (this *S3) ListContents(directory string, recursive ...bool) ([]map[string]any, error) {
+    prefix := strings.TrimSuffix(this.ApplyPathPrefix(directory), "/")
+    if prefix != "" {
+        prefix += "/"
+    }
+
+    delimiter := "/"
+    if len(recursive) > 0 && recursive[0] {
+        delimiter = ""
+    }
+
+    var result []map[string]any
+
+    token := ""
+    for {
+        list, err := this.listObjects(prefix, delimiter, token, 1000)
+        if err != nil {
+            return nil, err
+        }
+
+        for _, dir := range list.CommonPrefixes {
+            result = append(result, map[string]any{
+                "type": "dir",
+                "path": this.RemovePathPrefix(strings.TrimSuffix(dir.Prefix, "/")),
+            })
+        }
+
+        for _, object := range list.Contents {
+            // 跳过当前目录标识
+            if object.Key == prefix {
+                continue
+            }
+
+            if strings.HasSuffix(object.Key, "/") {
+                result = append(result, map[string]any{
+                    "type":      "dir",
+                    "path":      this.RemovePathPrefix(strings.TrimSuffix(object.Key, "/")),
+                    "timestamp": object.LastModified.Unix(),
+                })
+
+                continue
+            }
+
+            result = append(result, map[string]any{
+                "type":      "file",
+                "path":      this.RemovePathPrefix(object.Key),
+                "timestamp": object.LastModified.Unix(),
+                "size":      object.Size,
+            })
+        }
+
+        if !list.IsTruncated {
+            break
+        }
+
+        token = list.NextContinuationToken
+    }
+
+    return result, nil
+}
+
+// 文件信息
+func (this *S3) GetMetadata(path string) (map[string]any, error) {
+    location := this.ApplyPathPrefix(path)
+
+    resp, err := this.do(http.MethodHead, location, nil, nil, nil)
+    if err != nil {
+        return nil, err
+    }
+    resp.Body.Close()
+
+    size, _ := strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64)
+
+    var timestamp int64
+    if modified, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+        timestamp = modified.Unix()
+    }
+
+    return map[string]any{
+        "type":      "file",
+        "path":      path,
+        "size":      size,
+        "timestamp": timestamp,
+        "mimetype":  resp.Header.Get("Content-Type"),
+        "etag":      strings.Trim(resp.Header.Get("ETag"), `"`),
+    }, nil
+}
+
+func (this *S3) GetSize(path string) (map[string]any, error) {
+    return this.GetMetadata(path)
+}
+
+func (this *S3) GetMimetype(path string) (map[string]any, error) {
+    return this.GetMetadata(path)
+}
+
+func (this *S3) GetTimestamp(path string) (map[string]any, error) {
+    return this.GetMetadata(path)
+}
+
+// 获取文件的权限
+// 所有用户可读时为 public
+func (this *S3) GetVisibility(path string) (map[string]string, error) {
+    location := this.ApplyPathPrefix(path)
+
+    query := url.Values{}
+    query.Set("acl", "")
+
+    resp, err := this.do(http.MethodGet, location, query, nil, nil)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+
+    var policy accessControlPolicy
+    if err := xml.NewDecoder(resp.Body).Decode(&policy); err != nil {
+        return nil, errors.New("go-filesystem: parse acl fail, error: " + err.Error())
+    }
+
+    visibility := "private"
+    for _, g := range policy.AccessControlList.Grants {
+        if g.Grantee.URI == allUsersURI &&
+            (g.Permission == "READ" || g.Permission == "FULL_CONTROL") {
+            visibility = "public"
+            break
+        }
+    }
+
+    return map[string]string{
+        "path":       path,
+        "visibility": visibility,
+    }, nil
+}
+
+// 设置文件的权限
+func (this *S3) SetVisibility(path string, visibility string) (map[string]string, error) {
+    location := this.ApplyPathPrefix(path)
+
+    if visibility != "private" {
+        visibility = "public"
+    }
+
+    query := url.Values{}
+    query.Set("acl", "")
+
+    header := http.Header{}
+    header.Set("X-Amz-Acl", aclMap[visibility])
+
+    resp, err := this.do(http.MethodPut, location, query, header, nil)
+    if err != nil {
+        return nil, errors.New("go-filesystem: set permission fail, error: " + err.Error())
+    }
+    resp.Body.Close()
+
+    return map[string]string{
+        "path":       path,
+        "visibility": visibility,
+    }, nil
+}
+
+// 生成预签名链接
+// method 默认为 GET，有效期最长 7 天
+func (this *S3) PresignedUrl(path string, expires time.Duration, method ...string) (string, error) {
+    return this.PresignedUrlWithQuery(path, expires, nil, method...)
+}
+
+// 生成带参数的预签名链接
+// query 参与签名，可用 response-content-disposition 等参数覆盖响应头
+func (this *S3) PresignedUrlWithQuery(path string, expires time.Duration, query url.Values, method ...string) (string, error) {
+    if expires <= 0 || expires > 7 * 24 * time.Hour {
+        return "", errors.New("go-filesystem: s3 presign expires must between 1s and 7 days")
+    }
+
+    useMethod := http.MethodGet
+    if len(method) > 0 && method[0] != "" {
+        useMethod = strings.ToUpper(method[0])
+    }
+
+    u := this.objectURL(this.ApplyPathPrefix(path), query)
+
+    return this.signer.Presign(useMethod, u, expires, this.now()), nil
+}
+
+// 上传请求头
+// contents 为 nil 时不计算类型及校验值
+func (this *S3) writeHeader(path string, contents []byte, conf interfaces.Config) http.Header {
+    header := http.Header{}
+
+    mimetype, _ := conf.Get("mimetype", "").(string)
+    if mimetype == "" {
+        mimetype = mime.TypeByExtension(filepath.Ext(path))
+    }
+    if mimetype == "" && len(contents) > 0 {
+        mimetype = http.DetectContentType(contents)
+    }
+    if mimetype == "" {
+        mimetype = "application/octet-stream"
+    }
+
+    header.Set("Content-Type", mimetype)
+
+    if contents != nil {
+        sum := md5.Sum(contents)
+        header.Set("Content-MD5", base64.StdEncoding.EncodeToString(sum[:]))
+    }
+
+    if visibility, ok := conf.Get("visibility", "").(string); ok {
+        if acl, ok := aclMap[visibility]; ok {
+            header.Set("X-Amz-Acl", acl)
+        }
+    }
+
+    return header
+}
+
+// 列出文件
+func (this *S3) listObjects(prefix, delimiter, token string, maxKeys int) (*listBucketResult, error) {
+    query := url.Values{}
+    query.Set("list-type", "2")
+    query.Set("prefix", prefix)
+    query.Set("max-keys", strconv.Itoa(maxKeys))
+    if delimiter != "" {
+        query.Set("delimiter", delimiter)
+    }
+    if token != "" {
+        query.Set("continuation-token", token)
+    }
+
+    resp, err := this.do(http.MethodGet, "", query, nil, nil)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+
+    var result listBucketResult
+    if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+        return nil, errors.New("go-filesystem: parse list fail, error: " + err.Error())
+    }
+
+    return &result, nil
+}
+
+// 批量删除
+func (this *S3) deleteObjects(keys []string) error {
+    if len(keys) == 0 {
+        return nil
+    }
+
+    req := deleteRequest{
+        Quiet: true,
+    }
+    for _, key := range keys {
+        req.Objects = append(req.Objects, deleteObject{Key: key})
+    }
+
+    body, err := xml.Marshal(req)
+    if err != nil {
+        return err
+    }
+
+    query := url.Values{}
+    query.Set("delete", "")
+
+    sum := md5.Sum(body)
+
+    header := http.Header{}
+    header.Set("Content-Type", "application/xml")
+    header.Set("Content-MD5", base64.StdEncoding.EncodeToString(sum[:]))
+
+    resp, err := this.do(http.MethodPost, "", query, header, body)
+    if err != nil {
+        return err
+    }
+    defer resp.Body.Close()
+
+    var result deleteResult
+    if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+        return errors.New("go-filesystem: parse delete result fail, error: " + err.Error())
+    }
+
+    if len(result.Errors) > 0 {
+        first := result.Errors[0]
+        return errors.New("go-filesystem: delete " + first.Key + " fail, error: " + first.Message)
+    }
+
+    return nil
+}
+
+// 文件链接
+func (this *S3) objectURL(key string, query url.Values) *url.URL {
+    u := *this.endpoint
+
+    path := "/" + key
+    if this.config.UsePathStyle {
+        path = "/" + this.config.Bucket + path
+    } else {
+        u.Host = this.config.Bucket + "." + u.Host
+    }
+
+    u.Path = this.endpoint.Path + path
+    u.RawPath = uriEncode(u.Path, false)
+
+    if query != nil {
+        u.RawQuery = canonicalQuery(query)
+    }
+
+    return &u
+}
+
+// 发送请求
+// 返回状态码非 2xx 时解析为 ResponseError
+func (this *S3) do(
+    method string,
+    key string,
+    query url.Values,
+    header http.Header,
+    body []byte,
+) (*http.Response, error) {
+    u := this.objectURL(key, query)
+
+    req, err := http.NewRequest(method, u.String(), bytes.NewReader(body))
+    if err != nil {
+        return nil, err
+    }
+
+    for k, v := range header {
+        req.Header[k] = v
+    }
+
+    req.ContentLength = int64(len(body))
+
+    payloadHash := emptyPayloadHash
+    if len(body) > 0 {
+        payloadHash = hashHex(body)
+    }
+
+    this.signer.Sign(req, payloadHash, this.now())
+
+    resp, err := this.config.Client.Do(req)
+    if err != nil {
+        return nil, errors.New("go-filesystem: s3 request fail, error: " + err.Error())
+    }
+
+    if resp.StatusCode < 200 || resp.StatusCode > 299 {
+        defer resp.Body.Close()
+
+        data, _ := io.ReadAll(resp.Body)
+        return nil, this.parseError(resp.StatusCode, data)
+    }
+
+    return resp, nil
+}
+
+// 解析错误
+func (this *S3) parseError(status int, data []byte) error {
+    respErr := &ResponseError{}
+    if len(data) > 0 {
+        xml.Unmarshal(data, respErr)
+    }
+
+    respErr.StatusCode = status
+
+    return respErr
+}
@@ -0,0 +1,241 @@
+package s3
+
+import (
+    "sort"
+    "strings"
+    "strconv"
+    "net/url"
+    "net/http"
+    "crypto/hmac"
+    "crypto/sha256"
+    "encoding/hex"
+    "time"
+)
+
+const (
+    // 签名算法
+    signAlgorithm = "AWS4-HMAC-SHA256"
+
+    // 服务名称
+    signService = "s3"
+
+    // 时间格式
+    amzDateFormat  = "20060102T150405Z"
+    amzShortFormat = "20060102"
+
+    // 空内容哈希
+    emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+    // 不签名内容
+    unsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+/**
+ * AWS Signature Version 4 签名
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type signer struct {
+    accessKey    string
+    secretKey    string
+    sessionToken string
+    region       string
+}
+
+// 签名请求头
+// 请求需已设置 Host 及需要签名的头信息
+func (this signer) Sign(req *http.Request, payloadHash string, t time.Time) {
+    t = t.UTC()
+
+    amzDate := t.Format(amzDateFormat)
+
+    req.Header.Set("X-Amz-Date", amzDate)
+    req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+    if this.sessionToken != "" {
+        req.Header.Set("X-Amz-Security-Token", this.sessionToken)
+    }
+
+    signedHeaders, canonicalHeaders := this.canonicalHeaders(req)
+
+    canonicalRequest := strings.Join([]string{
+        req.Method,
+        canonicalURI(req.URL),
+        canonicalQuery(req.URL.Query()),
+        canonicalHeaders,
+        signedHeaders,
+        payloadHash,
+    }, "\n")
+
+    scope := this.scope(t)
+    signature := this.signature(t, this.stringToSign(amzDate, scope, canonicalRequest))
+
+    req.Header.Set("Authorization", signAlgorithm +
+        " Credential=" + this.accessKey + "/" + scope +
+        ", SignedHeaders=" + signedHeaders +
+        ", Signature=" + signature)
+}
+
+// 生成预签名链接
+func (this signer) Presign(method string, u *url.URL, expires time.Duration, t time.Time) string {
+    t = t.UTC()
+
+    amzDate := t.Format(amzDateFormat)
+    scope := this.scope(t)
+
+    query := u.Query()
+    query.Set("X-Amz-Algorithm", signAlgorithm)
+    query.Set("X-Amz-Credential", this.accessKey + "/" + scope)
+    query.Set("X-Amz-Date", amzDate)
+    query.Set("X-Amz-Expires", strconv.FormatInt(int64(expires / time.Second), 10))
+    query.Set("X-Amz-SignedHeaders", "host")
+    if this.sessionToken != "" {
+        query.Set("X-Amz-Security-Token", this.sessionToken)
+    }
+
+    canonicalRequest := strings.Join([]string{
+        method,
+        canonicalURI(u),
+        canonicalQuery(query),
+        "host:" + u.Host + "\n",
+        "host",
+        unsignedPayload,
+    }, "\n")
+
+    signature := this.signature(t, this.stringToSign(amzDate, scope, canonicalRequest))
+
+    signed := *u
+    signed.RawQuery = canonicalQuery(query) + "&X-Amz-Signature=" + signature
+
+    return signed.String()
+}
+
+// 签名范围
+func (this signer) scope(t time.Time) string {
+    return t.Format(amzShortFormat) + "/" + this.region + "/" + signService + "/aws4_request"
+}
+
+// 待签名字符
+func (this signer) stringToSign(amzDate, scope, canonicalRequest string) string {
+    return strings.Join([]string{
+        signAlgorithm,
+        amzDate,
+        scope,
+        hashHex([]byte(canonicalRequest)),
+    }, "\n")
+}
+
+// 计算签名
+func (this signer) signature(t time.Time, stringToSign string) string {
+    key := hmacSHA256([]byte("AWS4" + this.secretKey), t.Format(amzShortFormat))
+    key = hmacSHA256(key, this.region)
+    key = hmacSHA256(key, signService)
+    key = hmacSHA256(key, "aws4_request")
+
+    return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+// 需要签名的头信息
+func (this signer) canonicalHeaders(req *http.Request) (string, string) {
+    headers := map[string]string{
+        "host": req.URL.Host,
+    }
+    if req.Host != "" {
+        headers["host"] = req.Host
+    }
+
+    for k, v := range req.Header {
+        name := strings.ToLower(k)
+        if name == "authorization" {
+            continue
+        }
+
+        if strings.HasPrefix(name, "x-amz-") ||
+            name == "content-type" ||
+            name == "content-md5" ||
+            name == "range" {
+            values := make([]string, 0, len(v))
+            for _, val := range v {
+                values = append(values, strings.Join(strings.Fields(val), " "))
+            }
+
+            headers[name] = strings.Join(values, ",")
+        }
+    }
+
+    names := make([]string, 0, len(headers))
+    for name := range headers {
+        names = append(names, name)
+    }
+    sort.Strings(names)
+
+    var buf strings.Builder
+    for _, name := range names {
+        buf.WriteString(name + ":" + headers[name] + "\n")
+    }
+
+    return strings.Join(names, ";"), buf.String()
+}
+
+// 规范路径
+func canonicalURI(u *url.URL) string {
+    if u.Path == "" {
+        return "/"
+    }
+
+    return uriEncode(u.Path, false)
+}
+
+// 规范查询字符
+func canonicalQuery(query url.Values) string {
+    keys := make([]string, 0, len(query))
+    for k := range query {
+        keys = append(keys, k)
+    }
+    sort.Strings(keys)
+
+    parts := make([]string, 0, len(keys))
+    for _, k := range keys {
+        values := append([]string{}, query[k]...)
+        sort.Strings(values)
+
+        for _, v := range values {
+            parts = append(parts, uriEncode(k, true) + "=" + uriEncode(v, true))
+        }
+    }
+
+    return strings.Join(parts, "&")
+}
+
+// URI 编码
+// 只保留 RFC 3986 未保留字符
+func uriEncode(s string, encodeSlash bool) string {
+    var buf strings.Builder
+    for i := 0; i < len(s); i++ {
+        c := s[i]
+
+        if (c >= 'A' && c <= 'Z') ||
+            (c >= 'a' && c <= 'z') ||
+            (c >= '0' && c <= '9') ||
+            c == '-' || c == '_' || c == '.' || c == '~' {
+            buf.WriteByte(c)
+        } else if c == '/' && !encodeSlash {
+            buf.WriteByte(c)
+        } else {
+            buf.WriteString("%" + strings.ToUpper(hex.EncodeToString([]byte{c})))
+        }
+    }
+
+    return buf.String()
+}
+
+func hashHex(data []byte) string {
+    sum := sha256.Sum256(data)
+    return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+    h := hmac.New(sha256.New, key)
+    h.Write([]byte(data))
+    return h.Sum(nil)
+}
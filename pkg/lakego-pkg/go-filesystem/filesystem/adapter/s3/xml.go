@@ -0,0 +1,116 @@
+package s3
+
+import (
+    "fmt"
+    "time"
+    "encoding/xml"
+)
+
+/**
+ * S3 接口返回的错误
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type ResponseError struct {
+    XMLName    xml.Name `xml:"Error"`
+    StatusCode int      `xml:"-"`
+    Code       string   `xml:"Code"`
+    Message    string   `xml:"Message"`
+    Key        string   `xml:"Key"`
+    RequestId  string   `xml:"RequestId"`
+}
+
+// 错误信息
+func (this *ResponseError) Error() string {
+    if this.Code == "" {
+        return fmt.Sprintf("go-filesystem: s3 request fail, status: %d", this.StatusCode)
+    }
+
+    return fmt.Sprintf("go-filesystem: s3 request fail, status: %d, code: %s, message: %s", this.StatusCode, this.Code, this.Message)
+}
+
+// 列表结果
+type listBucketResult struct {
+    XMLName               xml.Name       `xml:"ListBucketResult"`
+    Name                  string         `xml:"Name"`
+    Prefix                string         `xml:"Prefix"`
+    KeyCount              int            `xml:"KeyCount"`
+    MaxKeys               int            `xml:"MaxKeys"`
+    Delimiter             string         `xml:"Delimiter,omitempty"`
+    IsTruncated           bool           `xml:"IsTruncated"`
+    ContinuationToken     string         `xml:"ContinuationToken,omitempty"`
+    NextContinuationToken string         `xml:"NextContinuationToken,omitempty"`
+    Contents              []listObject   `xml:"Contents"`
+    CommonPrefixes        []commonPrefix `xml:"CommonPrefixes"`
+}
+
+// 列表文件
+type listObject struct {
+    Key          string    `xml:"Key"`
+    LastModified time.Time `xml:"LastModified"`
+    ETag         string    `xml:"ETag"`
+    Size         int64     `xml:"Size"`
+    StorageClass string    `xml:"StorageClass,omitempty"`
+}
+
+// 列表目录
+type commonPrefix struct {
+    Prefix string `xml:"Prefix"`
+}
+
+// 分片上传初始化结果
+type initiateMultipartUploadResult struct {
+    XMLName  xml.Name `xml:"InitiateMultipartUploadResult"`
+    Bucket   string   `xml:"Bucket"`
+    Key      string   `xml:"Key"`
+    UploadId string   `xml:"UploadId"`
+}
+
+// 分片上传完成请求
+type completeMultipartUpload struct {
+    XMLName xml.Name       `xml:"CompleteMultipartUpload"`
+    Parts   []completePart `xml:"Part"`
+}
+
+// 已上传分片
+type completePart struct {
+    PartNumber int    `xml:"PartNumber"`
+    ETag       string `xml:"ETag"`
+}
+
+// 批量删除请求
+type deleteRequest struct {
+    XMLName xml.Name       `xml:"Delete"`
+    Quiet   bool           `xml:"Quiet"`
+    Objects []deleteObject `xml:"Object"`
+}
+
+// 批量删除的文件
+type deleteObject struct {
+    Key string `xml:"Key"`
+}
+
+// 批量删除结果
+type deleteResult struct {
+    XMLName xml.Name        `xml:"DeleteResult"`
+    Errors  []ResponseError `xml:"Error"`
+}
+
+// 权限列表
+type accessControlPolicy struct {
+    XMLName           xml.Name `xml:"AccessControlPolicy"`
+    AccessControlList struct {
+        Grants []grant `xml:"Grant"`
+    } `xml:"AccessControlList"`
+}
+
+// 权限
+type grant struct {
+    Grantee struct {
+        Type string `xml:"http://www.w3.org/2001/XMLSchema-instance type,attr"`
+        ID   string `xml:"ID,omitempty"`
+        URI  string `xml:"URI,omitempty"`
+    } `xml:"Grantee"`
+    Permission string `xml:"Permission"`
+}
@@ -0,0 +1,92 @@
+package sftp
+
+import (
+    "io"
+    "os"
+    "errors"
+
+    "github.com/pkg/sftp"
+    "golang.org/x/crypto/ssh"
+)
+
+// 文件是否不存在
+func IsNotExist(err error) bool {
+    return errors.Is(err, os.ErrNotExist)
+}
+
+// 是否为服务端返回的状态错误，连接仍可用
+func isStatusError(err error) bool {
+    var statusErr *sftp.StatusError
+    if errors.As(err, &statusErr) {
+        return true
+    }
+
+    return errors.Is(err, os.ErrNotExist) || errors.Is(err, os.ErrPermission)
+}
+
+/**
+ * SFTP 客户端，协议由 github.com/pkg/sftp 处理
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type client struct {
+    *sftp.Client
+
+    conn *ssh.Client
+}
+
+// 创建客户端
+func newClient(conn *ssh.Client) (*client, error) {
+    c, err := sftp.NewClient(conn)
+    if err != nil {
+        return nil, err
+    }
+
+    return &client{
+        Client: c,
+        conn:   conn,
+    }, nil
+}
+
+// 关闭
+func (this *client) Close() error {
+    this.Client.Close()
+
+    return this.conn.Close()
+}
+
+// 写入文件，返回写入的长度
+func (this *client) WriteFile(path string, r io.Reader) (int64, error) {
+    f, err := this.OpenFile(path, os.O_WRONLY | os.O_CREATE | os.O_TRUNC)
+    if err != nil {
+        return 0, err
+    }
+
+    n, err := f.ReadFrom(r)
+    if closeErr := f.Close(); err == nil {
+        err = closeErr
+    }
+
+    return n, err
+}
+
+// 读取文件，limit 大于 0 时只读取前 limit 个字节
+func (this *client) ReadFile(path string, w io.Writer, limit int64) (int64, error) {
+    f, err := this.Open(path)
+    if err != nil {
+        return 0, err
+    }
+    defer f.Close()
+
+    if limit > 0 {
+        return io.Copy(w, io.LimitReader(f, limit))
+    }
+
+    return f.WriteTo(w)
+}
+
+// 权限
+func filePerm(info os.FileInfo) uint32 {
+    return uint32(info.Mode().Perm())
+}
@@ -0,0 +1,143 @@
+package sftp
+
+import (
+    "net"
+    "sync"
+    "errors"
+    "strconv"
+    "testing"
+    "crypto/ed25519"
+    "crypto/rand"
+
+    "github.com/pkg/sftp"
+    "golang.org/x/crypto/ssh"
+)
+
+// 测试用 SFTP 服务，文件保存在临时目录
+type fakeServer struct {
+    root     string
+    addr     string
+    hostKey  ssh.PublicKey
+    listener net.Listener
+
+    mu    sync.Mutex
+    conns int
+}
+
+func newFakeServer(t *testing.T, password string, authorized ssh.PublicKey) *fakeServer {
+    _, hostPriv, _ := ed25519.GenerateKey(rand.Reader)
+    hostSigner, err := ssh.NewSignerFromKey(hostPriv)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    config := &ssh.ServerConfig{
+        PasswordCallback: func(conn ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+            if password != "" && string(pass) == password {
+                return nil, nil
+            }
+
+            return nil, errors.New("password rejected")
+        },
+        PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+            if authorized != nil && string(key.Marshal()) == string(authorized.Marshal()) {
+                return nil, nil
+            }
+
+            return nil, errors.New("key rejected")
+        },
+    }
+    config.AddHostKey(hostSigner)
+
+    listener, err := net.Listen("tcp", "127.0.0.1:0")
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    server := &fakeServer{
+        root:     t.TempDir(),
+        addr:     listener.Addr().String(),
+        hostKey:  hostSigner.PublicKey(),
+        listener: listener,
+    }
+
+    go func() {
+        for {
+            conn, err := listener.Accept()
+            if err != nil {
+                return
+            }
+
+            go server.serveConn(conn, config)
+        }
+    }()
+
+    t.Cleanup(func() {
+        listener.Close()
+    })
+
+    return server
+}
+
+func (this *fakeServer) port() int {
+    _, port, _ := net.SplitHostPort(this.addr)
+    p, _ := strconv.Atoi(port)
+    return p
+}
+
+func (this *fakeServer) connCount() int {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    return this.conns
+}
+
+func (this *fakeServer) serveConn(nConn net.Conn, config *ssh.ServerConfig) {
+    _, chans, reqs, err := ssh.NewServerConn(nConn, config)
+    if err != nil {
+        nConn.Close()
+        return
+    }
+
+    this.mu.Lock()
+    this.conns++
+    this.mu.Unlock()
+
+    go ssh.DiscardRequests(reqs)
+
+    for newChannel := range chans {
+        if newChannel.ChannelType() != "session" {
+            newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+            continue
+        }
+
+        channel, requests, err := newChannel.Accept()
+        if err != nil {
+            continue
+        }
+
+        go func() {
+            for req := range requests {
+                ok := req.Type == "subsystem" && len(req.Payload) > 4 && string(req.Payload[4:]) == "sftp"
+                req.Reply(ok, nil)
+
+                if ok {
+                    go this.serveSftp(channel)
+                }
+            }
+        }()
+    }
+}
+
+// 处理 SFTP 请求，使用本地文件系统
+func (this *fakeServer) serveSftp(ch ssh.Channel) {
+    defer ch.Close()
+
+    server, err := sftp.NewServer(ch)
+    if err != nil {
+        return
+    }
+
+    server.Serve()
+    server.Close()
+}
@@ -0,0 +1,663 @@
+package sftp
+
+import (
+    "io"
+    "os"
+    "fmt"
+    "net"
+    "bytes"
+    "errors"
+    "strings"
+    "net/http"
+    "path/filepath"
+    "time"
+
+    "golang.org/x/crypto/ssh"
+    cryptobin_ssh "github.com/deatil/go-cryptobin/ssh"
+
+    "github.com/deatil/go-filesystem/filesystem/adapter"
+    "github.com/deatil/go-filesystem/filesystem/adapter/pool"
+    "github.com/deatil/go-filesystem/filesystem/interfaces"
+)
+
+// 权限列表
+var permissionMap map[string]map[string]uint32 = map[string]map[string]uint32{
+    "file": {
+        "public":  0644,
+        "private": 0600,
+    },
+    "dir": {
+        "public":  0755,
+        "private": 0700,
+    },
+}
+
+/**
+ * SFTP 配置
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Config struct {
+    // 地址
+    Host string
+    Port int
+
+    // 账号
+    Username string
+    Password string
+
+    // 私钥 PEM 数据及密码，支持 RSA / ECDSA / ED25519 / SM2 等
+    PrivateKey []byte
+    Passphrase string
+
+    // 服务端公钥，authorized_keys 格式
+    HostKey string
+
+    // 不校验服务端公钥，仅用于测试环境
+    InsecureIgnoreHostKey bool
+
+    // 根目录
+    Root string
+
+    // 连接超时时间
+    Timeout time.Duration
+
+    // 连接池
+    MaxOpen     int
+    MaxIdle     int
+    IdleTimeout time.Duration
+}
+
+/**
+ * SFTP 适配器 / Sftp adapter
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Sftp struct {
+    // 默认适配器基类
+    adapter.Adapter
+
+    // 配置
+    config Config
+
+    // 连接池
+    pool *pool.Pool[*client]
+}
+
+// SFTP 适配器
+func New(conf Config) (*Sftp, error) {
+    if conf.Host == "" {
+        return nil, errors.New("go-filesystem: sftp host is empty")
+    }
+
+    if conf.Port == 0 {
+        conf.Port = 22
+    }
+
+    if conf.Timeout == 0 {
+        conf.Timeout = 30 * time.Second
+    }
+
+    sshConfig, err := newSSHConfig(conf)
+    if err != nil {
+        return nil, err
+    }
+
+    addr := net.JoinHostPort(conf.Host, fmt.Sprintf("%d", conf.Port))
+
+    fs := &Sftp{
+        config: conf,
+    }
+
+    fs.pool = pool.New(pool.Config[*client]{
+        Factory: func() (*client, error) {
+            conn, err := ssh.Dial("tcp", addr, sshConfig)
+            if err != nil {
+                return nil, errors.New("go-filesystem: sftp connect fail, error: " + err.Error())
+            }
+
+            c, err := newClient(conn)
+            if err != nil {
+                conn.Close()
+                return nil, errors.New("go-filesystem: sftp session fail, error: " + err.Error())
+            }
+
+            return c, nil
+        },
+        Close: func(c *client) error {
+            return c.Close()
+        },
+        MaxOpen:     conf.MaxOpen,
+        MaxIdle:     conf.MaxIdle,
+        IdleTimeout: conf.IdleTimeout,
+    })
+
+    root := conf.Root
+    if root == "" {
+        root = "/"
+    }
+
+    fs.SetPathPrefix(root)
+
+    return fs, nil
+}
+
+// SSH 连接配置
+func newSSHConfig(conf Config) (*ssh.ClientConfig, error) {
+    var auths []ssh.AuthMethod
+
+    if len(conf.PrivateKey) > 0 {
+        var signer ssh.Signer
+        var err error
+
+        if conf.Passphrase != "" {
+            signer, err = cryptobin_ssh.ParsePrivateKeyWithPassphrase(conf.PrivateKey, []byte(conf.Passphrase))
+        } else {
+            signer, err = cryptobin_ssh.ParsePrivateKey(conf.PrivateKey)
+        }
+
+        if err != nil {
+            return nil, errors.New("go-filesystem: sftp parse private key fail, error: " + err.Error())
+        }
+
+        auths = append(auths, ssh.PublicKeys(signer))
+    }
+
+    if conf.Password != "" {
+        auths = append(auths, ssh.Password(conf.Password))
+    }
+
+    if len(auths) == 0 {
+        return nil, errors.New("go-filesystem: sftp password or private key is empty")
+    }
+
+    var hostKeyCallback ssh.HostKeyCallback
+    if conf.HostKey != "" {
+        hostKey, _, _, _, err := cryptobin_ssh.ParseAuthorizedKey([]byte(conf.HostKey))
+        if err != nil {
+            return nil, errors.New("go-filesystem: sftp parse host key fail, error: " + err.Error())
+        }
+
+        hostKeyCallback = ssh.FixedHostKey(hostKey)
+    } else if conf.InsecureIgnoreHostKey {
+        hostKeyCallback = ssh.InsecureIgnoreHostKey()
+    } else {
+        return nil, errors.New("go-filesystem: sftp host key is empty")
+    }
+
+    return &ssh.ClientConfig{
+        User:            conf.Username,
+        Auth:            auths,
+        HostKeyCallback: hostKeyCallback,
+        Timeout:         conf.Timeout,
+    }, nil
+}
+
+// 关闭连接池
+func (this *Sftp) Close() error {
+    return this.pool.Close()
+}
+
+// 判断是否存在
+func (this *Sftp) Has(path string) bool {
+    location := this.ApplyPathPrefix(path)
+
+    err := this.do(func(c *client) error {
+        _, err := c.Stat(location)
+        return err
+    })
+
+    return err == nil
+}
+
+// 上传
+func (this *Sftp) Write(path string, contents []byte, conf interfaces.Config) (map[string]any, error) {
+    result, err := this.WriteStream(path, bytes.NewReader(contents), conf)
+    if err != nil {
+        return nil, err
+    }
+
+    result["contents"] = contents
+
+    return result, nil
+}
+
+// 上传 Stream 文件类型
+func (this *Sftp) WriteStream(path string, stream io.Reader, conf interfaces.Config) (map[string]any, error) {
+    location := this.ApplyPathPrefix(path)
+
+    visibility := this.visibility(conf)
+
+    var size int64
+    err := this.do(func(c *client) error {
+        if err := this.ensureDirectory(c, filepath.ToSlash(filepath.Dir(location))); err != nil {
+            return err
+        }
+
+        var err error
+        size, err = c.WriteFile(location, stream)
+        if err != nil {
+            return err
+        }
+
+        // 服务端创建文件时可能受 umask 影响
+        return c.Chmod(location, os.FileMode(permissionMap["file"][visibility]))
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    result := map[string]any{
+        "type": "file",
+        "size": size,
+        "path": path,
+    }
+
+    if conf.Get("visibility") != nil {
+        result["visibility"] = visibility
+    }
+
+    return result, nil
+}
+
+// 更新
+func (this *Sftp) Update(path string, contents []byte, conf interfaces.Config) (map[string]any, error) {
+    return this.Write(path, contents, conf)
+}
+
+// 更新
+func (this *Sftp) UpdateStream(path string, stream io.Reader, conf interfaces.Config) (map[string]any, error) {
+    return this.WriteStream(path, stream, conf)
+}
+
+// 读取
+func (this *Sftp) Read(path string) (map[string]any, error) {
+    location := this.ApplyPathPrefix(path)
+
+    var buf bytes.Buffer
+    err := this.do(func(c *client) error {
+        _, err := c.ReadFile(location, &buf, 0)
+        return err
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    return map[string]any{
+        "type":     "file",
+        "path":     path,
+        "contents": buf.Bytes(),
+    }, nil
+}
+
+// 读取成文件流
+// 数据先写入临时文件，打开文件需要手动关闭
+func (this *Sftp) ReadStream(path string) (map[string]any, error) {
+    location := this.ApplyPathPrefix(path)
+
+    stream, err := adapter.TempStream(func(w io.Writer) error {
+        return this.do(func(c *client) error {
+            _, err := c.ReadFile(location, w, 0)
+            return err
+        })
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    return map[string]any{
+        "type":   "file",
+        "path":   path,
+        "stream": stream,
+    }, nil
+}
+
+// 重命名
+func (this *Sftp) Rename(path string, newpath string) error {
+    location := this.ApplyPathPrefix(path)
+    destination := this.ApplyPathPrefix(newpath)
+
+    return this.do(func(c *client) error {
+        if err := this.ensureDirectory(c, filepath.ToSlash(filepath.Dir(destination))); err != nil {
+            return err
+        }
+
+        // SFTP v3 不能覆盖已存在的文件
+        if _, err := c.Stat(destination); err == nil {
+            if err := c.Remove(destination); err != nil {
+                return err
+            }
+        }
+
+        return c.Rename(location, destination)
+    })
+}
+
+// 复制
+// 协议不支持服务端复制，数据经本地中转
+func (this *Sftp) Copy(path string, newpath string) error {
+    location := this.ApplyPathPrefix(path)
+    destination := this.ApplyPathPrefix(newpath)
+
+    return this.do(func(c *client) error {
+        info, err := c.Stat(location)
+        if err != nil {
+            return err
+        }
+
+        if info.IsDir() {
+            return fmt.Errorf("go-filesystem: %s not right file", path)
+        }
+
+        var buf bytes.Buffer
+        if _, err := c.ReadFile(location, &buf, 0); err != nil {
+            return err
+        }
+
+        if err := this.ensureDirectory(c, filepath.ToSlash(filepath.Dir(destination))); err != nil {
+            return err
+        }
+
+        if _, err := c.WriteFile(destination, &buf); err != nil {
+            return err
+        }
+
+        return c.Chmod(destination, info.Mode().Perm())
+    })
+}
+
+// 删除
+func (this *Sftp) Delete(path string) error {
+    location := this.ApplyPathPrefix(path)
+
+    return this.do(func(c *client) error {
+        if err := c.Remove(location); err != nil {
+            return errors.New("go-filesystem: file delete fail, error: " + err.Error())
+        }
+
+        return nil
+    })
+}
+
+// 删除文件夹
+func (this *Sftp) DeleteDir(dirname string) error {
+    location := this.ApplyPathPrefix(dirname)
+
+    return this.do(func(c *client) error {
+        return this.removeAll(c, location)
+    })
+}
+
+// 创建文件夹
+func (this *Sftp) CreateDir(dirname string, conf interfaces.Config) (map[string]string, error) {
+    location := this.ApplyPathPrefix(dirname)
+
+    visibility := this.visibility(conf)
+
+    err := this.do(func(c *client) error {
+        if err := this.ensureDirectory(c, location); err != nil {
+            return err
+        }
+
+        return c.Chmod(location, os.FileMode(permissionMap["dir"][visibility]))
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    return map[string]string{
+        "path": dirname,
+        "type": "dir",
+    }, nil
+}
+
+// 列出内容
+func (this *Sftp) ListContents(directory string, recursive ...bool) ([]map[string]any, error) {
+    location := strings.TrimSuffix(this.ApplyPathPrefix(directory), "/")
+    if location == "" {
+        location = "/"
+    }
+
+    isRecursive := len(recursive) > 0 && recursive[0]
+
+    var result []map[string]any
+    err := this.do(func(c *client) error {
+        return this.walk(c, location, isRecursive, func(pathname string, info os.FileInfo) {
+            result = append(result, this.normalizeFileInfo(pathname, info))
+        })
+    })
+    if err != nil {
+        if IsNotExist(err) {
+            return []map[string]any{}, nil
+        }
+
+        return nil, err
+    }
+
+    return result, nil
+}
+
+// 文件信息
+func (this *Sftp) GetMetadata(path string) (map[string]any, error) {
+    location := this.ApplyPathPrefix(path)
+
+    var info os.FileInfo
+    err := this.do(func(c *client) error {
+        var err error
+        info, err = c.Stat(location)
+        return err
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    return this.normalizeFileInfo(location, info), nil
+}
+
+func (this *Sftp) GetSize(path string) (map[string]any, error) {
+    return this.GetMetadata(path)
+}
+
+func (this *Sftp) GetMimetype(path string) (map[string]any, error) {
+    location := this.ApplyPathPrefix(path)
+
+    var buf bytes.Buffer
+    err := this.do(func(c *client) error {
+        _, err := c.ReadFile(location, &buf, 512)
+        return err
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    return map[string]any{
+        "path":     path,
+        "type":     "file",
+        "mimetype": http.DetectContentType(buf.Bytes()),
+    }, nil
+}
+
+func (this *Sftp) GetTimestamp(path string) (map[string]any, error) {
+    return this.GetMetadata(path)
+}
+
+// 获取文件的权限
+func (this *Sftp) GetVisibility(path string) (map[string]string, error) {
+    location := this.ApplyPathPrefix(path)
+
+    var info os.FileInfo
+    err := this.do(func(c *client) error {
+        var err error
+        info, err = c.Stat(location)
+        return err
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    pathType := "file"
+    if info.IsDir() {
+        pathType = "dir"
+    }
+
+    permissions := filePerm(info)
+    for visibility, visibilityPermissions := range permissionMap[pathType] {
+        if visibilityPermissions == permissions {
+            return map[string]string{
+                "path":       path,
+                "visibility": visibility,
+            }, nil
+        }
+    }
+
+    return map[string]string{
+        "path":       path,
+        "visibility": fmt.Sprintf("%o", permissions),
+    }, nil
+}
+
+// 设置文件的权限
+func (this *Sftp) SetVisibility(path string, visibility string) (map[string]string, error) {
+    location := this.ApplyPathPrefix(path)
+
+    if visibility != "private" {
+        visibility = "public"
+    }
+
+    err := this.do(func(c *client) error {
+        info, err := c.Stat(location)
+        if err != nil {
+            return err
+        }
+
+        pathType := "file"
+        if info.IsDir() {
+            pathType = "dir"
+        }
+
+        return c.Chmod(location, os.FileMode(permissionMap[pathType][visibility]))
+    })
+    if err != nil {
+        return nil, errors.New("go-filesystem: set permission fail, error: " + err.Error())
+    }
+
+    return map[string]string{
+        "path":       path,
+        "visibility": visibility,
+    }, nil
+}
+
+// 使用连接池执行
+// 协议状态错误时连接仍可用，其他错误丢弃连接
+func (this *Sftp) do(fn func(*client) error) error {
+    return this.pool.Do(fn, func(err error) bool {
+        return !isStatusError(err)
+    })
+}
+
+// 权限
+func (this *Sftp) visibility(conf interfaces.Config) string {
+    visibility, _ := conf.Get("visibility", "public").(string)
+    if visibility != "private" {
+        visibility = "public"
+    }
+
+    return visibility
+}
+
+// 确认文件夹，逐级创建
+func (this *Sftp) ensureDirectory(c *client, dir string) error {
+    if dir == "" || dir == "/" || dir == "." {
+        return nil
+    }
+
+    if info, err := c.Stat(dir); err == nil {
+        if !info.IsDir() {
+            return fmt.Errorf("go-filesystem: %s is not dir", dir)
+        }
+
+        return nil
+    } else if !IsNotExist(err) {
+        return err
+    }
+
+    if err := this.ensureDirectory(c, filepath.ToSlash(filepath.Dir(dir))); err != nil {
+        return err
+    }
+
+    if err := c.Mkdir(dir); err != nil {
+        // 并发创建时可能已存在
+        if info, statErr := c.Stat(dir); statErr == nil && info.IsDir() {
+            return nil
+        }
+
+        return errors.New("go-filesystem: sftp mkdir fail, error: " + err.Error())
+    }
+
+    return c.Chmod(dir, os.FileMode(permissionMap["dir"]["public"]))
+}
+
+// 遍历文件夹
+func (this *Sftp) walk(c *client, dir string, recursive bool, fn func(string, os.FileInfo)) error {
+    entries, err := c.ReadDir(dir)
+    if err != nil {
+        return err
+    }
+
+    for _, entry := range entries {
+        pathname := strings.TrimSuffix(dir, "/") + "/" + entry.Name()
+
+        fn(pathname, entry)
+
+        if recursive && entry.IsDir() {
+            if err := this.walk(c, pathname, recursive, fn); err != nil {
+                return err
+            }
+        }
+    }
+
+    return nil
+}
+
+// 递归删除
+func (this *Sftp) removeAll(c *client, dir string) error {
+    entries, err := c.ReadDir(dir)
+    if err != nil {
+        return err
+    }
+
+    for _, entry := range entries {
+        pathname := strings.TrimSuffix(dir, "/") + "/" + entry.Name()
+
+        if entry.IsDir() {
+            err = this.removeAll(c, pathname)
+        } else {
+            err = c.Remove(pathname)
+        }
+
+        if err != nil {
+            return err
+        }
+    }
+
+    return c.RemoveDirectory(dir)
+}
+
+// 格式化文件信息
+func (this *Sftp) normalizeFileInfo(pathname string, info os.FileInfo) map[string]any {
+    normalized := map[string]any{
+        "type":      "file",
+        "path":      strings.Trim(this.RemovePathPrefix(pathname), "/"),
+        "timestamp": info.ModTime().Unix(),
+    }
+
+    if info.IsDir() {
+        normalized["type"] = "dir"
+    } else {
+        normalized["size"] = info.Size()
+    }
+
+    return normalized
+}
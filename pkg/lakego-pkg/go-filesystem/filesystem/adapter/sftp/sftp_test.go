@@ -0,0 +1,317 @@
+package sftp
+
+import (
+    "io"
+    "os"
+    "sort"
+    "bytes"
+    "reflect"
+    "testing"
+    "crypto/rand"
+    "crypto/ed25519"
+    "encoding/pem"
+    "path/filepath"
+    "time"
+
+    "golang.org/x/crypto/ssh"
+    cryptobin_ssh "github.com/deatil/go-cryptobin/ssh"
+
+    "github.com/deatil/go-filesystem/filesystem"
+    "github.com/deatil/go-filesystem/filesystem/config"
+    local_adapter "github.com/deatil/go-filesystem/filesystem/adapter/local"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func assertErrorT(t *testing.T) func(error, string) {
+    return func(err error, msg string) {
+        if err != nil {
+            t.Errorf("Failed %s: error: %+v", msg, err)
+        }
+    }
+}
+
+func newTestSftp(t *testing.T, root string) (*Sftp, *fakeServer) {
+    server := newFakeServer(t, "pass123", nil)
+
+    fs, err := New(Config{
+        Host:     "127.0.0.1",
+        Port:     server.port(),
+        Username: "test",
+        Password: "pass123",
+        HostKey:  string(ssh.MarshalAuthorizedKey(server.hostKey)),
+        Root:     filepath.ToSlash(filepath.Join(server.root, root)),
+        MaxOpen:  2,
+    })
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    t.Cleanup(func() {
+        fs.Close()
+    })
+
+    return fs, server
+}
+
+func emptyConf() config.Config {
+    return config.New(map[string]any{})
+}
+
+func Test_WriteAndRead(t *testing.T) {
+    assertEqual := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    fs, server := newTestSftp(t, "files")
+
+    // 超过单个数据包长度
+    data := make([]byte, 32768 * 3 + 100)
+    rand.Read(data)
+
+    res, err := fs.Write("a/b/test.bin", data, emptyConf())
+    assertError(err, "Write")
+    assertEqual(res["size"], int64(len(data)), "Write size")
+
+    local, _ := os.ReadFile(filepath.Join(server.root, "files/a/b/test.bin"))
+    assertEqual(bytes.Equal(local, data), true, "Write local")
+
+    assertEqual(fs.Has("a/b/test.bin"), true, "Has")
+    assertEqual(fs.Has("a/b"), true, "Has dir")
+    assertEqual(fs.Has("a/b/no.txt"), false, "Has not")
+
+    read, err := fs.Read("a/b/test.bin")
+    assertError(err, "Read")
+    assertEqual(bytes.Equal(read["contents"].([]byte), data), true, "Read")
+
+    stream, err := fs.ReadStream("a/b/test.bin")
+    assertError(err, "ReadStream")
+
+    f := stream["stream"].(*os.File)
+    defer f.Close()
+
+    streamData, _ := io.ReadAll(f)
+    assertEqual(bytes.Equal(streamData, data), true, "ReadStream")
+
+    meta, err := fs.GetMetadata("a/b/test.bin")
+    assertError(err, "GetMetadata")
+    assertEqual(meta["size"], int64(len(data)), "GetMetadata size")
+    assertEqual(meta["path"], "a/b/test.bin", "GetMetadata path")
+
+    fs.Write("page.html", []byte("<html><body>test</body></html>"), emptyConf())
+    mime, err := fs.GetMimetype("page.html")
+    assertError(err, "GetMimetype")
+    assertEqual(mime["mimetype"], "text/html; charset=utf-8", "GetMimetype")
+
+    _, err = fs.Read("no.txt")
+    assertEqual(IsNotExist(err), true, "Read not exists")
+
+    // 连接可复用
+    assertEqual(server.connCount() <= 2, true, "pool conns")
+}
+
+func Test_ListAndDelete(t *testing.T) {
+    assertEqual := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    fs, _ := newTestSftp(t, "")
+
+    conf := emptyConf()
+    fs.Write("a.txt", []byte("a"), conf)
+    fs.Write("sub/b.txt", []byte("bb"), conf)
+    fs.Write("sub/deep/c.txt", []byte("ccc"), conf)
+
+    list, err := fs.ListContents("")
+    assertError(err, "ListContents")
+    assertEqual(paths(list), []string{"dir:sub", "file:a.txt"}, "ListContents")
+
+    list, err = fs.ListContents("sub", true)
+    assertError(err, "ListContents recursive")
+    assertEqual(paths(list), []string{"dir:sub/deep", "file:sub/b.txt", "file:sub/deep/c.txt"}, "ListContents recursive")
+
+    list, err = fs.ListContents("no-dir")
+    assertError(err, "ListContents not exists")
+    assertEqual(len(list), 0, "ListContents not exists")
+
+    assertError(fs.Copy("sub/b.txt", "copy/b.txt"), "Copy")
+    assertError(fs.Rename("copy/b.txt", "a.txt"), "Rename overwrite")
+
+    read, _ := fs.Read("a.txt")
+    assertEqual(read["contents"], []byte("bb"), "Rename overwrite data")
+
+    assertError(fs.DeleteDir("sub"), "DeleteDir")
+    assertEqual(fs.Has("sub"), false, "DeleteDir")
+
+    assertError(fs.Delete("a.txt"), "Delete")
+    assertEqual(fs.Has("a.txt"), false, "Delete")
+}
+
+func Test_Visibility(t *testing.T) {
+    assertEqual := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    fs, server := newTestSftp(t, "")
+
+    _, err := fs.Write("private.txt", []byte("p"), config.New(map[string]any{
+        "visibility": "private",
+    }))
+    assertError(err, "Write")
+
+    info, _ := os.Stat(filepath.Join(server.root, "private.txt"))
+    assertEqual(info.Mode().Perm(), os.FileMode(0600), "Write private perm")
+
+    res, err := fs.GetVisibility("private.txt")
+    assertError(err, "GetVisibility")
+    assertEqual(res["visibility"], "private", "GetVisibility")
+
+    _, err = fs.SetVisibility("private.txt", "public")
+    assertError(err, "SetVisibility")
+
+    res, _ = fs.GetVisibility("private.txt")
+    assertEqual(res["visibility"], "public", "SetVisibility")
+
+    _, err = fs.CreateDir("secret", config.New(map[string]any{
+        "visibility": "private",
+    }))
+    assertError(err, "CreateDir")
+
+    res, _ = fs.GetVisibility("secret")
+    assertEqual(res["visibility"], "private", "CreateDir visibility")
+}
+
+func Test_PrivateKeyAuth(t *testing.T) {
+    assertEqual := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+    sshPub, _ := ssh.NewPublicKey(pub)
+
+    block, err := cryptobin_ssh.MarshalOpenSSHPrivateKeyWithPassword(rand.Reader, priv, "test", []byte("key-pass"))
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    server := newFakeServer(t, "", sshPub)
+
+    fs, err := New(Config{
+        Host:       "127.0.0.1",
+        Port:       server.port(),
+        Username:   "test",
+        PrivateKey: pem.EncodeToMemory(block),
+        Passphrase: "key-pass",
+        HostKey:    string(ssh.MarshalAuthorizedKey(server.hostKey)),
+        Root:       server.root,
+    })
+    assertError(err, "New")
+    defer fs.Close()
+
+    _, err = fs.Write("key.txt", []byte("key"), emptyConf())
+    assertError(err, "Write with key")
+
+    // 服务端公钥不匹配
+    other, _, _ := ed25519.GenerateKey(rand.Reader)
+    otherPub, _ := ssh.NewPublicKey(other)
+
+    fs2, _ := New(Config{
+        Host:       "127.0.0.1",
+        Port:       server.port(),
+        Username:   "test",
+        PrivateKey: pem.EncodeToMemory(block),
+        Passphrase: "key-pass",
+        HostKey:    string(ssh.MarshalAuthorizedKey(otherPub)),
+        Root:       server.root,
+        Timeout:    2 * time.Second,
+    })
+    defer fs2.Close()
+
+    assertEqual(fs2.Has("key.txt"), false, "host key mismatch")
+
+    _, err = New(Config{
+        Host:       "127.0.0.1",
+        PrivateKey: pem.EncodeToMemory(block),
+        Passphrase: "wrong",
+    })
+    if err == nil {
+        t.Error("New should fail with wrong passphrase")
+    }
+}
+
+func Test_HostKeyRequired(t *testing.T) {
+    assertEqual := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    server := newFakeServer(t, "pass123", nil)
+
+    _, err := New(Config{
+        Host:     "127.0.0.1",
+        Port:     server.port(),
+        Username: "test",
+        Password: "pass123",
+    })
+    if err == nil {
+        t.Fatal("New should fail without host key")
+    }
+
+    fs, err := New(Config{
+        Host:                  "127.0.0.1",
+        Port:                  server.port(),
+        Username:              "test",
+        Password:              "pass123",
+        InsecureIgnoreHostKey: true,
+        Root:                  server.root,
+    })
+    assertError(err, "New with InsecureIgnoreHostKey")
+    defer fs.Close()
+
+    _, err = fs.Write("insecure.txt", []byte("data"), emptyConf())
+    assertError(err, "Write with InsecureIgnoreHostKey")
+
+    assertEqual(fs.Has("insecure.txt"), true, "Has with InsecureIgnoreHostKey")
+}
+
+func Test_MountManager(t *testing.T) {
+    assertEqual := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    fs, _ := newTestSftp(t, "")
+
+    local := local_adapter.New(t.TempDir())
+
+    manager := filesystem.NewMountManager(map[string]any{
+        "local": filesystem.New(local),
+        "sftp":  filesystem.New(fs),
+    })
+
+    _, err := manager.Write("local://from.txt", []byte("mount-data"))
+    assertError(err, "Write")
+
+    _, err = manager.Copy("local://from.txt", "sftp://to/to.txt")
+    assertError(err, "Copy to sftp")
+
+    data, err := manager.Read("sftp://to/to.txt")
+    assertError(err, "Read")
+    assertEqual(data, []byte("mount-data"), "Read sftp")
+
+    _, err = manager.Move("sftp://to/to.txt", "local://back.txt")
+    assertError(err, "Move to local")
+
+    data, _ = manager.Read("local://back.txt")
+    assertEqual(data, []byte("mount-data"), "Read local")
+    assertEqual(manager.Has("sftp://to/to.txt"), false, "Move deleted")
+}
+
+func paths(list []map[string]any) []string {
+    res := make([]string, 0, len(list))
+    for _, item := range list {
+        res = append(res, item["type"].(string) + ":" + item["path"].(string))
+    }
+
+    sort.Strings(res)
+
+    return res
+}
@@ -0,0 +1,31 @@
+package adapter
+
+import (
+    "io"
+    "os"
+    "errors"
+)
+
+// 生成临时文件流
+// 用于非本地适配器的 ReadStream，数据写入临时文件后从头读取
+// 文件打开后即删除，关闭后由系统回收
+func TempStream(write func(io.Writer) error) (*os.File, error) {
+    stream, err := os.CreateTemp("", "go-filesystem-*")
+    if err != nil {
+        return nil, errors.New("go-filesystem: exec os.CreateTemp() fail, error: " + err.Error())
+    }
+
+    os.Remove(stream.Name())
+
+    if err := write(stream); err != nil {
+        stream.Close()
+        return nil, err
+    }
+
+    if _, err := stream.Seek(0, io.SeekStart); err != nil {
+        stream.Close()
+        return nil, err
+    }
+
+    return stream, nil
+}
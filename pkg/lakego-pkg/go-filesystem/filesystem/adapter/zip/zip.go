@@ -0,0 +1,781 @@
+package zip
+
+import (
+    "io"
+    "os"
+    "fmt"
+    "sort"
+    "sync"
+    "bytes"
+    "errors"
+    "strings"
+    "net/http"
+    "path"
+    "path/filepath"
+    "time"
+    gozip "archive/zip"
+
+    "github.com/deatil/go-filesystem/filesystem/adapter"
+    "github.com/deatil/go-filesystem/filesystem/interfaces"
+)
+
+// 权限列表
+var permissionMap = map[string]map[string]os.FileMode{
+    "file": {
+        "public":  0644,
+        "private": 0600,
+    },
+    "dir": {
+        "public":  0755,
+        "private": 0700,
+    },
+}
+
+// 压缩包条目
+type entry struct {
+    // 原压缩包中的文件，未修改时直接复制原始数据
+    src *gozip.File
+
+    // 新写入的数据
+    contents []byte
+
+    // 是否为文件夹
+    isDir bool
+
+    // 权限
+    visibility string
+
+    // 修改时间
+    modified time.Time
+}
+
+/**
+ * zip 压缩包适配器 / Zip archive adapter
+ * 可读取已有压缩包或者生成新的压缩包，修改在 Save 后写入文件
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Zip struct {
+    // 默认适配器基类
+    adapter.Adapter
+
+    // 锁
+    mu sync.RWMutex
+
+    // 压缩包路径
+    path string
+
+    // 已打开的压缩包
+    reader *gozip.ReadCloser
+
+    // 条目列表
+    entries map[string]*entry
+
+    // 是否有修改
+    changed bool
+
+    // 当前时间，测试时可替换
+    now func() time.Time
+}
+
+// 新建压缩包，Save 时覆盖已存在的文件
+func New(path string) *Zip {
+    return &Zip{
+        path:    path,
+        entries: make(map[string]*entry),
+        now:     time.Now,
+    }
+}
+
+// 打开已有压缩包
+func Open(path string) (*Zip, error) {
+    fs := New(path)
+
+    if err := fs.load(); err != nil {
+        return nil, err
+    }
+
+    return fs, nil
+}
+
+// 判断是否存在
+func (this *Zip) Has(path string) bool {
+    location := this.location(path)
+
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    if location == "" {
+        return true
+    }
+
+    _, ok := this.entries[location]
+    return ok
+}
+
+// 上传
+func (this *Zip) Write(path string, contents []byte, conf interfaces.Config) (map[string]any, error) {
+    location := this.location(path)
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if e, ok := this.entries[location]; (ok && e.isDir) || location == "" {
+        return nil, fmt.Errorf("go-filesystem: %s is dir", path)
+    }
+
+    if err := this.ensureDirectory(parentDir(location)); err != nil {
+        return nil, err
+    }
+
+    visibility := "public"
+    if old, ok := this.entries[location]; ok {
+        visibility = old.visibility
+    }
+    if v, ok := conf.Get("visibility").(string); ok {
+        visibility = formatVisibility(v)
+    }
+
+    this.entries[location] = &entry{
+        contents:   append([]byte{}, contents...),
+        visibility: visibility,
+        modified:   this.now(),
+    }
+    this.changed = true
+
+    result := map[string]any{
+        "type":     "file",
+        "size":     int64(len(contents)),
+        "path":     path,
+        "contents": contents,
+    }
+
+    if conf.Get("visibility") != nil {
+        result["visibility"] = visibility
+    }
+
+    return result, nil
+}
+
+// 上传 Stream 文件类型
+func (this *Zip) WriteStream(path string, stream io.Reader, conf interfaces.Config) (map[string]any, error) {
+    contents, err := io.ReadAll(stream)
+    if err != nil {
+        return nil, fmt.Errorf("go-filesystem: write stream fail, error: %w", err)
+    }
+
+    result, err := this.Write(path, contents, conf)
+    if err != nil {
+        return nil, err
+    }
+
+    delete(result, "contents")
+
+    return result, nil
+}
+
+// 更新
+func (this *Zip) Update(path string, contents []byte, conf interfaces.Config) (map[string]any, error) {
+    return this.Write(path, contents, conf)
+}
+
+// 更新
+func (this *Zip) UpdateStream(path string, stream io.Reader, conf interfaces.Config) (map[string]any, error) {
+    return this.WriteStream(path, stream, conf)
+}
+
+// 读取
+func (this *Zip) Read(path string) (map[string]any, error) {
+    contents, err := this.contents(path)
+    if err != nil {
+        return nil, err
+    }
+
+    return map[string]any{
+        "type":     "file",
+        "path":     path,
+        "contents": contents,
+    }, nil
+}
+
+// 读取成文件流
+// 数据先解压到临时文件，打开文件需要手动关闭
+func (this *Zip) ReadStream(path string) (map[string]any, error) {
+    e, err := this.file(path)
+    if err != nil {
+        return nil, err
+    }
+
+    stream, err := adapter.TempStream(func(w io.Writer) error {
+        if e.src == nil {
+            _, err := w.Write(e.contents)
+            return err
+        }
+
+        rc, err := e.src.Open()
+        if err != nil {
+            return err
+        }
+        defer rc.Close()
+
+        _, err = io.Copy(w, rc)
+        return err
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    return map[string]any{
+        "type":   "file",
+        "path":   path,
+        "stream": stream,
+    }, nil
+}
+
+// 重命名
+func (this *Zip) Rename(path string, newpath string) error {
+    location := this.location(path)
+    destination := this.location(newpath)
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    e, ok := this.entries[location]
+    if !ok || e.isDir {
+        return notExist(path)
+    }
+
+    if err := this.ensureDirectory(parentDir(destination)); err != nil {
+        return err
+    }
+
+    delete(this.entries, location)
+    this.entries[destination] = e
+    this.changed = true
+
+    return nil
+}
+
+// 复制
+func (this *Zip) Copy(path string, newpath string) error {
+    location := this.location(path)
+    destination := this.location(newpath)
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    e, ok := this.entries[location]
+    if !ok || e.isDir {
+        return notExist(path)
+    }
+
+    if err := this.ensureDirectory(parentDir(destination)); err != nil {
+        return err
+    }
+
+    copied := *e
+    copied.modified = this.now()
+
+    this.entries[destination] = &copied
+    this.changed = true
+
+    return nil
+}
+
+// 删除
+func (this *Zip) Delete(path string) error {
+    location := this.location(path)
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    e, ok := this.entries[location]
+    if !ok || e.isDir {
+        return notExist(path)
+    }
+
+    delete(this.entries, location)
+    this.changed = true
+
+    return nil
+}
+
+// 删除文件夹
+func (this *Zip) DeleteDir(dirname string) error {
+    location := this.location(dirname)
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    e, ok := this.entries[location]
+    if !ok || !e.isDir {
+        return notExist(dirname)
+    }
+
+    prefix := location + "/"
+    for name := range this.entries {
+        if name == location || strings.HasPrefix(name, prefix) {
+            delete(this.entries, name)
+        }
+    }
+
+    this.changed = true
+
+    return nil
+}
+
+// 创建文件夹
+func (this *Zip) CreateDir(dirname string, conf interfaces.Config) (map[string]string, error) {
+    location := this.location(dirname)
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if err := this.ensureDirectory(location); err != nil {
+        return nil, err
+    }
+
+    if v, ok := conf.Get("visibility").(string); ok && location != "" {
+        this.entries[location].visibility = formatVisibility(v)
+        this.changed = true
+    }
+
+    return map[string]string{
+        "path": dirname,
+        "type": "dir",
+    }, nil
+}
+
+// 列出内容
+func (this *Zip) ListContents(directory string, recursive ...bool) ([]map[string]any, error) {
+    location := this.location(directory)
+
+    isRecursive := len(recursive) > 0 && recursive[0]
+
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    prefix := ""
+    if location != "" {
+        prefix = location + "/"
+    }
+
+    var result []map[string]any
+    for name, e := range this.entries {
+        if !strings.HasPrefix(name, prefix) || name == location {
+            continue
+        }
+
+        if !isRecursive && strings.Contains(strings.TrimPrefix(name, prefix), "/") {
+            continue
+        }
+
+        result = append(result, this.metadata(name, e))
+    }
+
+    sort.Slice(result, func(i, j int) bool {
+        return result[i]["path"].(string) < result[j]["path"].(string)
+    })
+
+    return result, nil
+}
+
+// 文件信息
+func (this *Zip) GetMetadata(path string) (map[string]any, error) {
+    location := this.location(path)
+
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    e, ok := this.entries[location]
+    if !ok {
+        return nil, notExist(path)
+    }
+
+    return this.metadata(location, e), nil
+}
+
+func (this *Zip) GetSize(path string) (map[string]any, error) {
+    return this.GetMetadata(path)
+}
+
+func (this *Zip) GetMimetype(path string) (map[string]any, error) {
+    contents, err := this.contents(path)
+    if err != nil {
+        return nil, err
+    }
+
+    return map[string]any{
+        "path":     path,
+        "type":     "file",
+        "mimetype": http.DetectContentType(contents),
+    }, nil
+}
+
+func (this *Zip) GetTimestamp(path string) (map[string]any, error) {
+    return this.GetMetadata(path)
+}
+
+// 获取文件的权限
+func (this *Zip) GetVisibility(path string) (map[string]string, error) {
+    location := this.location(path)
+
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    e, ok := this.entries[location]
+    if !ok {
+        return nil, notExist(path)
+    }
+
+    return map[string]string{
+        "path":       path,
+        "visibility": e.visibility,
+    }, nil
+}
+
+// 设置文件的权限
+func (this *Zip) SetVisibility(path string, visibility string) (map[string]string, error) {
+    location := this.location(path)
+
+    visibility = formatVisibility(visibility)
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    e, ok := this.entries[location]
+    if !ok {
+        return nil, notExist(path)
+    }
+
+    e.visibility = visibility
+    this.changed = true
+
+    return map[string]string{
+        "path":       path,
+        "visibility": visibility,
+    }, nil
+}
+
+// 压缩包路径
+func (this *Zip) Path() string {
+    return this.path
+}
+
+// 保存修改到压缩包文件
+func (this *Zip) Save() error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if !this.changed && this.reader != nil {
+        return nil
+    }
+
+    tmp, err := os.CreateTemp(filepath.Dir(this.path), filepath.Base(this.path) + ".*.tmp")
+    if err != nil {
+        return errors.New("go-filesystem: exec os.CreateTemp() fail, error: " + err.Error())
+    }
+    defer os.Remove(tmp.Name())
+
+    if err := this.writeTo(tmp); err != nil {
+        tmp.Close()
+        return err
+    }
+
+    if err := tmp.Close(); err != nil {
+        return err
+    }
+
+    if this.reader != nil {
+        this.reader.Close()
+        this.reader = nil
+    }
+
+    if err := os.Rename(tmp.Name(), this.path); err != nil {
+        return errors.New("go-filesystem: exec os.Rename() fail, error: " + err.Error())
+    }
+
+    return this.reload()
+}
+
+// 将压缩包写入到 w，不修改压缩包文件
+func (this *Zip) SaveTo(w io.Writer) error {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    return this.writeTo(w)
+}
+
+// 保存并关闭
+func (this *Zip) Close() error {
+    if err := this.Save(); err != nil {
+        return err
+    }
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if this.reader != nil {
+        err := this.reader.Close()
+        this.reader = nil
+
+        return err
+    }
+
+    return nil
+}
+
+// 写入压缩数据
+func (this *Zip) writeTo(w io.Writer) error {
+    names := make([]string, 0, len(this.entries))
+    for name := range this.entries {
+        names = append(names, name)
+    }
+
+    sort.Strings(names)
+
+    zw := gozip.NewWriter(w)
+
+    for _, name := range names {
+        if err := this.writeEntry(zw, name, this.entries[name]); err != nil {
+            return fmt.Errorf("go-filesystem: write zip entry %s fail, error: %w", name, err)
+        }
+    }
+
+    return zw.Close()
+}
+
+// 写入单个条目
+func (this *Zip) writeEntry(zw *gozip.Writer, name string, e *entry) error {
+    typ := "file"
+    if e.isDir {
+        typ = "dir"
+    }
+
+    mode := permissionMap[typ][e.visibility]
+    if e.isDir {
+        mode |= os.ModeDir
+    }
+
+    // 未修改的文件直接复制压缩后的数据
+    if e.src != nil {
+        header := e.src.FileHeader
+        header.Name = name
+        header.SetMode(mode)
+
+        raw, err := e.src.OpenRaw()
+        if err != nil {
+            return err
+        }
+
+        fw, err := zw.CreateRaw(&header)
+        if err != nil {
+            return err
+        }
+
+        _, err = io.Copy(fw, raw)
+        return err
+    }
+
+    header := &gozip.FileHeader{
+        Name:     name,
+        Method:   gozip.Deflate,
+        Modified: e.modified,
+    }
+    if e.isDir {
+        header.Name += "/"
+        header.Method = gozip.Store
+    }
+
+    header.SetMode(mode)
+
+    fw, err := zw.CreateHeader(header)
+    if err != nil {
+        return err
+    }
+
+    if !e.isDir {
+        _, err = fw.Write(e.contents)
+    }
+
+    return err
+}
+
+// 读取压缩包
+func (this *Zip) load() error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    return this.reload()
+}
+
+// 重新读取压缩包，需在加锁后调用
+func (this *Zip) reload() error {
+    reader, err := gozip.OpenReader(this.path)
+    if err != nil {
+        return errors.New("go-filesystem: open zip fail, error: " + err.Error())
+    }
+
+    entries := make(map[string]*entry)
+    for _, f := range reader.File {
+        name := strings.Trim(path.Clean("/" + f.Name), "/")
+        if name == "" {
+            continue
+        }
+
+        e := &entry{
+            src:        f,
+            isDir:      strings.HasSuffix(f.Name, "/") || f.FileInfo().IsDir(),
+            visibility: visibilityFromMode(f.Mode()),
+            modified:   f.Modified,
+        }
+
+        if e.isDir {
+            e.src = nil
+        }
+
+        entries[name] = e
+    }
+
+    this.reader = reader
+    this.entries = entries
+
+    // 补全隐含的上级文件夹
+    for name := range entries {
+        this.ensureDirectory(parentDir(name))
+    }
+
+    this.changed = false
+
+    return nil
+}
+
+// 获取文件条目
+func (this *Zip) file(path string) (*entry, error) {
+    location := this.location(path)
+
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    e, ok := this.entries[location]
+    if !ok || e.isDir {
+        return nil, notExist(path)
+    }
+
+    return e, nil
+}
+
+// 获取文件内容
+func (this *Zip) contents(path string) ([]byte, error) {
+    e, err := this.file(path)
+    if err != nil {
+        return nil, err
+    }
+
+    if e.src == nil {
+        return append([]byte{}, e.contents...), nil
+    }
+
+    rc, err := e.src.Open()
+    if err != nil {
+        return nil, fmt.Errorf("go-filesystem: open zip entry %s fail, error: %w", path, err)
+    }
+    defer rc.Close()
+
+    var buf bytes.Buffer
+    if _, err := io.Copy(&buf, rc); err != nil {
+        return nil, fmt.Errorf("go-filesystem: read zip entry %s fail, error: %w", path, err)
+    }
+
+    return buf.Bytes(), nil
+}
+
+// 文件信息
+func (this *Zip) metadata(name string, e *entry) map[string]any {
+    if e.isDir {
+        return map[string]any{
+            "type":      "dir",
+            "path":      name,
+            "timestamp": e.modified.Unix(),
+        }
+    }
+
+    size := int64(len(e.contents))
+    if e.src != nil {
+        size = int64(e.src.UncompressedSize64)
+    }
+
+    return map[string]any{
+        "type":      "file",
+        "path":      name,
+        "timestamp": e.modified.Unix(),
+        "size":      size,
+    }
+}
+
+// 逐级创建文件夹，需在加锁后调用
+func (this *Zip) ensureDirectory(location string) error {
+    if location == "" {
+        return nil
+    }
+
+    if e, ok := this.entries[location]; ok {
+        if !e.isDir {
+            return fmt.Errorf("go-filesystem: %s is not dir", location)
+        }
+
+        return nil
+    }
+
+    if err := this.ensureDirectory(parentDir(location)); err != nil {
+        return err
+    }
+
+    this.entries[location] = &entry{
+        isDir:      true,
+        visibility: "public",
+        modified:   this.now(),
+    }
+    this.changed = true
+
+    return nil
+}
+
+// 存储路径
+func (this *Zip) location(p string) string {
+    return strings.Trim(path.Clean("/" + p), "/")
+}
+
+// 上级目录
+func parentDir(location string) string {
+    parent := path.Dir(location)
+    if parent == "." || parent == "/" {
+        return ""
+    }
+
+    return parent
+}
+
+// 权限
+func formatVisibility(visibility string) string {
+    if visibility != "private" {
+        return "public"
+    }
+
+    return visibility
+}
+
+// 根据文件权限获取可见性
+func visibilityFromMode(mode os.FileMode) string {
+    if mode.Perm() & 0044 == 0 && mode.Perm() != 0 {
+        return "private"
+    }
+
+    return "public"
+}
+
+// 文件不存在
+func notExist(path string) error {
+    return fmt.Errorf("go-filesystem: %s %w", path, os.ErrNotExist)
+}
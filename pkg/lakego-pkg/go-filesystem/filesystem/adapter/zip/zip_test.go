@@ -0,0 +1,202 @@
+package zip
+
+import (
+    "io"
+    "os"
+    "bytes"
+    "errors"
+    "reflect"
+    "testing"
+    "path/filepath"
+    gozip "archive/zip"
+
+    "github.com/deatil/go-filesystem/filesystem/config"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func assertErrorT(t *testing.T) func(error, string) {
+    return func(err error, msg string) {
+        if err != nil {
+            t.Errorf("Failed %s: error: %+v", msg, err)
+        }
+    }
+}
+
+func emptyConf() config.Config {
+    return config.New(map[string]any{})
+}
+
+// 生成测试压缩包
+func writeTestZip(t *testing.T, name string, files map[string]string) {
+    f, err := os.Create(name)
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer f.Close()
+
+    zw := gozip.NewWriter(f)
+    for k, v := range files {
+        w, _ := zw.Create(k)
+        w.Write([]byte(v))
+    }
+
+    if err := zw.Close(); err != nil {
+        t.Fatal(err)
+    }
+}
+
+func Test_OpenExisting(t *testing.T) {
+    assertEqual := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    name := filepath.Join(t.TempDir(), "test.zip")
+    writeTestZip(t, name, map[string]string{
+        "a.txt":          "aaa",
+        "sub/deep/b.txt": "bbbb",
+        "../evil.txt":    "evil",
+    })
+
+    fs, err := Open(name)
+    assertError(err, "Open")
+    defer fs.Close()
+
+    assertEqual(fs.Has("sub/deep"), true, "Has implicit dir")
+    assertEqual(fs.Has("evil.txt"), true, "Has cleaned path")
+
+    read, err := fs.Read("sub/deep/b.txt")
+    assertError(err, "Read")
+    assertEqual(read["contents"], []byte("bbbb"), "Read")
+
+    stream, err := fs.ReadStream("a.txt")
+    assertError(err, "ReadStream")
+
+    f := stream["stream"].(*os.File)
+    streamData, _ := io.ReadAll(f)
+    f.Close()
+    assertEqual(streamData, []byte("aaa"), "ReadStream")
+
+    meta, err := fs.GetMetadata("sub/deep/b.txt")
+    assertError(err, "GetMetadata")
+    assertEqual(meta["size"], int64(4), "GetMetadata size")
+
+    list, err := fs.ListContents("")
+    assertError(err, "ListContents")
+    assertEqual(paths(list), []string{"file:a.txt", "file:evil.txt", "dir:sub"}, "ListContents")
+
+    list, err = fs.ListContents("sub", true)
+    assertError(err, "ListContents recursive")
+    assertEqual(paths(list), []string{"dir:sub/deep", "file:sub/deep/b.txt"}, "ListContents recursive")
+
+    _, err = fs.Read("no.txt")
+    assertEqual(errors.Is(err, os.ErrNotExist), true, "Read not exists")
+
+    _, err = Open(filepath.Join(t.TempDir(), "no.zip"))
+    if err == nil {
+        t.Error("Open not exists should fail")
+    }
+}
+
+func Test_ModifyAndSave(t *testing.T) {
+    assertEqual := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    name := filepath.Join(t.TempDir(), "test.zip")
+    writeTestZip(t, name, map[string]string{
+        "a.txt":     "aaa",
+        "sub/b.txt": "bbbb",
+    })
+
+    fs, err := Open(name)
+    assertError(err, "Open")
+
+    assertError(fs.Rename("a.txt", "moved/a.txt"), "Rename")
+    assertError(fs.Copy("sub/b.txt", "copy.txt"), "Copy")
+    assertError(fs.Delete("sub/b.txt"), "Delete")
+
+    _, err = fs.Write("new.txt", []byte("new"), config.New(map[string]any{
+        "visibility": "private",
+    }))
+    assertError(err, "Write")
+
+    // 保存前不修改文件
+    old, _ := gozip.OpenReader(name)
+    assertEqual(len(old.File), 2, "before Save")
+    old.Close()
+
+    assertError(fs.Close(), "Close")
+
+    fs, err = Open(name)
+    assertError(err, "Open saved")
+    defer fs.Close()
+
+    list, _ := fs.ListContents("", true)
+    assertEqual(paths(list), []string{
+        "file:copy.txt",
+        "dir:moved",
+        "file:moved/a.txt",
+        "file:new.txt",
+        "dir:sub",
+    }, "ListContents saved")
+
+    read, _ := fs.Read("moved/a.txt")
+    assertEqual(read["contents"], []byte("aaa"), "Rename data")
+
+    read, _ = fs.Read("copy.txt")
+    assertEqual(read["contents"], []byte("bbbb"), "Copy data")
+
+    res, _ := fs.GetVisibility("new.txt")
+    assertEqual(res["visibility"], "private", "visibility saved")
+
+    res, _ = fs.GetVisibility("copy.txt")
+    assertEqual(res["visibility"], "public", "visibility default")
+}
+
+func Test_NewAndSaveTo(t *testing.T) {
+    assertEqual := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    fs := New(filepath.Join(t.TempDir(), "new.zip"))
+
+    fs.Write("dir/a.txt", []byte("a-data"), emptyConf())
+    fs.CreateDir("empty", emptyConf())
+
+    var buf bytes.Buffer
+    assertError(fs.SaveTo(&buf), "SaveTo")
+
+    zr, err := gozip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+    assertError(err, "NewReader")
+
+    names := make([]string, 0)
+    for _, f := range zr.File {
+        names = append(names, f.Name)
+    }
+    assertEqual(names, []string{"dir/", "dir/a.txt", "empty/"}, "SaveTo entries")
+
+    _, err = os.Stat(fs.Path())
+    assertEqual(errors.Is(err, os.ErrNotExist), true, "SaveTo no file")
+
+    assertError(fs.Save(), "Save")
+
+    _, err = os.Stat(fs.Path())
+    assertError(err, "Save file")
+
+    read, err := fs.Read("dir/a.txt")
+    assertError(err, "Read after Save")
+    assertEqual(read["contents"], []byte("a-data"), "Read after Save")
+}
+
+func paths(list []map[string]any) []string {
+    res := make([]string, 0, len(list))
+    for _, item := range list {
+        res = append(res, item["type"].(string) + ":" + item["path"].(string))
+    }
+
+    return res
+}
@@ -2,3 +2,14 @@ module github.com/deatil/go-filesystem
 
 go 1.20
 
+require (
+	github.com/deatil/go-cryptobin v1.0.2042
+	github.com/pkg/sftp v1.13.9
+	golang.org/x/crypto v0.31.0
+)
+
+require (
+	github.com/kr/fs v0.1.0 // indirect
+	golang.org/x/sys v0.28.0 // indirect
+	golang.org/x/text v0.21.0 // indirect
+)
@@ -7,6 +7,7 @@ require (
 	github.com/deatil/go-goch v0.0.3
 	github.com/deatil/go-hash v0.0.3
 	github.com/deatil/go-cryptobin v0.0.3
+	github.com/deatil/go-encoding v1.0.2003
 	github.com/deatil/go-filesystem v0.0.3
 	github.com/deatil/go-cmd v0.0.3 // indirect
 	github.com/deatil/go-validator v0.0.3 // indirect
@@ -48,8 +49,10 @@ require (
 	github.com/tebeka/strftime v0.1.5 // indirect
 	github.com/robfig/cron/v3 v3.0.1 // indirect
 	go.uber.org/dig v1.16.1
+	github.com/alicebob/miniredis/v2 v2.30.0
 	golang.org/x/crypto v0.24.0 // indirect
 	golang.org/x/sys v0.21.0 // indirect
 	golang.org/x/term v0.21.0 // indirect
 	golang.org/x/text v0.16.0
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -2,14 +2,12 @@ package app
 
 import (
     "os"
-    "os/signal"
     "net"
     "net/http"
     "fmt"
     "log"
     "sync"
     "errors"
-    "context"
     "reflect"
 
     "github.com/deatil/go-datebin/datebin"
@@ -22,7 +20,9 @@ import (
     "github.com/deatil/lakego-doak/lakego/command"
     "github.com/deatil/lakego-doak/lakego/schedule"
     "github.com/deatil/lakego-doak/lakego/facade"
+    "github.com/deatil/lakego-doak/lakego/kernel/shutdown"
     "github.com/deatil/lakego-doak/lakego/middleware/recovery"
+    "github.com/deatil/lakego-doak/lakego/middleware/requestid"
     iprovider "github.com/deatil/lakego-doak/lakego/provider/interfaces"
 )
 
@@ -92,6 +92,7 @@ func New() *App {
     }
 
     // 计划任务
+    schedule.SetLogPrintf(facade.Logger.Errorf)
     scheduler := schedule.New().SetShowLogInfo(dev)
 
     return &App{
@@ -395,6 +396,20 @@ func (this *App) runApp() {
         }
     }
 
+    // 信任的代理，只读取信任代理传入的 X-Forwarded-For 等请求头
+    if err := r.SetTrustedProxies(serverConf.GetStringSlice("trusted-proxies")); err != nil {
+        log.Println("Trusted proxies:", err)
+
+        // 配置错误时不信任任何代理
+        r.SetTrustedProxies(nil)
+    }
+
+    // 请求 ID
+    r.Use(requestid.Handler(
+        serverConf.GetString("request-id.header"),
+        serverConf.GetBool("request-id.trust-header"),
+    ))
+
     // 全局中间件
     r.Use(recovery.Handler())
 
@@ -508,18 +523,20 @@ func (this *App) serverRun() {
             err = this.route.RunFd(fd)
 
         case "net-listener":
-            if this.netListener != nil {
-                err = this.route.RunListener(this.netListener)
-            } else {
+            listener := this.netListener
+            if listener == nil {
                 // 监听
                 typ := conf.GetString("types.net-listener.type")
                 addr := conf.GetString("types.net-listener.addr")
 
-                netListener, _ := net.Listen(typ, addr)
-
-                err = this.route.RunListener(netListener)
+                listener, err = net.Listen(typ, addr)
+                if err != nil {
+                    break
+                }
             }
 
+            this.graceServe(listener)
+
         default:
             err = errors.New("服务启动错误")
     }
@@ -531,36 +548,59 @@ func (this *App) serverRun() {
 
 // 优雅地关机
 func (this *App) graceRun(address string) {
+    // 重启后使用父进程传递的监听
+    listener := this.netListener
+    if listener == nil {
+        var err error
+        listener, err = net.Listen("tcp", address)
+        if err != nil {
+            log.Fatalf("listen: %s\n", err)
+        }
+    }
+
+    this.graceServe(listener)
+}
+
+// 使用监听运行服务，收到关闭信号后返回，收到重启信号时启动新进程接管监听
+func (this *App) graceServe(listener net.Listener) {
     conf := this.config
 
     srv := &http.Server{
-        Addr:           address,
         Handler:        this.route,
         ReadTimeout:    conf.GetDuration("types.http.grace-read-timeout"),
         WriteTimeout:   conf.GetDuration("types.http.grace-write-timeout"),
         MaxHeaderBytes: 1 << 20,
     }
 
+    // 重启启动时，开始接收连接后通知父进程关闭
+    serveListener, err := shutdown.ReadyListener(listener)
+    if err != nil {
+        log.Println("Ready listener:", err)
+    }
+
     go func() {
         // 服务连接
-        if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+        if err := srv.Serve(serveListener); err != nil && err != http.ErrServerClosed {
             log.Fatalf("listen: %s\n", err)
         }
     }()
 
-    // 等待中断信号以优雅地关闭服务器（设置 5 秒的超时时间）
-    quit := make(chan os.Signal)
-    signal.Notify(quit, os.Interrupt)
-    <-quit
-    log.Println("Shutdown Server ...")
+    // 关闭时先停止接收请求，等待处理中的请求完成
+    shutdown.Register("http", shutdown.PriorityHTTP, conf.GetDuration("types.http.grace-timeout"), srv.Shutdown)
 
-    ctx, cancel := context.WithTimeout(context.Background(), conf.GetDuration("types.http.grace-timeout"))
-    defer cancel()
-    if err := srv.Shutdown(ctx); err != nil {
-        log.Fatal("Server Shutdown:", err)
+    // 等待中断信号，其余关闭操作由 kernel 按顺序执行
+    for shutdown.Wait() {
+        // 新进程启动完成后再关闭当前进程
+        if _, err := shutdown.Restart(listener, conf.GetDuration("types.http.grace-restart-timeout")); err != nil {
+            log.Println("Restart Server:", err)
+            continue
+        }
+
+        log.Println("Restart Server ...")
+        break
     }
 
-    log.Println("Server exiting")
+    log.Println("Shutdown Server ...")
 }
 
 /**
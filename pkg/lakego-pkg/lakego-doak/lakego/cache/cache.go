@@ -3,6 +3,7 @@ package cache
 import (
     "fmt"
     "time"
+    "context"
 
     "github.com/deatil/go-goch/goch"
 
@@ -30,12 +31,23 @@ type Cache struct {
 
     // 驱动
     driver interfaces.Driver
+
+    // 合并并发的 Remember 调用
+    group *singleflight
+
+    // 编码器
+    codec Codec
+
+    // 上下文，用于链路追踪
+    ctx context.Context
 }
 
 // 创建
 func New(driver interfaces.Driver, conf ...Config) *Cache {
     cache := &Cache{
         driver: driver,
+        group:  &singleflight{},
+        codec:  JSONCodec{},
     }
 
     if len(conf) > 0{
@@ -69,6 +81,18 @@ func (this *Cache) GetConfig(name string) goch.Goch {
     return array.ArrayGet(this.config, name)
 }
 
+// 设置编码器
+func (this *Cache) WithCodec(codec Codec) *Cache {
+    this.codec = codec
+
+    return this
+}
+
+// 获取编码器
+func (this *Cache) GetCodec() Codec {
+    return this.codec
+}
+
 // 设置前缀
 func (this *Cache) WithPrefix(prefix string) *Cache {
     this.prefix = prefix
@@ -85,14 +109,23 @@ func (this *Cache) GetPrefix() string {
 func (this *Cache) Has(key string) bool {
     key = this.wrapperKey(key)
 
+    span := this.startSpan("has", key)
+    defer span.End()
+
     return this.driver.Exists(key)
 }
 
-// 获取
+// 获取，未命中时返回 MissError
 func (this *Cache) Get(key string) (any, error) {
-    key = this.wrapperKey(key)
+    span := this.startSpan("get", this.wrapperKey(key))
 
-    return this.driver.Get(key)
+    val, err := this.driver.Get(this.wrapperKey(key))
+    this.recordHit(err)
+
+    span.SetAttribute("cache.hit", err == nil)
+    this.endSpan(span, err)
+
+    return val, wrapMiss(key, err)
 }
 
 // 设置
@@ -101,14 +134,24 @@ func (this *Cache) Put(key string, value any, ttl any) error {
 
     expiration := this.formatTime(ttl)
 
-    return this.driver.Put(key, value, expiration)
+    span := this.startSpan("put", key)
+
+    err := this.driver.Put(key, value, expiration)
+    this.endSpan(span, err)
+
+    return err
 }
 
 // 永久设置
 func (this *Cache) Forever(key string, value any) error {
     key = this.wrapperKey(key)
 
-    return this.driver.Forever(key, value)
+    span := this.startSpan("forever", key)
+
+    err := this.driver.Forever(key, value)
+    this.endSpan(span, err)
+
+    return err
 }
 
 // 获取后删除
@@ -116,14 +159,19 @@ func (this *Cache) Pull(key string) (any, error) {
     var val any
     var err error
 
-    key = this.wrapperKey(key)
+    span := this.startSpan("pull", this.wrapperKey(key))
+
+    val, err = this.driver.Get(this.wrapperKey(key))
+    this.recordHit(err)
+
+    span.SetAttribute("cache.hit", err == nil)
+    this.endSpan(span, err)
 
-    val, err = this.driver.Get(key)
     if err != nil {
-        return val, err
+        return val, wrapMiss(key, err)
     }
 
-    this.driver.Forget(key)
+    this.driver.Forget(this.wrapperKey(key))
 
     return val, nil
 }
@@ -132,26 +180,46 @@ func (this *Cache) Pull(key string) (any, error) {
 func (this *Cache) Increment(key string, value ...int64) error {
     key = this.wrapperKey(key)
 
-    return this.driver.Increment(key, value...)
+    span := this.startSpan("increment", key)
+
+    err := this.driver.Increment(key, value...)
+    this.endSpan(span, err)
+
+    return err
 }
 
 // 减去一
 func (this *Cache) Decrement(key string, value ...int64) error {
     key = this.wrapperKey(key)
 
-    return this.driver.Decrement(key, value...)
+    span := this.startSpan("decrement", key)
+
+    err := this.driver.Decrement(key, value...)
+    this.endSpan(span, err)
+
+    return err
 }
 
 // 删除
 func (this *Cache) Forget(key string) (bool, error) {
     key = this.wrapperKey(key)
 
-    return this.driver.Forget(key)
+    span := this.startSpan("forget", key)
+
+    ok, err := this.driver.Forget(key)
+    this.endSpan(span, err)
+
+    return ok, err
 }
 
 // 清空
 func (this *Cache) Flush() (bool, error) {
-    return this.driver.Flush()
+    span := this.startSpan("flush", "")
+
+    ok, err := this.driver.Flush()
+    this.endSpan(span, err)
+
+    return ok, err
 }
 
 // 包装字段
@@ -0,0 +1,319 @@
+package cache
+
+import (
+    "sync"
+    "time"
+    "errors"
+    "context"
+    "testing"
+    "reflect"
+    "sync/atomic"
+
+    "github.com/deatil/lakego-doak/lakego/trace"
+    "github.com/deatil/lakego-doak/lakego/cache/driver/memory"
+)
+
+func assertT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func newTestCache() *Cache {
+    return New(memory.New(memory.Config{}), Config{"type": "memory"}).
+        WithPrefix("test")
+}
+
+func Test_Remember(t *testing.T) {
+    eq := assertT(t)
+
+    c := newTestCache()
+
+    var calls int32
+    fn := func() (any, error) {
+        atomic.AddInt32(&calls, 1)
+        time.Sleep(20 * time.Millisecond)
+
+        return "data", nil
+    }
+
+    var wg sync.WaitGroup
+    for i := 0; i < 10; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+
+            val, err := c.Remember("key", 60, fn)
+            eq(err, nil, "Remember err")
+            eq(val, "data", "Remember val")
+        }()
+    }
+
+    wg.Wait()
+
+    eq(atomic.LoadInt32(&calls), int32(1), "Remember single flight")
+
+    val, _ := c.Remember("key", 60, fn)
+    eq(val, "data", "Remember cached")
+    eq(atomic.LoadInt32(&calls), int32(1), "Remember cached calls")
+
+    // 回调失败时不缓存
+    errTest := errors.New("test")
+    _, err := c.Remember("key2", 60, func() (any, error) {
+        return nil, errTest
+    })
+    eq(err, errTest, "Remember error")
+    eq(c.Has("key2"), false, "Remember error not cached")
+}
+
+func Test_RememberStored(t *testing.T) {
+    eq := assertT(t)
+
+    c := newTestCache()
+
+    fn := func() (any, error) {
+        return 42, nil
+    }
+
+    miss, err := c.Remember("num", 60, fn)
+    eq(err, nil, "miss err")
+
+    hit, err := c.Remember("num", 60, fn)
+    eq(err, nil, "hit err")
+
+    eq(miss, "42", "miss val")
+    eq(hit, miss, "hit val same as miss")
+
+    n, err := RememberAs(c, "num2", 60, func() (int, error) {
+        return 42, nil
+    })
+    eq(err, nil, "RememberAs err")
+    eq(n, 42, "RememberAs val")
+}
+
+func Test_RememberWithRefresh(t *testing.T) {
+    eq := assertT(t)
+
+    c := newTestCache()
+
+    val, _ := c.RememberWithRefresh("key", 60, 1, func() (any, error) {
+        return "old", nil
+    })
+    eq(val, "old", "RememberWithRefresh first")
+
+    // 计算耗时远大于剩余时间时必定提前刷新
+    c.Put("key" + refreshSuffix, "3600000000000:1", 60)
+
+    val, _ = c.RememberWithRefresh("key", 60, 1, func() (any, error) {
+        return "new", nil
+    })
+    eq(val, "new", "RememberWithRefresh refresh")
+
+    // 刷新失败返回旧数据
+    c.Put("key" + refreshSuffix, "3600000000000:1", 60)
+
+    val, err := c.RememberWithRefresh("key", 60, 1, func() (any, error) {
+        return nil, errors.New("fail")
+    })
+    eq(err, nil, "RememberWithRefresh stale err")
+    eq(val, "new", "RememberWithRefresh stale")
+}
+
+func Test_Tags(t *testing.T) {
+    eq := assertT(t)
+
+    c := newTestCache()
+
+    c.Tags("admin:1").Put("profile", "p1", 60)
+    c.Tags("admin:1", "menu").Put("menu", "m1", 60)
+    c.Tags("admin:2").Put("profile", "p2", 60)
+
+    val, _ := c.Tags("admin:1").Get("profile")
+    eq(val, "p1", "Tags get")
+    eq(c.Has("profile"), false, "Tags isolated")
+
+    c.Tags("admin:1").Flush()
+
+    eq(c.Tags("admin:1").Has("profile"), false, "Tags flush")
+    eq(c.Tags("admin:1", "menu").Has("menu"), false, "Tags flush multi")
+
+    val, _ = c.Tags("admin:2").Get("profile")
+    eq(val, "p2", "Tags flush other")
+}
+
+func Test_Lock(t *testing.T) {
+    eq := assertT(t)
+
+    c := newTestCache()
+
+    l1 := c.Lock("job", 10)
+    l2 := c.Lock("job", 10)
+
+    ok, err := l1.Get()
+    eq(err, nil, "Lock err")
+    eq(ok, true, "Lock get")
+
+    ok, _ = l2.Get()
+    eq(ok, false, "Lock held")
+
+    eq(l2.Block(50 * time.Millisecond), ErrLockTimeout, "Lock block timeout")
+
+    // 非持有者不能释放
+    ok, _ = l2.Release()
+    eq(ok, false, "Lock release other")
+
+    eq(c.RestoreLock("job", l1.Owner()).CurrentOwner(), l1.Owner(), "Lock owner")
+
+    go func() {
+        time.Sleep(30 * time.Millisecond)
+        l1.Release()
+    }()
+
+    eq(l2.WithRetryInterval(10 * time.Millisecond).Block(time.Second), nil, "Lock block")
+    eq(l2.CurrentOwner(), l2.Owner(), "Lock block owner")
+
+    l2.ForceRelease()
+
+    ran, err := c.Lock("run", 10).Run(func() error {
+        return nil
+    })
+    eq(ran, true, "Lock run")
+    eq(err, nil, "Lock run err")
+    eq(c.Lock("run", 10).CurrentOwner(), "", "Lock run released")
+}
+
+type testUser struct {
+    Name  string
+    Roles []string
+}
+
+func Test_TypedCodecs(t *testing.T) {
+    eq := assertT(t)
+
+    user := testUser{Name: "admin", Roles: []string{"a", "b"}}
+
+    for name, codec := range map[string]Codec{
+        "json":   JSONCodec{},
+        "gob":    GobCodec{},
+        "binary": BinaryCodec{},
+    } {
+        c := newTestCache().WithCodec(codec)
+
+        eq(PutAs(c, "user", user, 60), nil, name + " PutAs")
+
+        got, err := GetAs[testUser](c, "user")
+        eq(err, nil, name + " GetAs err")
+        eq(got, user, name + " GetAs")
+
+        // 标签缓存
+        eq(PutAs(c.Tags("t"), "user", user, 60), nil, name + " tagged PutAs")
+        got, _ = GetAs[testUser](c.Tags("t"), "user")
+        eq(got, user, name + " tagged GetAs")
+    }
+}
+
+func Test_TypedScalars(t *testing.T) {
+    eq := assertT(t)
+
+    c := newTestCache()
+
+    type status int
+
+    PutAs(c, "n", 5, 60)
+    c.Increment("n", 2)
+
+    n, err := GetAs[int](c, "n")
+    eq(err, nil, "GetAs int err")
+    eq(n, 7, "GetAs int after Increment")
+
+    PutAs(c, "s", status(3), 60)
+    st, _ := GetAs[status](c, "s")
+    eq(st, status(3), "GetAs named int")
+
+    PutAs(c, "b", true, 60)
+    b, _ := GetAs[bool](c, "b")
+    eq(b, true, "GetAs bool")
+
+    // 和 Put 写入的数据兼容
+    c.Put("str", "no", 60)
+    s, _ := GetAs[string](c, "str")
+    eq(s, "no", "GetAs string")
+
+    s, _ = PullAs[string](c, "str")
+    eq(s, "no", "PullAs string")
+    eq(c.Has("str"), false, "PullAs removed")
+
+    _, err = GetAs[int](c, "str-none")
+    eq(IsMiss(err), true, "GetAs miss")
+
+    var miss *MissError
+    if errors.As(err, &miss) {
+        eq(miss.Key, "str-none", "MissError key")
+    } else {
+        t.Error("Failed GetAs miss: not MissError")
+    }
+
+    c.Put("bad", "abc", 60)
+    _, err = GetAs[int](c, "bad")
+    eq(err != nil && !IsMiss(err), true, "GetAs decode error")
+}
+
+func Test_RememberAs(t *testing.T) {
+    eq := assertT(t)
+
+    c := newTestCache()
+
+    calls := 0
+    fn := func() (testUser, error) {
+        calls++
+        return testUser{Name: "admin"}, nil
+    }
+
+    u, err := RememberAs(c, "user", 60, fn)
+    eq(err, nil, "RememberAs err")
+    eq(u.Name, "admin", "RememberAs miss")
+
+    u, _ = RememberAs(c, "user", 60, fn)
+    eq(u.Name, "admin", "RememberAs hit")
+    eq(calls, 1, "RememberAs calls")
+}
+
+func Test_WithContext(t *testing.T) {
+    eq := assertT(t)
+
+    exporter := trace.NewMemoryExporter()
+    trace.SetDefault(trace.NewTracer(trace.NewSimpleProcessor(exporter)))
+    defer trace.SetDefault(nil)
+
+    c := newTestCache()
+
+    // 未设置上下文时不记录
+    c.Put("trace", "value", 10)
+    eq(len(exporter.Spans()), 0, "without context")
+
+    ctx, root := trace.Start(context.Background(), "root")
+
+    tc := c.WithContext(ctx)
+    tc.Put("trace", "value", 10)
+    tc.Get("trace")
+    tc.Get("trace-none")
+
+    root.End()
+
+    spans := exporter.Spans()
+    eq(len(spans), 4, "spans len")
+
+    eq(spans[0].Name, "cache.put", "put name")
+    eq(spans[0].Attributes["cache.key"], "test:trace", "put key")
+    eq(spans[0].Attributes["cache.store"], "memory", "put store")
+    eq(spans[0].ParentSpanID, root.SpanContext().SpanID, "put parent")
+
+    eq(spans[1].Attributes["cache.hit"], true, "get hit")
+    eq(spans[2].Attributes["cache.hit"], false, "get miss")
+    eq(spans[2].StatusCode, trace.StatusUnset, "miss is not error")
+
+    // 原缓存不受影响
+    eq(c.ctx == nil, true, "original cache")
+}
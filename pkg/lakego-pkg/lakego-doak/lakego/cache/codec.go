@@ -0,0 +1,84 @@
+package cache
+
+import (
+    "sync"
+
+    "github.com/deatil/go-encoding/encoding"
+)
+
+/**
+ * 编码器，用于结构体等复杂类型的存储
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Codec interface {
+    // 编码
+    Marshal(any) ([]byte, error)
+
+    // 解码
+    Unmarshal([]byte, any) error
+}
+
+// JSON 编码
+type JSONCodec struct{}
+
+func (JSONCodec) Marshal(v any) ([]byte, error) {
+    en := encoding.New().JSONEncode(v)
+    return en.ToBytes(), en.Error
+}
+
+func (JSONCodec) Unmarshal(data []byte, v any) error {
+    return encoding.FromBytes(data).JSONDecode(v).Error
+}
+
+// gob 编码
+type GobCodec struct{}
+
+func (GobCodec) Marshal(v any) ([]byte, error) {
+    en := encoding.New().GobEncode(v)
+    return en.ToBytes(), en.Error
+}
+
+func (GobCodec) Unmarshal(data []byte, v any) error {
+    return encoding.FromBytes(data).GobDecode(v).Error
+}
+
+// 二进制编码，整数按文本存储，其他类型使用 gob
+type BinaryCodec struct{}
+
+func (BinaryCodec) Marshal(v any) ([]byte, error) {
+    en := encoding.New().SerializeEncode(v)
+    return en.ToBytes(), en.Error
+}
+
+func (BinaryCodec) Unmarshal(data []byte, v any) error {
+    return encoding.FromBytes(data).SerializeDecode(v).Error
+}
+
+var (
+    codecMu sync.RWMutex
+
+    // 已注册编码器
+    codecs = map[string]Codec{
+        "json":   JSONCodec{},
+        "gob":    GobCodec{},
+        "binary": BinaryCodec{},
+    }
+)
+
+// 注册编码器
+func RegisterCodec(name string, codec Codec) {
+    codecMu.Lock()
+    defer codecMu.Unlock()
+
+    codecs[name] = codec
+}
+
+// 获取编码器，不存在时返回 nil
+func GetCodec(name string) Codec {
+    codecMu.RLock()
+    defer codecMu.RUnlock()
+
+    return codecs[name]
+}
@@ -0,0 +1,296 @@
+package database
+
+import (
+    "time"
+    "errors"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+
+    "github.com/deatil/lakego-doak/lakego/cache/driver"
+)
+
+// 缓存配置
+type Config struct {
+    // 数据库连接
+    DB *gorm.DB
+
+    // 数据表
+    Table string
+
+    // 自动创建数据表
+    AutoMigrate bool
+}
+
+// 缓存数据
+type Item struct {
+    Key        string `gorm:"column:key;size:255;not null;primaryKey;"`
+    Value      []byte `gorm:"column:value;"`
+    Expiration int64  `gorm:"column:expiration;not null;default:0;index;"`
+}
+
+/**
+ * 数据库缓存
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Database struct {
+    // 数据库
+    db *gorm.DB
+
+    // 数据表
+    table string
+
+    // 当前时间，测试用
+    now func() time.Time
+}
+
+// 构造函数
+func New(config Config) *Database {
+    table := config.Table
+    if table == "" {
+        table = "cache"
+    }
+
+    d := &Database{
+        db:    config.DB,
+        table: table,
+        now:   time.Now,
+    }
+
+    if config.AutoMigrate {
+        d.Migrate()
+    }
+
+    return d
+}
+
+// 创建数据表
+func (this *Database) Migrate() error {
+    return this.db.Table(this.table).AutoMigrate(&Item{})
+}
+
+// 判断是否存在
+func (this *Database) Exists(key string) bool {
+    var count int64
+
+    err := this.alive(this.query()).
+        Where(keyEq(key)).
+        Count(&count).
+        Error
+    if err != nil {
+        return false
+    }
+
+    return count > 0
+}
+
+// 获取
+func (this *Database) Get(key string) (any, error) {
+    item, err := this.find(this.db, key)
+    if err != nil {
+        return "", err
+    }
+
+    return string(item.Value), nil
+}
+
+// 设置
+func (this *Database) Put(key string, value any, ttl time.Duration) error {
+    val, err := driver.FormatValue(value)
+    if err != nil {
+        return err
+    }
+
+    var expiration int64
+    if ttl > 0 {
+        expiration = this.now().Add(ttl).UnixNano()
+    }
+
+    return this.save(this.db, Item{
+        Key:        key,
+        Value:      []byte(val),
+        Expiration: expiration,
+    })
+}
+
+// 存在永久
+func (this *Database) Forever(key string, value any) error {
+    return this.Put(key, value, 0)
+}
+
+// 增加
+func (this *Database) Increment(key string, value ...int64) error {
+    return this.incr(key, driver.Step(value))
+}
+
+// 减少
+func (this *Database) Decrement(key string, value ...int64) error {
+    return this.incr(key, -driver.Step(value))
+}
+
+// 删除
+func (this *Database) Forget(key string) (bool, error) {
+    err := this.query().
+        Where(keyEq(key)).
+        Delete(&Item{}).
+        Error
+    if err != nil {
+        return false, err
+    }
+
+    return true, nil
+}
+
+// 清空
+func (this *Database) Flush() (bool, error) {
+    err := this.query().
+        Where("1 = 1").
+        Delete(&Item{}).
+        Error
+    if err != nil {
+        return false, err
+    }
+
+    return true, nil
+}
+
+// 不存在时设置
+func (this *Database) Add(key string, value any, ttl time.Duration) (bool, error) {
+    val, err := driver.FormatValue(value)
+    if err != nil {
+        return false, err
+    }
+
+    now := this.now().UnixNano()
+
+    var expiration int64
+    if ttl > 0 {
+        expiration = this.now().Add(ttl).UnixNano()
+    }
+
+    // 先删除已过期的数据
+    err = this.query().
+        Where(keyEq(key)).
+        Where("expiration > 0 AND expiration <= ?", now).
+        Delete(&Item{}).
+        Error
+    if err != nil {
+        return false, err
+    }
+
+    item := Item{
+        Key:        key,
+        Value:      []byte(val),
+        Expiration: expiration,
+    }
+
+    res := this.query().
+        Clauses(clause.OnConflict{
+            DoNothing: true,
+        }).
+        Create(&item)
+    if res.Error != nil {
+        return false, res.Error
+    }
+
+    return res.RowsAffected > 0, nil
+}
+
+// 值相同时删除
+func (this *Database) ForgetIf(key string, value any) (bool, error) {
+    val, err := driver.FormatValue(value)
+    if err != nil {
+        return false, err
+    }
+
+    res := this.query().
+        Where(keyEq(key)).
+        Where("value = ?", []byte(val)).
+        Delete(&Item{})
+    if res.Error != nil {
+        return false, res.Error
+    }
+
+    return res.RowsAffected > 0, nil
+}
+
+// 删除过期数据
+func (this *Database) DeleteExpired() error {
+    return this.query().
+        Where("expiration > 0 AND expiration <= ?", this.now().UnixNano()).
+        Delete(&Item{}).
+        Error
+}
+
+func (this *Database) incr(key string, step int64) error {
+    return this.db.Transaction(func(tx *gorm.DB) error {
+        current := ""
+        var expiration int64
+
+        item, err := this.find(tx.Clauses(clause.Locking{Strength: "UPDATE"}), key)
+        if err == nil {
+            current = string(item.Value)
+            expiration = item.Expiration
+        } else if err != driver.ErrNotFound {
+            return err
+        }
+
+        val, err := driver.IncrementValue(current, step)
+        if err != nil {
+            return err
+        }
+
+        // 自增不改变过期时间
+        return this.save(tx, Item{
+            Key:        key,
+            Value:      []byte(val),
+            Expiration: expiration,
+        })
+    })
+}
+
+func (this *Database) find(db *gorm.DB, key string) (Item, error) {
+    var item Item
+
+    err := this.alive(db.Table(this.table)).
+        Where(keyEq(key)).
+        Take(&item).
+        Error
+    if err != nil {
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            return item, driver.ErrNotFound
+        }
+
+        return item, err
+    }
+
+    return item, nil
+}
+
+func (this *Database) save(db *gorm.DB, item Item) error {
+    return db.Table(this.table).
+        Clauses(clause.OnConflict{
+            UpdateAll: true,
+        }).
+        Create(&item).
+        Error
+}
+
+func (this *Database) query() *gorm.DB {
+    return this.db.Table(this.table)
+}
+
+// 未过期条件
+func (this *Database) alive(db *gorm.DB) *gorm.DB {
+    return db.Where("expiration = 0 OR expiration > ?", this.now().UnixNano())
+}
+
+// key 条件，按数据库方言转义字段名
+func keyEq(key string) clause.Eq {
+    return clause.Eq{
+        Column: clause.Column{Name: "key"},
+        Value:  key,
+    }
+}
@@ -0,0 +1,350 @@
+package file
+
+import (
+    "os"
+    "sync"
+    "time"
+    "bytes"
+    "errors"
+    "strconv"
+    "crypto/sha1"
+    "encoding/hex"
+    "path/filepath"
+
+    "github.com/deatil/lakego-doak/lakego/cache/driver"
+)
+
+// 缓存配置
+type Config struct {
+    // 缓存目录
+    Path string
+
+    // 文件权限
+    FileMode os.FileMode
+
+    // 目录权限
+    DirMode os.FileMode
+}
+
+/**
+ * 文件缓存
+ *
+ * 文件第一行为过期时间戳(纳秒，0 为永久)，之后为缓存数据，
+ * 写入时先写临时文件再重命名，过期数据在读取时删除
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type File struct {
+    // 锁，用于自增等读写操作
+    mu sync.Mutex
+
+    // 目录
+    path string
+
+    // 文件权限
+    fileMode os.FileMode
+
+    // 目录权限
+    dirMode os.FileMode
+
+    // 当前时间，测试用
+    now func() time.Time
+}
+
+// 构造函数
+func New(config Config) *File {
+    fileMode := config.FileMode
+    if fileMode == 0 {
+        fileMode = 0644
+    }
+
+    dirMode := config.DirMode
+    if dirMode == 0 {
+        dirMode = 0755
+    }
+
+    return &File{
+        path:     config.Path,
+        fileMode: fileMode,
+        dirMode:  dirMode,
+        now:      time.Now,
+    }
+}
+
+// 判断是否存在
+func (this *File) Exists(key string) bool {
+    _, err := this.read(key)
+    return err == nil
+}
+
+// 获取
+func (this *File) Get(key string) (any, error) {
+    val, err := this.read(key)
+    if err != nil {
+        return "", err
+    }
+
+    return val, nil
+}
+
+// 设置
+func (this *File) Put(key string, value any, ttl time.Duration) error {
+    val, err := driver.FormatValue(value)
+    if err != nil {
+        return err
+    }
+
+    var expireAt int64
+    if ttl > 0 {
+        expireAt = this.now().Add(ttl).UnixNano()
+    }
+
+    return this.write(key, val, expireAt)
+}
+
+// 存在永久
+func (this *File) Forever(key string, value any) error {
+    return this.Put(key, value, 0)
+}
+
+// 增加
+func (this *File) Increment(key string, value ...int64) error {
+    return this.incr(key, driver.Step(value))
+}
+
+// 减少
+func (this *File) Decrement(key string, value ...int64) error {
+    return this.incr(key, -driver.Step(value))
+}
+
+// 删除
+func (this *File) Forget(key string) (bool, error) {
+    err := os.Remove(this.filename(key))
+    if err != nil && !os.IsNotExist(err) {
+        return false, err
+    }
+
+    return true, nil
+}
+
+// 清空
+func (this *File) Flush() (bool, error) {
+    entries, err := os.ReadDir(this.path)
+    if err != nil {
+        if os.IsNotExist(err) {
+            return true, nil
+        }
+
+        return false, err
+    }
+
+    for _, entry := range entries {
+        if err := os.RemoveAll(filepath.Join(this.path, entry.Name())); err != nil {
+            return false, err
+        }
+    }
+
+    return true, nil
+}
+
+// 不存在时设置，使用硬链接保证多进程下的原子性
+func (this *File) Add(key string, value any, ttl time.Duration) (bool, error) {
+    val, err := driver.FormatValue(value)
+    if err != nil {
+        return false, err
+    }
+
+    var expireAt int64
+    if ttl > 0 {
+        expireAt = this.now().Add(ttl).UnixNano()
+    }
+
+    file := this.filename(key)
+
+    tmpName, err := this.writeTemp(file, val, expireAt)
+    if err != nil {
+        return false, err
+    }
+    defer os.Remove(tmpName)
+
+    for i := 0; i < 2; i++ {
+        err = os.Link(tmpName, file)
+        if err == nil {
+            return true, nil
+        }
+
+        if !os.IsExist(err) {
+            return false, err
+        }
+
+        // 已存在但过期时删除后重试
+        if _, rerr := this.read(key); rerr != driver.ErrNotFound {
+            return false, nil
+        }
+    }
+
+    return false, nil
+}
+
+// 值相同时删除
+func (this *File) ForgetIf(key string, value any) (bool, error) {
+    val, err := driver.FormatValue(value)
+    if err != nil {
+        return false, err
+    }
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    current, err := this.read(key)
+    if err != nil {
+        if err == driver.ErrNotFound {
+            return false, nil
+        }
+
+        return false, err
+    }
+
+    if current != val {
+        return false, nil
+    }
+
+    if err := os.Remove(this.filename(key)); err != nil && !os.IsNotExist(err) {
+        return false, err
+    }
+
+    return true, nil
+}
+
+func (this *File) incr(key string, step int64) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    current := ""
+    var expireAt int64
+
+    data, err := os.ReadFile(this.filename(key))
+    if err == nil {
+        exp, val, perr := this.parse(data)
+        if perr == nil && !this.expired(exp) {
+            current = val
+            expireAt = exp
+        }
+    }
+
+    val, err := driver.IncrementValue(current, step)
+    if err != nil {
+        return err
+    }
+
+    // 自增不改变过期时间
+    return this.write(key, val, expireAt)
+}
+
+func (this *File) read(key string) (string, error) {
+    file := this.filename(key)
+
+    data, err := os.ReadFile(file)
+    if err != nil {
+        if os.IsNotExist(err) {
+            return "", driver.ErrNotFound
+        }
+
+        return "", err
+    }
+
+    expireAt, val, err := this.parse(data)
+    if err != nil {
+        return "", err
+    }
+
+    if this.expired(expireAt) {
+        os.Remove(file)
+        return "", driver.ErrNotFound
+    }
+
+    return val, nil
+}
+
+func (this *File) write(key string, value string, expireAt int64) error {
+    file := this.filename(key)
+
+    tmpName, err := this.writeTemp(file, value, expireAt)
+    if err != nil {
+        return err
+    }
+
+    if err = os.Rename(tmpName, file); err != nil {
+        os.Remove(tmpName)
+        return err
+    }
+
+    return nil
+}
+
+// 写入临时文件，返回临时文件名
+func (this *File) writeTemp(file string, value string, expireAt int64) (string, error) {
+    dir := filepath.Dir(file)
+
+    if err := os.MkdirAll(dir, this.dirMode); err != nil {
+        return "", err
+    }
+
+    tmp, err := os.CreateTemp(dir, ".tmp-*")
+    if err != nil {
+        return "", err
+    }
+
+    tmpName := tmp.Name()
+
+    buf := make([]byte, 0, len(value) + 21)
+    buf = strconv.AppendInt(buf, expireAt, 10)
+    buf = append(buf, '\n')
+    buf = append(buf, value...)
+
+    if _, err = tmp.Write(buf); err == nil {
+        err = tmp.Sync()
+    }
+
+    if cerr := tmp.Close(); err == nil {
+        err = cerr
+    }
+
+    if err == nil {
+        err = os.Chmod(tmpName, this.fileMode)
+    }
+
+    if err != nil {
+        os.Remove(tmpName)
+        return "", err
+    }
+
+    return tmpName, nil
+}
+
+func (this *File) parse(data []byte) (int64, string, error) {
+    i := bytes.IndexByte(data, '\n')
+    if i < 0 {
+        return 0, "", errors.New("cache: invalid cache file")
+    }
+
+    expireAt, err := strconv.ParseInt(string(data[:i]), 10, 64)
+    if err != nil {
+        return 0, "", errors.New("cache: invalid cache file")
+    }
+
+    return expireAt, string(data[i+1:]), nil
+}
+
+func (this *File) expired(expireAt int64) bool {
+    return expireAt > 0 && this.now().UnixNano() >= expireAt
+}
+
+// 缓存文件，按 key 的 sha1 分两级目录存放
+func (this *File) filename(key string) string {
+    sum := sha1.Sum([]byte(key))
+    name := hex.EncodeToString(sum[:])
+
+    return filepath.Join(this.path, name[0:2], name[2:4], name)
+}
@@ -0,0 +1,105 @@
+package file
+
+import (
+    "os"
+    "time"
+    "testing"
+    "reflect"
+
+    "github.com/deatil/lakego-doak/lakego/cache/driver"
+)
+
+func assertT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func Test_File(t *testing.T) {
+    eq := assertT(t)
+
+    f := New(Config{
+        Path: t.TempDir(),
+    })
+
+    eq(f.Put("a", "data\nline", 0), nil, "Put")
+    eq(f.Exists("a"), true, "Exists")
+
+    val, err := f.Get("a")
+    eq(err, nil, "Get err")
+    eq(val, "data\nline", "Get")
+
+    eq(f.Increment("n", 3), nil, "Increment")
+    eq(f.Decrement("n"), nil, "Decrement")
+    val, _ = f.Get("n")
+    eq(val, "2", "Increment value")
+
+    eq(f.Increment("a"), driver.ErrNotInteger, "Increment not integer")
+
+    f.Forget("a")
+    _, err = f.Get("a")
+    eq(err, driver.ErrNotFound, "Forget")
+
+    f.Flush()
+    eq(f.Exists("n"), false, "Flush")
+}
+
+func Test_FileTTL(t *testing.T) {
+    eq := assertT(t)
+
+    now := time.Unix(1000, 0)
+
+    f := New(Config{
+        Path: t.TempDir(),
+    })
+    f.now = func() time.Time {
+        return now
+    }
+
+    f.Put("a", "data", time.Minute)
+
+    now = now.Add(30 * time.Second)
+    val, _ := f.Get("a")
+    eq(val, "data", "TTL alive")
+
+    now = now.Add(time.Minute)
+    _, err := f.Get("a")
+    eq(err, driver.ErrNotFound, "TTL expired")
+
+    // 过期文件读取时删除
+    _, err = os.Stat(f.filename("a"))
+    eq(os.IsNotExist(err), true, "TTL lazy delete")
+}
+
+func Test_FileAdd(t *testing.T) {
+    eq := assertT(t)
+
+    now := time.Unix(1000, 0)
+
+    f := New(Config{
+        Path: t.TempDir(),
+    })
+    f.now = func() time.Time {
+        return now
+    }
+
+    ok, _ := f.Add("lock", "owner1", time.Minute)
+    eq(ok, true, "Add")
+
+    ok, _ = f.Add("lock", "owner2", time.Minute)
+    eq(ok, false, "Add exists")
+
+    ok, _ = f.ForgetIf("lock", "owner2")
+    eq(ok, false, "ForgetIf other")
+
+    // 过期后可重新获取
+    now = now.Add(2 * time.Minute)
+
+    ok, _ = f.Add("lock", "owner2", time.Minute)
+    eq(ok, true, "Add expired")
+
+    ok, _ = f.ForgetIf("lock", "owner2")
+    eq(ok, true, "ForgetIf owner")
+}
@@ -0,0 +1,331 @@
+package layered
+
+import (
+    "sort"
+    "errors"
+    "time"
+    "strings"
+    "context"
+    "sync/atomic"
+    "crypto/rand"
+    "encoding/hex"
+    "encoding/json"
+
+    "github.com/deatil/lakego-doak/lakego/cache/interfaces"
+)
+
+// 远程缓存不支持锁
+var errLockNotSupported = errors.New("cache: remote driver does not support lock")
+
+// 本地缓存规则
+type Rule struct {
+    // key 前缀
+    Prefix string
+
+    // 本地缓存时间
+    TTL time.Duration
+}
+
+// 缓存配置
+type Config struct {
+    // 本地缓存
+    Local interfaces.Driver
+
+    // 远程缓存
+    Remote interfaces.Driver
+
+    // 失效消息通道，为空时只清理本节点
+    PubSub PubSub
+
+    // 通道名称
+    Channel string
+
+    // 按前缀设置本地缓存时间，未匹配的 key 不使用本地缓存
+    Rules []Rule
+}
+
+// 失效消息
+type message struct {
+    Node string `json:"node"`
+    Op   string `json:"op"`
+    Key  string `json:"key,omitempty"`
+}
+
+/**
+ * 二级缓存，本地缓存在前，远程缓存在后
+ *
+ * 写入和删除时通过消息通道通知其他节点清理本地缓存
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Layered struct {
+    // 本地缓存
+    local interfaces.Driver
+
+    // 远程缓存
+    remote interfaces.Driver
+
+    // 消息通道
+    pubsub PubSub
+
+    // 通道名称
+    channel string
+
+    // 规则，长前缀在前
+    rules []Rule
+
+    // 节点标识
+    node string
+
+    // 失效计数，读取远程数据期间发生失效时不写入本地缓存
+    generation uint64
+
+    // 停止订阅
+    cancel context.CancelFunc
+}
+
+// 构造函数
+func New(config Config) *Layered {
+    rules := make([]Rule, len(config.Rules))
+    copy(rules, config.Rules)
+
+    sort.SliceStable(rules, func(i, j int) bool {
+        return len(rules[i].Prefix) > len(rules[j].Prefix)
+    })
+
+    channel := config.Channel
+    if channel == "" {
+        channel = "lakego-cache:invalidate"
+    }
+
+    l := &Layered{
+        local:   config.Local,
+        remote:  config.Remote,
+        pubsub:  config.PubSub,
+        channel: channel,
+        rules:   rules,
+        node:    newNodeId(),
+    }
+
+    if l.pubsub != nil {
+        ctx, cancel := context.WithCancel(context.Background())
+        l.cancel = cancel
+
+        go l.pubsub.Subscribe(ctx, l.channel, l.handle)
+    }
+
+    return l
+}
+
+// 判断是否存在
+func (this *Layered) Exists(key string) bool {
+    if this.ttlFor(key) > 0 && this.local.Exists(key) {
+        return true
+    }
+
+    return this.remote.Exists(key)
+}
+
+// 获取
+func (this *Layered) Get(key string) (any, error) {
+    ttl := this.ttlFor(key)
+    if ttl <= 0 {
+        return this.remote.Get(key)
+    }
+
+    if val, err := this.local.Get(key); err == nil {
+        return val, nil
+    }
+
+    gen := atomic.LoadUint64(&this.generation)
+
+    val, err := this.remote.Get(key)
+    if err != nil {
+        return val, err
+    }
+
+    if atomic.LoadUint64(&this.generation) == gen {
+        this.local.Put(key, val, ttl)
+    }
+
+    return val, nil
+}
+
+// 设置
+func (this *Layered) Put(key string, value any, ttl time.Duration) error {
+    if err := this.remote.Put(key, value, ttl); err != nil {
+        return err
+    }
+
+    this.invalidate(key)
+
+    return nil
+}
+
+// 存在永久
+func (this *Layered) Forever(key string, value any) error {
+    if err := this.remote.Forever(key, value); err != nil {
+        return err
+    }
+
+    this.invalidate(key)
+
+    return nil
+}
+
+// 增加
+func (this *Layered) Increment(key string, value ...int64) error {
+    if err := this.remote.Increment(key, value...); err != nil {
+        return err
+    }
+
+    this.invalidate(key)
+
+    return nil
+}
+
+// 减少
+func (this *Layered) Decrement(key string, value ...int64) error {
+    if err := this.remote.Decrement(key, value...); err != nil {
+        return err
+    }
+
+    this.invalidate(key)
+
+    return nil
+}
+
+// 删除
+func (this *Layered) Forget(key string) (bool, error) {
+    ok, err := this.remote.Forget(key)
+
+    this.invalidate(key)
+
+    return ok, err
+}
+
+// 清空
+func (this *Layered) Flush() (bool, error) {
+    ok, err := this.remote.Flush()
+
+    atomic.AddUint64(&this.generation, 1)
+    this.local.Flush()
+    this.publish(message{Op: "flush"})
+
+    return ok, err
+}
+
+// 不存在时设置，需远程缓存支持
+func (this *Layered) Add(key string, value any, ttl time.Duration) (bool, error) {
+    locker, ok := this.remote.(interfaces.LockDriver)
+    if !ok {
+        return false, errLockNotSupported
+    }
+
+    added, err := locker.Add(key, value, ttl)
+    if added {
+        this.invalidate(key)
+    }
+
+    return added, err
+}
+
+// 值相同时删除，需远程缓存支持
+func (this *Layered) ForgetIf(key string, value any) (bool, error) {
+    locker, ok := this.remote.(interfaces.LockDriver)
+    if !ok {
+        return false, errLockNotSupported
+    }
+
+    removed, err := locker.ForgetIf(key, value)
+    if removed {
+        this.invalidate(key)
+    }
+
+    return removed, err
+}
+
+// 本地缓存
+func (this *Layered) GetLocal() interfaces.Driver {
+    return this.local
+}
+
+// 远程缓存
+func (this *Layered) GetRemote() interfaces.Driver {
+    return this.remote
+}
+
+// 关闭订阅
+func (this *Layered) Close() error {
+    if this.cancel != nil {
+        this.cancel()
+    }
+
+    return nil
+}
+
+// 清理本地缓存并通知其他节点
+func (this *Layered) invalidate(key string) {
+    if this.ttlFor(key) <= 0 {
+        return
+    }
+
+    atomic.AddUint64(&this.generation, 1)
+    this.local.Forget(key)
+    this.publish(message{Op: "forget", Key: key})
+}
+
+func (this *Layered) publish(msg message) {
+    if this.pubsub == nil {
+        return
+    }
+
+    msg.Node = this.node
+
+    data, err := json.Marshal(msg)
+    if err != nil {
+        return
+    }
+
+    this.pubsub.Publish(context.Background(), this.channel, string(data))
+}
+
+// 处理其他节点的失效消息
+func (this *Layered) handle(payload string) {
+    var msg message
+    if err := json.Unmarshal([]byte(payload), &msg); err != nil {
+        return
+    }
+
+    if msg.Node == this.node {
+        return
+    }
+
+    atomic.AddUint64(&this.generation, 1)
+
+    switch msg.Op {
+        case "forget":
+            this.local.Forget(msg.Key)
+        case "flush":
+            this.local.Flush()
+    }
+}
+
+// 本地缓存时间，0 为不使用本地缓存
+func (this *Layered) ttlFor(key string) time.Duration {
+    for _, rule := range this.rules {
+        if strings.HasPrefix(key, rule.Prefix) {
+            return rule.TTL
+        }
+    }
+
+    return 0
+}
+
+func newNodeId() string {
+    b := make([]byte, 8)
+    rand.Read(b)
+
+    return hex.EncodeToString(b)
+}
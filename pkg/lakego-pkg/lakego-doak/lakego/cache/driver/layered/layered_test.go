@@ -0,0 +1,111 @@
+package layered
+
+import (
+    "sync"
+    "time"
+    "context"
+    "testing"
+    "reflect"
+
+    "github.com/deatil/lakego-doak/lakego/cache/driver/memory"
+)
+
+func assertT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+// 内存消息通道
+type bus struct {
+    mu       sync.Mutex
+    handlers []func(string)
+    ready    sync.WaitGroup
+}
+
+func (this *bus) Publish(ctx context.Context, channel string, message string) error {
+    this.mu.Lock()
+    handlers := append([]func(string){}, this.handlers...)
+    this.mu.Unlock()
+
+    for _, h := range handlers {
+        h(message)
+    }
+
+    return nil
+}
+
+func (this *bus) Subscribe(ctx context.Context, channel string, handler func(string)) {
+    this.mu.Lock()
+    this.handlers = append(this.handlers, handler)
+    this.mu.Unlock()
+
+    this.ready.Done()
+
+    <-ctx.Done()
+}
+
+func Test_Layered(t *testing.T) {
+    eq := assertT(t)
+
+    remote := memory.New(memory.Config{})
+
+    b := &bus{}
+    b.ready.Add(2)
+
+    newNode := func() *Layered {
+        return New(Config{
+            Local:  memory.New(memory.Config{}),
+            Remote: remote,
+            PubSub: b,
+            Rules: []Rule{
+                {Prefix: "admin:", TTL: time.Minute},
+                {Prefix: "admin:nocache:", TTL: 0},
+            },
+        })
+    }
+
+    n1 := newNode()
+    n2 := newNode()
+    defer n1.Close()
+    defer n2.Close()
+
+    b.ready.Wait()
+
+    n1.Put("admin:1", "v1", time.Minute)
+
+    // 读取后写入本地缓存
+    val, _ := n2.Get("admin:1")
+    eq(val, "v1", "Get remote")
+    eq(n2.GetLocal().Exists("admin:1"), true, "Get fill local")
+
+    // 其他节点写入后本地缓存失效
+    n1.Put("admin:1", "v2", time.Minute)
+    eq(n2.GetLocal().Exists("admin:1"), false, "Put invalidate")
+
+    val, _ = n2.Get("admin:1")
+    eq(val, "v2", "Get after invalidate")
+
+    n1.Forget("admin:1")
+    eq(n2.GetLocal().Exists("admin:1"), false, "Forget invalidate")
+
+    _, err := n2.Get("admin:1")
+    eq(err != nil, true, "Forget remote")
+
+    // 未匹配规则的 key 不使用本地缓存
+    n1.Put("other", "x", time.Minute)
+    n1.Get("other")
+    eq(n1.GetLocal().Exists("other"), false, "Rule unmatched")
+
+    // 长前缀优先
+    n1.Put("admin:nocache:1", "x", time.Minute)
+    n1.Get("admin:nocache:1")
+    eq(n1.GetLocal().Exists("admin:nocache:1"), false, "Rule longest prefix")
+
+    n1.Put("admin:2", "x", time.Minute)
+    n2.Get("admin:2")
+    n1.Flush()
+    eq(n2.GetLocal().Exists("admin:2"), false, "Flush invalidate")
+}
@@ -0,0 +1,58 @@
+package layered
+
+import (
+    "context"
+
+    "github.com/go-redis/redis/v8"
+)
+
+/**
+ * 失效消息通道
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type PubSub interface {
+    // 发布
+    Publish(ctx context.Context, channel string, message string) error
+
+    // 订阅，阻塞直到 ctx 结束
+    Subscribe(ctx context.Context, channel string, handler func(string))
+}
+
+// redis 消息通道
+type RedisPubSub struct {
+    client *redis.Client
+}
+
+// 构造函数
+func NewRedisPubSub(client *redis.Client) *RedisPubSub {
+    return &RedisPubSub{
+        client: client,
+    }
+}
+
+// 发布
+func (this *RedisPubSub) Publish(ctx context.Context, channel string, message string) error {
+    return this.client.Publish(ctx, channel, message).Err()
+}
+
+// 订阅，断线后由客户端自动重连
+func (this *RedisPubSub) Subscribe(ctx context.Context, channel string, handler func(string)) {
+    sub := this.client.Subscribe(ctx, channel)
+    defer sub.Close()
+
+    ch := sub.Channel()
+    for {
+        select {
+            case <-ctx.Done():
+                return
+            case msg, ok := <-ch:
+                if !ok {
+                    return
+                }
+
+                handler(msg.Payload)
+        }
+    }
+}
@@ -0,0 +1,326 @@
+package memory
+
+import (
+    "sync"
+    "time"
+    "container/list"
+
+    "github.com/deatil/lakego-doak/lakego/cache/driver"
+)
+
+// 缓存配置
+type Config struct {
+    // 最大缓存数量，0 为不限制
+    Size int
+
+    // 过期清理间隔，0 为只在访问时清理
+    CleanupInterval time.Duration
+}
+
+// 缓存项
+type entry struct {
+    key      string
+    value    string
+    expireAt time.Time
+}
+
+// 是否过期
+func (this *entry) expired(now time.Time) bool {
+    return !this.expireAt.IsZero() && !now.Before(this.expireAt)
+}
+
+/**
+ * 内存缓存，LRU 淘汰
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Memory struct {
+    // 锁
+    mu sync.Mutex
+
+    // 最大数量
+    size int
+
+    // 淘汰列表，最近使用的在前
+    ll *list.List
+
+    // 数据
+    items map[string]*list.Element
+
+    // 停止清理
+    stop chan struct{}
+
+    // 当前时间，测试用
+    now func() time.Time
+}
+
+// 构造函数
+func New(config Config) *Memory {
+    m := &Memory{
+        size:  config.Size,
+        ll:    list.New(),
+        items: make(map[string]*list.Element),
+        now:   time.Now,
+    }
+
+    if config.CleanupInterval > 0 {
+        m.stop = make(chan struct{})
+        go m.janitor(config.CleanupInterval)
+    }
+
+    return m
+}
+
+// 判断是否存在
+func (this *Memory) Exists(key string) bool {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    _, ok := this.get(key)
+    return ok
+}
+
+// 获取
+func (this *Memory) Get(key string) (any, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    e, ok := this.get(key)
+    if !ok {
+        return "", driver.ErrNotFound
+    }
+
+    return e.value, nil
+}
+
+// 设置
+func (this *Memory) Put(key string, value any, ttl time.Duration) error {
+    val, err := driver.FormatValue(value)
+    if err != nil {
+        return err
+    }
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    var expireAt time.Time
+    if ttl > 0 {
+        expireAt = this.now().Add(ttl)
+    }
+
+    this.set(key, val, expireAt)
+
+    return nil
+}
+
+// 存在永久
+func (this *Memory) Forever(key string, value any) error {
+    return this.Put(key, value, 0)
+}
+
+// 增加
+func (this *Memory) Increment(key string, value ...int64) error {
+    return this.incr(key, driver.Step(value))
+}
+
+// 减少
+func (this *Memory) Decrement(key string, value ...int64) error {
+    return this.incr(key, -driver.Step(value))
+}
+
+// 删除
+func (this *Memory) Forget(key string) (bool, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if el, ok := this.items[key]; ok {
+        this.remove(el)
+    }
+
+    return true, nil
+}
+
+// 清空
+func (this *Memory) Flush() (bool, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.ll.Init()
+    this.items = make(map[string]*list.Element)
+
+    return true, nil
+}
+
+// 不存在时设置
+func (this *Memory) Add(key string, value any, ttl time.Duration) (bool, error) {
+    val, err := driver.FormatValue(value)
+    if err != nil {
+        return false, err
+    }
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if _, ok := this.get(key); ok {
+        return false, nil
+    }
+
+    var expireAt time.Time
+    if ttl > 0 {
+        expireAt = this.now().Add(ttl)
+    }
+
+    this.set(key, val, expireAt)
+
+    return true, nil
+}
+
+// 值相同时删除
+func (this *Memory) ForgetIf(key string, value any) (bool, error) {
+    val, err := driver.FormatValue(value)
+    if err != nil {
+        return false, err
+    }
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    e, ok := this.get(key)
+    if !ok || e.value != val {
+        return false, nil
+    }
+
+    this.remove(this.items[key])
+
+    return true, nil
+}
+
+// 缓存数量，包括未清理的过期数据
+func (this *Memory) Len() int {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    return this.ll.Len()
+}
+
+// 清理过期数据
+func (this *Memory) DeleteExpired() {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    now := this.now()
+    for el := this.ll.Back(); el != nil; {
+        prev := el.Prev()
+        if el.Value.(*entry).expired(now) {
+            this.remove(el)
+        }
+
+        el = prev
+    }
+}
+
+// 关闭
+func (this *Memory) Close() error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if this.stop != nil {
+        close(this.stop)
+        this.stop = nil
+    }
+
+    return nil
+}
+
+func (this *Memory) incr(key string, step int64) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    current := ""
+    var expireAt time.Time
+
+    if e, ok := this.get(key); ok {
+        current = e.value
+        expireAt = e.expireAt
+    }
+
+    val, err := driver.IncrementValue(current, step)
+    if err != nil {
+        return err
+    }
+
+    // 自增不改变过期时间
+    this.set(key, val, expireAt)
+
+    return nil
+}
+
+func (this *Memory) get(key string) (*entry, bool) {
+    el, ok := this.items[key]
+    if !ok {
+        return nil, false
+    }
+
+    e := el.Value.(*entry)
+    if e.expired(this.now()) {
+        this.remove(el)
+        return nil, false
+    }
+
+    this.ll.MoveToFront(el)
+
+    return e, true
+}
+
+func (this *Memory) set(key string, value string, expireAt time.Time) {
+    if el, ok := this.items[key]; ok {
+        e := el.Value.(*entry)
+        e.value = value
+        e.expireAt = expireAt
+
+        this.ll.MoveToFront(el)
+        return
+    }
+
+    el := this.ll.PushFront(&entry{
+        key:      key,
+        value:    value,
+        expireAt: expireAt,
+    })
+    this.items[key] = el
+
+    if this.size > 0 && this.ll.Len() > this.size {
+        this.evict()
+    }
+}
+
+// 淘汰最久未使用的数据
+func (this *Memory) evict() {
+    if el := this.ll.Back(); el != nil {
+        this.remove(el)
+    }
+}
+
+func (this *Memory) remove(el *list.Element) {
+    this.ll.Remove(el)
+    delete(this.items, el.Value.(*entry).key)
+}
+
+func (this *Memory) janitor(interval time.Duration) {
+    ticker := time.NewTicker(interval)
+    defer ticker.Stop()
+
+    this.mu.Lock()
+    stop := this.stop
+    this.mu.Unlock()
+
+    for {
+        select {
+            case <-ticker.C:
+                this.DeleteExpired()
+            case <-stop:
+                return
+        }
+    }
+}
@@ -0,0 +1,121 @@
+package memory
+
+import (
+    "time"
+    "testing"
+    "reflect"
+
+    "github.com/deatil/lakego-doak/lakego/cache/driver"
+)
+
+func assertT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func Test_Memory(t *testing.T) {
+    eq := assertT(t)
+
+    m := New(Config{})
+
+    eq(m.Put("a", 123, 0), nil, "Put")
+    eq(m.Exists("a"), true, "Exists")
+
+    val, err := m.Get("a")
+    eq(err, nil, "Get err")
+    eq(val, "123", "Get")
+
+    eq(m.Increment("a", 7), nil, "Increment")
+    val, _ = m.Get("a")
+    eq(val, "130", "Increment value")
+
+    eq(m.Decrement("b"), nil, "Decrement new")
+    val, _ = m.Get("b")
+    eq(val, "-1", "Decrement new value")
+
+    m.Put("c", "str", 0)
+    eq(m.Increment("c"), driver.ErrNotInteger, "Increment not integer")
+
+    m.Forget("a")
+    _, err = m.Get("a")
+    eq(err, driver.ErrNotFound, "Forget")
+
+    m.Flush()
+    eq(m.Len(), 0, "Flush")
+}
+
+func Test_MemoryTTL(t *testing.T) {
+    eq := assertT(t)
+
+    now := time.Unix(1000, 0)
+
+    m := New(Config{})
+    m.now = func() time.Time {
+        return now
+    }
+
+    m.Put("a", "data", time.Minute)
+    m.Increment("n")
+    m.Put("n", 5, time.Second)
+    m.Increment("n")
+
+    now = now.Add(30 * time.Second)
+
+    val, _ := m.Get("a")
+    eq(val, "data", "TTL alive")
+    eq(m.Exists("n"), false, "TTL increment keeps expiry")
+
+    now = now.Add(time.Minute)
+
+    _, err := m.Get("a")
+    eq(err, driver.ErrNotFound, "TTL expired")
+
+    m.Put("x", "1", time.Second)
+    m.Put("y", "1", 0)
+
+    now = now.Add(2 * time.Second)
+
+    m.DeleteExpired()
+    eq(m.Len(), 1, "DeleteExpired")
+}
+
+func Test_MemoryLRU(t *testing.T) {
+    eq := assertT(t)
+
+    m := New(Config{Size: 2})
+
+    m.Put("a", "1", 0)
+    m.Put("b", "2", 0)
+
+    // a 变为最近使用
+    m.Get("a")
+
+    m.Put("c", "3", 0)
+
+    eq(m.Exists("a"), true, "LRU keep a")
+    eq(m.Exists("b"), false, "LRU evict b")
+    eq(m.Exists("c"), true, "LRU keep c")
+    eq(m.Len(), 2, "LRU size")
+}
+
+func Test_MemoryAdd(t *testing.T) {
+    eq := assertT(t)
+
+    m := New(Config{})
+
+    ok, _ := m.Add("lock", "owner1", time.Minute)
+    eq(ok, true, "Add")
+
+    ok, _ = m.Add("lock", "owner2", time.Minute)
+    eq(ok, false, "Add exists")
+
+    ok, _ = m.ForgetIf("lock", "owner2")
+    eq(ok, false, "ForgetIf other")
+
+    ok, _ = m.ForgetIf("lock", "owner1")
+    eq(ok, true, "ForgetIf owner")
+    eq(m.Exists("lock"), false, "ForgetIf removed")
+}
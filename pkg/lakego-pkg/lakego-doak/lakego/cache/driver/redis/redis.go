@@ -2,13 +2,22 @@ package redis
 
 import (
     "time"
-    "errors"
     "context"
 
     "github.com/go-redis/redis/v8"
     "github.com/go-redis/redis/extra/redisotel/v8"
+
+    "github.com/deatil/lakego-doak/lakego/cache/driver"
 )
 
+// 值相同时删除脚本
+var forgetIfScript = redis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+    return redis.call("del", KEYS[1])
+end
+return 0
+`)
+
 // 日志接口
 type iLogger interface {
     Errorf(template string, args ...any)
@@ -102,7 +111,7 @@ func (this *Redis) Get(key string) (any, error) {
 
     val, err = this.client.Get(this.ctx, key).Result()
     if err == redis.Nil {
-        return val, errors.New("redis nil")
+        return val, driver.ErrNotFound
     } else if err != nil {
         return val, err
     } else {
@@ -184,6 +193,26 @@ func (this *Redis) Flush() (bool, error) {
     return true, nil
 }
 
+// 不存在时设置
+func (this *Redis) Add(key string, value any, ttl time.Duration) (bool, error) {
+    return this.client.SetNX(this.ctx, key, value, ttl).Result()
+}
+
+// 值相同时删除
+func (this *Redis) ForgetIf(key string, value any) (bool, error) {
+    val, err := driver.FormatValue(value)
+    if err != nil {
+        return false, err
+    }
+
+    n, err := forgetIfScript.Run(this.ctx, this.client, []string{key}, val).Int64()
+    if err != nil {
+        return false, err
+    }
+
+    return n > 0, nil
+}
+
 // HashSet
 func (this *Redis) HashSet(key string, field string, value string) error {
     return this.client.HSet(this.ctx, key, field, value).Err()
@@ -0,0 +1,96 @@
+package driver
+
+import (
+    "fmt"
+    "time"
+    "errors"
+    "strconv"
+    "encoding"
+)
+
+var (
+    // 缓存不存在
+    ErrNotFound = errors.New("cache: key not found")
+
+    // 缓存值不是整数
+    ErrNotInteger = errors.New("cache: value is not an integer")
+)
+
+// 格式化缓存值，和 redis 驱动保持一致，统一存储为字符串
+func FormatValue(value any) (string, error) {
+    switch v := value.(type) {
+        case nil:
+            return "", nil
+        case string:
+            return v, nil
+        case []byte:
+            return string(v), nil
+        case int:
+            return strconv.FormatInt(int64(v), 10), nil
+        case int8:
+            return strconv.FormatInt(int64(v), 10), nil
+        case int16:
+            return strconv.FormatInt(int64(v), 10), nil
+        case int32:
+            return strconv.FormatInt(int64(v), 10), nil
+        case int64:
+            return strconv.FormatInt(v, 10), nil
+        case uint:
+            return strconv.FormatUint(uint64(v), 10), nil
+        case uint8:
+            return strconv.FormatUint(uint64(v), 10), nil
+        case uint16:
+            return strconv.FormatUint(uint64(v), 10), nil
+        case uint32:
+            return strconv.FormatUint(uint64(v), 10), nil
+        case uint64:
+            return strconv.FormatUint(v, 10), nil
+        case float32:
+            return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+        case float64:
+            return strconv.FormatFloat(v, 'f', -1, 64), nil
+        case bool:
+            if v {
+                return "1", nil
+            }
+
+            return "0", nil
+        case time.Time:
+            return v.Format(time.RFC3339Nano), nil
+        case time.Duration:
+            return strconv.FormatInt(v.Nanoseconds(), 10), nil
+        case encoding.BinaryMarshaler:
+            b, err := v.MarshalBinary()
+            if err != nil {
+                return "", err
+            }
+
+            return string(b), nil
+    }
+
+    return "", fmt.Errorf("cache: can't marshal %T (implement encoding.BinaryMarshaler)", value)
+}
+
+// 计算自增后的值
+func IncrementValue(current string, step int64) (string, error) {
+    var n int64
+
+    if current != "" {
+        var err error
+        n, err = strconv.ParseInt(current, 10, 64)
+        if err != nil {
+            return "", ErrNotInteger
+        }
+    }
+
+    return strconv.FormatInt(n + step, 10), nil
+}
+
+// 步长
+func Step(value []int64) int64 {
+    if len(value) > 0 {
+        return value[0]
+    }
+
+    return 1
+}
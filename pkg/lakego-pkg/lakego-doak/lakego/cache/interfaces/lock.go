@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+    "time"
+)
+
+/**
+ * 锁驱动接口
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type LockDriver interface {
+    // 不存在时存储，存储成功返回 true
+    Add(string, any, time.Duration) (bool, error)
+
+    // 值相同时删除，删除成功返回 true
+    ForgetIf(string, any) (bool, error)
+}
@@ -0,0 +1,187 @@
+package cache
+
+import (
+    "time"
+    "errors"
+    "context"
+    "crypto/rand"
+    "encoding/hex"
+
+    "github.com/deatil/lakego-doak/lakego/cache/interfaces"
+)
+
+// 锁前缀
+const lockPrefix = "lock:"
+
+var (
+    // 驱动不支持锁
+    ErrLockNotSupported = errors.New("cache: driver does not support lock")
+
+    // 获取锁超时
+    ErrLockTimeout = errors.New("cache: lock wait timeout")
+)
+
+// 创建锁，ttl 单位为秒，0 为不过期
+// owner 为空时生成随机持有者标识
+func (this *Cache) Lock(name string, ttl any, owner ...string) *Lock {
+    lockOwner := ""
+    if len(owner) > 0 && owner[0] != "" {
+        lockOwner = owner[0]
+    } else {
+        lockOwner = newLockOwner()
+    }
+
+    return &Lock{
+        cache: this,
+        name:  name,
+        owner: lockOwner,
+        ttl:   this.formatTime(ttl),
+        retry: 100 * time.Millisecond,
+    }
+}
+
+// 根据持有者恢复锁，用于在其他进程中释放锁
+func (this *Cache) RestoreLock(name string, owner string) *Lock {
+    return this.Lock(name, 0, owner)
+}
+
+/**
+ * 分布式锁
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Lock struct {
+    // 缓存
+    cache *Cache
+
+    // 名称
+    name string
+
+    // 持有者
+    owner string
+
+    // 过期时间
+    ttl time.Duration
+
+    // 阻塞获取时的重试间隔
+    retry time.Duration
+}
+
+// 设置重试间隔
+func (this *Lock) WithRetryInterval(interval time.Duration) *Lock {
+    this.retry = interval
+
+    return this
+}
+
+// 持有者标识
+func (this *Lock) Owner() string {
+    return this.owner
+}
+
+// 尝试获取锁
+func (this *Lock) Get() (bool, error) {
+    driver, err := this.driver()
+    if err != nil {
+        return false, err
+    }
+
+    return driver.Add(this.key(), this.owner, this.ttl)
+}
+
+// 阻塞获取锁，超时返回 ErrLockTimeout
+func (this *Lock) Block(timeout time.Duration) error {
+    ctx, cancel := context.WithTimeout(context.Background(), timeout)
+    defer cancel()
+
+    err := this.BlockContext(ctx)
+    if errors.Is(err, context.DeadlineExceeded) {
+        return ErrLockTimeout
+    }
+
+    return err
+}
+
+// 阻塞获取锁，直到获取成功或 ctx 结束
+func (this *Lock) BlockContext(ctx context.Context) error {
+    for {
+        ok, err := this.Get()
+        if err != nil {
+            return err
+        }
+
+        if ok {
+            return nil
+        }
+
+        timer := time.NewTimer(this.retry)
+        select {
+            case <-ctx.Done():
+                timer.Stop()
+                return ctx.Err()
+            case <-timer.C:
+        }
+    }
+}
+
+// 获取锁后执行，执行完成后释放锁
+func (this *Lock) Run(fn func() error) (bool, error) {
+    ok, err := this.Get()
+    if err != nil || !ok {
+        return false, err
+    }
+
+    defer this.Release()
+
+    return true, fn()
+}
+
+// 释放锁，只能释放自己持有的锁
+func (this *Lock) Release() (bool, error) {
+    driver, err := this.driver()
+    if err != nil {
+        return false, err
+    }
+
+    return driver.ForgetIf(this.key(), this.owner)
+}
+
+// 强制释放锁
+func (this *Lock) ForceRelease() error {
+    _, err := this.cache.driver.Forget(this.key())
+
+    return err
+}
+
+// 当前持有者，锁不存在时返回空
+func (this *Lock) CurrentOwner() string {
+    val, err := this.cache.driver.Get(this.key())
+    if err != nil {
+        return ""
+    }
+
+    owner, _ := val.(string)
+
+    return owner
+}
+
+func (this *Lock) key() string {
+    return this.cache.wrapperKey(lockPrefix + this.name)
+}
+
+func (this *Lock) driver() (interfaces.LockDriver, error) {
+    driver, ok := this.cache.driver.(interfaces.LockDriver)
+    if !ok {
+        return nil, ErrLockNotSupported
+    }
+
+    return driver, nil
+}
+
+func newLockOwner() string {
+    b := make([]byte, 16)
+    rand.Read(b)
+
+    return hex.EncodeToString(b)
+}
@@ -0,0 +1,36 @@
+package cache
+
+import (
+    "github.com/deatil/lakego-doak/lakego/metrics"
+)
+
+var (
+    // 命中
+    cacheHits = metrics.NewCounterVec(
+        "lakego_cache_hits_total",
+        "Total number of cache hits by store.",
+        "store",
+    )
+
+    // 未命中
+    cacheMisses = metrics.NewCounterVec(
+        "lakego_cache_misses_total",
+        "Total number of cache misses by store.",
+        "store",
+    )
+)
+
+func init() {
+    metrics.MustRegister(cacheHits, cacheMisses)
+}
+
+// 记录命中情况
+func (this *Cache) recordHit(err error) {
+    store := this.GetConfig("type").ToString()
+
+    if err != nil {
+        cacheMisses.With(store).Inc()
+    } else {
+        cacheHits.With(store).Inc()
+    }
+}
@@ -0,0 +1,119 @@
+package cache
+
+import (
+    "fmt"
+    "math"
+    "time"
+    "strings"
+    "strconv"
+    "math/rand"
+
+    "github.com/deatil/go-goch/goch"
+
+    "github.com/deatil/lakego-doak/lakego/cache/driver"
+)
+
+// 提前刷新信息后缀
+const refreshSuffix = ":xfetch"
+
+// 获取缓存，不存在时执行回调并缓存结果，ttl 单位为秒，0 为永久
+// 同一进程内相同 key 的并发调用只执行一次回调
+// 命中和未命中都返回驱动存储的数据，比如回调返回 42 时得到 "42"，
+// 需要原始类型时使用 RememberAs
+func (this *Cache) Remember(key string, ttl any, fn func() (any, error)) (any, error) {
+    return this.remember(key, ttl, 0, fn)
+}
+
+// 获取缓存，不存在时执行回调并永久缓存结果
+func (this *Cache) RememberForever(key string, fn func() (any, error)) (any, error) {
+    return this.remember(key, 0, 0, fn)
+}
+
+// 获取缓存，并在过期前按概率提前刷新，beta 越大越早刷新，通常为 1
+// 提前刷新失败时返回旧数据
+func (this *Cache) RememberWithRefresh(key string, ttl any, beta float64, fn func() (any, error)) (any, error) {
+    return this.remember(key, ttl, beta, fn)
+}
+
+func (this *Cache) remember(key string, ttl any, beta float64, fn func() (any, error)) (any, error) {
+    val, err := this.Get(key)
+    if err == nil {
+        if beta <= 0 || !this.shouldRefresh(key, beta) {
+            return val, nil
+        }
+
+        fresh, err := this.compute(key, ttl, beta, fn)
+        if err != nil {
+            return val, nil
+        }
+
+        return fresh, nil
+    }
+
+    return this.compute(key, ttl, beta, fn)
+}
+
+// 执行回调并缓存
+func (this *Cache) compute(key string, ttl any, beta float64, fn func() (any, error)) (any, error) {
+    return this.group.Do(this.wrapperKey(key), func() (any, error) {
+        start := time.Now()
+
+        val, err := fn()
+        if err != nil {
+            return nil, err
+        }
+
+        delta := time.Since(start)
+        expiration := this.formatTime(ttl)
+
+        if expiration > 0 {
+            err = this.Put(key, val, ttl)
+        } else {
+            err = this.Forever(key, val)
+        }
+
+        if err != nil {
+            return val, err
+        }
+
+        // 和命中时返回的数据保持一致，驱动统一存储为字符串
+        stored, err := driver.FormatValue(val)
+        if err != nil {
+            return val, err
+        }
+
+        // 记录计算耗时和过期时间，用于提前刷新
+        if beta > 0 && expiration > 0 {
+            expireAt := start.Add(expiration).UnixNano()
+            meta := fmt.Sprintf("%d:%d", delta.Nanoseconds(), expireAt)
+
+            this.Put(key + refreshSuffix, meta, ttl)
+        }
+
+        return stored, nil
+    })
+}
+
+// 判断是否提前刷新
+// 参考 XFetch 算法: now - delta * beta * ln(rand) >= expiry
+func (this *Cache) shouldRefresh(key string, beta float64) bool {
+    meta, err := this.driver.Get(this.wrapperKey(key + refreshSuffix))
+    if err != nil {
+        return false
+    }
+
+    parts := strings.SplitN(goch.ToString(meta), ":", 2)
+    if len(parts) != 2 {
+        return false
+    }
+
+    delta, err1 := strconv.ParseInt(parts[0], 10, 64)
+    expireAt, err2 := strconv.ParseInt(parts[1], 10, 64)
+    if err1 != nil || err2 != nil || expireAt <= 0 {
+        return false
+    }
+
+    gap := float64(delta) * beta * -math.Log(1 - rand.Float64())
+
+    return float64(time.Now().UnixNano()) + gap >= float64(expireAt)
+}
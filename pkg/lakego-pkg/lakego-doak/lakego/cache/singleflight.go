@@ -0,0 +1,62 @@
+package cache
+
+import (
+    "sync"
+    "errors"
+)
+
+// 回调异常
+var errCallPanicked = errors.New("cache: callback panicked")
+
+// 单次调用
+type call struct {
+    wg  sync.WaitGroup
+    val any
+    err error
+}
+
+// 相同 key 的并发调用只执行一次
+type singleflight struct {
+    mu    sync.Mutex
+    calls map[string]*call
+}
+
+func (this *singleflight) Do(key string, fn func() (any, error)) (any, error) {
+    this.mu.Lock()
+    if this.calls == nil {
+        this.calls = make(map[string]*call)
+    }
+
+    if c, ok := this.calls[key]; ok {
+        this.mu.Unlock()
+        c.wg.Wait()
+
+        return c.val, c.err
+    }
+
+    c := new(call)
+    c.wg.Add(1)
+    this.calls[key] = c
+    this.mu.Unlock()
+
+    defer func() {
+        r := recover()
+        if r != nil {
+            c.val, c.err = nil, errCallPanicked
+        }
+
+        c.wg.Done()
+
+        this.mu.Lock()
+        delete(this.calls, key)
+        this.mu.Unlock()
+
+        if r != nil {
+            panic(r)
+        }
+    }()
+
+    c.val, c.err = fn()
+
+    return c.val, c.err
+}
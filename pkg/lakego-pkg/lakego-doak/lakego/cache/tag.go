@@ -0,0 +1,177 @@
+package cache
+
+import (
+    "strings"
+    "crypto/rand"
+    "crypto/sha1"
+    "encoding/hex"
+
+    "github.com/deatil/go-goch/goch"
+
+    "github.com/deatil/lakego-doak/lakego/cache/interfaces"
+)
+
+// 标签版本前缀
+const tagPrefix = "tag:"
+
+// 标签缓存
+func (this *Cache) Tags(names ...string) *TaggedCache {
+    return &TaggedCache{
+        cache: this,
+        tags:  names,
+    }
+}
+
+/**
+ * 标签缓存
+ *
+ * 每个标签保存一个随机版本号，缓存 key 由标签版本号生成命名空间，
+ * 清空标签时更新版本号，旧缓存不再被访问并在过期后删除
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type TaggedCache struct {
+    // 缓存
+    cache *Cache
+
+    // 标签
+    tags []string
+}
+
+// 获取标签
+func (this *TaggedCache) GetTags() []string {
+    return this.tags
+}
+
+// 获取编码器
+func (this *TaggedCache) GetCodec() Codec {
+    return this.cache.GetCodec()
+}
+
+// 判断是否存在
+func (this *TaggedCache) Has(key string) bool {
+    return this.namespaced().Has(key)
+}
+
+// 获取
+func (this *TaggedCache) Get(key string) (any, error) {
+    return this.namespaced().Get(key)
+}
+
+// 设置
+func (this *TaggedCache) Put(key string, value any, ttl any) error {
+    return this.namespaced().Put(key, value, ttl)
+}
+
+// 永久设置
+func (this *TaggedCache) Forever(key string, value any) error {
+    return this.namespaced().Forever(key, value)
+}
+
+// 获取后删除
+func (this *TaggedCache) Pull(key string) (any, error) {
+    return this.namespaced().Pull(key)
+}
+
+// 增加
+func (this *TaggedCache) Increment(key string, value ...int64) error {
+    return this.namespaced().Increment(key, value...)
+}
+
+// 减少
+func (this *TaggedCache) Decrement(key string, value ...int64) error {
+    return this.namespaced().Decrement(key, value...)
+}
+
+// 删除
+func (this *TaggedCache) Forget(key string) (bool, error) {
+    return this.namespaced().Forget(key)
+}
+
+// 获取缓存，不存在时执行回调并缓存结果
+func (this *TaggedCache) Remember(key string, ttl any, fn func() (any, error)) (any, error) {
+    return this.namespaced().Remember(key, ttl, fn)
+}
+
+// 获取缓存，并在过期前按概率提前刷新
+func (this *TaggedCache) RememberWithRefresh(key string, ttl any, beta float64, fn func() (any, error)) (any, error) {
+    return this.namespaced().RememberWithRefresh(key, ttl, beta, fn)
+}
+
+// 获取缓存，不存在时执行回调并永久缓存结果
+func (this *TaggedCache) RememberForever(key string, fn func() (any, error)) (any, error) {
+    return this.namespaced().RememberForever(key, fn)
+}
+
+// 清空标签下的全部缓存
+func (this *TaggedCache) Flush() (bool, error) {
+    for _, name := range this.tags {
+        if err := this.cache.Forever(this.tagKey(name), newTagId()); err != nil {
+            return false, err
+        }
+    }
+
+    return true, nil
+}
+
+// 带标签命名空间的缓存
+func (this *TaggedCache) namespaced() *Cache {
+    c := *this.cache
+    c.prefix = this.cache.wrapperKey(this.namespace())
+
+    return &c
+}
+
+// 命名空间，由全部标签的版本号生成
+func (this *TaggedCache) namespace() string {
+    ids := make([]string, 0, len(this.tags))
+    for _, name := range this.tags {
+        ids = append(ids, this.tagId(name))
+    }
+
+    sum := sha1.Sum([]byte(strings.Join(ids, "|")))
+
+    return hex.EncodeToString(sum[:])
+}
+
+// 标签版本号，不存在时创建
+func (this *TaggedCache) tagId(name string) string {
+    key := this.tagKey(name)
+
+    id, err := this.cache.driver.Get(this.cache.wrapperKey(key))
+    if err == nil {
+        if s := goch.ToString(id); s != "" {
+            return s
+        }
+    }
+
+    newId := newTagId()
+
+    // 支持时使用原子写入，避免并发创建不同的版本号
+    if locker, ok := this.cache.driver.(interfaces.LockDriver); ok {
+        added, err := locker.Add(this.cache.wrapperKey(key), newId, 0)
+        if err == nil && !added {
+            if id, err := this.cache.driver.Get(this.cache.wrapperKey(key)); err == nil {
+                return goch.ToString(id)
+            }
+        }
+
+        return newId
+    }
+
+    this.cache.Forever(key, newId)
+
+    return newId
+}
+
+func (this *TaggedCache) tagKey(name string) string {
+    return tagPrefix + name + ":key"
+}
+
+func newTagId() string {
+    b := make([]byte, 12)
+    rand.Read(b)
+
+    return hex.EncodeToString(b)
+}
@@ -0,0 +1,49 @@
+package cache
+
+import (
+    "errors"
+    "context"
+
+    "github.com/deatil/lakego-doak/lakego/trace"
+    "github.com/deatil/lakego-doak/lakego/cache/driver"
+)
+
+// 带上下文的缓存，上下文中有链路时记录缓存操作
+func (this *Cache) WithContext(ctx context.Context) *Cache {
+    cache := *this
+    cache.ctx = ctx
+
+    return &cache
+}
+
+// 开始记录，未设置上下文时返回 nil
+func (this *Cache) startSpan(operation string, key string) *trace.Span {
+    if this.ctx == nil {
+        return nil
+    }
+
+    attributes := map[string]any{
+        "cache.store":     this.GetConfig("type").ToString(),
+        "cache.operation": operation,
+    }
+
+    if key != "" {
+        attributes["cache.key"] = key
+    }
+
+    _, span := trace.StartChild(this.ctx, "cache." + operation,
+        trace.WithSpanKind(trace.SpanKindClient),
+        trace.WithSpanAttributes(attributes),
+    )
+
+    return span
+}
+
+// 结束记录，未命中不作为错误
+func (this *Cache) endSpan(span *trace.Span, err error) {
+    if err != nil && !errors.Is(err, driver.ErrNotFound) {
+        span.RecordError(err)
+    }
+
+    span.End()
+}
@@ -0,0 +1,247 @@
+package cache
+
+import (
+    "fmt"
+    "time"
+    "errors"
+    "reflect"
+    "strconv"
+
+    "github.com/deatil/lakego-doak/lakego/cache/driver"
+)
+
+var (
+    // 缓存未命中
+    ErrMiss = errors.New("cache: miss")
+)
+
+// 未命中错误
+type MissError struct {
+    // 缓存 key
+    Key string
+
+    // 驱动返回的错误
+    Err error
+}
+
+func (this *MissError) Error() string {
+    return fmt.Sprintf("cache: miss for key %q", this.Key)
+}
+
+// 支持 errors.Is(err, ErrMiss)
+func (this *MissError) Is(target error) bool {
+    return target == ErrMiss
+}
+
+func (this *MissError) Unwrap() error {
+    return this.Err
+}
+
+// 判断是否未命中
+func IsMiss(err error) bool {
+    return errors.Is(err, ErrMiss)
+}
+
+// 缓存仓库，Cache 和 TaggedCache 都实现该接口
+type Repository interface {
+    Get(string) (any, error)
+    Put(string, any, any) error
+    Forever(string, any) error
+    Pull(string) (any, error)
+    Remember(string, any, func() (any, error)) (any, error)
+    GetCodec() Codec
+}
+
+// 获取指定类型的缓存，未命中时返回 MissError
+func GetAs[T any](c Repository, key string) (T, error) {
+    raw, err := c.Get(key)
+    if err != nil {
+        var zero T
+        return zero, err
+    }
+
+    return decodeValue[T](c.GetCodec(), key, raw)
+}
+
+// 获取后删除
+func PullAs[T any](c Repository, key string) (T, error) {
+    raw, err := c.Pull(key)
+    if err != nil {
+        var zero T
+        return zero, err
+    }
+
+    return decodeValue[T](c.GetCodec(), key, raw)
+}
+
+// 存储指定类型的缓存，标量类型直接存储，其他类型使用编码器编码
+func PutAs[T any](c Repository, key string, value T, ttl any) error {
+    data, err := encodeValue(c.GetCodec(), value)
+    if err != nil {
+        return err
+    }
+
+    return c.Put(key, data, ttl)
+}
+
+// 永久存储指定类型的缓存
+func ForeverAs[T any](c Repository, key string, value T) error {
+    data, err := encodeValue(c.GetCodec(), value)
+    if err != nil {
+        return err
+    }
+
+    return c.Forever(key, data)
+}
+
+// 获取指定类型的缓存，不存在时执行回调并缓存结果
+func RememberAs[T any](c Repository, key string, ttl any, fn func() (T, error)) (T, error) {
+    codec := c.GetCodec()
+
+    raw, err := c.Remember(key, ttl, func() (any, error) {
+        val, err := fn()
+        if err != nil {
+            return nil, err
+        }
+
+        return encodeValue(codec, val)
+    })
+    if err != nil {
+        var zero T
+        return zero, err
+    }
+
+    return decodeValue[T](codec, key, raw)
+}
+
+// 包装未命中错误
+func wrapMiss(key string, err error) error {
+    if errors.Is(err, driver.ErrNotFound) {
+        return &MissError{
+            Key: key,
+            Err: err,
+        }
+    }
+
+    return err
+}
+
+// 编码
+func encodeValue(codec Codec, value any) (any, error) {
+    switch v := value.(type) {
+        case string, []byte, time.Time:
+            return v, nil
+    }
+
+    rv := reflect.ValueOf(value)
+
+    switch rv.Kind() {
+        case reflect.String:
+            return rv.String(), nil
+        case reflect.Bool:
+            return rv.Bool(), nil
+        case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+            return rv.Int(), nil
+        case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+            return rv.Uint(), nil
+        case reflect.Float32, reflect.Float64:
+            return rv.Float(), nil
+    }
+
+    if codec == nil {
+        codec = JSONCodec{}
+    }
+
+    return codec.Marshal(value)
+}
+
+// 解码
+func decodeValue[T any](codec Codec, key string, raw any) (T, error) {
+    var out T
+
+    // 驱动直接返回了原始类型
+    if v, ok := raw.(T); ok {
+        return v, nil
+    }
+
+    var data string
+    switch v := raw.(type) {
+        case string:
+            data = v
+        case []byte:
+            data = string(v)
+        default:
+            data = fmt.Sprint(v)
+    }
+
+    if err := decodeString(codec, data, &out); err != nil {
+        return out, fmt.Errorf("cache: decode key %q: %w", key, err)
+    }
+
+    return out, nil
+}
+
+func decodeString(codec Codec, data string, out any) error {
+    switch p := out.(type) {
+        case *string:
+            *p = data
+            return nil
+        case *[]byte:
+            *p = []byte(data)
+            return nil
+        case *time.Time:
+            t, err := time.Parse(time.RFC3339Nano, data)
+            if err != nil {
+                return err
+            }
+
+            *p = t
+            return nil
+    }
+
+    rv := reflect.ValueOf(out).Elem()
+
+    switch rv.Kind() {
+        case reflect.String:
+            rv.SetString(data)
+            return nil
+        case reflect.Bool:
+            b, err := strconv.ParseBool(data)
+            if err != nil {
+                return err
+            }
+
+            rv.SetBool(b)
+            return nil
+        case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+            n, err := strconv.ParseInt(data, 10, rv.Type().Bits())
+            if err != nil {
+                return err
+            }
+
+            rv.SetInt(n)
+            return nil
+        case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+            n, err := strconv.ParseUint(data, 10, rv.Type().Bits())
+            if err != nil {
+                return err
+            }
+
+            rv.SetUint(n)
+            return nil
+        case reflect.Float32, reflect.Float64:
+            n, err := strconv.ParseFloat(data, rv.Type().Bits())
+            if err != nil {
+                return err
+            }
+
+            rv.SetFloat(n)
+            return nil
+    }
+
+    if codec == nil {
+        codec = JSONCodec{}
+    }
+
+    return codec.Unmarshal([]byte(data), out)
+}
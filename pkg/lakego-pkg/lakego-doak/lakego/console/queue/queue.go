@@ -0,0 +1,140 @@
+package queue
+
+import (
+    "os"
+    "fmt"
+    "time"
+    "context"
+    "strings"
+    "syscall"
+    "strconv"
+    "os/signal"
+
+    "github.com/deatil/go-datebin/datebin"
+
+    "github.com/deatil/lakego-doak/lakego/color"
+    "github.com/deatil/lakego-doak/lakego/command"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+    "github.com/deatil/lakego-doak/lakego/facade/queue"
+)
+
+/**
+ * 执行队列任务
+ *
+ * > ./main lakego:queue-work [--queue=default:2,mail] [--concurrency=1]
+ * > main.exe lakego:queue-work [--queue=default:2,mail] [--concurrency=1]
+ * > go run main.go lakego:queue-work [--queue=default:2,mail] [--concurrency=1]
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var QueueWorkCmd = &command.Command{
+    Use: "lakego:queue-work",
+    Short: "执行队列任务。",
+    Example: "{execfile} lakego:queue-work --queue=default:2,mail",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+    },
+    Run: func(cmd *command.Command, args []string) {
+        QueueWork()
+    },
+}
+
+// 队列
+var pQueue string
+
+// 并发数
+var pConcurrency int
+
+// 停止等待时间
+var pStopTimeout time.Duration
+
+func init() {
+    pf := QueueWorkCmd.Flags()
+    pf.StringVarP(&pQueue, "queue", "q", "", "队列名称，多个用逗号分隔，可用 名称:并发数 设置并发数")
+    pf.IntVarP(&pConcurrency, "concurrency", "c", 1, "未设置并发数的队列使用的并发数")
+    pf.DurationVar(&pStopTimeout, "stop-timeout", 0, "停止时等待执行中任务的时间")
+}
+
+// 执行队列任务
+func QueueWork() {
+    queues := ParseQueues(pQueue, pConcurrency)
+
+    // 创建数据表
+    if err := queue.Migrate(); err != nil {
+        color.Redln("队列数据表创建失败: " + err.Error())
+        return
+    }
+
+    worker := queue.NewWorker(queues)
+    if err := worker.Start(); err != nil {
+        color.Redln(err.Error())
+        return
+    }
+
+    names := make([]string, 0)
+    for name, concurrency := range worker.GetQueues() {
+        names = append(names, name + ":" + strconv.Itoa(concurrency))
+    }
+
+    nowDate := datebin.Now().ToDatetimeString()
+
+    fmt.Print("\n")
+    color.
+        NewWithOption(
+            color.ForegroundOption("green"),
+            color.BaseOption("bold"),
+        ).
+        Print("[" + nowDate + "] 队列 " + strings.Join(names, ", ") + " 已开始执行...")
+    fmt.Print("\n")
+
+    quit := make(chan os.Signal, 1)
+    signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+    <-quit
+
+    timeout := pStopTimeout
+    if timeout <= 0 {
+        timeout = config.New("queue").GetDuration("worker.stop-timeout")
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), timeout)
+    defer cancel()
+
+    if err := queue.Shutdown(ctx); err != nil {
+        color.Redln("队列停止超时，未完成的任务已放回队列")
+        return
+    }
+
+    color.Greenln("队列已停止")
+}
+
+// 解析队列参数，格式为 default:2,mail
+func ParseQueues(value string, concurrency int) map[string]int {
+    queues := make(map[string]int)
+
+    if concurrency < 1 {
+        concurrency = 1
+    }
+
+    for _, item := range strings.Split(value, ",") {
+        item = strings.TrimSpace(item)
+        if item == "" {
+            continue
+        }
+
+        name, num, found := strings.Cut(item, ":")
+        if !found {
+            queues[name] = concurrency
+            continue
+        }
+
+        n, err := strconv.Atoi(num)
+        if err != nil || n < 1 {
+            n = concurrency
+        }
+
+        queues[name] = n
+    }
+
+    return queues
+}
@@ -1,7 +1,13 @@
 package schedule
 
 import (
+    "os"
     "fmt"
+    "time"
+    "strings"
+    "syscall"
+    "os/signal"
+    "text/tabwriter"
 
     "github.com/deatil/go-datebin/datebin"
 
@@ -38,7 +44,6 @@ func NewScheduleCmd(s *schedule.Schedule) *command.Command {
         nowDate := datebin.Now().ToDatetimeString()
 
         s.Start()
-        defer s.Stop()
 
         ids := s.CronIDs()
         cronCount := fmt.Sprintf("%d", len(ids))
@@ -53,8 +58,141 @@ func NewScheduleCmd(s *schedule.Schedule) *command.Command {
             Print("[" + nowDate + "] 计划任务共 " + cronCount + " 条已开始进行...")
         fmt.Print("\n")
 
-        select {}
+        quit := make(chan os.Signal, 1)
+        signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+        <-quit
+
+        // 等待运行中的任务结束
+        <-s.Stop().Done()
+
+        color.Greenln("计划任务已停止")
     }
 
     return ScheduleCmd
 }
+
+/**
+ * 计划任务列表
+ *
+ * > ./main lakego:schedule-list [--next=3]
+ * > main.exe lakego:schedule-list [--next=3]
+ * > go run main.go lakego:schedule-list [--next=3]
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var ScheduleListCmd = &command.Command{
+    Use: "lakego:schedule-list",
+    Short: "计划任务列表。",
+    Example: "{execfile} lakego:schedule-list",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+    },
+    Run: func(cmd *command.Command, args []string) {
+
+    },
+}
+
+// 显示的下次运行时间数量
+var pNext int
+
+func init() {
+    pf := ScheduleListCmd.Flags()
+    pf.IntVarP(&pNext, "next", "n", 1, "显示的下次运行时间数量")
+}
+
+// 构造函数
+func NewScheduleListCmd(s *schedule.Schedule) *command.Command {
+    ScheduleListCmd.Run = func(cmd *command.Command, args []string) {
+        ScheduleList(s, pNext)
+    }
+
+    return ScheduleListCmd
+}
+
+// 显示计划任务列表
+func ScheduleList(s *schedule.Schedule, next int) {
+    if next < 1 {
+        next = 1
+    }
+
+    entries := s.Entries()
+    if len(entries) == 0 {
+        color.Yellowln("没有计划任务")
+        return
+    }
+
+    w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+    fmt.Fprintln(w, "名称\t计划时间\t选项\t下次运行\t上次运行")
+
+    now := time.Now()
+    for _, entry := range entries {
+        name := entry.Name
+        if name == "" {
+            name = "-"
+        }
+
+        spec := entry.Spec
+        if entry.Schedule != nil {
+            spec = fmt.Sprintf("%v", entry.Schedule)
+        }
+
+        nextRuns := "-"
+        if runs, err := s.NextRuns(entry, now, next); err != nil {
+            nextRuns = "错误: " + err.Error()
+        } else if len(runs) > 0 {
+            times := make([]string, 0, len(runs))
+            for _, run := range runs {
+                times = append(times, run.Format("2006-01-02 15:04:05"))
+            }
+
+            nextRuns = strings.Join(times, ", ")
+        }
+
+        fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", name, spec, entryOptions(s, entry), nextRuns, lastRun(s, entry))
+    }
+
+    w.Flush()
+}
+
+// 任务选项
+func entryOptions(s *schedule.Schedule, entry *schedule.Entry) string {
+    opts := make([]string, 0)
+
+    if entry.Name != "" && s.IsPaused(entry.Name) {
+        opts = append(opts, "paused")
+    }
+
+    if entry.SkipOverlapping {
+        opts = append(opts, "without-overlapping")
+    }
+
+    if entry.OneServer {
+        opts = append(opts, "one-server")
+    }
+
+    if entry.Timeout > 0 {
+        opts = append(opts, "timeout=" + entry.Timeout.String())
+    }
+
+    if len(opts) == 0 {
+        return "-"
+    }
+
+    return strings.Join(opts, ",")
+}
+
+// 上次运行
+func lastRun(s *schedule.Schedule, entry *schedule.Entry) string {
+    history := s.GetHistory()
+    if history == nil {
+        return "-"
+    }
+
+    runs, err := history.Recent(entry.DisplayName(), 1)
+    if err != nil || len(runs) == 0 {
+        return "-"
+    }
+
+    return runs[0].Start.Format("2006-01-02 15:04:05") + " " + runs[0].Outcome
+}
@@ -11,6 +11,8 @@ import (
 
     "github.com/deatil/lakego-doak/lakego/array"
     "github.com/deatil/lakego-doak/lakego/database/interfaces"
+    trace_plugin "github.com/deatil/lakego-doak/lakego/database/plugin/trace"
+    metrics_plugin "github.com/deatil/lakego-doak/lakego/database/plugin/metrics"
 )
 
 /**
@@ -138,6 +140,16 @@ func (this *Driver) CreateOpenConnection(dia gorm.Dialector) {
             db.Statement.RaiseErrorOnNotFound = false
         })
 
+    // 查询指标
+    if err := db.Use(metrics_plugin.New()); err != nil {
+        log.Printf("Error to use database metrics plugin: %v", err)
+    }
+
+    // 链路追踪
+    if err := db.Use(trace_plugin.New()); err != nil {
+        log.Printf("Error to use database trace plugin: %v", err)
+    }
+
     this.db = db
 }
 
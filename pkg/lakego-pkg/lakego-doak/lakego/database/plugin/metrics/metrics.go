@@ -0,0 +1,116 @@
+package metrics
+
+import (
+    "time"
+    "errors"
+
+    "gorm.io/gorm"
+
+    "github.com/deatil/lakego-doak/lakego/metrics"
+)
+
+// 开始时间存储键
+const startTimeKey = "lakego:metrics_start_time"
+
+var (
+    // 查询耗时
+    queryDuration = metrics.NewHistogramVec(
+        "lakego_db_query_duration_seconds",
+        "Database query latency in seconds by operation and table.",
+        nil,
+        "operation", "table",
+    )
+
+    // 查询错误
+    queryErrors = metrics.NewCounterVec(
+        "lakego_db_query_errors_total",
+        "Total number of failed database queries by operation and table.",
+        "operation", "table",
+    )
+)
+
+func init() {
+    metrics.MustRegister(queryDuration, queryErrors)
+}
+
+/**
+ * gorm 查询指标插件
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Plugin struct {}
+
+// 构造函数
+func New() *Plugin {
+    return &Plugin{}
+}
+
+// 名称
+func (this *Plugin) Name() string {
+    return "lakego:metrics"
+}
+
+// 初始化
+func (this *Plugin) Initialize(db *gorm.DB) error {
+    cb := db.Callback()
+
+    errs := []error{
+        cb.Create().Before("gorm:create").Register("lakego:metrics_before_create", this.before),
+        cb.Create().After("gorm:create").Register("lakego:metrics_after_create", this.after("create")),
+
+        cb.Query().Before("gorm:query").Register("lakego:metrics_before_query", this.before),
+        cb.Query().After("gorm:query").Register("lakego:metrics_after_query", this.after("query")),
+
+        cb.Update().Before("gorm:update").Register("lakego:metrics_before_update", this.before),
+        cb.Update().After("gorm:update").Register("lakego:metrics_after_update", this.after("update")),
+
+        cb.Delete().Before("gorm:delete").Register("lakego:metrics_before_delete", this.before),
+        cb.Delete().After("gorm:delete").Register("lakego:metrics_after_delete", this.after("delete")),
+
+        cb.Row().Before("gorm:row").Register("lakego:metrics_before_row", this.before),
+        cb.Row().After("gorm:row").Register("lakego:metrics_after_row", this.after("row")),
+
+        cb.Raw().Before("gorm:raw").Register("lakego:metrics_before_raw", this.before),
+        cb.Raw().After("gorm:raw").Register("lakego:metrics_after_raw", this.after("raw")),
+    }
+
+    for _, err := range errs {
+        if err != nil {
+            return err
+        }
+    }
+
+    return nil
+}
+
+// 记录开始时间
+func (this *Plugin) before(db *gorm.DB) {
+    db.InstanceSet(startTimeKey, time.Now())
+}
+
+// 记录耗时
+func (this *Plugin) after(operation string) func(*gorm.DB) {
+    return func(db *gorm.DB) {
+        value, ok := db.InstanceGet(startTimeKey)
+        if !ok {
+            return
+        }
+
+        start, ok := value.(time.Time)
+        if !ok {
+            return
+        }
+
+        table := db.Statement.Table
+        if table == "" {
+            table = "unknown"
+        }
+
+        queryDuration.With(operation, table).ObserveSince(start)
+
+        if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+            queryErrors.With(operation, table).Inc()
+        }
+    }
+}
@@ -0,0 +1,133 @@
+package trace
+
+import (
+    "errors"
+
+    "gorm.io/gorm"
+
+    "github.com/deatil/lakego-doak/lakego/trace"
+)
+
+// 节点存储键
+const spanKey = "lakego:trace_span"
+
+// 记录的 sql 最大长度
+const maxStatement = 2048
+
+/**
+ * gorm 链路追踪插件
+ * 只在 db.WithContext 传入的上下文中有链路时记录
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Plugin struct {}
+
+// 构造函数
+func New() *Plugin {
+    return &Plugin{}
+}
+
+// 名称
+func (this *Plugin) Name() string {
+    return "lakego:trace"
+}
+
+// 初始化
+func (this *Plugin) Initialize(db *gorm.DB) error {
+    cb := db.Callback()
+
+    errs := []error{
+        cb.Create().Before("gorm:create").Register("lakego:trace_before_create", this.before("create")),
+        cb.Create().After("gorm:create").Register("lakego:trace_after_create", this.after),
+
+        cb.Query().Before("gorm:query").Register("lakego:trace_before_query", this.before("query")),
+        cb.Query().After("gorm:query").Register("lakego:trace_after_query", this.after),
+
+        cb.Update().Before("gorm:update").Register("lakego:trace_before_update", this.before("update")),
+        cb.Update().After("gorm:update").Register("lakego:trace_after_update", this.after),
+
+        cb.Delete().Before("gorm:delete").Register("lakego:trace_before_delete", this.before("delete")),
+        cb.Delete().After("gorm:delete").Register("lakego:trace_after_delete", this.after),
+
+        cb.Row().Before("gorm:row").Register("lakego:trace_before_row", this.before("row")),
+        cb.Row().After("gorm:row").Register("lakego:trace_after_row", this.after),
+
+        cb.Raw().Before("gorm:raw").Register("lakego:trace_before_raw", this.before("raw")),
+        cb.Raw().After("gorm:raw").Register("lakego:trace_after_raw", this.after),
+    }
+
+    for _, err := range errs {
+        if err != nil {
+            return err
+        }
+    }
+
+    return nil
+}
+
+// 开始节点
+func (this *Plugin) before(operation string) func(*gorm.DB) {
+    return func(db *gorm.DB) {
+        if db.Statement == nil || db.Statement.Context == nil {
+            return
+        }
+
+        attributes := map[string]any{
+            "db.operation": operation,
+        }
+
+        if db.Dialector != nil {
+            attributes["db.system"] = db.Dialector.Name()
+        }
+
+        // 有模型时已解析出表名
+        name := "db." + operation
+        if table := db.Statement.Table; table != "" {
+            name += " " + table
+        }
+
+        _, span := trace.StartChild(db.Statement.Context, name,
+            trace.WithSpanKind(trace.SpanKindClient),
+            trace.WithSpanAttributes(attributes),
+        )
+        if span == nil {
+            return
+        }
+
+        db.InstanceSet(spanKey, span)
+    }
+}
+
+// 结束节点
+func (this *Plugin) after(db *gorm.DB) {
+    value, ok := db.InstanceGet(spanKey)
+    if !ok {
+        return
+    }
+
+    span, ok := value.(*trace.Span)
+    if !ok {
+        return
+    }
+
+    if table := db.Statement.Table; table != "" {
+        span.SetAttribute("db.sql.table", table)
+    }
+
+    statement := db.Statement.SQL.String()
+    if len(statement) > maxStatement {
+        statement = statement[:maxStatement]
+    }
+
+    span.SetAttributes(map[string]any{
+        "db.statement":     statement,
+        "db.rows_affected": db.Statement.RowsAffected,
+    })
+
+    if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+        span.RecordError(db.Error)
+    }
+
+    span.End()
+}
@@ -0,0 +1,68 @@
+package trace
+
+import (
+    "context"
+    "reflect"
+    "testing"
+
+    "gorm.io/gorm"
+    "gorm.io/driver/mysql"
+
+    "github.com/deatil/lakego-doak/lakego/trace"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+type testUser struct {
+    ID   int
+    Name string
+}
+
+func (testUser) TableName() string {
+    return "users"
+}
+
+func Test_Plugin(t *testing.T) {
+    eq := assertEqualT(t)
+
+    // DryRun 只生成 sql，不连接数据库
+    db, err := gorm.Open(mysql.New(mysql.Config{
+        DSN:                       "user:pass@tcp(127.0.0.1:3306)/test",
+        SkipInitializeWithVersion: true,
+    }), &gorm.Config{
+        DryRun:               true,
+        DisableAutomaticPing: true,
+    })
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    eq(db.Use(New()), nil, "Use")
+
+    exporter := trace.NewMemoryExporter()
+    trace.SetDefault(trace.NewTracer(trace.NewSimpleProcessor(exporter)))
+    defer trace.SetDefault(nil)
+
+    // 没有链路时不记录
+    var users []testUser
+    db.Where("id = ?", 1).Find(&users)
+    eq(len(exporter.Spans()), 0, "without parent")
+
+    ctx, root := trace.Start(context.Background(), "root")
+    db.WithContext(ctx).Where("id = ?", 1).Find(&users)
+    root.End()
+
+    span, ok := exporter.FindSpan("db.query users")
+    eq(ok, true, "query span")
+    eq(span.Kind, trace.SpanKindClient, "kind")
+    eq(span.ParentSpanID, root.SpanContext().SpanID, "parent")
+    eq(span.Attributes["db.system"], "mysql", "db.system")
+    eq(span.Attributes["db.sql.table"], "users", "db.sql.table")
+    eq(span.Attributes["db.statement"], "SELECT * FROM `users` WHERE id = ?", "db.statement")
+}
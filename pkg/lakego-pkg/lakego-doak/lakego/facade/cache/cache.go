@@ -3,13 +3,21 @@ package cache
 import (
     "strings"
 
+    goredis "github.com/go-redis/redis/v8"
+
+    "github.com/deatil/lakego-doak/lakego/path"
     "github.com/deatil/lakego-doak/lakego/array"
     "github.com/deatil/lakego-doak/lakego/register"
     "github.com/deatil/lakego-doak/lakego/facade/config"
     "github.com/deatil/lakego-doak/lakego/facade/logger"
+    "github.com/deatil/lakego-doak/lakego/facade/database"
     "github.com/deatil/lakego-doak/lakego/cache"
     "github.com/deatil/lakego-doak/lakego/cache/interfaces"
+    fileDriver "github.com/deatil/lakego-doak/lakego/cache/driver/file"
     redisDriver "github.com/deatil/lakego-doak/lakego/cache/driver/redis"
+    memoryDriver "github.com/deatil/lakego-doak/lakego/cache/driver/memory"
+    layeredDriver "github.com/deatil/lakego-doak/lakego/cache/driver/layered"
+    databaseDriver "github.com/deatil/lakego-doak/lakego/cache/driver/database"
 )
 
 /**
@@ -77,6 +85,16 @@ func Cache(name string, once ...bool) *cache.Cache {
     keyPrefix := conf.GetString("key-prefix")
     c.WithPrefix(keyPrefix)
 
+    // 编码器，用于 cache.GetAs 等类型化方法
+    if codecName := cfg.Value(name + ".codec").ToString(); codecName != "" {
+        codec := cache.GetCodec(codecName)
+        if codec == nil {
+            panic("缓存编码器[" + codecName + "]不存在")
+        }
+
+        c.WithCodec(codec)
+    }
+
     return c
 }
 
@@ -112,5 +130,100 @@ func registerDriver() {
 
             return driver
         })
-}
 
+    // 内存缓存
+    register.
+        NewManagerWithPrefix("cache").
+        Register("memory", func(conf map[string]any) any {
+            cfg := array.ArrayFrom(conf)
+
+            driver := memoryDriver.New(memoryDriver.Config{
+                Size:            cfg.Value("size").ToInt(),
+                CleanupInterval: cfg.Value("cleanup-interval").ToDuration(),
+            })
+
+            return driver
+        })
+
+    // 文件缓存
+    register.
+        NewManagerWithPrefix("cache").
+        Register("file", func(conf map[string]any) any {
+            cfg := array.ArrayFrom(conf)
+
+            cachePath := cfg.Value("path").ToString()
+            if cachePath == "" {
+                cachePath = "{runtime}/cache"
+            }
+
+            driver := fileDriver.New(fileDriver.Config{
+                Path: path.FormatPath(cachePath),
+            })
+
+            return driver
+        })
+
+    // 数据库缓存
+    register.
+        NewManagerWithPrefix("cache").
+        Register("database", func(conf map[string]any) any {
+            cfg := array.ArrayFrom(conf)
+
+            db := database.Default
+            if conn := cfg.Value("connection").ToString(); conn != "" {
+                db = database.Database(conn)
+            }
+
+            driver := databaseDriver.New(databaseDriver.Config{
+                DB:          db,
+                Table:       cfg.Value("table").ToString(),
+                AutoMigrate: cfg.Value("auto-migrate").ToBool(),
+            })
+
+            return driver
+        })
+    // 二级缓存
+    register.
+        NewManagerWithPrefix("cache").
+        Register("layered", func(conf map[string]any) any {
+            cfg := array.ArrayFrom(conf)
+
+            remote := Cache(cfg.Value("remote").ToString(), true).GetDriver()
+
+            local := memoryDriver.New(memoryDriver.Config{
+                Size:            cfg.Value("local-size").ToInt(),
+                CleanupInterval: cfg.Value("cleanup-interval").ToDuration(),
+            })
+
+            // 规则前缀不包含缓存前缀
+            keyPrefix := config.New("cache").GetString("key-prefix")
+            if keyPrefix != "" {
+                keyPrefix += ":"
+            }
+
+            rules := make([]layeredDriver.Rule, 0)
+            for _, rule := range cfg.Value("rules").ToSlice() {
+                r := array.ArrayFrom(rule)
+
+                rules = append(rules, layeredDriver.Rule{
+                    Prefix: keyPrefix + r.Value("prefix").ToString(),
+                    TTL:    r.Value("ttl").ToDuration(),
+                })
+            }
+
+            var pubsub layeredDriver.PubSub
+            if client, ok := remote.(interface{ GetClient() *goredis.Client }); ok {
+                pubsub = layeredDriver.NewRedisPubSub(client.GetClient())
+            }
+
+            driver := layeredDriver.New(layeredDriver.Config{
+                Local:   local,
+                Remote:  remote,
+                PubSub:  pubsub,
+                Channel: cfg.Value("channel").ToString(),
+                Rules:   rules,
+            })
+
+            return driver
+        })
+}
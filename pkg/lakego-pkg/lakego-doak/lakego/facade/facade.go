@@ -46,6 +46,9 @@ var (
 
     // 文件操作
     NewStorageWithDisk = facade_storage.NewWithDisk
+
+    // 文件操作，配置错误时返回错误
+    TryStorageWithDisk = facade_storage.TryDisk
 )
 
 // 配置
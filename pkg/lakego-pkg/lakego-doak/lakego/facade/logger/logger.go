@@ -8,6 +8,7 @@ import (
     "github.com/deatil/lakego-doak/lakego/facade/config"
     "github.com/deatil/lakego-doak/lakego/logger"
     "github.com/deatil/lakego-doak/lakego/logger/interfaces"
+    multiDriver "github.com/deatil/lakego-doak/lakego/logger/driver/multi"
     logrusDriver "github.com/deatil/lakego-doak/lakego/logger/driver/logrus"
 )
 
@@ -98,5 +99,19 @@ func registerDriver() {
 
                 return driver
             },
+
+            // 多输出日志
+            "multi": func(conf map[string]any) any {
+                driver := multiDriver.New()
+
+                driver.WithConfig(conf)
+
+                return driver
+            },
         })
+
+    // slog 日志
+    register.
+        NewManagerWithPrefix("logger").
+        RegisterMany(slogDrivers())
 }
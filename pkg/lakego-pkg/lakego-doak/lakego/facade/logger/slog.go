@@ -0,0 +1,21 @@
+//go:build go1.21
+
+package logger
+
+import (
+    slogDriver "github.com/deatil/lakego-doak/lakego/logger/driver/slog"
+)
+
+// slog 驱动，需要 go1.21 及以上版本
+func slogDrivers() map[string]func(map[string]any) any {
+    return map[string]func(map[string]any) any {
+        // slog 日志
+        "slog": func(conf map[string]any) any {
+            driver := slogDriver.New()
+
+            driver.WithConfig(conf)
+
+            return driver
+        },
+    }
+}
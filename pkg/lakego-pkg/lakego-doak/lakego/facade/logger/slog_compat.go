@@ -0,0 +1,8 @@
+//go:build !go1.21
+
+package logger
+
+// go1.21 以下版本没有 slog 驱动
+func slogDrivers() map[string]func(map[string]any) any {
+    return map[string]func(map[string]any) any {}
+}
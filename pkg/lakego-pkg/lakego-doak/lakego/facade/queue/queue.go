@@ -0,0 +1,226 @@
+package queue
+
+import (
+    "sync"
+    "context"
+    "strings"
+
+    "github.com/deatil/go-goch/goch"
+
+    "github.com/deatil/lakego-doak/lakego/array"
+    "github.com/deatil/lakego-doak/lakego/queue"
+    "github.com/deatil/lakego-doak/lakego/register"
+    "github.com/deatil/lakego-doak/lakego/facade/redis"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+    "github.com/deatil/lakego-doak/lakego/facade/logger"
+    "github.com/deatil/lakego-doak/lakego/facade/database"
+    "github.com/deatil/lakego-doak/lakego/queue/interfaces"
+    redisDriver "github.com/deatil/lakego-doak/lakego/queue/driver/redis"
+    memoryDriver "github.com/deatil/lakego-doak/lakego/queue/driver/memory"
+    databaseDriver "github.com/deatil/lakego-doak/lakego/queue/driver/database"
+)
+
+/**
+ * 任务队列
+ *
+ * queue.Register(queue.Default, "send-mail", func(ctx context.Context, mail Mail) error {
+ *     return nil
+ * })
+ * queue.Default.Dispatch("send-mail", mail, queue.Delay(time.Minute))
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+
+// 默认
+var Default *queue.Queue
+
+// 运行中的执行者
+var (
+    workerMu sync.Mutex
+    workers  []*queue.Worker
+)
+
+// 初始化
+func init() {
+    // 注册默认
+    registerDriver()
+
+    // 默认
+    Default = New()
+}
+
+// 实例化
+func New(once ...bool) *queue.Queue {
+    name := GetDefaultConnection()
+
+    return Connection(name, once...)
+}
+
+// 指定连接
+func Connection(name string, once ...bool) *queue.Queue {
+    conf := config.New("queue")
+
+    // 连接列表
+    cfg := array.ArrayFrom(conf.GetStringMap("connections"))
+
+    // 转为小写
+    name = strings.ToLower(name)
+
+    if !cfg.Has(name) {
+        panic("队列连接[" + name + "]配置不存在")
+    }
+
+    // 配置
+    driverConf := cfg.Value(name).ToStringMap()
+    driverType := cfg.Value(name + ".type").ToString()
+
+    driver := register.
+        NewManagerWithPrefix("queue").
+        GetRegister(driverType, driverConf, once...)
+    if driver == nil {
+        panic("队列驱动[" + driverType + "]没有被注册")
+    }
+
+    q := queue.New(driver.(interfaces.Driver)).
+        WithQueue(cfg.Value(name + ".queue").ToString()).
+        WithMaxAttempts(conf.GetInt("tries")).
+        WithTimeout(conf.GetDuration("timeout"))
+
+    // 重试间隔
+    if backoff := conf.GetDuration("backoff"); backoff > 0 {
+        q.WithBackoff(queue.ExponentialBackoff(backoff, conf.GetDuration("max-backoff")))
+    }
+
+    return q
+}
+
+// 默认连接
+func GetDefaultConnection() string {
+    return config.New("queue").GetString("default")
+}
+
+// 创建默认连接的数据表，连接开启 auto-migrate 且驱动支持时执行
+func Migrate() error {
+    name := strings.ToLower(GetDefaultConnection())
+
+    cfg := array.ArrayFrom(config.New("queue").GetStringMap("connections"))
+    if !cfg.Value(name + ".auto-migrate").ToBool() {
+        return nil
+    }
+
+    migrator, ok := Default.GetDriver().(interface{ Migrate() error })
+    if !ok {
+        return nil
+    }
+
+    return migrator.Migrate()
+}
+
+// 创建执行者，queues 为空时使用配置的队列
+// 创建的执行者可通过 Shutdown 统一停止
+func NewWorker(queues map[string]int) *queue.Worker {
+    if len(queues) == 0 {
+        queues = GetWorkerQueues()
+    }
+
+    w := queue.NewWorker(Default, queues).
+        WithSleep(config.New("queue").GetDuration("worker.sleep")).
+        WithLogger(logger.New())
+
+    workerMu.Lock()
+    workers = append(workers, w)
+    workerMu.Unlock()
+
+    return w
+}
+
+// 配置的队列及并发数
+func GetWorkerQueues() map[string]int {
+    queues := make(map[string]int)
+
+    conf := config.New("queue").GetStringMap("worker.queues")
+    for name, concurrency := range conf {
+        queues[name] = goch.ToInt(concurrency)
+    }
+
+    return queues
+}
+
+// 停止全部执行者，等待执行中的任务完成
+func Shutdown(ctx context.Context) error {
+    workerMu.Lock()
+    list := workers
+    workers = nil
+    workerMu.Unlock()
+
+    var errs []error
+    for _, w := range list {
+        if err := w.Stop(ctx); err != nil {
+            errs = append(errs, err)
+        }
+    }
+
+    if len(errs) > 0 {
+        return errs[0]
+    }
+
+    return nil
+}
+
+// 注册
+func registerDriver() {
+    // 内存队列
+    register.
+        NewManagerWithPrefix("queue").
+        Register("memory", func(conf map[string]any) any {
+            cfg := array.ArrayFrom(conf)
+
+            driver := memoryDriver.New(memoryDriver.Config{
+                RetryAfter: cfg.Value("retry-after").ToDuration(),
+            })
+
+            return driver
+        })
+
+    // 数据库队列
+    register.
+        NewManagerWithPrefix("queue").
+        Register("database", func(conf map[string]any) any {
+            cfg := array.ArrayFrom(conf)
+
+            db := database.Default
+            if conn := cfg.Value("connection").ToString(); conn != "" {
+                db = database.Database(conn)
+            }
+
+            driver := databaseDriver.New(databaseDriver.Config{
+                DB:          db,
+                Table:       cfg.Value("table").ToString(),
+                FailedTable: cfg.Value("failed-table").ToString(),
+                RetryAfter:  cfg.Value("retry-after").ToDuration(),
+            })
+
+            return driver
+        })
+
+    // redis 队列
+    register.
+        NewManagerWithPrefix("queue").
+        Register("redis", func(conf map[string]any) any {
+            cfg := array.ArrayFrom(conf)
+
+            client := redis.Default
+            if conn := cfg.Value("connection").ToString(); conn != "" {
+                client = redis.Connect(conn)
+            }
+
+            driver := redisDriver.New(redisDriver.Config{
+                Client:     client.GetClient(),
+                Prefix:     cfg.Value("prefix").ToString(),
+                RetryAfter: cfg.Value("retry-after").ToDuration(),
+            })
+
+            return driver
+        })
+}
@@ -1,16 +1,31 @@
 package storage
 
 import(
+    "os"
+    "errors"
     "strings"
+    "time"
+    "net/url"
+    "encoding/hex"
+    "net/http"
 
     "github.com/deatil/lakego-doak/lakego/path"
     "github.com/deatil/lakego-doak/lakego/array"
     "github.com/deatil/lakego-doak/lakego/storage"
     "github.com/deatil/lakego-doak/lakego/register"
+    "github.com/deatil/lakego-doak/lakego/facade/cache"
     "github.com/deatil/lakego-doak/lakego/facade/config"
 
     "github.com/deatil/go-filesystem/filesystem"
     "github.com/deatil/go-filesystem/filesystem/interfaces"
+    s3Adapter "github.com/deatil/go-filesystem/filesystem/adapter/s3"
+    ftpAdapter "github.com/deatil/go-filesystem/filesystem/adapter/ftp"
+    zipAdapter "github.com/deatil/go-filesystem/filesystem/adapter/zip"
+    cachedAdapter "github.com/deatil/go-filesystem/filesystem/adapter/cached"
+    encryptAdapter "github.com/deatil/go-filesystem/filesystem/adapter/encrypt"
+    readonlyAdapter "github.com/deatil/go-filesystem/filesystem/adapter/readonly"
+    sftpAdapter "github.com/deatil/go-filesystem/filesystem/adapter/sftp"
+    memoryAdapter "github.com/deatil/go-filesystem/filesystem/adapter/memory"
     localAdapter "github.com/deatil/go-filesystem/filesystem/adapter/local"
 )
 
@@ -44,6 +59,16 @@ func MountManager(filesystems ...map[string]any) *filesystem.MountManager {
 }
 
 func Disk(name string, once ...bool) *storage.Storage {
+    disk, err := TryDisk(name, once...)
+    if err != nil {
+        panic(err.Error())
+    }
+
+    return disk
+}
+
+// 实例化磁盘，配置错误时返回错误
+func TryDisk(name string, once ...bool) (*storage.Storage, error) {
     // 磁盘列表
     disks := config.New("filesystem").GetStringMap("disks")
 
@@ -54,7 +79,7 @@ func Disk(name string, once ...bool) *storage.Storage {
 
     // 获取驱动配置
     if !cfg.Has(name) {
-        panic("文件管理器[" + name + "]配置不存在")
+        return nil, errors.New("文件管理器[" + name + "]配置不存在")
     }
 
     // 配置
@@ -66,16 +91,90 @@ func Disk(name string, once ...bool) *storage.Storage {
         NewManagerWithPrefix("database").
         GetRegister(diskType, diskConf, once...)
     if driver == nil {
-        panic("文件管理器驱动[" + diskType + "]没有被注册")
+        return nil, errors.New("文件管理器驱动[" + diskType + "]没有被注册")
+    }
+
+    adapter, err := decorateAdapter(name, diskConf, driver.(interfaces.Adapter))
+    if err != nil {
+        return nil, err
     }
 
     // 磁盘
-    disk := filesystem.New(driver.(interfaces.Adapter), diskConf)
+    disk := filesystem.New(adapter, diskConf)
+
+    // 临时链接验证地址，磁盘未设置时使用全局配置
+    temporaryUrl := cfg.Value(name + ".temporary-url").ToString()
+    if temporaryUrl == "" {
+        temporaryUrl = config.New("filesystem").GetString("temporary-url.url")
+    }
 
     // 使用自定义文件管理器
-    disk2 := storage.NewWithFilesystem(disk)
+    disk2 := storage.NewWithFilesystem(disk).
+        WithDisk(name).
+        WithTemporaryUrl(temporaryUrl).
+        WithUrlSigner(UrlSigner())
+
+    return disk2, nil
+}
+
+// 按磁盘配置包装适配器，顺序为 加密 -> 缓存 -> 只读
+func decorateAdapter(name string, conf map[string]any, adapter interfaces.Adapter) (interfaces.Adapter, error) {
+    cfg := array.ArrayFrom(conf)
+
+    // 加密存储，密钥为 hex 编码
+    if cfg.Has("encrypt") {
+        key, err := hex.DecodeString(cfg.Value("encrypt.key").ToString())
+        if err != nil {
+            return nil, errors.New("文件管理器[" + name + "]加密密钥错误: " + err.Error())
+        }
+
+        encrypted, err := encryptAdapter.New(adapter, encryptAdapter.Config{
+            Cipher:    cfg.Value("encrypt.cipher").ToString(),
+            Key:       key,
+            ChunkSize: cfg.Value("encrypt.chunk-size").ToInt(),
+        })
+        if err != nil {
+            return nil, errors.New("文件管理器[" + name + "]加密配置错误: " + err.Error())
+        }
+
+        adapter = encrypted
+    }
+
+    // 文件信息缓存
+    if cfg.Has("cache") {
+        store := cfg.Value("cache.store").ToString()
 
-    return disk2
+        c := cache.New()
+        if store != "" {
+            c = cache.Cache(store)
+        }
+
+        ttl := time.Duration(cfg.Value("cache.ttl").ToInt64()) * time.Second
+        if ttl <= 0 {
+            ttl = 300 * time.Second
+        }
+
+        adapter = cachedAdapter.New(adapter, storage.NewCacheStore(c), "filesystem:" + name + ":", ttl)
+    }
+
+    // 只读
+    if cfg.Value("read-only").ToBool() {
+        adapter = readonlyAdapter.New(adapter)
+    }
+
+    return adapter, nil
+}
+
+// 临时链接签名
+func UrlSigner() *storage.UrlSigner {
+    key := config.New("filesystem").GetString("temporary-url.key")
+
+    return storage.NewUrlSigner(key)
+}
+
+// 验证临时链接参数
+func VerifyTemporaryUrl(query url.Values, ip string) (*storage.SignedUrl, error) {
+    return UrlSigner().Verify(query, ip)
 }
 
 func GetDefaultDisk() string {
@@ -95,6 +194,134 @@ func registerStorage() {
 
             driver := localAdapter.New(root)
 
+            return driver
+        })
+
+    // S3 兼容对象存储
+    register.
+        NewManagerWithPrefix("database").
+        Register("s3", func(conf map[string]any) any {
+            cfg := array.ArrayFrom(conf)
+
+            timeout := cfg.Value("timeout").ToDuration()
+            if timeout == 0 {
+                timeout = 60 * time.Second
+            }
+
+            driver, err := s3Adapter.New(s3Adapter.Config{
+                Endpoint:     cfg.Value("endpoint").ToString(),
+                Region:       cfg.Value("region").ToString(),
+                Bucket:       cfg.Value("bucket").ToString(),
+                AccessKey:    cfg.Value("key").ToString(),
+                SecretKey:    cfg.Value("secret").ToString(),
+                SessionToken: cfg.Value("token").ToString(),
+                Root:         cfg.Value("root").ToString(),
+                UsePathStyle: cfg.Value("use-path-style").ToBool(),
+                PartSize:     cfg.Value("part-size").ToInt64(),
+                Client:       &http.Client{
+                    Timeout: timeout,
+                },
+            })
+            if err != nil {
+                panic("文件管理器驱动[s3]配置错误: " + err.Error())
+            }
+
+            return driver
+        })
+
+    // SFTP
+    register.
+        NewManagerWithPrefix("database").
+        Register("sftp", func(conf map[string]any) any {
+            cfg := array.ArrayFrom(conf)
+
+            // 私钥可为文件路径或 PEM 数据
+            var privateKey []byte
+            if key := cfg.Value("private-key").ToString(); key != "" {
+                if strings.Contains(key, "-----BEGIN") {
+                    privateKey = []byte(key)
+                } else {
+                    data, err := os.ReadFile(path.FormatPath(key))
+                    if err != nil {
+                        panic("文件管理器驱动[sftp]私钥读取失败: " + err.Error())
+                    }
+
+                    privateKey = data
+                }
+            }
+
+            driver, err := sftpAdapter.New(sftpAdapter.Config{
+                Host:        cfg.Value("host").ToString(),
+                Port:        cfg.Value("port").ToInt(),
+                Username:    cfg.Value("username").ToString(),
+                Password:    cfg.Value("password").ToString(),
+                PrivateKey:  privateKey,
+                Passphrase:  cfg.Value("passphrase").ToString(),
+                HostKey:     cfg.Value("host-key").ToString(),
+                Root:        cfg.Value("root").ToString(),
+                Timeout:     cfg.Value("timeout").ToDuration(),
+                MaxOpen:     cfg.Value("max-open").ToInt(),
+                MaxIdle:     cfg.Value("max-idle").ToInt(),
+                IdleTimeout: cfg.Value("idle-timeout").ToDuration(),
+
+                InsecureIgnoreHostKey: cfg.Value("insecure-ignore-host-key").ToBool(),
+            })
+            if err != nil {
+                panic("文件管理器驱动[sftp]配置错误: " + err.Error())
+            }
+
+            return driver
+        })
+
+    // FTP
+    register.
+        NewManagerWithPrefix("database").
+        Register("ftp", func(conf map[string]any) any {
+            cfg := array.ArrayFrom(conf)
+
+            driver, err := ftpAdapter.New(ftpAdapter.Config{
+                Host:        cfg.Value("host").ToString(),
+                Port:        cfg.Value("port").ToInt(),
+                Username:    cfg.Value("username").ToString(),
+                Password:    cfg.Value("password").ToString(),
+                Root:        cfg.Value("root").ToString(),
+                Timeout:     cfg.Value("timeout").ToDuration(),
+                MaxOpen:     cfg.Value("max-open").ToInt(),
+                MaxIdle:     cfg.Value("max-idle").ToInt(),
+                IdleTimeout: cfg.Value("idle-timeout").ToDuration(),
+            })
+            if err != nil {
+                panic("文件管理器驱动[ftp]配置错误: " + err.Error())
+            }
+
+            return driver
+        })
+
+    // 内存存储
+    register.
+        NewManagerWithPrefix("database").
+        Register("memory", func(conf map[string]any) any {
+            driver := memoryAdapter.New()
+
+            return driver
+        })
+
+    // zip 压缩包
+    register.
+        NewManagerWithPrefix("database").
+        Register("zip", func(conf map[string]any) any {
+            file := path.FormatPath(array.ArrayFrom(conf).Value("path").ToString())
+
+            // 文件不存在时新建压缩包
+            if _, err := os.Stat(file); os.IsNotExist(err) {
+                return zipAdapter.New(file)
+            }
+
+            driver, err := zipAdapter.Open(file)
+            if err != nil {
+                panic("文件管理器驱动[zip]配置错误: " + err.Error())
+            }
+
             return driver
         })
 }
@@ -17,6 +17,8 @@ type Payload struct {
 type Handler func(value any)
 
 // 消息中间件
+//
+// Deprecated: 消息不持久化且关闭时会丢失，使用 lakego/queue 代替
 type GMQ struct {
     // 载荷
     payload chan Payload
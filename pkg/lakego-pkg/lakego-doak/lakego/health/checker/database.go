@@ -0,0 +1,26 @@
+package checker
+
+import (
+    "context"
+
+    "gorm.io/gorm"
+
+    "github.com/deatil/lakego-doak/lakego/health"
+)
+
+/**
+ * 数据库检测
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Database(name string, db *gorm.DB) health.Checker {
+    return health.NewChecker(name, func(ctx context.Context) error {
+        sqlDB, err := db.DB()
+        if err != nil {
+            return err
+        }
+
+        return sqlDB.PingContext(ctx)
+    })
+}
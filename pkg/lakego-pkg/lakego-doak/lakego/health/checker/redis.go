@@ -0,0 +1,21 @@
+package checker
+
+import (
+    "context"
+
+    "github.com/go-redis/redis/v8"
+
+    "github.com/deatil/lakego-doak/lakego/health"
+)
+
+/**
+ * redis 检测
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Redis(name string, client *redis.Client) health.Checker {
+    return health.NewChecker(name, func(ctx context.Context) error {
+        return client.Ping(ctx).Err()
+    })
+}
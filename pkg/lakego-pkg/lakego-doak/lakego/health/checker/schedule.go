@@ -0,0 +1,34 @@
+package checker
+
+import (
+    "time"
+    "errors"
+    "context"
+
+    "github.com/deatil/lakego-doak/lakego/health"
+    "github.com/deatil/lakego-doak/lakego/schedule"
+)
+
+/**
+ * 计划任务检测
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Schedule(name string, s *schedule.Schedule) health.Checker {
+    return health.NewChecker(name, func(ctx context.Context) error {
+        if !s.IsRunning() {
+            return errors.New("schedule is not running")
+        }
+
+        // 下次运行时间已过去较久说明任务被阻塞
+        now := time.Now().In(s.CronLocation())
+        for _, entry := range s.CronEntries() {
+            if !entry.Next.IsZero() && now.Sub(entry.Next) > time.Minute {
+                return errors.New("schedule is stalled")
+            }
+        }
+
+        return nil
+    })
+}
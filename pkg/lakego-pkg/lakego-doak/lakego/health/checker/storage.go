@@ -0,0 +1,48 @@
+package checker
+
+import (
+    "sync"
+    "errors"
+    "context"
+
+    "github.com/deatil/lakego-doak/lakego/health"
+    "github.com/deatil/lakego-doak/lakego/storage"
+)
+
+// 磁盘根目录不存在
+var ErrStorageRootMissing = errors.New("health: storage root does not exist")
+
+/**
+ * 文件磁盘检测
+ *
+ * 磁盘在首次检测时才创建，配置错误只影响该项检测结果，
+ * 创建失败时下次检测重试
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Storage(name string, resolve func() (*storage.Storage, error)) health.Checker {
+    var mu sync.Mutex
+    var disk *storage.Storage
+
+    return health.NewChecker(name, func(ctx context.Context) error {
+        mu.Lock()
+        if disk == nil {
+            d, err := resolve()
+            if err != nil {
+                mu.Unlock()
+                return err
+            }
+
+            disk = d
+        }
+        mu.Unlock()
+
+        // 根目录不存在时 ListContents 也不会返回错误，直接检测根目录
+        if !disk.GetAdapter().Has("") {
+            return ErrStorageRootMissing
+        }
+
+        return nil
+    })
+}
@@ -0,0 +1,33 @@
+package health
+
+import (
+    "time"
+    "net/http"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+// 存活检测
+func LiveHandler(h *Health) router.HandlerFunc {
+    return func(ctx *router.Context) {
+        ctx.JSON(http.StatusOK, router.H{
+            "status": StatusUp,
+            "time":   time.Now(),
+            "uptime": h.Uptime().String(),
+        })
+    }
+}
+
+// 就绪检测
+func ReadyHandler(h *Health) router.HandlerFunc {
+    return func(ctx *router.Context) {
+        report := h.Check(ctx.Request.Context())
+
+        status := http.StatusOK
+        if !report.IsUp() {
+            status = http.StatusServiceUnavailable
+        }
+
+        ctx.JSON(status, report)
+    }
+}
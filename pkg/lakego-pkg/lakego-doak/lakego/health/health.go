@@ -0,0 +1,310 @@
+package health
+
+import (
+    "sync"
+    "time"
+    "context"
+    "errors"
+)
+
+// 状态
+const (
+    StatusUp   = "up"
+    StatusDown = "down"
+)
+
+// 默认
+var defaultHealth = New()
+
+// 默认检测器
+func Default() *Health {
+    return defaultHealth
+}
+
+/**
+ * 检测接口
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Checker interface {
+    // 名称
+    Name() string
+
+    // 检测
+    Check(context.Context) error
+}
+
+// 函数检测
+type checkerFunc struct {
+    name string
+    fn   func(context.Context) error
+}
+
+func (this checkerFunc) Name() string {
+    return this.name
+}
+
+func (this checkerFunc) Check(ctx context.Context) error {
+    return this.fn(ctx)
+}
+
+// 使用函数生成检测
+func NewChecker(name string, fn func(context.Context) error) Checker {
+    return checkerFunc{name, fn}
+}
+
+// 单项检测结果
+type Result struct {
+    Name      string    `json:"name"`
+    Status    string    `json:"status"`
+    Error     string    `json:"error,omitempty"`
+    Duration  string    `json:"duration"`
+    CheckedAt time.Time `json:"checked_at"`
+    Cached    bool      `json:"cached"`
+}
+
+// 检测报告
+type Report struct {
+    Status string    `json:"status"`
+    Time   time.Time `json:"time"`
+    Checks []Result  `json:"checks"`
+}
+
+// 是否正常
+func (this Report) IsUp() bool {
+    return this.Status == StatusUp
+}
+
+// 已注册检测
+type entry struct {
+    // 锁定
+    mu sync.Mutex
+
+    // 检测
+    checker Checker
+
+    // 超时时间
+    timeout time.Duration
+
+    // 最后结果
+    last *Result
+}
+
+/**
+ * 健康检测
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Health struct {
+    // 锁定
+    mu sync.RWMutex
+
+    // 检测列表
+    entries []*entry
+
+    // 默认超时时间
+    timeout time.Duration
+
+    // 结果缓存时间
+    cacheTTL time.Duration
+
+    // 启动时间
+    startTime time.Time
+}
+
+// 构造函数
+func New() *Health {
+    return &Health{
+        entries:   make([]*entry, 0),
+        timeout:   3 * time.Second,
+        startTime: time.Now(),
+    }
+}
+
+// 设置默认超时时间
+func (this *Health) WithTimeout(timeout time.Duration) *Health {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if timeout > 0 {
+        this.timeout = timeout
+    }
+
+    return this
+}
+
+// 设置结果缓存时间
+func (this *Health) WithCacheTTL(ttl time.Duration) *Health {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.cacheTTL = ttl
+
+    return this
+}
+
+// 注册检测，同名时覆盖
+func (this *Health) Register(checker Checker, timeout ...time.Duration) *Health {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    e := &entry{
+        checker: checker,
+    }
+
+    if len(timeout) > 0 {
+        e.timeout = timeout[0]
+    }
+
+    for i, old := range this.entries {
+        if old.checker.Name() == checker.Name() {
+            this.entries[i] = e
+            return this
+        }
+    }
+
+    this.entries = append(this.entries, e)
+
+    return this
+}
+
+// 移除检测
+func (this *Health) Remove(name string) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    entries := make([]*entry, 0, len(this.entries))
+    for _, e := range this.entries {
+        if e.checker.Name() != name {
+            entries = append(entries, e)
+        }
+    }
+
+    this.entries = entries
+}
+
+// 已注册检测名称
+func (this *Health) Names() []string {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    names := make([]string, 0, len(this.entries))
+    for _, e := range this.entries {
+        names = append(names, e.checker.Name())
+    }
+
+    return names
+}
+
+// 运行时长
+func (this *Health) Uptime() time.Duration {
+    return time.Since(this.startTime)
+}
+
+// 运行全部检测
+func (this *Health) Check(ctx context.Context) Report {
+    this.mu.RLock()
+    entries := make([]*entry, len(this.entries))
+    copy(entries, this.entries)
+    timeout := this.timeout
+    cacheTTL := this.cacheTTL
+    this.mu.RUnlock()
+
+    results := make([]Result, len(entries))
+
+    var wg sync.WaitGroup
+    for i, e := range entries {
+        wg.Add(1)
+
+        go func(i int, e *entry) {
+            defer wg.Done()
+
+            results[i] = e.run(ctx, timeout, cacheTTL)
+        }(i, e)
+    }
+
+    wg.Wait()
+
+    report := Report{
+        Status: StatusUp,
+        Time:   time.Now(),
+        Checks: results,
+    }
+
+    for _, r := range results {
+        if r.Status != StatusUp {
+            report.Status = StatusDown
+            break
+        }
+    }
+
+    return report
+}
+
+// 运行单个检测
+func (this *entry) run(ctx context.Context, timeout, cacheTTL time.Duration) Result {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if cacheTTL > 0 && this.last != nil && time.Since(this.last.CheckedAt) < cacheTTL {
+        cached := *this.last
+        cached.Cached = true
+
+        return cached
+    }
+
+    if this.timeout > 0 {
+        timeout = this.timeout
+    }
+
+    start := time.Now()
+    err := runWithTimeout(ctx, this.checker, timeout)
+
+    result := Result{
+        Name:      this.checker.Name(),
+        Status:    StatusUp,
+        Duration:  time.Since(start).String(),
+        CheckedAt: start,
+    }
+
+    if err != nil {
+        result.Status = StatusDown
+        result.Error = err.Error()
+    }
+
+    this.last = &result
+
+    return result
+}
+
+// 超时控制，检测未响应 ctx 时也能按时返回
+func runWithTimeout(ctx context.Context, checker Checker, timeout time.Duration) (err error) {
+    ctx, cancel := context.WithTimeout(ctx, timeout)
+    defer cancel()
+
+    done := make(chan error, 1)
+
+    go func() {
+        defer func() {
+            if r := recover(); r != nil {
+                done <- errors.New("health: checker panic")
+            }
+        }()
+
+        done <- checker.Check(ctx)
+    }()
+
+    select {
+        case err = <-done:
+            return err
+        case <-ctx.Done():
+            return errors.New("health: check timeout")
+    }
+}
+
+// 注册到默认检测器
+func Register(checker Checker, timeout ...time.Duration) *Health {
+    return defaultHealth.Register(checker, timeout...)
+}
@@ -0,0 +1,68 @@
+package health
+
+import (
+    "time"
+    "errors"
+    "context"
+    "testing"
+    "reflect"
+)
+
+func assertT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func Test_Check(t *testing.T) {
+    assert := assertT(t)
+
+    h := New()
+    h.Register(NewChecker("ok", func(ctx context.Context) error {
+        return nil
+    }))
+
+    report := h.Check(context.Background())
+    assert(report.Status, StatusUp, "Test_Check up")
+
+    h.Register(NewChecker("fail", func(ctx context.Context) error {
+        return errors.New("fail")
+    }))
+
+    report = h.Check(context.Background())
+    assert(report.Status, StatusDown, "Test_Check down")
+    assert(report.Checks[1].Error, "fail", "Test_Check error")
+}
+
+func Test_Timeout(t *testing.T) {
+    assert := assertT(t)
+
+    h := New()
+    h.Register(NewChecker("slow", func(ctx context.Context) error {
+        time.Sleep(time.Second)
+        return nil
+    }), 10 * time.Millisecond)
+
+    report := h.Check(context.Background())
+    assert(report.Checks[0].Status, StatusDown, "Test_Timeout")
+}
+
+func Test_CacheTTL(t *testing.T) {
+    assert := assertT(t)
+
+    n := 0
+
+    h := New().WithCacheTTL(time.Minute)
+    h.Register(NewChecker("count", func(ctx context.Context) error {
+        n++
+        return nil
+    }))
+
+    h.Check(context.Background())
+    report := h.Check(context.Background())
+
+    assert(n, 1, "Test_CacheTTL count")
+    assert(report.Checks[0].Cached, true, "Test_CacheTTL cached")
+}
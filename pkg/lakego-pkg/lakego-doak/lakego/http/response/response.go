@@ -2,9 +2,12 @@ package response
 
 import (
     "io"
+    "os"
+    "strconv"
     "net/http"
 
     "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/view/data"
 )
 
 // 默认
@@ -89,6 +92,9 @@ func (this *Response) ReturnJsonFromString(jsonStr string) {
 
 // 渲染模板
 func (this *Response) View(template string, obj any) {
+    // 合并中间件添加的模板数据
+    obj = data.Merge(this.ctx, obj)
+
     this.ctx.HTML(this.httpCode, template, obj)
 }
 
@@ -97,6 +103,12 @@ func (this *Response) Download(filePath string, fileName string) {
     this.ctx.Header("Content-Type", "application/octet-stream")
     this.ctx.Header("Content-Disposition", "attachment; filename=" + fileName)
     this.ctx.Header("Content-Transfer-Encoding", "binary")
+
+    // 文件未修改时返回 304，Last-Modified 由 File 设置
+    if info, err := os.Stat(filePath); err == nil && !info.IsDir() {
+        this.ctx.Header("ETag", `W/"` + strconv.FormatInt(info.Size(), 36) + "-" + strconv.FormatInt(info.ModTime().UnixNano(), 36) + `"`)
+    }
+
     this.ctx.File(filePath)
 }
 
@@ -0,0 +1,47 @@
+package i18n
+
+import (
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+// 上下文中保存语言的键名
+const ContextKey = "locale"
+
+// 默认
+var defaultTranslator = New()
+
+// 默认翻译
+func Default() *Translator {
+    return defaultTranslator
+}
+
+// 设置默认翻译
+func SetDefault(translator *Translator) {
+    defaultTranslator = translator
+}
+
+// 设置请求使用的语言
+func SetLocale(ctx *router.Context, locale string) {
+    ctx.Set(ContextKey, Canonical(locale))
+}
+
+// 请求使用的语言，没有设置时返回默认语言
+func Locale(ctx *router.Context) string {
+    if ctx != nil {
+        if locale := ctx.GetString(ContextKey); locale != "" {
+            return locale
+        }
+    }
+
+    return defaultTranslator.GetFallback()
+}
+
+// 使用请求的语言翻译
+func Trans(ctx *router.Context, key string, params ...Params) string {
+    return defaultTranslator.Trans(Locale(ctx), key, params...)
+}
+
+// 使用请求的语言复数翻译
+func Choice(ctx *router.Context, key string, count int, params ...Params) string {
+    return defaultTranslator.Choice(Locale(ctx), key, count, params...)
+}
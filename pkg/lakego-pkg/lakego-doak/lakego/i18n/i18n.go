@@ -0,0 +1,393 @@
+package i18n
+
+import (
+    "os"
+    "sort"
+    "sync"
+    "errors"
+    "strings"
+    "path/filepath"
+    "encoding/json"
+
+    "gopkg.in/yaml.v3"
+    "golang.org/x/text/language"
+)
+
+// 命名空间分隔符，如 admin::passport.login-fail
+const NamespaceSeparator = "::"
+
+// 插值参数
+type Params = map[string]any
+
+var (
+    // 不支持的语言包文件
+    ErrInvalidFile = errors.New("i18n: catalogue file must be .yml, .yaml or .json")
+
+    // 语言包解析失败
+    ErrInvalidCatalogue = errors.New("i18n: invalid catalogue")
+)
+
+/**
+ * 翻译
+ *
+ * 语言包按 语言 -> 命名空间 -> 键名 保存，嵌套的键名以 . 连接
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Translator struct {
+    // 锁
+    mu sync.RWMutex
+
+    // 语言包
+    messages map[string]map[string]map[string]string
+
+    // 已添加语言，按添加顺序
+    locales []string
+
+    // 找不到翻译时使用的语言
+    fallback string
+
+    // 语言匹配
+    matcher language.Matcher
+
+    // 语言匹配使用的语言
+    matchLocales []string
+}
+
+// 构造函数
+func New() *Translator {
+    return &Translator{
+        messages: make(map[string]map[string]map[string]string),
+        fallback: "zh-CN",
+    }
+}
+
+// 设置默认语言
+func (this *Translator) WithFallback(locale string) *Translator {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.fallback = Canonical(locale)
+    this.matcher = nil
+
+    return this
+}
+
+// 默认语言
+func (this *Translator) GetFallback() string {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    return this.fallback
+}
+
+// 添加语言包，嵌套数据会展开为 . 连接的键名
+func (this *Translator) AddMessages(locale string, namespace string, messages map[string]any) *Translator {
+    locale = Canonical(locale)
+
+    flat := make(map[string]string)
+    flatten("", messages, flat)
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if _, ok := this.messages[locale]; !ok {
+        this.messages[locale] = make(map[string]map[string]string)
+        this.locales = append(this.locales, locale)
+        this.matcher = nil
+    }
+
+    if _, ok := this.messages[locale][namespace]; !ok {
+        this.messages[locale][namespace] = make(map[string]string)
+    }
+
+    for key, value := range flat {
+        this.messages[locale][namespace][key] = value
+    }
+
+    return this
+}
+
+// 导入语言包文件，文件名为语言，如 en.yml、zh-CN.json
+func (this *Translator) LoadFile(namespace string, file string) error {
+    ext := strings.ToLower(filepath.Ext(file))
+    if ext != ".yml" && ext != ".yaml" && ext != ".json" {
+        return ErrInvalidFile
+    }
+
+    content, err := os.ReadFile(file)
+    if err != nil {
+        return err
+    }
+
+    messages := make(map[string]any)
+    if ext == ".json" {
+        err = json.Unmarshal(content, &messages)
+    } else {
+        err = yaml.Unmarshal(content, &messages)
+    }
+
+    if err != nil {
+        return errors.New(ErrInvalidCatalogue.Error() + " " + file + ": " + err.Error())
+    }
+
+    locale := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+    this.AddMessages(locale, namespace, messages)
+
+    return nil
+}
+
+// 导入目录下的语言包文件，目录不存在时跳过
+func (this *Translator) LoadDir(namespace string, dir string) error {
+    entries, err := os.ReadDir(dir)
+    if err != nil {
+        if os.IsNotExist(err) {
+            return nil
+        }
+
+        return err
+    }
+
+    for _, entry := range entries {
+        if entry.IsDir() {
+            continue
+        }
+
+        err := this.LoadFile(namespace, filepath.Join(dir, entry.Name()))
+        if err != nil && err != ErrInvalidFile {
+            return err
+        }
+    }
+
+    return nil
+}
+
+// 已添加语言
+func (this *Translator) Locales() []string {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    locales := make([]string, len(this.locales))
+    copy(locales, this.locales)
+
+    return locales
+}
+
+// 是否有语言
+func (this *Translator) HasLocale(locale string) bool {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    _, ok := this.messages[Canonical(locale)]
+
+    return ok
+}
+
+// 获取翻译，依次查找 语言、上级语言、默认语言
+func (this *Translator) Get(locale string, key string) (string, bool) {
+    namespace, name := ParseKey(key)
+
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    for _, loc := range this.candidates(locale) {
+        if message, ok := this.messages[loc][namespace][name]; ok {
+            return message, true
+        }
+    }
+
+    return "", false
+}
+
+// 是否有翻译
+func (this *Translator) Has(locale string, key string) bool {
+    _, ok := this.Get(locale, key)
+
+    return ok
+}
+
+// 翻译，找不到时返回键名
+func (this *Translator) Trans(locale string, key string, params ...Params) string {
+    message, ok := this.Get(locale, key)
+    if !ok {
+        _, message = ParseKey(key)
+    }
+
+    return Replace(message, mergeParams(params))
+}
+
+// 复数翻译，按数量选择 | 分隔的内容，:count 替换为数量
+func (this *Translator) Choice(locale string, key string, count int, params ...Params) string {
+    message, ok := this.Get(locale, key)
+    if !ok {
+        _, message = ParseKey(key)
+    }
+
+    replace := mergeParams(params)
+    if _, ok := replace["count"]; !ok {
+        replace["count"] = count
+    }
+
+    if locale == "" {
+        locale = this.GetFallback()
+    }
+
+    return Replace(SelectPlural(message, locale, count), replace)
+}
+
+// 根据 Accept-Language 等语言设置匹配已添加语言，没有匹配时返回默认语言
+func (this *Translator) Match(accept string) string {
+    fallback := this.GetFallback()
+
+    accept = strings.TrimSpace(accept)
+    if accept == "" {
+        return fallback
+    }
+
+    tags, _, err := language.ParseAcceptLanguage(strings.Replace(accept, "_", "-", -1))
+    if err != nil || len(tags) == 0 {
+        return fallback
+    }
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    // 默认语言作为首个可匹配语言
+    if this.matcher == nil {
+        locales := make([]string, 0, len(this.locales) + 1)
+        if this.fallback != "" {
+            locales = append(locales, this.fallback)
+        }
+
+        for _, locale := range this.locales {
+            if locale != this.fallback {
+                locales = append(locales, locale)
+            }
+        }
+
+        supported := make([]language.Tag, 0, len(locales))
+        for _, locale := range locales {
+            supported = append(supported, language.Make(locale))
+        }
+
+        this.matcher = language.NewMatcher(supported)
+        this.matchLocales = locales
+    }
+
+    if len(this.matchLocales) == 0 {
+        return fallback
+    }
+
+    _, index, confidence := this.matcher.Match(tags...)
+    if confidence == language.No {
+        return fallback
+    }
+
+    return this.matchLocales[index]
+}
+
+// 查找顺序
+func (this *Translator) candidates(locale string) []string {
+    list := make([]string, 0)
+
+    locale = Canonical(locale)
+    for locale != "" {
+        list = append(list, locale)
+
+        index := strings.LastIndex(locale, "-")
+        if index < 0 {
+            break
+        }
+
+        locale = locale[:index]
+    }
+
+    if this.fallback != "" {
+        list = append(list, this.fallback)
+    }
+
+    return list
+}
+
+// 规范语言名称，如 zh_cn 转为 zh-CN
+func Canonical(locale string) string {
+    locale = strings.TrimSpace(strings.Replace(locale, "_", "-", -1))
+    if locale == "" {
+        return ""
+    }
+
+    tag, err := language.Parse(locale)
+    if err != nil {
+        return locale
+    }
+
+    return tag.String()
+}
+
+// 解析键名为命名空间和名称
+func ParseKey(key string) (string, string) {
+    if index := strings.Index(key, NamespaceSeparator); index >= 0 {
+        return key[:index], key[index + len(NamespaceSeparator):]
+    }
+
+    return "", key
+}
+
+// 替换 :name 形式的参数，长名称优先替换
+func Replace(message string, params Params) string {
+    if len(params) == 0 || !strings.Contains(message, ":") {
+        return message
+    }
+
+    names := make([]string, 0, len(params))
+    for name := range params {
+        names = append(names, name)
+    }
+
+    sort.Slice(names, func(i, j int) bool {
+        return len(names[i]) > len(names[j])
+    })
+
+    pairs := make([]string, 0, len(names) * 2)
+    for _, name := range names {
+        pairs = append(pairs, ":" + name, toString(params[name]))
+    }
+
+    return strings.NewReplacer(pairs...).Replace(message)
+}
+
+// 合并参数
+func mergeParams(params []Params) Params {
+    result := make(Params)
+    for _, param := range params {
+        for k, v := range param {
+            result[k] = v
+        }
+    }
+
+    return result
+}
+
+// 展开嵌套数据
+func flatten(prefix string, data map[string]any, result map[string]string) {
+    for key, value := range data {
+        if prefix != "" {
+            key = prefix + "." + key
+        }
+
+        switch v := value.(type) {
+            case map[string]any:
+                flatten(key, v, result)
+            case map[any]any:
+                items := make(map[string]any)
+                for k, item := range v {
+                    items[toString(k)] = item
+                }
+
+                flatten(key, items, result)
+            default:
+                result[key] = toString(v)
+        }
+    }
+}
@@ -0,0 +1,137 @@
+package i18n
+
+import (
+    "os"
+    "bytes"
+    "reflect"
+    "testing"
+    "path/filepath"
+
+    "github.com/flosch/pongo2/v6"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func newTranslator() *Translator {
+    return New().
+        WithFallback("zh_CN").
+        AddMessages("zh-cn", "", map[string]any{
+            "welcome": "欢迎，:name",
+            "apples":  ":count 个苹果",
+        }).
+        AddMessages("en", "", map[string]any{
+            "welcome": "Welcome, :name",
+            "apples":  "{0} no apples|one apple|:count apples",
+            "nested": map[string]any{
+                "title": "Title",
+            },
+        }).
+        AddMessages("en", "admin", map[string]any{
+            "账号或者密码错误": "Incorrect account or password",
+        })
+}
+
+func Test_Trans(t *testing.T) {
+    eq := assertEqualT(t)
+
+    tr := newTranslator()
+
+    eq(tr.GetFallback(), "zh-CN", "GetFallback")
+    eq(tr.Locales(), []string{"zh-CN", "en"}, "Locales")
+
+    eq(tr.Trans("en", "welcome", Params{"name": "lakego"}), "Welcome, lakego", "en")
+    eq(tr.Trans("en-US", "nested.title"), "Title", "parent locale")
+    eq(tr.Trans("fr", "welcome", Params{"name": "lakego"}), "欢迎，lakego", "fallback")
+    eq(tr.Trans("en", "admin::账号或者密码错误"), "Incorrect account or password", "namespace")
+    eq(tr.Trans("en", "admin::未翻译"), "未翻译", "missing")
+    eq(tr.Has("en", "nested.title"), true, "Has")
+    eq(tr.HasLocale("zh_cn"), true, "HasLocale")
+}
+
+func Test_Choice(t *testing.T) {
+    eq := assertEqualT(t)
+
+    tr := newTranslator()
+
+    eq(tr.Choice("en", "apples", 0), "no apples", "en 0")
+    eq(tr.Choice("en", "apples", 1), "one apple", "en 1")
+    eq(tr.Choice("en", "apples", 5), "5 apples", "en 5")
+    eq(tr.Choice("zh-CN", "apples", 5), "5 个苹果", "zh 5")
+
+    eq(SelectPlural("[0,1] few|[2,*] many", "en", 3), "many", "range")
+    eq(SelectPlural("яблоко|яблока|яблок", "ru", 22), "яблока", "ru 22")
+    eq(SelectPlural("яблоко|яблока|яблок", "ru", 11), "яблок", "ru 11")
+    eq(SelectPlural("pomme|pommes", "fr", 0), "pomme", "fr 0")
+}
+
+func Test_Match(t *testing.T) {
+    eq := assertEqualT(t)
+
+    tr := newTranslator()
+
+    eq(tr.Match("en-US,en;q=0.9,zh;q=0.8"), "en", "en-US")
+    eq(tr.Match("zh-CN,zh;q=0.9"), "zh-CN", "zh-CN")
+    eq(tr.Match("de"), "zh-CN", "no match")
+    eq(tr.Match(""), "zh-CN", "empty")
+    eq(tr.Match("en_GB"), "en", "underscore")
+
+    // 默认语言没有语言包时也可匹配
+    tr2 := New().WithFallback("zh-CN").AddMessages("en", "admin", map[string]any{"a": "b"})
+    eq(tr2.Match("zh-CN,en;q=0.5"), "zh-CN", "fallback supported")
+    eq(tr2.Match("en,zh-CN;q=0.5"), "en", "en supported")
+}
+
+func Test_LoadDir(t *testing.T) {
+    eq := assertEqualT(t)
+
+    dir := t.TempDir()
+    os.WriteFile(filepath.Join(dir, "en.yml"), []byte("login:\n  fail: \"Login failed\"\n"), 0644)
+    os.WriteFile(filepath.Join(dir, "zh-CN.json"), []byte(`{"login": {"fail": "登录失败"}}`), 0644)
+    os.WriteFile(filepath.Join(dir, "README.md"), []byte("skip"), 0644)
+
+    tr := New()
+    if err := tr.LoadDir("admin", dir); err != nil {
+        t.Fatal(err)
+    }
+
+    eq(tr.Trans("en", "admin::login.fail"), "Login failed", "yaml")
+    eq(tr.Trans("zh-CN", "admin::login.fail"), "登录失败", "json")
+    eq(tr.LoadDir("admin", filepath.Join(dir, "none")), nil, "not exists")
+
+    os.WriteFile(filepath.Join(dir, "ja.json"), []byte(`{`), 0644)
+    eq(tr.LoadDir("admin", dir) != nil, true, "invalid file")
+}
+
+func Test_Filter(t *testing.T) {
+    eq := assertEqualT(t)
+
+    tr := newTranslator()
+
+    set := pongo2.NewSet("i18n-test", pongo2.MustNewLocalFileSystemLoader(""))
+    RegisterFilter("trans", tr)
+
+    tpl, err := set.FromString(`{{ "welcome"|trans:locale }}|{{ "apples"|trans:params }}`)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    var buf bytes.Buffer
+    err = tpl.ExecuteWriter(pongo2.Context{
+        "locale": "en",
+        "params": map[string]any{
+            "locale": "en",
+            "count":  3,
+        },
+    }, &buf)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    eq(buf.String(), "Welcome, :name|3 apples", "filter")
+}
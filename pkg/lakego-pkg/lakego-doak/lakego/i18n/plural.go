@@ -0,0 +1,157 @@
+package i18n
+
+import (
+    "fmt"
+    "regexp"
+    "strconv"
+    "strings"
+)
+
+// 指定数量范围，如 {0}、[1,19]、[20,*]
+var rangeRegexp = regexp.MustCompile(`^\s*(\{\s*(-?\d+)\s*\}|\[\s*(-?\d+|\*)\s*,\s*(-?\d+|\*)\s*\])\s?`)
+
+/**
+ * 按数量选择复数内容
+ *
+ * 内容以 | 分隔，可用 {n} 或 [min,max] 指定数量范围，
+ * 都不匹配时按语言的复数规则选择未指定范围的内容
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func SelectPlural(message string, locale string, count int) string {
+    segments := strings.Split(message, "|")
+    if len(segments) == 1 {
+        return message
+    }
+
+    plain := make([]string, 0, len(segments))
+    for _, segment := range segments {
+        match := rangeRegexp.FindStringSubmatch(segment)
+        if match == nil {
+            plain = append(plain, strings.TrimSpace(segment))
+            continue
+        }
+
+        text := strings.TrimSpace(segment[len(match[0]):])
+
+        if match[2] != "" {
+            if n, _ := strconv.Atoi(match[2]); n == count {
+                return text
+            }
+
+            continue
+        }
+
+        if inRange(count, match[3], match[4]) {
+            return text
+        }
+    }
+
+    if len(plain) == 0 {
+        return ""
+    }
+
+    index := PluralIndex(locale, count)
+    if index >= len(plain) {
+        index = len(plain) - 1
+    }
+
+    return plain[index]
+}
+
+// 是否在范围内
+func inRange(count int, min string, max string) bool {
+    if min != "*" {
+        if n, _ := strconv.Atoi(min); count < n {
+            return false
+        }
+    }
+
+    if max != "*" {
+        if n, _ := strconv.Atoi(max); count > n {
+            return false
+        }
+    }
+
+    return true
+}
+
+// 语言的复数形式序号
+func PluralIndex(locale string, count int) int {
+    lang := strings.ToLower(Canonical(locale))
+    if index := strings.Index(lang, "-"); index > 0 {
+        lang = lang[:index]
+    }
+
+    n := count
+    if n < 0 {
+        n = -n
+    }
+
+    switch lang {
+        // 没有复数形式
+        case "zh", "ja", "ko", "th", "vi", "id", "ms", "lo", "km", "my", "fa", "tr", "ka":
+            return 0
+
+        // 0 和 1 为单数
+        case "fr", "hy", "ak", "am", "bh", "fil", "hi", "ln", "mg", "nso", "ti", "wa":
+            if n == 0 || n == 1 {
+                return 0
+            }
+
+            return 1
+
+        case "ru", "uk", "be", "sr", "hr", "bs":
+            switch {
+                case n % 10 == 1 && n % 100 != 11:
+                    return 0
+                case n % 10 >= 2 && n % 10 <= 4 && (n % 100 < 10 || n % 100 >= 20):
+                    return 1
+            }
+
+            return 2
+
+        case "cs", "sk":
+            switch {
+                case n == 1:
+                    return 0
+                case n >= 2 && n <= 4:
+                    return 1
+            }
+
+            return 2
+
+        case "pl":
+            switch {
+                case n == 1:
+                    return 0
+                case n % 10 >= 2 && n % 10 <= 4 && (n % 100 < 12 || n % 100 > 14):
+                    return 1
+            }
+
+            return 2
+    }
+
+    if n == 1 {
+        return 0
+    }
+
+    return 1
+}
+
+// 转为字符
+func toString(value any) string {
+    switch v := value.(type) {
+        case nil:
+            return ""
+        case string:
+            return v
+        case []byte:
+            return string(v)
+        case fmt.Stringer:
+            return v.String()
+    }
+
+    return fmt.Sprintf("%v", value)
+}
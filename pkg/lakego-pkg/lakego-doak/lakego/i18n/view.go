@@ -0,0 +1,79 @@
+package i18n
+
+import (
+    "github.com/flosch/pongo2/v6"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+/**
+ * 模板过滤器
+ *
+ * {{ "admin::login.title"|trans:locale }}
+ * {{ "example::list.total"|trans:params }}
+ *
+ * 参数为语言或视图数据中的参数数据，参数数据中 locale 为语言，有 count 时使用复数翻译
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Filter(translator *Translator) pongo2.FilterFunction {
+    return func(in *pongo2.Value, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+        locale := translator.GetFallback()
+        params := make(Params)
+
+        if param != nil && !param.IsNil() {
+            if param.IsString() {
+                locale = param.String()
+            } else if data, ok := toParams(param.Interface()); ok {
+                for k, v := range data {
+                    params[k] = v
+                }
+
+                if v, ok := params["locale"]; ok {
+                    locale = toString(v)
+                }
+            }
+        }
+
+        key := in.String()
+
+        if count, ok := params["count"]; ok {
+            n := pongo2.AsValue(count).Integer()
+
+            return pongo2.AsValue(translator.Choice(locale, key, n, params)), nil
+        }
+
+        return pongo2.AsValue(translator.Trans(locale, key, params)), nil
+    }
+}
+
+// 注册模板过滤器，已存在时替换
+func RegisterFilter(name string, translator *Translator) error {
+    if pongo2.FilterExists(name) {
+        return pongo2.ReplaceFilter(name, Filter(translator))
+    }
+
+    return pongo2.RegisterFilter(name, Filter(translator))
+}
+
+// 转为参数数据
+func toParams(value any) (Params, bool) {
+    switch v := value.(type) {
+        case map[string]any:
+            return v, true
+        case pongo2.Context:
+            return Params(v), true
+        case router.H:
+            return Params(v), true
+        case map[string]string:
+            params := make(Params)
+            for k, item := range v {
+                params[k] = item
+            }
+
+            return params, true
+    }
+
+    return nil, false
+}
@@ -2,14 +2,18 @@ package kernel
 
 import (
     "os"
+    "log"
     "net"
     "flag"
+    "context"
 
     "github.com/deatil/lakego-doak/lakego/app"
     "github.com/deatil/lakego-doak/lakego/command"
     "github.com/deatil/lakego-doak/lakego/provider"
     "github.com/deatil/lakego-doak/lakego/provider/interfaces"
     "github.com/deatil/lakego-doak/lakego/service_provider"
+    "github.com/deatil/lakego-doak/lakego/kernel/shutdown"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
 
     _ "github.com/deatil/lakego-doak/lakego/facade"
 )
@@ -60,6 +64,9 @@ func (this *Kernel) Terminate() {
     startName := flag.String("lakego", "", "系统启动参数")
     flag.Parse()
 
+    // 退出前执行关闭操作
+    defer this.shutdown()
+
     if len(args) == 1 || *startName == "start" {
         this.runServer()
     } else {
@@ -67,6 +74,19 @@ func (this *Kernel) Terminate() {
     }
 }
 
+// 按顺序执行服务提供者注册的关闭操作
+func (this *Kernel) shutdown() {
+    conf := config.New("server")
+
+    if timeout := conf.GetDuration("shutdown.timeout"); timeout > 0 {
+        shutdown.Default().WithTimeout(timeout)
+    }
+
+    if err := shutdown.Shutdown(context.Background()); err != nil {
+        log.Println("Shutdown:", err)
+    }
+}
+
 // 运行服务
 func (this *Kernel) runServer() {
     this.runApp(false)
@@ -77,6 +97,7 @@ func (this *Kernel) runCmd() {
     this.runApp(true)
 
     if err := rootCmd.Execute(); err != nil {
+        this.shutdown()
         os.Exit(-1)
     }
 }
@@ -101,6 +122,16 @@ func (this *Kernel) runApp(console bool) {
         newApp.WithRunningInConsole(false)
     }
 
+    // 重启时使用父进程传递的监听
+    if this.NetListener == nil && !console {
+        listener, err := shutdown.InheritedListener()
+        if err != nil {
+            log.Println("Inherited listener:", err)
+        } else if listener != nil {
+            this.WithNetListener(listener)
+        }
+    }
+
     // 设置自定义监听
     if this.NetListener != nil {
         newApp.WithNetListener(this.NetListener)
@@ -0,0 +1,198 @@
+package shutdown
+
+import (
+    "os"
+    "net"
+    "sync"
+    "time"
+    "errors"
+    "strconv"
+    "strings"
+    "os/signal"
+)
+
+// 子进程继承监听的环境变量，值为监听的文件描述符
+const InheritEnv = "LAKEGO_INHERIT_FD"
+
+// 子进程通知启动完成的环境变量，值为通知管道的文件描述符
+const ReadyEnv = "LAKEGO_READY_FD"
+
+// 默认等待子进程启动完成的时间
+const DefaultReadyTimeout = 30 * time.Second
+
+var (
+    // 当前平台不支持重启
+    ErrRestartNotSupported = errors.New("shutdown: restart is not supported on this platform")
+
+    // 监听不能传递给子进程
+    ErrListenerNotSupported = errors.New("shutdown: listener can not be passed to child process")
+
+    // 子进程未在超时时间内启动完成
+    ErrReadyTimeout = errors.New("shutdown: child process not ready before timeout")
+
+    // 子进程启动完成前已退出
+    ErrChildExited = errors.New("shutdown: child process exited before ready")
+)
+
+/**
+ * 获取父进程传递的监听，没有时返回 nil
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func InheritedListener() (net.Listener, error) {
+    value := os.Getenv(InheritEnv)
+    if value == "" {
+        return nil, nil
+    }
+
+    // 避免再传给之后启动的子进程
+    os.Unsetenv(InheritEnv)
+
+    fd, err := strconv.Atoi(value)
+    if err != nil {
+        return nil, errors.New("shutdown: invalid " + InheritEnv + " " + value)
+    }
+
+    file := os.NewFile(uintptr(fd), "lakego-listener")
+    if file == nil {
+        return nil, errors.New("shutdown: invalid listener fd " + value)
+    }
+    defer file.Close()
+
+    return net.FileListener(file)
+}
+
+/**
+ * 包装监听，服务开始接收连接时通知父进程启动完成，
+ * 不是由重启启动时返回原监听
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func ReadyListener(listener net.Listener) (net.Listener, error) {
+    value := os.Getenv(ReadyEnv)
+    if value == "" {
+        return listener, nil
+    }
+
+    // 避免再传给之后启动的子进程
+    os.Unsetenv(ReadyEnv)
+
+    fd, err := strconv.Atoi(value)
+    if err != nil {
+        return listener, errors.New("shutdown: invalid " + ReadyEnv + " " + value)
+    }
+
+    file := os.NewFile(uintptr(fd), "lakego-ready")
+    if file == nil {
+        return listener, errors.New("shutdown: invalid ready fd " + value)
+    }
+
+    return &readyListener{
+        Listener: listener,
+        file:     file,
+    }, nil
+}
+
+// 首次接收连接时通知父进程
+type readyListener struct {
+    net.Listener
+
+    file *os.File
+    once sync.Once
+}
+
+func (this *readyListener) Accept() (net.Conn, error) {
+    this.once.Do(this.notify)
+
+    return this.Listener.Accept()
+}
+
+func (this *readyListener) Close() error {
+    // 未接收连接就关闭时，父进程读取到结束后继续服务
+    this.once.Do(func() {
+        this.file.Close()
+    })
+
+    return this.Listener.Close()
+}
+
+func (this *readyListener) notify() {
+    this.file.Write([]byte{1})
+    this.file.Close()
+}
+
+/**
+ * 等待关闭或重启信号，收到重启信号时返回 true
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Wait() bool {
+    signals := append([]os.Signal{}, stopSignals...)
+    if restartSignal != nil {
+        signals = append(signals, restartSignal)
+    }
+
+    quit := make(chan os.Signal, 1)
+    signal.Notify(quit, signals...)
+    defer signal.Stop(quit)
+
+    sig := <-quit
+
+    return restartSignal != nil && sig == restartSignal
+}
+
+// 监听对应的文件
+func listenerFile(listener net.Listener) (*os.File, error) {
+    switch l := listener.(type) {
+        case *net.TCPListener:
+            return l.File()
+        case *net.UnixListener:
+            // 关闭时不删除 socket 文件，由子进程继续使用
+            l.SetUnlinkOnClose(false)
+
+            return l.File()
+    }
+
+    return nil, ErrListenerNotSupported
+}
+
+// 等待子进程通知启动完成
+func waitReady(file *os.File, timeout time.Duration) error {
+    if timeout <= 0 {
+        timeout = DefaultReadyTimeout
+    }
+
+    if err := file.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+        return err
+    }
+
+    buf := make([]byte, 1)
+    if _, err := file.Read(buf); err != nil {
+        if errors.Is(err, os.ErrDeadlineExceeded) {
+            return ErrReadyTimeout
+        }
+
+        return ErrChildExited
+    }
+
+    return nil
+}
+
+// 子进程环境变量
+func childEnv(fd int, readyFd int) []string {
+    env := make([]string, 0)
+    for _, item := range os.Environ() {
+        if !strings.HasPrefix(item, InheritEnv + "=") &&
+            !strings.HasPrefix(item, ReadyEnv + "=") {
+            env = append(env, item)
+        }
+    }
+
+    return append(env,
+        InheritEnv + "=" + strconv.Itoa(fd),
+        ReadyEnv + "=" + strconv.Itoa(readyFd),
+    )
+}
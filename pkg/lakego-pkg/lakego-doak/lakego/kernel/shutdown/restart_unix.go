@@ -0,0 +1,90 @@
+//go:build !windows
+
+package shutdown
+
+import (
+    "os"
+    "net"
+    "time"
+    "syscall"
+)
+
+// 关闭信号
+var stopSignals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+
+// 重启信号
+var restartSignal os.Signal = syscall.SIGUSR2
+
+/**
+ * 启动新进程并传递监听，等待新进程开始接收连接后返回，
+ * 超时或新进程退出时结束新进程并返回错误，当前进程继续服务
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Restart(listener net.Listener, timeout time.Duration) (*os.Process, error) {
+    file, err := listenerFile(listener)
+    if err != nil {
+        return nil, err
+    }
+    defer file.Close()
+
+    execPath, err := os.Executable()
+    if err != nil {
+        return nil, err
+    }
+
+    dir, err := os.Getwd()
+    if err != nil {
+        return nil, err
+    }
+
+    // 子进程启动完成后写入通知
+    readyReader, readyWriter, err := os.Pipe()
+    if err != nil {
+        return nil, err
+    }
+    defer readyReader.Close()
+
+    // 前三个为标准输入输出，监听的文件描述符为 3，通知管道为 4
+    proc, err := os.StartProcess(execPath, os.Args, &os.ProcAttr{
+        Dir:   dir,
+        Env:   childEnv(3, 4),
+        Files: []*os.File{os.Stdin, os.Stdout, os.Stderr, file, readyWriter},
+    })
+
+    // 关闭当前进程的写入端，子进程退出时读取到结束
+    readyWriter.Close()
+
+    if err != nil {
+        return nil, err
+    }
+
+    if err := waitReady(readyReader, timeout); err != nil {
+        proc.Kill()
+        proc.Wait()
+
+        return nil, err
+    }
+
+    return proc, nil
+}
+
+// 通知进程关闭
+func SignalStop(pid int) error {
+    return signalProcess(pid, syscall.SIGTERM)
+}
+
+// 通知进程重启
+func SignalRestart(pid int) error {
+    return signalProcess(pid, syscall.SIGUSR2)
+}
+
+func signalProcess(pid int, sig os.Signal) error {
+    proc, err := os.FindProcess(pid)
+    if err != nil {
+        return err
+    }
+
+    return proc.Signal(sig)
+}
@@ -0,0 +1,116 @@
+//go:build !windows
+
+package shutdown
+
+import (
+    "os"
+    "net"
+    "time"
+    "strconv"
+    "syscall"
+    "testing"
+)
+
+func Test_InheritedListener(t *testing.T) {
+    eq := assertEqualT(t)
+
+    os.Unsetenv(InheritEnv)
+
+    listener, err := InheritedListener()
+    eq(listener, nil, "no env")
+    eq(err, nil, "no env error")
+
+    l, err := net.Listen("tcp", "127.0.0.1:0")
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer l.Close()
+
+    file, err := listenerFile(l)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    // 复制一份，InheritedListener 会关闭传入的描述符
+    fd, err := syscall.Dup(int(file.Fd()))
+    file.Close()
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    os.Setenv(InheritEnv, strconv.Itoa(fd))
+
+    inherited, err := InheritedListener()
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer inherited.Close()
+
+    eq(inherited.Addr().String(), l.Addr().String(), "inherited Addr")
+    eq(os.Getenv(InheritEnv), "", "env unset")
+
+    env := childEnv(3, 4)
+    eq(env[len(env) - 2], InheritEnv + "=3", "childEnv listener")
+    eq(env[len(env) - 1], ReadyEnv + "=4", "childEnv ready")
+}
+
+func Test_ReadyListener(t *testing.T) {
+    eq := assertEqualT(t)
+
+    l, err := net.Listen("tcp", "127.0.0.1:0")
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer l.Close()
+
+    os.Unsetenv(ReadyEnv)
+
+    same, err := ReadyListener(l)
+    eq(same, l, "no env")
+    eq(err, nil, "no env error")
+
+    reader, writer, err := os.Pipe()
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer reader.Close()
+
+    // 复制一份，ReadyListener 通知后关闭传入的描述符
+    fd, err := syscall.Dup(int(writer.Fd()))
+    writer.Close()
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    os.Setenv(ReadyEnv, strconv.Itoa(fd))
+
+    ready, err := ReadyListener(l)
+    if err != nil {
+        t.Fatal(err)
+    }
+    eq(os.Getenv(ReadyEnv), "", "env unset")
+
+    // 未接收连接前不通知
+    eq(waitReady(reader, 20 * time.Millisecond), ErrReadyTimeout, "not ready")
+
+    go ready.Accept()
+
+    eq(waitReady(reader, time.Second), nil, "ready")
+
+    ready.Close()
+}
+
+func Test_WaitReadyExited(t *testing.T) {
+    eq := assertEqualT(t)
+
+    reader, writer, err := os.Pipe()
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer reader.Close()
+
+    // 子进程退出时写入端关闭
+    writer.Close()
+
+    eq(waitReady(reader, time.Second), ErrChildExited, "exited")
+}
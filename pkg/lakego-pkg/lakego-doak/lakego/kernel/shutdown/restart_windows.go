@@ -0,0 +1,35 @@
+//go:build windows
+
+package shutdown
+
+import (
+    "os"
+    "net"
+    "time"
+)
+
+// 关闭信号
+var stopSignals = []os.Signal{os.Interrupt}
+
+// 不支持重启信号
+var restartSignal os.Signal = nil
+
+// 不支持传递监听重启
+func Restart(listener net.Listener, timeout time.Duration) (*os.Process, error) {
+    return nil, ErrRestartNotSupported
+}
+
+// 通知进程关闭，windows 只能直接结束进程
+func SignalStop(pid int) error {
+    proc, err := os.FindProcess(pid)
+    if err != nil {
+        return err
+    }
+
+    return proc.Kill()
+}
+
+// 不支持重启
+func SignalRestart(pid int) error {
+    return ErrRestartNotSupported
+}
@@ -0,0 +1,285 @@
+package shutdown
+
+import (
+    "fmt"
+    "sort"
+    "sync"
+    "time"
+    "errors"
+    "strings"
+    "context"
+)
+
+// 优先级，数值小的先执行
+const (
+    // 停止接收请求，等待处理中的请求完成
+    PriorityHTTP = 100
+
+    // 计划任务停止调度新任务，等待运行中的任务完成
+    PrioritySchedule = 200
+
+    // 队列停止取任务，等待执行中的任务完成
+    PriorityQueue = 300
+
+    // 导出未导出的链路数据
+    PriorityTrace = 800
+
+    // 关闭数据库、redis 等连接
+    PriorityConnection = 900
+)
+
+// 已在关闭中
+var ErrShuttingDown = errors.New("shutdown: already shutting down")
+
+/**
+ * 关闭操作
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Hook struct {
+    // 名称
+    Name string
+
+    // 优先级
+    Priority int
+
+    // 超时时间，为 0 时使用默认超时时间
+    Timeout time.Duration
+
+    // 执行函数
+    Fn func(context.Context) error
+}
+
+// 关闭操作错误
+type HookError struct {
+    Name string
+    Err  error
+}
+
+func (this *HookError) Error() string {
+    return "shutdown " + this.Name + ": " + this.Err.Error()
+}
+
+func (this *HookError) Unwrap() error {
+    return this.Err
+}
+
+// 多个错误
+type Errors []error
+
+func (this Errors) Error() string {
+    msgs := make([]string, 0, len(this))
+    for _, err := range this {
+        msgs = append(msgs, err.Error())
+    }
+
+    return strings.Join(msgs, "; ")
+}
+
+/**
+ * 关闭管理
+ *
+ * 按优先级依次执行关闭操作，每个操作有单独的超时时间，
+ * 超时后不再等待该操作并继续执行下一个
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Manager struct {
+    // 锁
+    mu sync.Mutex
+
+    // 关闭操作
+    hooks []Hook
+
+    // 默认超时时间
+    timeout time.Duration
+
+    // 是否关闭中
+    shuttingDown bool
+
+    // 关闭完成
+    done chan struct{}
+
+    // 关闭结果
+    err error
+}
+
+// 构造函数
+func New() *Manager {
+    return &Manager{
+        timeout: 10 * time.Second,
+        done:    make(chan struct{}),
+    }
+}
+
+// 设置默认超时时间
+func (this *Manager) WithTimeout(timeout time.Duration) *Manager {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.timeout = timeout
+
+    return this
+}
+
+// 注册关闭操作，同名操作会被替换
+func (this *Manager) Register(name string, priority int, timeout time.Duration, fn func(context.Context) error) *Manager {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    hook := Hook{
+        Name:     name,
+        Priority: priority,
+        Timeout:  timeout,
+        Fn:       fn,
+    }
+
+    for i, h := range this.hooks {
+        if h.Name == name {
+            this.hooks[i] = hook
+            return this
+        }
+    }
+
+    this.hooks = append(this.hooks, hook)
+
+    return this
+}
+
+// 移除关闭操作
+func (this *Manager) Remove(name string) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    for i, h := range this.hooks {
+        if h.Name == name {
+            this.hooks = append(this.hooks[:i], this.hooks[i+1:]...)
+            return
+        }
+    }
+}
+
+// 按执行顺序返回关闭操作
+func (this *Manager) Hooks() []Hook {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    hooks := make([]Hook, len(this.hooks))
+    copy(hooks, this.hooks)
+
+    sort.SliceStable(hooks, func(i, j int) bool {
+        return hooks[i].Priority < hooks[j].Priority
+    })
+
+    return hooks
+}
+
+// 是否关闭中
+func (this *Manager) IsShuttingDown() bool {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    return this.shuttingDown
+}
+
+// 关闭完成
+func (this *Manager) Done() <-chan struct{} {
+    return this.done
+}
+
+// 执行关闭，只执行一次，重复调用时等待首次关闭完成
+func (this *Manager) Shutdown(ctx context.Context) error {
+    this.mu.Lock()
+    if this.shuttingDown {
+        this.mu.Unlock()
+
+        select {
+            case <-this.done:
+                return this.err
+            case <-ctx.Done():
+                return ErrShuttingDown
+        }
+    }
+
+    this.shuttingDown = true
+    timeout := this.timeout
+    this.mu.Unlock()
+
+    var errs Errors
+    for _, hook := range this.Hooks() {
+        if hook.Timeout <= 0 {
+            hook.Timeout = timeout
+        }
+
+        if err := runHook(ctx, hook); err != nil {
+            errs = append(errs, &HookError{
+                Name: hook.Name,
+                Err:  err,
+            })
+        }
+    }
+
+    if len(errs) > 0 {
+        this.err = errs
+    }
+
+    close(this.done)
+
+    return this.err
+}
+
+// 执行关闭操作，操作未响应超时也不会阻塞后续操作
+func runHook(ctx context.Context, hook Hook) error {
+    if hook.Fn == nil {
+        return nil
+    }
+
+    if hook.Timeout > 0 {
+        var cancel context.CancelFunc
+        ctx, cancel = context.WithTimeout(ctx, hook.Timeout)
+        defer cancel()
+    }
+
+    result := make(chan error, 1)
+    go func() {
+        defer func() {
+            if r := recover(); r != nil {
+                result <- fmt.Errorf("panic: %v", r)
+            }
+        }()
+
+        result <- hook.Fn(ctx)
+    }()
+
+    select {
+        case err := <-result:
+            return err
+        case <-ctx.Done():
+            return ctx.Err()
+    }
+}
+
+// 默认
+var defaultManager = New()
+
+// 默认关闭管理
+func Default() *Manager {
+    return defaultManager
+}
+
+// 注册关闭操作
+func Register(name string, priority int, timeout time.Duration, fn func(context.Context) error) *Manager {
+    return defaultManager.Register(name, priority, timeout, fn)
+}
+
+// 执行关闭
+func Shutdown(ctx context.Context) error {
+    return defaultManager.Shutdown(ctx)
+}
+
+// 是否关闭中
+func IsShuttingDown() bool {
+    return defaultManager.IsShuttingDown()
+}
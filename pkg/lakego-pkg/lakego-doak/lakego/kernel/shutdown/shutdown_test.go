@@ -0,0 +1,116 @@
+package shutdown
+
+import (
+    "time"
+    "errors"
+    "reflect"
+    "testing"
+    "context"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func Test_Order(t *testing.T) {
+    eq := assertEqualT(t)
+
+    order := make([]string, 0)
+    record := func(name string) func(context.Context) error {
+        return func(context.Context) error {
+            order = append(order, name)
+            return nil
+        }
+    }
+
+    m := New()
+    m.Register("redis", PriorityConnection, 0, record("redis"))
+    m.Register("queue", PriorityQueue, 0, record("queue"))
+    m.Register("database", PriorityConnection, 0, record("database"))
+    m.Register("http", PriorityHTTP, 0, record("http"))
+    m.Register("schedule", PrioritySchedule, 0, record("schedule"))
+
+    // 同名替换
+    m.Register("queue", PriorityQueue, 0, record("queue2"))
+
+    eq(m.IsShuttingDown(), false, "before Shutdown")
+
+    err := m.Shutdown(context.Background())
+
+    eq(err, nil, "Shutdown error")
+    eq(order, []string{"http", "schedule", "queue2", "redis", "database"}, "order")
+    eq(m.IsShuttingDown(), true, "after Shutdown")
+
+    // 只执行一次
+    m.Shutdown(context.Background())
+    eq(len(order), 5, "run once")
+}
+
+func Test_Timeout(t *testing.T) {
+    eq := assertEqualT(t)
+
+    ran := false
+
+    m := New()
+    m.Register("slow", PriorityHTTP, 10 * time.Millisecond, func(context.Context) error {
+        // 不响应 ctx 也不阻塞后续操作
+        time.Sleep(time.Second)
+        return nil
+    })
+    m.Register("fail", PriorityQueue, 0, func(context.Context) error {
+        return errors.New("queue error")
+    })
+    m.Register("panic", PriorityTrace, 0, func(context.Context) error {
+        panic("trace panic")
+    })
+    m.Register("next", PriorityConnection, 0, func(context.Context) error {
+        ran = true
+        return nil
+    })
+
+    start := time.Now()
+    err := m.Shutdown(context.Background())
+
+    eq(time.Since(start) < 500 * time.Millisecond, true, "not blocked")
+    eq(ran, true, "next hook ran")
+
+    errs, ok := err.(Errors)
+    eq(ok, true, "Errors type")
+    eq(len(errs), 3, "Errors len")
+    eq(errors.Is(errs[0], context.DeadlineExceeded), true, "timeout error")
+    eq(errs[1].Error(), "shutdown fail: queue error", "hook error")
+    eq(errs[2].Error(), "shutdown panic: panic: trace panic", "panic error")
+}
+
+func Test_Wait(t *testing.T) {
+    eq := assertEqualT(t)
+
+    release := make(chan struct{})
+
+    m := New()
+    m.Register("http", PriorityHTTP, 0, func(context.Context) error {
+        <-release
+        return errors.New("http error")
+    })
+
+    go m.Shutdown(context.Background())
+
+    for !m.IsShuttingDown() {
+        time.Sleep(time.Millisecond)
+    }
+
+    // 关闭中时等待完成
+    ctx, cancel := context.WithTimeout(context.Background(), 10 * time.Millisecond)
+    defer cancel()
+
+    eq(m.Shutdown(ctx), ErrShuttingDown, "ctx done")
+
+    close(release)
+    <-m.Done()
+
+    eq(m.Shutdown(context.Background()).Error(), "shutdown http: http error", "wait result")
+}
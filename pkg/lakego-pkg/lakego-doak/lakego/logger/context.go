@@ -0,0 +1,217 @@
+package logger
+
+import (
+    "context"
+    "net/http"
+
+    "github.com/deatil/lakego-doak/lakego/logger/interfaces"
+)
+
+// 上下文中的日志字段名称
+const (
+    // 请求 ID
+    RequestIDKey = "request_id"
+
+    // 管理员 ID
+    AdminIDKey = "admin_id"
+
+    // 路由
+    RouteKey = "route"
+
+    // 链路 ID
+    TraceIDKey = "trace_id"
+)
+
+// WithContext 从上下文读取的字段，可追加
+var ContextKeys = []string{
+    RequestIDKey,
+    AdminIDKey,
+    RouteKey,
+    TraceIDKey,
+}
+
+// 上下文字段 key
+type fieldsKey struct{}
+
+// 添加日志字段到上下文
+func NewContext(ctx context.Context, fields Fields) context.Context {
+    data := make(Fields)
+    if old, ok := ctx.Value(fieldsKey{}).(Fields); ok {
+        for k, v := range old {
+            data[k] = v
+        }
+    }
+
+    for k, v := range fields {
+        data[k] = v
+    }
+
+    return context.WithValue(ctx, fieldsKey{}, data)
+}
+
+// 获取上下文中的日志字段
+func FromContext(ctx context.Context) Fields {
+    fields := make(Fields)
+    if ctx == nil {
+        return fields
+    }
+
+    // gin 的 Context 未开启 ContextWithFallback 时读取请求的上下文
+    if req, ok := ctx.Value(0).(*http.Request); ok && req != nil {
+        if data, ok := req.Context().Value(fieldsKey{}).(Fields); ok {
+            for k, v := range data {
+                fields[k] = v
+            }
+        }
+    }
+
+    if data, ok := ctx.Value(fieldsKey{}).(Fields); ok {
+        for k, v := range data {
+            fields[k] = v
+        }
+    }
+
+    for _, key := range ContextKeys {
+        if value := ctx.Value(key); value != nil && value != "" {
+            fields[key] = value
+        }
+    }
+
+    return fields
+}
+
+// 日志输出接口，logrus 的 Entry 实现该接口
+type printer interface {
+    Trace(...any)
+    Debug(...any)
+    Info(...any)
+    Warn(...any)
+    Warning(...any)
+    Error(...any)
+    Fatal(...any)
+    Panic(...any)
+
+    Tracef(string, ...any)
+    Debugf(string, ...any)
+    Infof(string, ...any)
+    Warnf(string, ...any)
+    Warningf(string, ...any)
+    Errorf(string, ...any)
+    Fatalf(string, ...any)
+    Panicf(string, ...any)
+}
+
+/**
+ * 带固定字段的日志驱动
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type fieldsDriver struct {
+    // 原始驱动
+    driver interfaces.Driver
+
+    // 字段
+    fields Fields
+}
+
+// 合并字段
+func (this *fieldsDriver) merge(fields map[string]any) map[string]any {
+    data := make(map[string]any, len(this.fields) + len(fields))
+    for k, v := range this.fields {
+        data[k] = v
+    }
+
+    for k, v := range fields {
+        data[k] = v
+    }
+
+    return data
+}
+
+// 带字段的输出
+func (this *fieldsDriver) entry() printer {
+    if p, ok := this.driver.WithFields(this.fields).(printer); ok {
+        return p
+    }
+
+    return this.driver
+}
+
+func (this *fieldsDriver) WithFields(fields map[string]any) any {
+    return this.driver.WithFields(this.merge(fields))
+}
+
+func (this *fieldsDriver) WithField(key string, value any) any {
+    return this.driver.WithFields(this.merge(map[string]any{
+        key: value,
+    }))
+}
+
+// ========
+
+func (this *fieldsDriver) Trace(args ...any) {
+    this.entry().Trace(args...)
+}
+
+func (this *fieldsDriver) Debug(args ...any) {
+    this.entry().Debug(args...)
+}
+
+func (this *fieldsDriver) Info(args ...any) {
+    this.entry().Info(args...)
+}
+
+func (this *fieldsDriver) Warn(args ...any) {
+    this.entry().Warn(args...)
+}
+
+func (this *fieldsDriver) Warning(args ...any) {
+    this.entry().Warning(args...)
+}
+
+func (this *fieldsDriver) Error(args ...any) {
+    this.entry().Error(args...)
+}
+
+func (this *fieldsDriver) Fatal(args ...any) {
+    this.entry().Fatal(args...)
+}
+
+func (this *fieldsDriver) Panic(args ...any) {
+    this.entry().Panic(args...)
+}
+
+// ========
+
+func (this *fieldsDriver) Tracef(template string, args ...any) {
+    this.entry().Tracef(template, args...)
+}
+
+func (this *fieldsDriver) Debugf(template string, args ...any) {
+    this.entry().Debugf(template, args...)
+}
+
+func (this *fieldsDriver) Infof(template string, args ...any) {
+    this.entry().Infof(template, args...)
+}
+
+func (this *fieldsDriver) Warnf(template string, args ...any) {
+    this.entry().Warnf(template, args...)
+}
+
+func (this *fieldsDriver) Warningf(template string, args ...any) {
+    this.entry().Warningf(template, args...)
+}
+
+func (this *fieldsDriver) Errorf(template string, args ...any) {
+    this.entry().Errorf(template, args...)
+}
+
+func (this *fieldsDriver) Fatalf(template string, args ...any) {
+    this.entry().Fatalf(template, args...)
+}
+
+func (this *fieldsDriver) Panicf(template string, args ...any) {
+    this.entry().Panicf(template, args...)
+}
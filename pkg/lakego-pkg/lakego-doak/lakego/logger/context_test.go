@@ -0,0 +1,119 @@
+package logger
+
+import (
+    "bytes"
+    "errors"
+    "reflect"
+    "testing"
+    "context"
+    "encoding/json"
+
+    "github.com/sirupsen/logrus"
+
+    jsonFormatter "github.com/deatil/lakego-doak/lakego/logger/driver/logrus/formatter/json"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+// 测试驱动
+type testDriver struct {
+    *logrus.Logger
+}
+
+func (this *testDriver) WithField(key string, value any) any {
+    return this.Logger.WithField(key, value)
+}
+
+func (this *testDriver) WithFields(fields map[string]any) any {
+    return this.Logger.WithFields(logrus.Fields(fields))
+}
+
+func newTestLogger() (*Logger, *bytes.Buffer) {
+    buf := &bytes.Buffer{}
+
+    log := logrus.New()
+    log.SetOutput(buf)
+    log.SetFormatter(&jsonFormatter.JSONFormatter{})
+    log.SetLevel(logrus.TraceLevel)
+
+    return New(&testDriver{log}), buf
+}
+
+func decodeLine(t *testing.T, buf *bytes.Buffer) map[string]any {
+    data := make(map[string]any)
+    if err := json.Unmarshal(buf.Bytes(), &data); err != nil {
+        t.Fatalf("decode log %q: %v", buf.String(), err)
+    }
+
+    buf.Reset()
+
+    return data
+}
+
+func Test_FromContext(t *testing.T) {
+    eq := assertEqualT(t)
+
+    ctx := NewContext(context.Background(), Fields{
+        RequestIDKey: "req-1",
+    })
+    ctx = NewContext(ctx, Fields{
+        RouteKey: "/admin/:id",
+    })
+    ctx = context.WithValue(ctx, AdminIDKey, "admin-1")
+
+    fields := FromContext(ctx)
+
+    eq(fields, Fields{
+        RequestIDKey: "req-1",
+        RouteKey:     "/admin/:id",
+        AdminIDKey:   "admin-1",
+    }, "FromContext")
+
+    eq(len(FromContext(context.Background())), 0, "FromContext empty")
+}
+
+func Test_WithContext(t *testing.T) {
+    eq := assertEqualT(t)
+
+    log, buf := newTestLogger()
+
+    ctx := NewContext(context.Background(), Fields{
+        RequestIDKey: "req-2",
+    })
+
+    log.WithContext(ctx).Infof("hello %s", "lakego")
+
+    data := decodeLine(t, buf)
+    eq(data["msg"], "hello lakego", "WithContext msg")
+    eq(data["level"], "info", "WithContext level")
+    eq(data[RequestIDKey], "req-2", "WithContext request_id")
+
+    // 不带字段时返回原日志
+    eq(log.WithContext(context.Background()) == log, true, "WithContext empty")
+}
+
+func Test_WithContextField(t *testing.T) {
+    eq := assertEqualT(t)
+
+    log, buf := newTestLogger()
+
+    ctx := NewContext(context.Background(), Fields{
+        RequestIDKey: "req-3",
+        "msg":        "reserved",
+    })
+
+    entry := log.WithContext(ctx).WithField("error", errors.New("failed")).(*logrus.Entry)
+    entry.Error("save")
+
+    data := decodeLine(t, buf)
+    eq(data["msg"], "save", "WithField msg")
+    eq(data["fields.msg"], "reserved", "WithField reserved")
+    eq(data["error"], "failed", "WithField error")
+    eq(data[RequestIDKey], "req-3", "WithField request_id")
+}
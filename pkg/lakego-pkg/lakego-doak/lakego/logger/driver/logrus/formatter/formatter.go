@@ -1,12 +1,33 @@
 package formatter
 
 import (
-    "path"
-    "runtime"
     "github.com/sirupsen/logrus"
+    "github.com/deatil/lakego-doak/lakego/logger/driver/logrus/formatter/json"
     "github.com/deatil/lakego-doak/lakego/logger/driver/logrus/formatter/normal"
+    "github.com/deatil/lakego-doak/lakego/logger/driver/logrus/formatter/syslog"
 )
 
+// 根据名称获取格式化
+func Formatter(name string) logrus.Formatter {
+    switch name {
+        case "json":
+            // json 格式
+            return JSONFormatter()
+
+        case "text":
+            // 文档格式
+            return TextFormatter()
+
+        case "syslog":
+            // RFC 5424 格式
+            return SyslogFormatter("lakego", "user")
+
+        default:
+            // 正常格式
+            return NormalFormatter()
+    }
+}
+
 // 正常存储格式
 func NormalFormatter() logrus.Formatter {
     formatter := &normal.NormalFormatter{}
@@ -27,16 +48,18 @@ func TextFormatter() logrus.Formatter {
     return formatter
 }
 
-// json 存储格式
+// json 存储格式，每条日志一行
 func JSONFormatter() logrus.Formatter {
-    formatter := &logrus.JSONFormatter{
-        TimestampFormat:"2006-01-02 15:03:04",
-
-        CallerPrettyfier: func(frame *runtime.Frame) (function string, file string) {
-            //处理文件名
-            fileName := path.Base(frame.File)
-            return frame.Function, fileName
-        },
+    formatter := &json.JSONFormatter{}
+
+    return formatter
+}
+
+// RFC 5424 格式
+func SyslogFormatter(appName string, facility string) logrus.Formatter {
+    formatter := &syslog.SyslogFormatter{
+        AppName:  appName,
+        Facility: syslog.ParseFacility(facility),
     }
 
     return formatter
@@ -0,0 +1,80 @@
+package json
+
+import (
+    "bytes"
+    "strconv"
+    "path/filepath"
+    "encoding/json"
+
+    "github.com/sirupsen/logrus"
+)
+
+// 保留字段名称
+const (
+    FieldTime   = "time"
+    FieldLevel  = "level"
+    FieldMsg    = "msg"
+    FieldCaller = "caller"
+    FieldFunc   = "func"
+)
+
+/**
+ * json 格式化，每条日志一行
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type JSONFormatter struct {
+    // 时间格式，默认 RFC3339 带毫秒
+    TimestampFormat string
+}
+
+func (this *JSONFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+    data := make(logrus.Fields, len(entry.Data) + 5)
+
+    for k, v := range entry.Data {
+        // 与保留字段冲突时加前缀
+        switch k {
+            case FieldTime, FieldLevel, FieldMsg, FieldCaller, FieldFunc:
+                k = "fields." + k
+        }
+
+        switch value := v.(type) {
+            case error:
+                // error 类型 json 编码后为空
+                data[k] = value.Error()
+            default:
+                data[k] = v
+        }
+    }
+
+    timestampFormat := this.TimestampFormat
+    if timestampFormat == "" {
+        timestampFormat = "2006-01-02T15:04:05.000Z07:00"
+    }
+
+    data[FieldTime] = entry.Time.Format(timestampFormat)
+    data[FieldLevel] = entry.Level.String()
+    data[FieldMsg] = entry.Message
+
+    // HasCaller() 为 true 才会有调用信息
+    if entry.HasCaller() {
+        data[FieldCaller] = filepath.Base(entry.Caller.File) + ":" + strconv.Itoa(entry.Caller.Line)
+        data[FieldFunc] = entry.Caller.Function
+    }
+
+    var b *bytes.Buffer
+    if entry.Buffer != nil {
+        b = entry.Buffer
+    } else {
+        b = &bytes.Buffer{}
+    }
+
+    encoder := json.NewEncoder(b)
+    encoder.SetEscapeHTML(false)
+    if err := encoder.Encode(data); err != nil {
+        return nil, err
+    }
+
+    return b.Bytes(), nil
+}
@@ -0,0 +1,202 @@
+package syslog
+
+import (
+    "os"
+    "fmt"
+    "sort"
+    "bytes"
+    "strconv"
+    "strings"
+
+    "github.com/sirupsen/logrus"
+)
+
+// 默认结构化数据 ID
+const DefaultSDID = "lakego@32473"
+
+// 等级对应的 syslog 严重程度
+var severities = map[logrus.Level]int{
+    logrus.PanicLevel: 0,
+    logrus.FatalLevel: 2,
+    logrus.ErrorLevel: 3,
+    logrus.WarnLevel:  4,
+    logrus.InfoLevel:  6,
+    logrus.DebugLevel: 7,
+    logrus.TraceLevel: 7,
+}
+
+/**
+ * RFC 5424 格式化
+ *
+ * <PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID [SD-ID key="value"] MSG
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type SyslogFormatter struct {
+    // 设施，默认 1 (user)
+    Facility int
+
+    // 主机名，为空时使用当前主机名
+    Hostname string
+
+    // 应用名称
+    AppName string
+
+    // 结构化数据 ID，为空时使用 lakego@32473
+    SDID string
+
+    // 不添加换行，用于 udp 等按包发送的场景
+    NoNewline bool
+}
+
+func (this *SyslogFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+    var b *bytes.Buffer
+    if entry.Buffer != nil {
+        b = entry.Buffer
+    } else {
+        b = &bytes.Buffer{}
+    }
+
+    facility := this.Facility
+    if facility <= 0 || facility > 23 {
+        facility = 1
+    }
+
+    severity, ok := severities[entry.Level]
+    if !ok {
+        severity = 6
+    }
+
+    hostname := this.Hostname
+    if hostname == "" {
+        hostname, _ = os.Hostname()
+    }
+
+    sdid := this.SDID
+    if sdid == "" {
+        sdid = DefaultSDID
+    }
+
+    fmt.Fprintf(b, "<%d>1 %s %s %s %d - %s",
+        facility * 8 + severity,
+        entry.Time.Format("2006-01-02T15:04:05.000000Z07:00"),
+        header(hostname, 255),
+        header(this.AppName, 48),
+        os.Getpid(),
+        structuredData(sdid, entry.Data),
+    )
+
+    if entry.Message != "" {
+        b.WriteString(" ")
+        b.WriteString(entry.Message)
+    }
+
+    if !this.NoNewline {
+        b.WriteString("\n")
+    }
+
+    return b.Bytes(), nil
+}
+
+// 头部字段，只允许可见 ASCII 字符，为空时使用 -
+func header(value string, max int) string {
+    var b strings.Builder
+    for i := 0; i < len(value) && b.Len() < max; i++ {
+        if value[i] > ' ' && value[i] <= '~' {
+            b.WriteByte(value[i])
+        }
+    }
+
+    if b.Len() == 0 {
+        return "-"
+    }
+
+    return b.String()
+}
+
+// 结构化数据，为空时使用 -
+func structuredData(sdid string, data logrus.Fields) string {
+    if len(data) == 0 {
+        return "-"
+    }
+
+    keys := make([]string, 0, len(data))
+    for k := range data {
+        keys = append(keys, k)
+    }
+
+    sort.Strings(keys)
+
+    var b strings.Builder
+    b.WriteString("[")
+    b.WriteString(sdid)
+
+    for _, k := range keys {
+        name := paramName(k)
+        if name == "" {
+            continue
+        }
+
+        var value string
+        switch v := data[k].(type) {
+            case error:
+                value = v.Error()
+            case string:
+                value = v
+            default:
+                value = fmt.Sprint(v)
+        }
+
+        b.WriteString(" ")
+        b.WriteString(name)
+        b.WriteString(`="`)
+        b.WriteString(paramValue(value))
+        b.WriteString(`"`)
+    }
+
+    b.WriteString("]")
+
+    return b.String()
+}
+
+// 参数名称，最长 32 个字符，不能包含 = 空格 ] "
+func paramName(name string) string {
+    var b strings.Builder
+    for i := 0; i < len(name) && b.Len() < 32; i++ {
+        c := name[i]
+        if c > ' ' && c <= '~' && c != '=' && c != ']' && c != '"' {
+            b.WriteByte(c)
+        }
+    }
+
+    return b.String()
+}
+
+// 参数值，转义 " \ ]
+func paramValue(value string) string {
+    replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+
+    return replacer.Replace(value)
+}
+
+// 设施名称转为数值
+func ParseFacility(name string) int {
+    facilities := map[string]int{
+        "kern": 0, "user": 1, "mail": 2, "daemon": 3,
+        "auth": 4, "syslog": 5, "lpr": 6, "news": 7,
+        "uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11,
+        "local0": 16, "local1": 17, "local2": 18, "local3": 19,
+        "local4": 20, "local5": 21, "local6": 22, "local7": 23,
+    }
+
+    if facility, ok := facilities[strings.ToLower(name)]; ok {
+        return facility
+    }
+
+    if facility, err := strconv.Atoi(name); err == nil {
+        return facility
+    }
+
+    return 1
+}
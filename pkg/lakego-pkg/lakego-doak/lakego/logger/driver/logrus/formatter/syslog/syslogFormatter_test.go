@@ -0,0 +1,80 @@
+package syslog
+
+import (
+    "os"
+    "time"
+    "errors"
+    "strconv"
+    "reflect"
+    "testing"
+
+    "github.com/sirupsen/logrus"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func Test_Format(t *testing.T) {
+    eq := assertEqualT(t)
+
+    loc := time.FixedZone("CST", 8 * 3600)
+
+    entry := &logrus.Entry{
+        Time:    time.Date(2026, 10, 19, 8, 30, 1, 123456000, loc),
+        Level:   logrus.ErrorLevel,
+        Message: "save failed",
+        Data: logrus.Fields{
+            "request_id": "abc",
+            "error":      errors.New(`bad "value" ]`),
+        },
+    }
+
+    f := &SyslogFormatter{
+        Facility: 16,
+        Hostname: "web 1",
+        AppName:  "lakego",
+    }
+
+    data, err := f.Format(entry)
+    eq(err, nil, "Format err")
+
+    pid := strconv.Itoa(os.Getpid())
+    expected := `<131>1 2026-10-19T08:30:01.123456+08:00 web1 lakego ` + pid +
+        ` - [lakego@32473 error="bad \"value\" \]" request_id="abc"] save failed` + "\n"
+
+    eq(string(data), expected, "Format")
+}
+
+func Test_FormatEmpty(t *testing.T) {
+    eq := assertEqualT(t)
+
+    entry := &logrus.Entry{
+        Time:    time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+        Level:   logrus.InfoLevel,
+        Message: "hello",
+    }
+
+    f := &SyslogFormatter{
+        Hostname:  "host",
+        NoNewline: true,
+    }
+
+    data, _ := f.Format(entry)
+
+    expected := "<14>1 2026-10-19T00:00:00.000000Z host - " + strconv.Itoa(os.Getpid()) + " - - hello"
+    eq(string(data), expected, "Format empty")
+}
+
+func Test_ParseFacility(t *testing.T) {
+    eq := assertEqualT(t)
+
+    eq(ParseFacility("local0"), 16, "local0")
+    eq(ParseFacility("DAEMON"), 3, "daemon")
+    eq(ParseFacility("9"), 9, "number")
+    eq(ParseFacility("unknown"), 1, "unknown")
+}
@@ -224,22 +224,8 @@ func (this *Logrus) getLogger() *logrus.Logger {
 
     log.SetReportCaller(true)
 
-    var useFormatter logrus.Formatter
-
-    formatterType := conf["formatter"].(string)
-    switch formatterType {
-        case "json":
-            // json 格式
-            useFormatter = formatter.JSONFormatter()
-
-        case "text":
-            // 文档格式
-            useFormatter = formatter.TextFormatter()
-
-        default:
-            // 正常格式
-            useFormatter = formatter.NormalFormatter()
-    }
+    // 格式化类型 normal, json, text, syslog
+    useFormatter := formatter.Formatter(conf["formatter"].(string))
 
     // 设置输出样式
     log.SetFormatter(useFormatter)
@@ -0,0 +1,210 @@
+package multi
+
+import (
+    "io"
+    "fmt"
+    "sync"
+    logger "log"
+
+    "github.com/sirupsen/logrus"
+    "github.com/deatil/go-goch/goch"
+)
+
+/**
+ * 日志 multi 驱动，同时写入多个输出，每个输出可设置最低等级
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Multi struct {
+    // 配置
+    Config map[string]any
+
+    // 只初始化一次
+    once sync.Once
+
+    // 日志
+    logger *logrus.Logger
+}
+
+// 构造方法
+func New() *Multi {
+    return &Multi{}
+}
+
+// 设置配置
+func (this *Multi) WithConfig(config map[string]any) {
+    this.Config = config
+}
+
+// 批量设置自定义变量
+func (this *Multi) WithFields(fields map[string]any) any {
+    data := make(logrus.Fields, len(fields))
+    for k, v := range fields {
+        data[k] = v
+    }
+
+    return this.getLogger().WithFields(data)
+}
+
+// 设置自定义变量
+// *logrus.Entry
+func (this *Multi) WithField(key string, value any) any {
+    return this.getLogger().WithField(key, value)
+}
+
+// ========
+
+func (this *Multi) Trace(args ...any) {
+    this.getLogger().Trace(args...)
+}
+
+func (this *Multi) Debug(args ...any) {
+    this.getLogger().Debug(args...)
+}
+
+func (this *Multi) Info(args ...any) {
+    this.getLogger().Info(args...)
+}
+
+func (this *Multi) Warn(args ...any) {
+    this.getLogger().Warn(args...)
+}
+
+func (this *Multi) Warning(args ...any) {
+    this.getLogger().Warning(args...)
+}
+
+func (this *Multi) Error(args ...any) {
+    this.getLogger().Error(args...)
+}
+
+func (this *Multi) Fatal(args ...any) {
+    this.getLogger().Fatal(args...)
+}
+
+func (this *Multi) Panic(args ...any) {
+    this.getLogger().Panic(args...)
+}
+
+// ========
+
+func (this *Multi) Tracef(template string, args ...any) {
+    this.getLogger().Tracef(template, args...)
+}
+
+func (this *Multi) Debugf(template string, args ...any) {
+    this.getLogger().Debugf(template, args...)
+}
+
+func (this *Multi) Infof(template string, args ...any) {
+    this.getLogger().Infof(template, args...)
+}
+
+func (this *Multi) Warnf(template string, args ...any) {
+    this.getLogger().Warnf(template, args...)
+}
+
+func (this *Multi) Warningf(template string, args ...any) {
+    this.getLogger().Warningf(template, args...)
+}
+
+func (this *Multi) Errorf(template string, args ...any) {
+    this.getLogger().Errorf(template, args...)
+}
+
+func (this *Multi) Fatalf(template string, args ...any) {
+    this.getLogger().Fatalf(template, args...)
+}
+
+func (this *Multi) Panicf(template string, args ...any) {
+    this.getLogger().Panicf(template, args...)
+}
+
+// 获取日志
+func (this *Multi) getLogger() *logrus.Logger {
+    this.once.Do(func() {
+        this.logger = this.newLogger()
+    })
+
+    return this.logger
+}
+
+// 生成日志
+func (this *Multi) newLogger() *logrus.Logger {
+    log := logrus.New()
+
+    log.SetReportCaller(true)
+
+    // 日志只通过钩子写入各个输出
+    log.SetOutput(io.Discard)
+
+    sinks := make([]Sink, 0)
+
+    list, _ := this.Config["sinks"].([]any)
+    for _, item := range list {
+        conf := goch.ToStringMap(item)
+        if len(conf) == 0 {
+            continue
+        }
+
+        sink, err := NewSink(conf)
+        if err != nil {
+            logger.Print(fmt.Sprintf("日志输出[%v]配置错误：%v", conf["type"], err))
+            continue
+        }
+
+        sinks = append(sinks, sink)
+    }
+
+    // 最低等级取全部输出中最低的
+    level := logrus.PanicLevel
+    for _, sink := range sinks {
+        if sink.Level() > level {
+            level = sink.Level()
+        }
+    }
+
+    log.SetLevel(level)
+    log.AddHook(NewHook(sinks))
+
+    return log
+}
+
+/**
+ * 分发到各个输出的钩子
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Hook struct {
+    // 输出列表
+    sinks []Sink
+}
+
+// 构造函数
+func NewHook(sinks []Sink) *Hook {
+    return &Hook{
+        sinks: sinks,
+    }
+}
+
+// 全部等级
+func (this *Hook) Levels() []logrus.Level {
+    return logrus.AllLevels
+}
+
+// 写入满足等级的输出
+func (this *Hook) Fire(entry *logrus.Entry) error {
+    for _, sink := range this.sinks {
+        if entry.Level > sink.Level() {
+            continue
+        }
+
+        if err := sink.Write(entry); err != nil {
+            logger.Print(fmt.Sprintf("日志写入错误：%v", err))
+        }
+    }
+
+    return nil
+}
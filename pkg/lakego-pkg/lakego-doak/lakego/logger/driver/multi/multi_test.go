@@ -0,0 +1,131 @@
+package multi
+
+import (
+    "net"
+    "time"
+    "bytes"
+    "errors"
+    "strings"
+    "reflect"
+    "testing"
+
+    "github.com/sirupsen/logrus"
+
+    "github.com/deatil/lakego-doak/lakego/logger/ring"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func Test_Multi(t *testing.T) {
+    eq := assertEqualT(t)
+
+    driver := New()
+    driver.WithConfig(map[string]any{
+        "type": "multi",
+        "sinks": []any{
+            map[string]any{
+                "type":  "memory",
+                "level": "info",
+                "name":  "test-multi-info",
+                "size":  10,
+            },
+            map[string]any{
+                "type":  "memory",
+                "level": "debug",
+                "name":  "test-multi-debug",
+                "size":  10,
+            },
+            map[string]any{
+                "type": "unknown",
+            },
+        },
+    })
+
+    driver.Trace("trace message")
+    driver.Debug("debug message")
+    driver.Infof("info %s", "message")
+    driver.WithField("error", errors.New("failed")).(*logrus.Entry).Error("error message")
+
+    info, ok := ring.Get("test-multi-info")
+    eq(ok, true, "Get info buffer")
+
+    entries := info.Recent(0)
+    eq(len(entries), 2, "info buffer len")
+    eq(entries[0].Message, "error message", "newest message")
+    eq(entries[0].Level, "error", "newest level")
+    eq(entries[0].Fields["error"], "failed", "newest field")
+    eq(entries[1].Message, "info message", "oldest message")
+
+    debug, _ := ring.Get("test-multi-debug")
+    eq(debug.Len(), 3, "debug buffer len")
+}
+
+func Test_WriterSink(t *testing.T) {
+    eq := assertEqualT(t)
+
+    var out bytes.Buffer
+    sink := NewWriterSink(logrus.WarnLevel, &logrus.TextFormatter{DisableColors: true}, &out)
+
+    log := logrus.New()
+    log.SetOutput(&bytes.Buffer{})
+    log.SetLevel(logrus.TraceLevel)
+    log.AddHook(NewHook([]Sink{sink}))
+
+    log.Info("skipped")
+    log.Warn("written")
+
+    eq(strings.Contains(out.String(), "skipped"), false, "info skipped")
+    eq(strings.Contains(out.String(), "msg=written"), true, "warn written")
+}
+
+func Test_NewSink(t *testing.T) {
+    eq := assertEqualT(t)
+
+    _, err := NewSink(map[string]any{
+        "type": "unknown",
+    })
+    eq(err, ErrSinkTypeInvalid, "invalid type")
+
+    _, err = NewSink(map[string]any{
+        "type":  "stdout",
+        "level": "nothing",
+    })
+    eq(err != nil, true, "invalid level")
+
+    sink, err := NewSink(map[string]any{
+        "type":  "syslog",
+        "level": "warning",
+    })
+    eq(err, nil, "syslog sink")
+    eq(sink.Level(), logrus.WarnLevel, "syslog level")
+}
+
+func Test_SyslogWriter(t *testing.T) {
+    eq := assertEqualT(t)
+
+    conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+    if err != nil {
+        t.Skip(err)
+    }
+    defer conn.Close()
+
+    writer := NewSyslogWriter("udp", conn.LocalAddr().String())
+    defer writer.Close()
+
+    n, err := writer.Write([]byte("<14>1 - - - - - - hello"))
+    eq(err, nil, "Write err")
+    eq(n, 23, "Write len")
+
+    buf := make([]byte, 1024)
+    conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+
+    n, _, err = conn.ReadFrom(buf)
+    eq(err, nil, "ReadFrom err")
+    eq(string(buf[:n]), "<14>1 - - - - - - hello", "ReadFrom data")
+}
@@ -0,0 +1,280 @@
+package multi
+
+import (
+    "io"
+    "os"
+    "fmt"
+    "net"
+    "sync"
+    "time"
+    "errors"
+
+    "github.com/sirupsen/logrus"
+    "github.com/deatil/go-goch/goch"
+    "github.com/lestrrat/go-file-rotatelogs"
+
+    "github.com/deatil/lakego-doak/lakego/path"
+    "github.com/deatil/lakego-doak/lakego/logger/ring"
+    "github.com/deatil/lakego-doak/lakego/logger/driver/logrus/formatter"
+    "github.com/deatil/lakego-doak/lakego/logger/driver/logrus/formatter/syslog"
+)
+
+var (
+    // 输出类型不存在
+    ErrSinkTypeInvalid = errors.New("logger: sink type is invalid")
+)
+
+/**
+ * 日志输出
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Sink interface {
+    // 最低等级
+    Level() logrus.Level
+
+    // 写入
+    Write(*logrus.Entry) error
+}
+
+// 根据配置生成输出
+func NewSink(conf map[string]any) (Sink, error) {
+    level, err := logrus.ParseLevel(goch.ToString(defaultValue(conf, "level", "trace")))
+    if err != nil {
+        return nil, err
+    }
+
+    useFormatter := formatter.Formatter(goch.ToString(defaultValue(conf, "formatter", "normal")))
+
+    switch goch.ToString(conf["type"]) {
+        case "file":
+            // 文件，按时间切割
+            maxAge := time.Duration(goch.ToInt64(defaultValue(conf, "max-age", 168)))
+            rotationTime := time.Duration(goch.ToInt64(defaultValue(conf, "rotation-time", 24)))
+
+            writer, err := rotatelogs.New(
+                path.FormatPath(goch.ToString(conf["filepath"])),
+                rotatelogs.WithMaxAge(maxAge * time.Hour),
+                rotatelogs.WithRotationTime(rotationTime * time.Hour),
+            )
+            if err != nil {
+                return nil, err
+            }
+
+            return NewWriterSink(level, useFormatter, writer), nil
+
+        case "stdout":
+            // 标准输出
+            return NewWriterSink(level, useFormatter, os.Stdout), nil
+
+        case "stderr":
+            // 标准错误输出
+            return NewWriterSink(level, useFormatter, os.Stderr), nil
+
+        case "memory":
+            // 内存缓冲，可在后台查看
+            name := goch.ToString(defaultValue(conf, "name", "default"))
+            size := goch.ToInt(defaultValue(conf, "size", 500))
+
+            return NewMemorySink(level, ring.Register(name, size)), nil
+
+        case "syslog":
+            // 本地 unix 或者 udp 等 syslog 服务
+            network := goch.ToString(defaultValue(conf, "network", "unixgram"))
+            address := goch.ToString(defaultValue(conf, "address", "/dev/log"))
+
+            useFormatter := &syslog.SyslogFormatter{
+                AppName:   goch.ToString(defaultValue(conf, "app-name", "lakego")),
+                Facility:  syslog.ParseFacility(goch.ToString(defaultValue(conf, "facility", "user"))),
+                NoNewline: true,
+            }
+
+            return NewWriterSink(level, useFormatter, NewSyslogWriter(network, address)), nil
+    }
+
+    return nil, ErrSinkTypeInvalid
+}
+
+/**
+ * 格式化后写入 io.Writer
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type WriterSink struct {
+    // 锁定
+    mu sync.Mutex
+
+    // 最低等级
+    level logrus.Level
+
+    // 格式化
+    formatter logrus.Formatter
+
+    // 输出
+    writer io.Writer
+}
+
+// 构造函数
+func NewWriterSink(level logrus.Level, formatter logrus.Formatter, writer io.Writer) *WriterSink {
+    return &WriterSink{
+        level:     level,
+        formatter: formatter,
+        writer:    writer,
+    }
+}
+
+// 最低等级
+func (this *WriterSink) Level() logrus.Level {
+    return this.level
+}
+
+// 写入
+func (this *WriterSink) Write(entry *logrus.Entry) error {
+    data, err := this.formatter.Format(entry)
+    if err != nil {
+        return err
+    }
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    _, err = this.writer.Write(data)
+
+    return err
+}
+
+/**
+ * 写入内存缓冲
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type MemorySink struct {
+    // 最低等级
+    level logrus.Level
+
+    // 缓冲
+    buffer *ring.Buffer
+}
+
+// 构造函数
+func NewMemorySink(level logrus.Level, buffer *ring.Buffer) *MemorySink {
+    return &MemorySink{
+        level:  level,
+        buffer: buffer,
+    }
+}
+
+// 最低等级
+func (this *MemorySink) Level() logrus.Level {
+    return this.level
+}
+
+// 写入
+func (this *MemorySink) Write(entry *logrus.Entry) error {
+    fields := make(map[string]any, len(entry.Data))
+    for k, v := range entry.Data {
+        switch value := v.(type) {
+            case error:
+                fields[k] = value.Error()
+            case fmt.Stringer:
+                fields[k] = value.String()
+            default:
+                fields[k] = v
+        }
+    }
+
+    this.buffer.Add(ring.Entry{
+        Time:    entry.Time,
+        Level:   entry.Level.String(),
+        Message: entry.Message,
+        Fields:  fields,
+    })
+
+    return nil
+}
+
+/**
+ * syslog 连接，首次写入时连接，写入失败时重连一次
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type SyslogWriter struct {
+    // 锁定
+    mu sync.Mutex
+
+    // 网络类型 unixgram, unix, udp, tcp
+    network string
+
+    // 地址
+    address string
+
+    // 连接
+    conn net.Conn
+}
+
+// 构造函数
+func NewSyslogWriter(network string, address string) *SyslogWriter {
+    return &SyslogWriter{
+        network: network,
+        address: address,
+    }
+}
+
+// 写入
+func (this *SyslogWriter) Write(p []byte) (int, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    // 流式连接使用 RFC 6587 长度前缀分帧
+    data := p
+    if this.network == "tcp" || this.network == "unix" {
+        data = append([]byte(fmt.Sprintf("%d ", len(p))), p...)
+    }
+
+    var err error
+    for i := 0; i < 2; i++ {
+        if this.conn == nil {
+            this.conn, err = net.DialTimeout(this.network, this.address, 5 * time.Second)
+            if err != nil {
+                return 0, err
+            }
+        }
+
+        if _, err = this.conn.Write(data); err == nil {
+            return len(p), nil
+        }
+
+        this.conn.Close()
+        this.conn = nil
+    }
+
+    return 0, err
+}
+
+// 关闭
+func (this *SyslogWriter) Close() error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if this.conn == nil {
+        return nil
+    }
+
+    err := this.conn.Close()
+    this.conn = nil
+
+    return err
+}
+
+// 配置默认值
+func defaultValue(conf map[string]any, key string, value any) any {
+    if data, ok := conf[key]; ok && data != nil && data != "" {
+        return data
+    }
+
+    return value
+}
@@ -0,0 +1,412 @@
+//go:build go1.21
+
+package slog
+
+import (
+    "io"
+    "os"
+    "fmt"
+    "sort"
+    "sync"
+    "time"
+    "context"
+    "runtime"
+    "strings"
+    "log/slog"
+    logger "log"
+
+    "github.com/deatil/go-goch/goch"
+    "github.com/lestrrat/go-file-rotatelogs"
+
+    "github.com/deatil/lakego-doak/lakego/path"
+)
+
+// 扩展等级
+const (
+    LevelTrace = slog.LevelDebug - 4
+    LevelFatal = slog.LevelError + 4
+    LevelPanic = slog.LevelError + 8
+)
+
+// 调用位置查找时跳过的包
+const loggerPackage = "github.com/deatil/lakego-doak/lakego/logger"
+
+/**
+ * 日志 slog 驱动
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Slog struct {
+    // 配置
+    Config map[string]any
+
+    // 自定义输出
+    writer io.Writer
+
+    // 只初始化一次
+    once sync.Once
+
+    // 日志
+    logger *slog.Logger
+}
+
+// 构造方法
+func New() *Slog {
+    return &Slog{}
+}
+
+// 设置配置
+func (this *Slog) WithConfig(config map[string]any) {
+    this.Config = config
+}
+
+// 设置输出，设置后忽略配置的输出
+func (this *Slog) WithWriter(writer io.Writer) {
+    this.writer = writer
+}
+
+// 获取 slog 日志
+func (this *Slog) GetLogger() *slog.Logger {
+    this.once.Do(func() {
+        this.logger = this.newLogger()
+    })
+
+    return this.logger
+}
+
+// 批量设置自定义变量
+// *Entry
+func (this *Slog) WithFields(fields map[string]any) any {
+    return this.entry().WithFields(fields)
+}
+
+// 设置自定义变量
+// *Entry
+func (this *Slog) WithField(key string, value any) any {
+    return this.entry().WithField(key, value)
+}
+
+// ========
+
+func (this *Slog) Trace(args ...any) {
+    this.entry().Trace(args...)
+}
+
+func (this *Slog) Debug(args ...any) {
+    this.entry().Debug(args...)
+}
+
+func (this *Slog) Info(args ...any) {
+    this.entry().Info(args...)
+}
+
+func (this *Slog) Warn(args ...any) {
+    this.entry().Warn(args...)
+}
+
+func (this *Slog) Warning(args ...any) {
+    this.entry().Warning(args...)
+}
+
+func (this *Slog) Error(args ...any) {
+    this.entry().Error(args...)
+}
+
+func (this *Slog) Fatal(args ...any) {
+    this.entry().Fatal(args...)
+}
+
+func (this *Slog) Panic(args ...any) {
+    this.entry().Panic(args...)
+}
+
+// ========
+
+func (this *Slog) Tracef(template string, args ...any) {
+    this.entry().Tracef(template, args...)
+}
+
+func (this *Slog) Debugf(template string, args ...any) {
+    this.entry().Debugf(template, args...)
+}
+
+func (this *Slog) Infof(template string, args ...any) {
+    this.entry().Infof(template, args...)
+}
+
+func (this *Slog) Warnf(template string, args ...any) {
+    this.entry().Warnf(template, args...)
+}
+
+func (this *Slog) Warningf(template string, args ...any) {
+    this.entry().Warningf(template, args...)
+}
+
+func (this *Slog) Errorf(template string, args ...any) {
+    this.entry().Errorf(template, args...)
+}
+
+func (this *Slog) Fatalf(template string, args ...any) {
+    this.entry().Fatalf(template, args...)
+}
+
+func (this *Slog) Panicf(template string, args ...any) {
+    this.entry().Panicf(template, args...)
+}
+
+// 日志记录
+func (this *Slog) entry() *Entry {
+    return NewEntry(this.GetLogger())
+}
+
+// 生成日志
+func (this *Slog) newLogger() *slog.Logger {
+    conf := this.Config
+
+    opts := &slog.HandlerOptions{
+        AddSource:   goch.ToBool(conf["add-source"]),
+        Level:       ParseLevel(goch.ToString(conf["level"])),
+        ReplaceAttr: replaceLevel,
+    }
+
+    writer := this.writer
+    if writer == nil {
+        writer = newWriter(conf)
+    }
+
+    var handler slog.Handler
+    switch goch.ToString(conf["handler"]) {
+        case "json":
+            // json 格式
+            handler = slog.NewJSONHandler(writer, opts)
+
+        default:
+            // 文本格式
+            handler = slog.NewTextHandler(writer, opts)
+    }
+
+    log := slog.New(handler)
+
+    // 设置为 slog 的默认日志
+    if goch.ToBool(conf["set-default"]) {
+        slog.SetDefault(log)
+    }
+
+    return log
+}
+
+// 输出
+func newWriter(conf map[string]any) io.Writer {
+    switch goch.ToString(conf["output"]) {
+        case "stdout":
+            return os.Stdout
+
+        case "stderr":
+            return os.Stderr
+    }
+
+    maxAge := time.Duration(goch.ToInt64(conf["max-age"]))
+    rotationTime := time.Duration(goch.ToInt64(conf["rotation-time"]))
+
+    writer, err := rotatelogs.New(
+        path.FormatPath(goch.ToString(conf["filepath"])),
+        rotatelogs.WithMaxAge(maxAge * time.Hour),
+        rotatelogs.WithRotationTime(rotationTime * time.Hour),
+    )
+    if err != nil {
+        logger.Print(fmt.Sprintf("日志配置错误：%v", err))
+        return os.Stderr
+    }
+
+    return writer
+}
+
+// 等级名称
+func replaceLevel(groups []string, a slog.Attr) slog.Attr {
+    if a.Key != slog.LevelKey || len(groups) > 0 {
+        return a
+    }
+
+    level, ok := a.Value.Any().(slog.Level)
+    if !ok {
+        return a
+    }
+
+    switch level {
+        case LevelTrace:
+            a.Value = slog.StringValue("TRACE")
+        case LevelFatal:
+            a.Value = slog.StringValue("FATAL")
+        case LevelPanic:
+            a.Value = slog.StringValue("PANIC")
+    }
+
+    return a
+}
+
+// 解析等级，默认为 trace
+func ParseLevel(level string) slog.Level {
+    switch strings.ToLower(level) {
+        case "panic":
+            return LevelPanic
+        case "fatal":
+            return LevelFatal
+        case "error":
+            return slog.LevelError
+        case "warn", "warning":
+            return slog.LevelWarn
+        case "info":
+            return slog.LevelInfo
+        case "debug":
+            return slog.LevelDebug
+    }
+
+    return LevelTrace
+}
+
+/**
+ * 日志记录
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Entry struct {
+    // 日志
+    logger *slog.Logger
+}
+
+// 构造函数
+func NewEntry(logger *slog.Logger) *Entry {
+    return &Entry{
+        logger: logger,
+    }
+}
+
+// 批量设置自定义变量
+func (this *Entry) WithFields(fields map[string]any) *Entry {
+    keys := make([]string, 0, len(fields))
+    for k := range fields {
+        keys = append(keys, k)
+    }
+
+    sort.Strings(keys)
+
+    args := make([]any, 0, len(fields))
+    for _, k := range keys {
+        args = append(args, slog.Any(k, fields[k]))
+    }
+
+    return NewEntry(this.logger.With(args...))
+}
+
+// 设置自定义变量
+func (this *Entry) WithField(key string, value any) *Entry {
+    return NewEntry(this.logger.With(slog.Any(key, value)))
+}
+
+// ========
+
+func (this *Entry) Trace(args ...any) {
+    this.log(LevelTrace, fmt.Sprint(args...))
+}
+
+func (this *Entry) Debug(args ...any) {
+    this.log(slog.LevelDebug, fmt.Sprint(args...))
+}
+
+func (this *Entry) Info(args ...any) {
+    this.log(slog.LevelInfo, fmt.Sprint(args...))
+}
+
+func (this *Entry) Warn(args ...any) {
+    this.log(slog.LevelWarn, fmt.Sprint(args...))
+}
+
+func (this *Entry) Warning(args ...any) {
+    this.log(slog.LevelWarn, fmt.Sprint(args...))
+}
+
+func (this *Entry) Error(args ...any) {
+    this.log(slog.LevelError, fmt.Sprint(args...))
+}
+
+func (this *Entry) Fatal(args ...any) {
+    this.log(LevelFatal, fmt.Sprint(args...))
+}
+
+func (this *Entry) Panic(args ...any) {
+    this.log(LevelPanic, fmt.Sprint(args...))
+}
+
+// ========
+
+func (this *Entry) Tracef(template string, args ...any) {
+    this.log(LevelTrace, fmt.Sprintf(template, args...))
+}
+
+func (this *Entry) Debugf(template string, args ...any) {
+    this.log(slog.LevelDebug, fmt.Sprintf(template, args...))
+}
+
+func (this *Entry) Infof(template string, args ...any) {
+    this.log(slog.LevelInfo, fmt.Sprintf(template, args...))
+}
+
+func (this *Entry) Warnf(template string, args ...any) {
+    this.log(slog.LevelWarn, fmt.Sprintf(template, args...))
+}
+
+func (this *Entry) Warningf(template string, args ...any) {
+    this.log(slog.LevelWarn, fmt.Sprintf(template, args...))
+}
+
+func (this *Entry) Errorf(template string, args ...any) {
+    this.log(slog.LevelError, fmt.Sprintf(template, args...))
+}
+
+func (this *Entry) Fatalf(template string, args ...any) {
+    this.log(LevelFatal, fmt.Sprintf(template, args...))
+}
+
+func (this *Entry) Panicf(template string, args ...any) {
+    this.log(LevelPanic, fmt.Sprintf(template, args...))
+}
+
+// 写入日志，Fatal 写入后退出，Panic 写入后 panic
+func (this *Entry) log(level slog.Level, msg string) {
+    ctx := context.Background()
+
+    if this.logger.Enabled(ctx, level) {
+        record := slog.NewRecord(time.Now(), level, msg, callerPC())
+        this.logger.Handler().Handle(ctx, record)
+    }
+
+    switch level {
+        case LevelFatal:
+            os.Exit(1)
+        case LevelPanic:
+            panic(msg)
+    }
+}
+
+// 调用位置，跳过日志包内的调用
+func callerPC() uintptr {
+    var pcs [16]uintptr
+    n := runtime.Callers(3, pcs[:])
+
+    frames := runtime.CallersFrames(pcs[:n])
+    for {
+        frame, more := frames.Next()
+        if !strings.HasPrefix(frame.Function, loggerPackage) ||
+            strings.HasSuffix(frame.File, "_test.go") {
+            return frame.PC
+        }
+
+        if !more {
+            break
+        }
+    }
+
+    return 0
+}
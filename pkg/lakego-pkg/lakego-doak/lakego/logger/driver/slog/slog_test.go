@@ -0,0 +1,103 @@
+//go:build go1.21
+
+package slog
+
+import (
+    "bytes"
+    "errors"
+    "strings"
+    "reflect"
+    "testing"
+    "encoding/json"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func Test_Slog(t *testing.T) {
+    eq := assertEqualT(t)
+
+    var out bytes.Buffer
+
+    driver := New()
+    driver.WithWriter(&out)
+    driver.WithConfig(map[string]any{
+        "type":       "slog",
+        "handler":    "json",
+        "level":      "info",
+        "add-source": true,
+    })
+
+    driver.Debug("skipped")
+    driver.WithFields(map[string]any{
+        "request_id": "abc",
+        "error":      errors.New("failed"),
+    }).(*Entry).Errorf("save %s", "failed")
+
+    lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+    eq(len(lines), 1, "lines")
+
+    data := make(map[string]any)
+    err := json.Unmarshal([]byte(lines[0]), &data)
+    eq(err, nil, "Unmarshal")
+
+    eq(data["level"], "ERROR", "level")
+    eq(data["msg"], "save failed", "msg")
+    eq(data["request_id"], "abc", "request_id")
+    eq(data["error"], "failed", "error")
+
+    source, _ := data["source"].(map[string]any)
+    eq(strings.HasSuffix(source["file"].(string), "slog_test.go"), true, "source")
+}
+
+func Test_TraceLevel(t *testing.T) {
+    eq := assertEqualT(t)
+
+    var out bytes.Buffer
+
+    driver := New()
+    driver.WithWriter(&out)
+    driver.WithConfig(map[string]any{
+        "type":  "slog",
+        "level": "trace",
+    })
+
+    driver.Tracef("trace %d", 1)
+
+    eq(strings.Contains(out.String(), "level=TRACE"), true, "trace level")
+    eq(strings.Contains(out.String(), `msg="trace 1"`), true, "trace msg")
+}
+
+func Test_Panic(t *testing.T) {
+    eq := assertEqualT(t)
+
+    var out bytes.Buffer
+
+    driver := New()
+    driver.WithWriter(&out)
+    driver.WithConfig(map[string]any{
+        "type":  "slog",
+        "level": "error",
+    })
+
+    defer func() {
+        r := recover()
+        eq(r, "boom", "recover")
+        eq(strings.Contains(out.String(), "level=PANIC"), true, "panic level")
+    }()
+
+    driver.Panic("boom")
+}
+
+func Test_ParseLevel(t *testing.T) {
+    eq := assertEqualT(t)
+
+    eq(ParseLevel("warning"), ParseLevel("warn"), "warning")
+    eq(ParseLevel("fatal"), LevelFatal, "fatal")
+    eq(ParseLevel(""), LevelTrace, "default")
+}
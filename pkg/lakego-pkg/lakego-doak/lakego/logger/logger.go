@@ -1,6 +1,8 @@
 package logger
 
 import (
+    "context"
+
     "github.com/deatil/lakego-doak/lakego/logger/interfaces"
 )
 
@@ -49,6 +51,19 @@ func (this *Logger) WithField(key string, value any) any {
     return this.driver.WithField(key, value)
 }
 
+// 使用上下文中的请求 ID、管理员 ID 及路由等字段
+func (this *Logger) WithContext(ctx context.Context) *Logger {
+    fields := FromContext(ctx)
+    if len(fields) == 0 {
+        return this
+    }
+
+    return New(&fieldsDriver{
+        driver: this.driver,
+        fields: fields,
+    })
+}
+
 // ========
 
 func (this *Logger) Trace(args ...any) {
@@ -0,0 +1,178 @@
+package ring
+
+import (
+    "sync"
+    "time"
+)
+
+/**
+ * 日志记录
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Entry struct {
+    // 时间
+    Time time.Time `json:"time"`
+
+    // 等级
+    Level string `json:"level"`
+
+    // 信息
+    Message string `json:"message"`
+
+    // 自定义数据
+    Fields map[string]any `json:"fields"`
+}
+
+/**
+ * 内存环形缓冲，只保留最近的日志
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Buffer struct {
+    // 锁定
+    mu sync.RWMutex
+
+    // 记录
+    entries []Entry
+
+    // 下一个写入位置
+    next int
+
+    // 是否已写满
+    full bool
+}
+
+// 构造函数
+func New(size int) *Buffer {
+    if size <= 0 {
+        size = 500
+    }
+
+    return &Buffer{
+        entries: make([]Entry, size),
+    }
+}
+
+// 添加
+func (this *Buffer) Add(entry Entry) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.entries[this.next] = entry
+
+    this.next++
+    if this.next >= len(this.entries) {
+        this.next = 0
+        this.full = true
+    }
+}
+
+// 数量
+func (this *Buffer) Len() int {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    if this.full {
+        return len(this.entries)
+    }
+
+    return this.next
+}
+
+// 最近的记录，按时间倒序，levels 为空时返回全部等级
+func (this *Buffer) Recent(limit int, levels ...string) []Entry {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    count := this.next
+    if this.full {
+        count = len(this.entries)
+    }
+
+    list := make([]Entry, 0)
+    for i := 1; i <= count; i++ {
+        if limit > 0 && len(list) >= limit {
+            break
+        }
+
+        index := (this.next - i + len(this.entries)) % len(this.entries)
+        entry := this.entries[index]
+
+        if len(levels) > 0 && !inLevels(entry.Level, levels) {
+            continue
+        }
+
+        list = append(list, entry)
+    }
+
+    return list
+}
+
+// 清空
+func (this *Buffer) Clear() {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.entries = make([]Entry, len(this.entries))
+    this.next = 0
+    this.full = false
+}
+
+func inLevels(level string, levels []string) bool {
+    for _, l := range levels {
+        if l == level {
+            return true
+        }
+    }
+
+    return false
+}
+
+// 已注册的缓冲
+var buffers = struct {
+    sync.RWMutex
+    list map[string]*Buffer
+}{
+    list: make(map[string]*Buffer),
+}
+
+// 注册，名称已存在时返回已注册的缓冲
+func Register(name string, size int) *Buffer {
+    buffers.Lock()
+    defer buffers.Unlock()
+
+    if buffer, ok := buffers.list[name]; ok {
+        return buffer
+    }
+
+    buffer := New(size)
+    buffers.list[name] = buffer
+
+    return buffer
+}
+
+// 获取
+func Get(name string) (*Buffer, bool) {
+    buffers.RLock()
+    defer buffers.RUnlock()
+
+    buffer, ok := buffers.list[name]
+
+    return buffer, ok
+}
+
+// 全部名称
+func Names() []string {
+    buffers.RLock()
+    defer buffers.RUnlock()
+
+    names := make([]string, 0, len(buffers.list))
+    for name := range buffers.list {
+        names = append(names, name)
+    }
+
+    return names
+}
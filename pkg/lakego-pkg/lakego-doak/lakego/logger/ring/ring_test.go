@@ -0,0 +1,60 @@
+package ring
+
+import (
+    "reflect"
+    "testing"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func messages(entries []Entry) []string {
+    list := make([]string, 0, len(entries))
+    for _, entry := range entries {
+        list = append(list, entry.Message)
+    }
+
+    return list
+}
+
+func Test_Buffer(t *testing.T) {
+    eq := assertEqualT(t)
+
+    buf := New(3)
+    eq(buf.Len(), 0, "Len empty")
+    eq(messages(buf.Recent(0)), []string{}, "Recent empty")
+
+    buf.Add(Entry{Level: "info", Message: "1"})
+    buf.Add(Entry{Level: "error", Message: "2"})
+    eq(buf.Len(), 2, "Len")
+    eq(messages(buf.Recent(0)), []string{"2", "1"}, "Recent")
+
+    buf.Add(Entry{Level: "info", Message: "3"})
+    buf.Add(Entry{Level: "warning", Message: "4"})
+    eq(buf.Len(), 3, "Len full")
+    eq(messages(buf.Recent(0)), []string{"4", "3", "2"}, "Recent full")
+    eq(messages(buf.Recent(2)), []string{"4", "3"}, "Recent limit")
+    eq(messages(buf.Recent(0, "error", "warning")), []string{"4", "2"}, "Recent levels")
+
+    buf.Clear()
+    eq(buf.Len(), 0, "Clear")
+}
+
+func Test_Register(t *testing.T) {
+    eq := assertEqualT(t)
+
+    buf := Register("test-register", 10)
+    eq(Register("test-register", 20) == buf, true, "Register same")
+
+    got, ok := Get("test-register")
+    eq(ok, true, "Get ok")
+    eq(got == buf, true, "Get")
+
+    _, ok = Get("test-missing")
+    eq(ok, false, "Get missing")
+}
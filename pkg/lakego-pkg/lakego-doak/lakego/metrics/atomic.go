@@ -0,0 +1,30 @@
+package metrics
+
+import (
+    "math"
+    "sync/atomic"
+)
+
+// 原子浮点数
+type atomicFloat struct {
+    bits uint64
+}
+
+func (this *atomicFloat) Load() float64 {
+    return math.Float64frombits(atomic.LoadUint64(&this.bits))
+}
+
+func (this *atomicFloat) Store(v float64) {
+    atomic.StoreUint64(&this.bits, math.Float64bits(v))
+}
+
+func (this *atomicFloat) Add(v float64) {
+    for {
+        old := atomic.LoadUint64(&this.bits)
+        newBits := math.Float64bits(math.Float64frombits(old) + v)
+
+        if atomic.CompareAndSwapUint64(&this.bits, old, newBits) {
+            return
+        }
+    }
+}
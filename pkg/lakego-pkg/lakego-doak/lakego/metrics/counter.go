@@ -0,0 +1,133 @@
+package metrics
+
+import (
+    "sync"
+    "bytes"
+)
+
+/**
+ * 计数器
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Counter struct {
+    // 值
+    value atomicFloat
+}
+
+// 加一
+func (this *Counter) Inc() {
+    this.value.Add(1)
+}
+
+// 增加，负数会被忽略
+func (this *Counter) Add(v float64) {
+    if v < 0 {
+        return
+    }
+
+    this.value.Add(v)
+}
+
+// 当前值
+func (this *Counter) Value() float64 {
+    return this.value.Load()
+}
+
+/**
+ * 带 label 的计数器
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type CounterVec struct {
+    // 锁定
+    mu sync.RWMutex
+
+    // 名称
+    name string
+
+    // 说明
+    help string
+
+    // label 名称
+    labels []string
+
+    // 数据
+    children map[string]*counterChild
+}
+
+type counterChild struct {
+    Counter
+
+    values []string
+}
+
+// 构造函数
+func NewCounterVec(name, help string, labels ...string) *CounterVec {
+    return &CounterVec{
+        name:     name,
+        help:     help,
+        labels:   labels,
+        children: make(map[string]*counterChild),
+    }
+}
+
+// 获取对应 label 值的计数器
+func (this *CounterVec) With(values ...string) *Counter {
+    values = fixLabelValues(values, len(this.labels))
+    key := joinLabelValues(values)
+
+    this.mu.RLock()
+    child, ok := this.children[key]
+    this.mu.RUnlock()
+
+    if ok {
+        return &child.Counter
+    }
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if child, ok = this.children[key]; !ok {
+        child = &counterChild{values: values}
+        this.children[key] = child
+    }
+
+    return &child.Counter
+}
+
+// 重置
+func (this *CounterVec) Reset() {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.children = make(map[string]*counterChild)
+}
+
+// 名称
+func (this *CounterVec) Name() string {
+    return this.name
+}
+
+// 写入
+func (this *CounterVec) Write(buf *bytes.Buffer) {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    writeHeader(buf, this.name, this.help, TypeCounter)
+
+    for _, key := range sortedKeys(this.children) {
+        child := this.children[key]
+        writeSample(buf, this.name, this.labels, child.values, child.Value())
+    }
+}
+
+// 补齐 label 值
+func fixLabelValues(values []string, n int) []string {
+    fixed := make([]string, n)
+    copy(fixed, values)
+
+    return fixed
+}
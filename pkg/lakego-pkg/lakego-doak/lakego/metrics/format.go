@@ -0,0 +1,99 @@
+package metrics
+
+import (
+    "sort"
+    "math"
+    "bytes"
+    "strconv"
+    "strings"
+)
+
+// 文本格式 Content-Type
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// 指标类型
+const (
+    TypeCounter   = "counter"
+    TypeGauge     = "gauge"
+    TypeHistogram = "histogram"
+)
+
+// label 值分隔符
+const labelSep = "\xff"
+
+var (
+    helpReplacer  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+    valueReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+// 写入 HELP 和 TYPE
+func writeHeader(buf *bytes.Buffer, name, help, typ string) {
+    if help != "" {
+        buf.WriteString("# HELP ")
+        buf.WriteString(name)
+        buf.WriteByte(' ')
+        buf.WriteString(helpReplacer.Replace(help))
+        buf.WriteByte('\n')
+    }
+
+    buf.WriteString("# TYPE ")
+    buf.WriteString(name)
+    buf.WriteByte(' ')
+    buf.WriteString(typ)
+    buf.WriteByte('\n')
+}
+
+// 写入单条数据
+func writeSample(buf *bytes.Buffer, name string, names, values []string, value float64) {
+    buf.WriteString(name)
+
+    if len(names) > 0 {
+        buf.WriteByte('{')
+        for i, n := range names {
+            if i > 0 {
+                buf.WriteByte(',')
+            }
+
+            buf.WriteString(n)
+            buf.WriteString(`="`)
+            buf.WriteString(valueReplacer.Replace(values[i]))
+            buf.WriteByte('"')
+        }
+        buf.WriteByte('}')
+    }
+
+    buf.WriteByte(' ')
+    buf.WriteString(formatFloat(value))
+    buf.WriteByte('\n')
+}
+
+// 格式化数值
+func formatFloat(v float64) string {
+    switch {
+        case math.IsInf(v, 1):
+            return "+Inf"
+        case math.IsInf(v, -1):
+            return "-Inf"
+        case math.IsNaN(v):
+            return "NaN"
+    }
+
+    return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// 排序后的 key 列表
+func sortedKeys[T any](m map[string]T) []string {
+    keys := make([]string, 0, len(m))
+    for k := range m {
+        keys = append(keys, k)
+    }
+
+    sort.Strings(keys)
+
+    return keys
+}
+
+// 组合 label 值
+func joinLabelValues(values []string) string {
+    return strings.Join(values, labelSep)
+}
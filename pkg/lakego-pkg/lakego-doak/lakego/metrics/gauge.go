@@ -0,0 +1,173 @@
+package metrics
+
+import (
+    "sync"
+    "bytes"
+)
+
+/**
+ * 仪表
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Gauge struct {
+    // 值
+    value atomicFloat
+}
+
+// 设置
+func (this *Gauge) Set(v float64) {
+    this.value.Store(v)
+}
+
+// 加一
+func (this *Gauge) Inc() {
+    this.value.Add(1)
+}
+
+// 减一
+func (this *Gauge) Dec() {
+    this.value.Add(-1)
+}
+
+// 增加
+func (this *Gauge) Add(v float64) {
+    this.value.Add(v)
+}
+
+// 减少
+func (this *Gauge) Sub(v float64) {
+    this.value.Add(-v)
+}
+
+// 当前值
+func (this *Gauge) Value() float64 {
+    return this.value.Load()
+}
+
+/**
+ * 带 label 的仪表
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type GaugeVec struct {
+    // 锁定
+    mu sync.RWMutex
+
+    // 名称
+    name string
+
+    // 说明
+    help string
+
+    // label 名称
+    labels []string
+
+    // 数据
+    children map[string]*gaugeChild
+}
+
+type gaugeChild struct {
+    Gauge
+
+    values []string
+}
+
+// 构造函数
+func NewGaugeVec(name, help string, labels ...string) *GaugeVec {
+    return &GaugeVec{
+        name:     name,
+        help:     help,
+        labels:   labels,
+        children: make(map[string]*gaugeChild),
+    }
+}
+
+// 获取对应 label 值的仪表
+func (this *GaugeVec) With(values ...string) *Gauge {
+    values = fixLabelValues(values, len(this.labels))
+    key := joinLabelValues(values)
+
+    this.mu.RLock()
+    child, ok := this.children[key]
+    this.mu.RUnlock()
+
+    if ok {
+        return &child.Gauge
+    }
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if child, ok = this.children[key]; !ok {
+        child = &gaugeChild{values: values}
+        this.children[key] = child
+    }
+
+    return &child.Gauge
+}
+
+// 重置
+func (this *GaugeVec) Reset() {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.children = make(map[string]*gaugeChild)
+}
+
+// 名称
+func (this *GaugeVec) Name() string {
+    return this.name
+}
+
+// 写入
+func (this *GaugeVec) Write(buf *bytes.Buffer) {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    writeHeader(buf, this.name, this.help, TypeGauge)
+
+    for _, key := range sortedKeys(this.children) {
+        child := this.children[key]
+        writeSample(buf, this.name, this.labels, child.values, child.Value())
+    }
+}
+
+/**
+ * 采集时计算的仪表
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type GaugeFunc struct {
+    // 名称
+    name string
+
+    // 说明
+    help string
+
+    // 取值
+    fn func() float64
+}
+
+// 构造函数
+func NewGaugeFunc(name, help string, fn func() float64) *GaugeFunc {
+    return &GaugeFunc{
+        name: name,
+        help: help,
+        fn:   fn,
+    }
+}
+
+// 名称
+func (this *GaugeFunc) Name() string {
+    return this.name
+}
+
+// 写入
+func (this *GaugeFunc) Write(buf *bytes.Buffer) {
+    writeHeader(buf, this.name, this.help, TypeGauge)
+    writeSample(buf, this.name, nil, nil, this.fn())
+}
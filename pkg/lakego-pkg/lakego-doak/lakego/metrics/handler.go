@@ -0,0 +1,25 @@
+package metrics
+
+import (
+    "net/http"
+)
+
+// 输出默认注册器的 http 处理器
+func Handler() http.Handler {
+    return HandlerFor(defaultRegistry)
+}
+
+// 输出指定注册器的 http 处理器
+func HandlerFor(registry *Registry) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        w.Header().Set("Content-Type", ContentType)
+        w.WriteHeader(http.StatusOK)
+
+        registry.WriteTo(w)
+    })
+}
+
+func init() {
+    // 运行时指标
+    MustRegister(NewRuntimeCollector())
+}
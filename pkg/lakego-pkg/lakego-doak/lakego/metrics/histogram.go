@@ -0,0 +1,200 @@
+package metrics
+
+import (
+    "sort"
+    "sync"
+    "math"
+    "time"
+    "bytes"
+)
+
+// 默认区间，单位：秒
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+/**
+ * 直方图
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Histogram struct {
+    // 锁定
+    mu sync.Mutex
+
+    // 区间上限
+    upperBounds []float64
+
+    // 各区间数量，非累计
+    counts []uint64
+
+    // 总和
+    sum float64
+
+    // 总数
+    count uint64
+}
+
+func newHistogram(buckets []float64) *Histogram {
+    return &Histogram{
+        upperBounds: buckets,
+        counts:      make([]uint64, len(buckets)),
+    }
+}
+
+// 记录
+func (this *Histogram) Observe(v float64) {
+    i := sort.SearchFloat64s(this.upperBounds, v)
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if i < len(this.counts) {
+        this.counts[i]++
+    }
+
+    this.sum += v
+    this.count++
+}
+
+// 记录从 start 开始的耗时
+func (this *Histogram) ObserveSince(start time.Time) {
+    this.Observe(time.Since(start).Seconds())
+}
+
+// 快照
+func (this *Histogram) snapshot() (counts []uint64, sum float64, count uint64) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    counts = make([]uint64, len(this.counts))
+    copy(counts, this.counts)
+
+    return counts, this.sum, this.count
+}
+
+/**
+ * 带 label 的直方图
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type HistogramVec struct {
+    // 锁定
+    mu sync.RWMutex
+
+    // 名称
+    name string
+
+    // 说明
+    help string
+
+    // label 名称
+    labels []string
+
+    // 区间
+    buckets []float64
+
+    // 数据
+    children map[string]*histogramChild
+}
+
+type histogramChild struct {
+    *Histogram
+
+    values []string
+}
+
+// 构造函数，buckets 为空时使用默认区间
+func NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+    if len(buckets) == 0 {
+        buckets = DefaultBuckets
+    }
+
+    sorted := make([]float64, 0, len(buckets))
+    for _, b := range buckets {
+        if !math.IsInf(b, 1) {
+            sorted = append(sorted, b)
+        }
+    }
+
+    sort.Float64s(sorted)
+
+    return &HistogramVec{
+        name:     name,
+        help:     help,
+        labels:   labels,
+        buckets:  sorted,
+        children: make(map[string]*histogramChild),
+    }
+}
+
+// 获取对应 label 值的直方图
+func (this *HistogramVec) With(values ...string) *Histogram {
+    values = fixLabelValues(values, len(this.labels))
+    key := joinLabelValues(values)
+
+    this.mu.RLock()
+    child, ok := this.children[key]
+    this.mu.RUnlock()
+
+    if ok {
+        return child.Histogram
+    }
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if child, ok = this.children[key]; !ok {
+        child = &histogramChild{
+            Histogram: newHistogram(this.buckets),
+            values:    values,
+        }
+        this.children[key] = child
+    }
+
+    return child.Histogram
+}
+
+// 重置
+func (this *HistogramVec) Reset() {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.children = make(map[string]*histogramChild)
+}
+
+// 名称
+func (this *HistogramVec) Name() string {
+    return this.name
+}
+
+// 写入
+func (this *HistogramVec) Write(buf *bytes.Buffer) {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    writeHeader(buf, this.name, this.help, TypeHistogram)
+
+    names := append(append([]string{}, this.labels...), "le")
+
+    for _, key := range sortedKeys(this.children) {
+        child := this.children[key]
+        counts, sum, count := child.snapshot()
+
+        values := append(append([]string{}, child.values...), "")
+
+        var cumulative uint64
+        for i, upper := range this.buckets {
+            cumulative += counts[i]
+
+            values[len(values)-1] = formatFloat(upper)
+            writeSample(buf, this.name + "_bucket", names, values, float64(cumulative))
+        }
+
+        values[len(values)-1] = "+Inf"
+        writeSample(buf, this.name + "_bucket", names, values, float64(count))
+
+        writeSample(buf, this.name + "_sum", this.labels, child.values, sum)
+        writeSample(buf, this.name + "_count", this.labels, child.values, float64(count))
+    }
+}
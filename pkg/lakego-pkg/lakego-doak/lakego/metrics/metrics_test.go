@@ -0,0 +1,73 @@
+package metrics
+
+import (
+    "strings"
+    "testing"
+    "reflect"
+)
+
+func assertT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func Test_CounterVec(t *testing.T) {
+    assert := assertT(t)
+
+    c := NewCounterVec("test_requests_total", "Test requests.", "method", "path")
+    c.With("GET", `/a"b`).Inc()
+    c.With("GET", `/a"b`).Add(2)
+    c.With("POST", "/").Add(-1)
+
+    r := NewRegistry()
+    r.MustRegister(c)
+
+    expected := "# HELP test_requests_total Test requests.\n" +
+        "# TYPE test_requests_total counter\n" +
+        "test_requests_total{method=\"GET\",path=\"/a\\\"b\"} 3\n" +
+        "test_requests_total{method=\"POST\",path=\"/\"} 0\n"
+
+    assert(r.String(), expected, "Test_CounterVec")
+}
+
+func Test_HistogramVec(t *testing.T) {
+    assert := assertT(t)
+
+    h := NewHistogramVec("test_duration_seconds", "", []float64{1, 0.1}, "job")
+    h.With("a").Observe(0.05)
+    h.With("a").Observe(0.5)
+    h.With("a").Observe(5)
+
+    r := NewRegistry()
+    r.MustRegister(h)
+
+    expected := "# TYPE test_duration_seconds histogram\n" +
+        "test_duration_seconds_bucket{job=\"a\",le=\"0.1\"} 1\n" +
+        "test_duration_seconds_bucket{job=\"a\",le=\"1\"} 2\n" +
+        "test_duration_seconds_bucket{job=\"a\",le=\"+Inf\"} 3\n" +
+        "test_duration_seconds_sum{job=\"a\"} 5.55\n" +
+        "test_duration_seconds_count{job=\"a\"} 3\n"
+
+    assert(r.String(), expected, "Test_HistogramVec")
+}
+
+func Test_Registry(t *testing.T) {
+    assert := assertT(t)
+
+    r := NewRegistry()
+    r.MustRegister(NewGaugeFunc("test_up", "Up.", func() float64 {
+        return 1
+    }))
+
+    err := r.Register(NewGaugeVec("test_up", ""))
+    assert(err != nil, true, "Test_Registry duplicate")
+
+    r.MustRegister(NewRuntimeCollector())
+    out := r.String()
+
+    assert(strings.Contains(out, "test_up 1\n"), true, "Test_Registry GaugeFunc")
+    assert(strings.Contains(out, "# TYPE go_goroutines gauge\n"), true, "Test_Registry runtime")
+}
@@ -0,0 +1,134 @@
+package metrics
+
+import (
+    "io"
+    "sort"
+    "sync"
+    "bytes"
+    "errors"
+)
+
+// 默认
+var defaultRegistry = NewRegistry()
+
+// 默认注册器
+func Default() *Registry {
+    return defaultRegistry
+}
+
+/**
+ * 指标收集器接口
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Collector interface {
+    // 名称
+    Name() string
+
+    // 按文本格式写入
+    Write(*bytes.Buffer)
+}
+
+/**
+ * 指标注册器
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Registry struct {
+    // 锁定
+    mu sync.RWMutex
+
+    // 收集器
+    collectors map[string]Collector
+}
+
+// 构造函数
+func NewRegistry() *Registry {
+    return &Registry{
+        collectors: make(map[string]Collector),
+    }
+}
+
+// 注册
+func (this *Registry) Register(c Collector) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if _, ok := this.collectors[c.Name()]; ok {
+        return errors.New("metrics: collector [" + c.Name() + "] already registered")
+    }
+
+    this.collectors[c.Name()] = c
+
+    return nil
+}
+
+// 注册，出错时 panic
+func (this *Registry) MustRegister(cs ...Collector) {
+    for _, c := range cs {
+        if err := this.Register(c); err != nil {
+            panic(err)
+        }
+    }
+}
+
+// 移除
+func (this *Registry) Unregister(name string) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    delete(this.collectors, name)
+}
+
+// 获取
+func (this *Registry) Get(name string) Collector {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    if c, ok := this.collectors[name]; ok {
+        return c
+    }
+
+    return nil
+}
+
+// 按名称排序输出全部指标
+func (this *Registry) WriteTo(w io.Writer) (int64, error) {
+    this.mu.RLock()
+    names := make([]string, 0, len(this.collectors))
+    for name := range this.collectors {
+        names = append(names, name)
+    }
+    this.mu.RUnlock()
+
+    sort.Strings(names)
+
+    var buf bytes.Buffer
+    for _, name := range names {
+        if c := this.Get(name); c != nil {
+            c.Write(&buf)
+        }
+    }
+
+    return buf.WriteTo(w)
+}
+
+// 全部指标文本
+func (this *Registry) String() string {
+    var buf bytes.Buffer
+    this.WriteTo(&buf)
+
+    return buf.String()
+}
+
+// 注册到默认注册器
+func Register(c Collector) error {
+    return defaultRegistry.Register(c)
+}
+
+// 注册到默认注册器，出错时 panic
+func MustRegister(cs ...Collector) {
+    defaultRegistry.MustRegister(cs...)
+}
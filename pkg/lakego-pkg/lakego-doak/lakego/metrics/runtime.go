@@ -0,0 +1,68 @@
+package metrics
+
+import (
+    "time"
+    "bytes"
+    "runtime"
+)
+
+// 启动时间
+var startTime = time.Now()
+
+/**
+ * 运行时指标
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type RuntimeCollector struct {}
+
+// 构造函数
+func NewRuntimeCollector() *RuntimeCollector {
+    return &RuntimeCollector{}
+}
+
+// 名称
+func (this *RuntimeCollector) Name() string {
+    return "go_runtime"
+}
+
+// 写入
+func (this *RuntimeCollector) Write(buf *bytes.Buffer) {
+    var ms runtime.MemStats
+    runtime.ReadMemStats(&ms)
+
+    gauge := func(name, help string, v float64) {
+        writeHeader(buf, name, help, TypeGauge)
+        writeSample(buf, name, nil, nil, v)
+    }
+
+    counter := func(name, help string, v float64) {
+        writeHeader(buf, name, help, TypeCounter)
+        writeSample(buf, name, nil, nil, v)
+    }
+
+    gauge("go_goroutines", "Number of goroutines that currently exist.", float64(runtime.NumGoroutine()))
+    gauge("go_gomaxprocs", "Value of GOMAXPROCS.", float64(runtime.GOMAXPROCS(0)))
+
+    writeHeader(buf, "go_info", "Information about the Go environment.", TypeGauge)
+    writeSample(buf, "go_info", []string{"version"}, []string{runtime.Version()}, 1)
+
+    gauge("go_memstats_alloc_bytes", "Number of bytes allocated and still in use.", float64(ms.Alloc))
+    counter("go_memstats_alloc_bytes_total", "Total number of bytes allocated, even if freed.", float64(ms.TotalAlloc))
+    gauge("go_memstats_sys_bytes", "Number of bytes obtained from system.", float64(ms.Sys))
+    counter("go_memstats_mallocs_total", "Total number of mallocs.", float64(ms.Mallocs))
+    counter("go_memstats_frees_total", "Total number of frees.", float64(ms.Frees))
+    gauge("go_memstats_heap_alloc_bytes", "Number of heap bytes allocated and still in use.", float64(ms.HeapAlloc))
+    gauge("go_memstats_heap_inuse_bytes", "Number of heap bytes that are in use.", float64(ms.HeapInuse))
+    gauge("go_memstats_heap_idle_bytes", "Number of heap bytes waiting to be used.", float64(ms.HeapIdle))
+    gauge("go_memstats_heap_objects", "Number of allocated objects.", float64(ms.HeapObjects))
+    gauge("go_memstats_stack_inuse_bytes", "Number of bytes in use by the stack allocator.", float64(ms.StackInuse))
+    gauge("go_memstats_next_gc_bytes", "Number of heap bytes when next garbage collection will take place.", float64(ms.NextGC))
+    gauge("go_memstats_last_gc_time_seconds", "Number of seconds since 1970 of last garbage collection.", float64(ms.LastGC) / 1e9)
+    counter("go_gc_cycles_total", "Number of completed GC cycles.", float64(ms.NumGC))
+    counter("go_gc_pause_seconds_total", "Total GC pause duration in seconds.", float64(ms.PauseTotalNs) / 1e9)
+
+    gauge("process_start_time_seconds", "Start time of the process since unix epoch in seconds.", float64(startTime.UnixNano()) / 1e9)
+    gauge("process_uptime_seconds", "Number of seconds since the process started.", time.Since(startTime).Seconds())
+}
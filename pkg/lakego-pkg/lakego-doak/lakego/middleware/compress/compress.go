@@ -0,0 +1,315 @@
+package compress
+
+import (
+    "io"
+    "bytes"
+    "strings"
+    "net/http"
+    "compress/gzip"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+// 默认压缩的内容类型前缀
+var DefaultContentTypes = []string{
+    "text/",
+    "application/json",
+    "application/javascript",
+    "application/xml",
+    "application/x-javascript",
+    "image/svg+xml",
+}
+
+/**
+ * 压缩设置
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Options struct {
+    // 压缩等级，默认为 gzip.DefaultCompression
+    Level int
+
+    // 最小压缩长度，默认 1024 字节
+    MinLength int
+
+    // 压缩的内容类型前缀
+    ContentTypes []string
+
+    // 编码优先顺序，默认为 gzip, deflate
+    Encodings []string
+
+    // 不压缩的路径前缀
+    ExcludePaths []string
+}
+
+/**
+ * 响应压缩中间件
+ *
+ * 响应达到最小长度前先缓存，调用 Flush 的流式响应和 text/event-stream 不压缩
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Handler(opts Options) router.HandlerFunc {
+    if opts.Level == 0 {
+        opts.Level = gzip.DefaultCompression
+    }
+
+    if opts.MinLength <= 0 {
+        opts.MinLength = 1024
+    }
+
+    if len(opts.ContentTypes) == 0 {
+        opts.ContentTypes = DefaultContentTypes
+    }
+
+    if len(opts.Encodings) == 0 {
+        opts.Encodings = []string{"gzip", "deflate"}
+    }
+
+    return func(ctx *router.Context) {
+        if skipRequest(ctx, opts) {
+            ctx.Next()
+            return
+        }
+
+        encoding := negotiate(ctx.GetHeader("Accept-Encoding"), opts.Encodings)
+
+        writer := &compressWriter{
+            ResponseWriter: ctx.Writer,
+            opts:           opts,
+            encoding:       encoding,
+        }
+        ctx.Writer = writer
+
+        defer func() {
+            if err := recover(); err != nil {
+                // 丢弃未输出的缓存，由异常处理中间件输出
+                ctx.Writer = writer.ResponseWriter
+                writer.close()
+
+                panic(err)
+            }
+        }()
+
+        ctx.Next()
+
+        writer.finish()
+        ctx.Writer = writer.ResponseWriter
+    }
+}
+
+// 不处理的请求
+func skipRequest(ctx *router.Context, opts Options) bool {
+    req := ctx.Request
+
+    if req.Method == http.MethodHead || req.Header.Get("Range") != "" {
+        return true
+    }
+
+    // websocket 等升级请求
+    if req.Header.Get("Upgrade") != "" {
+        return true
+    }
+
+    for _, prefix := range opts.ExcludePaths {
+        if prefix != "" && strings.HasPrefix(req.URL.Path, prefix) {
+            return true
+        }
+    }
+
+    return false
+}
+
+// 压缩输出
+type compressWriter struct {
+    router.ResponseWriter
+
+    // 设置
+    opts Options
+
+    // 协商的编码，为空时不压缩
+    encoding string
+
+    // 状态码
+    status int
+
+    // 未确定是否压缩前的缓存
+    buf bytes.Buffer
+
+    // 是否已确定
+    decided bool
+
+    // 压缩输出
+    encoder io.WriteCloser
+}
+
+func (this *compressWriter) WriteHeader(code int) {
+    if this.decided {
+        this.ResponseWriter.WriteHeader(code)
+        return
+    }
+
+    this.status = code
+}
+
+func (this *compressWriter) WriteHeaderNow() {
+    if this.decided {
+        this.ResponseWriter.WriteHeaderNow()
+    }
+}
+
+func (this *compressWriter) Status() int {
+    if !this.decided && this.status != 0 {
+        return this.status
+    }
+
+    return this.ResponseWriter.Status()
+}
+
+func (this *compressWriter) Written() bool {
+    if !this.decided {
+        return this.status != 0 || this.buf.Len() > 0
+    }
+
+    return this.ResponseWriter.Written()
+}
+
+func (this *compressWriter) Write(data []byte) (int, error) {
+    if !this.decided {
+        this.buf.Write(data)
+
+        if this.buf.Len() >= this.opts.MinLength {
+            if err := this.decide(true); err != nil {
+                return 0, err
+            }
+        }
+
+        return len(data), nil
+    }
+
+    if this.encoder != nil {
+        return this.encoder.Write(data)
+    }
+
+    return this.ResponseWriter.Write(data)
+}
+
+func (this *compressWriter) WriteString(s string) (int, error) {
+    return this.Write([]byte(s))
+}
+
+// 流式响应，未确定时不压缩
+func (this *compressWriter) Flush() {
+    if !this.decided {
+        this.decide(false)
+    }
+
+    if flusher, ok := this.encoder.(interface{ Flush() error }); ok {
+        flusher.Flush()
+    }
+
+    this.ResponseWriter.Flush()
+}
+
+// 确定是否压缩并输出缓存
+func (this *compressWriter) decide(compress bool) error {
+    this.decided = true
+
+    header := this.Header()
+
+    eligible := this.eligible()
+    if eligible {
+        header.Add("Vary", "Accept-Encoding")
+    }
+
+    if compress && eligible && this.encoding != "" {
+        encoder, _ := GetEncoder(this.encoding)
+
+        enc, err := encoder(this.ResponseWriter, this.opts.Level)
+        if err == nil {
+            header.Set("Content-Encoding", this.encoding)
+            header.Del("Content-Length")
+
+            // 压缩后内容不同，强 ETag 改为弱 ETag
+            if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+                header.Set("ETag", "W/" + etag)
+            }
+
+            this.encoder = enc
+        }
+    }
+
+    if this.status != 0 {
+        this.ResponseWriter.WriteHeader(this.status)
+    }
+
+    if this.buf.Len() == 0 {
+        return nil
+    }
+
+    data := this.buf.Bytes()
+    this.buf = bytes.Buffer{}
+
+    if this.encoder != nil {
+        _, err := this.encoder.Write(data)
+        return err
+    }
+
+    _, err := this.ResponseWriter.Write(data)
+    return err
+}
+
+// 是否可以压缩
+func (this *compressWriter) eligible() bool {
+    header := this.Header()
+
+    if header.Get("Content-Encoding") != "" {
+        return false
+    }
+
+    status := this.status
+    if status == 0 {
+        status = http.StatusOK
+    }
+
+    if status < 200 || status == http.StatusNoContent || status == http.StatusNotModified {
+        return false
+    }
+
+    contentType := header.Get("Content-Type")
+    if contentType == "" {
+        contentType = http.DetectContentType(this.buf.Bytes())
+    }
+
+    contentType = strings.ToLower(contentType)
+    if strings.HasPrefix(contentType, "text/event-stream") {
+        return false
+    }
+
+    for _, prefix := range this.opts.ContentTypes {
+        if strings.HasPrefix(contentType, prefix) {
+            return true
+        }
+    }
+
+    return false
+}
+
+// 请求结束，未达到最小长度的响应不压缩
+func (this *compressWriter) finish() {
+    if !this.decided && this.Written() {
+        this.decide(false)
+    }
+
+    this.close()
+}
+
+// 关闭压缩输出
+func (this *compressWriter) close() {
+    if this.encoder != nil {
+        this.encoder.Close()
+        this.encoder = nil
+    }
+}
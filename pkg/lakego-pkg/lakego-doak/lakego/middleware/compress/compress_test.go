@@ -0,0 +1,138 @@
+package compress
+
+import (
+    "io"
+    "strings"
+    "reflect"
+    "testing"
+    "net/http"
+    "compress/gzip"
+    "compress/flate"
+    "net/http/httptest"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+var largeText = strings.Repeat("lakego-admin ", 200)
+
+func newEngine() *router.Engine {
+    router.SetMode(router.ReleaseMode)
+
+    r := router.New()
+    r.Use(Handler(Options{
+        MinLength:    100,
+        ExcludePaths: []string{"/skip"},
+    }))
+    r.GET("/large", func(ctx *router.Context) {
+        ctx.JSON(http.StatusOK, router.H{"data": largeText})
+    })
+    r.GET("/small", func(ctx *router.Context) {
+        ctx.String(http.StatusOK, "small")
+    })
+    r.GET("/binary", func(ctx *router.Context) {
+        ctx.Data(http.StatusOK, "application/octet-stream", []byte(largeText))
+    })
+    r.GET("/stream", func(ctx *router.Context) {
+        ctx.Header("Content-Type", "text/plain")
+        ctx.Writer.WriteString("chunk1")
+        ctx.Writer.Flush()
+        ctx.Writer.WriteString(largeText)
+    })
+    r.GET("/skip", func(ctx *router.Context) {
+        ctx.String(http.StatusOK, largeText)
+    })
+
+    return r
+}
+
+func request(r *router.Engine, path string, accept string) *httptest.ResponseRecorder {
+    req := httptest.NewRequest("GET", path, nil)
+    if accept != "" {
+        req.Header.Set("Accept-Encoding", accept)
+    }
+
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+
+    return w
+}
+
+func Test_Gzip(t *testing.T) {
+    eq := assertEqualT(t)
+
+    w := request(newEngine(), "/large", "gzip, deflate")
+    eq(w.Header().Get("Content-Encoding"), "gzip", "Content-Encoding")
+    eq(w.Header().Get("Vary"), "Accept-Encoding", "Vary")
+
+    reader, err := gzip.NewReader(w.Body)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    body, _ := io.ReadAll(reader)
+    eq(strings.Contains(string(body), largeText), true, "gzip body")
+}
+
+func Test_Deflate(t *testing.T) {
+    eq := assertEqualT(t)
+
+    w := request(newEngine(), "/large", "gzip;q=0.5, deflate")
+    eq(w.Header().Get("Content-Encoding"), "deflate", "Content-Encoding")
+
+    body, _ := io.ReadAll(flate.NewReader(w.Body))
+    eq(strings.Contains(string(body), largeText), true, "deflate body")
+}
+
+func Test_Skip(t *testing.T) {
+    eq := assertEqualT(t)
+
+    r := newEngine()
+
+    w := request(r, "/large", "")
+    eq(w.Header().Get("Content-Encoding"), "", "no Accept-Encoding")
+    eq(w.Header().Get("Vary"), "Accept-Encoding", "no Accept-Encoding Vary")
+
+    w = request(r, "/large", "gzip;q=0")
+    eq(w.Header().Get("Content-Encoding"), "", "q=0")
+
+    w = request(r, "/small", "gzip")
+    eq(w.Header().Get("Content-Encoding"), "", "small")
+    eq(w.Body.String(), "small", "small body")
+
+    w = request(r, "/binary", "gzip")
+    eq(w.Header().Get("Content-Encoding"), "", "binary")
+    eq(w.Body.String(), largeText, "binary body")
+
+    w = request(r, "/skip", "gzip")
+    eq(w.Header().Get("Content-Encoding"), "", "exclude path")
+}
+
+func Test_Stream(t *testing.T) {
+    eq := assertEqualT(t)
+
+    w := request(newEngine(), "/stream", "gzip")
+    eq(w.Header().Get("Content-Encoding"), "", "stream Content-Encoding")
+    eq(w.Body.String(), "chunk1" + largeText, "stream body")
+    eq(w.Flushed, true, "stream flushed")
+}
+
+func Test_Negotiate(t *testing.T) {
+    eq := assertEqualT(t)
+
+    preferred := []string{"gzip", "deflate"}
+
+    eq(negotiate("gzip, deflate", preferred), "gzip", "order")
+    eq(negotiate("deflate", preferred), "deflate", "single")
+    eq(negotiate("br", preferred), "", "unsupported")
+    eq(negotiate("*", preferred), "gzip", "wildcard")
+    eq(negotiate("*;q=0.1, deflate;q=0.5", preferred), "deflate", "weight")
+    eq(negotiate("gzip;q=0", preferred), "", "disabled")
+}
@@ -0,0 +1,108 @@
+package compress
+
+import (
+    "io"
+    "sync"
+    "strconv"
+    "strings"
+    "compress/gzip"
+    "compress/flate"
+)
+
+// 编码器，level 为压缩等级
+type Encoder func(w io.Writer, level int) (io.WriteCloser, error)
+
+// 已注册编码器
+var encoders = struct {
+    sync.RWMutex
+    list map[string]Encoder
+}{
+    list: map[string]Encoder{
+        "gzip":    gzipEncoder,
+        "deflate": deflateEncoder,
+    },
+}
+
+// 注册编码器，如 br 等需第三方库的编码
+func RegisterEncoder(name string, encoder Encoder) {
+    encoders.Lock()
+    defer encoders.Unlock()
+
+    encoders.list[strings.ToLower(name)] = encoder
+}
+
+// 获取编码器
+func GetEncoder(name string) (Encoder, bool) {
+    encoders.RLock()
+    defer encoders.RUnlock()
+
+    encoder, ok := encoders.list[strings.ToLower(name)]
+
+    return encoder, ok
+}
+
+// gzip
+func gzipEncoder(w io.Writer, level int) (io.WriteCloser, error) {
+    return gzip.NewWriterLevel(w, level)
+}
+
+// deflate
+func deflateEncoder(w io.Writer, level int) (io.WriteCloser, error) {
+    return flate.NewWriter(w, level)
+}
+
+// 根据 Accept-Encoding 选择编码，相同权重时按 preferred 顺序
+func negotiate(accept string, preferred []string) string {
+    if accept == "" {
+        return ""
+    }
+
+    weights := make(map[string]float64)
+    for _, part := range strings.Split(accept, ",") {
+        name, q := parseEncoding(part)
+        if name != "" {
+            weights[name] = q
+        }
+    }
+
+    best, bestQ := "", 0.0
+    for _, name := range preferred {
+        q, ok := weights[name]
+        if !ok {
+            q, ok = weights["*"]
+        }
+
+        if !ok || q <= 0 {
+            continue
+        }
+
+        if _, exists := GetEncoder(name); !exists {
+            continue
+        }
+
+        if q > bestQ {
+            best, bestQ = name, q
+        }
+    }
+
+    return best
+}
+
+// 解析编码及权重
+func parseEncoding(part string) (string, float64) {
+    fields := strings.Split(part, ";")
+
+    name := strings.ToLower(strings.TrimSpace(fields[0]))
+    q := 1.0
+
+    for _, param := range fields[1:] {
+        param = strings.TrimSpace(param)
+        if strings.HasPrefix(param, "q=") {
+            if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+                q = v
+            }
+        }
+    }
+
+    return name, q
+}
@@ -0,0 +1,260 @@
+package conditional
+
+import (
+    "time"
+    "bytes"
+    "strings"
+    "net/http"
+    "crypto/sha1"
+    "encoding/base64"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+/**
+ * 条件请求设置
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Options struct {
+    // 生成 ETag 的最大响应长度，超过时直接输出，默认 1MB
+    MaxSize int
+
+    // 不处理的路径前缀
+    ExcludePaths []string
+}
+
+/**
+ * 条件请求中间件
+ *
+ * GET 请求的 200 响应自动生成弱 ETag，处理 If-None-Match 和 If-Modified-Since，
+ * 调用 Flush 的流式响应和超过最大长度的响应直接输出
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Handler(opts Options) router.HandlerFunc {
+    if opts.MaxSize <= 0 {
+        opts.MaxSize = 1 << 20
+    }
+
+    return func(ctx *router.Context) {
+        method := ctx.Request.Method
+        if method != http.MethodGet && method != http.MethodHead {
+            ctx.Next()
+            return
+        }
+
+        for _, prefix := range opts.ExcludePaths {
+            if prefix != "" && strings.HasPrefix(ctx.Request.URL.Path, prefix) {
+                ctx.Next()
+                return
+            }
+        }
+
+        writer := &bufferWriter{
+            ResponseWriter: ctx.Writer,
+            maxSize:        opts.MaxSize,
+        }
+        ctx.Writer = writer
+
+        defer func() {
+            if err := recover(); err != nil {
+                ctx.Writer = writer.ResponseWriter
+                panic(err)
+            }
+        }()
+
+        ctx.Next()
+
+        ctx.Writer = writer.ResponseWriter
+
+        if writer.passthrough {
+            return
+        }
+
+        writer.finish(ctx.Request)
+    }
+}
+
+// 弱 ETag
+func WeakETag(data []byte) string {
+    sum := sha1.Sum(data)
+    return `W/"` + base64.RawURLEncoding.EncodeToString(sum[:]) + `"`
+}
+
+// 是否未修改
+func NotModified(req *http.Request, header http.Header) bool {
+    etag := header.Get("ETag")
+
+    // If-None-Match 优先
+    if match := req.Header.Get("If-None-Match"); match != "" {
+        return etag != "" && etagMatch(match, etag)
+    }
+
+    lastModified := header.Get("Last-Modified")
+    since := req.Header.Get("If-Modified-Since")
+    if lastModified == "" || since == "" {
+        return false
+    }
+
+    modified, err := http.ParseTime(lastModified)
+    if err != nil {
+        return false
+    }
+
+    sinceTime, err := http.ParseTime(since)
+    if err != nil {
+        return false
+    }
+
+    return !modified.Truncate(time.Second).After(sinceTime)
+}
+
+// 弱比较 If-None-Match
+func etagMatch(match string, etag string) bool {
+    etag = strings.TrimPrefix(etag, "W/")
+
+    for _, item := range strings.Split(match, ",") {
+        item = strings.TrimSpace(item)
+        if item == "*" || strings.TrimPrefix(item, "W/") == etag {
+            return true
+        }
+    }
+
+    return false
+}
+
+// 缓存输出
+type bufferWriter struct {
+    router.ResponseWriter
+
+    // 最大缓存长度
+    maxSize int
+
+    // 状态码
+    status int
+
+    // 缓存
+    buf bytes.Buffer
+
+    // 直接输出
+    passthrough bool
+}
+
+func (this *bufferWriter) WriteHeader(code int) {
+    if this.passthrough {
+        this.ResponseWriter.WriteHeader(code)
+        return
+    }
+
+    this.status = code
+}
+
+func (this *bufferWriter) WriteHeaderNow() {
+    if this.passthrough {
+        this.ResponseWriter.WriteHeaderNow()
+    }
+}
+
+func (this *bufferWriter) Status() int {
+    if !this.passthrough && this.status != 0 {
+        return this.status
+    }
+
+    return this.ResponseWriter.Status()
+}
+
+func (this *bufferWriter) Written() bool {
+    if !this.passthrough {
+        return this.status != 0 || this.buf.Len() > 0
+    }
+
+    return this.ResponseWriter.Written()
+}
+
+func (this *bufferWriter) Write(data []byte) (int, error) {
+    if this.passthrough {
+        return this.ResponseWriter.Write(data)
+    }
+
+    if this.buf.Len() + len(data) > this.maxSize {
+        if err := this.startPassthrough(); err != nil {
+            return 0, err
+        }
+
+        return this.ResponseWriter.Write(data)
+    }
+
+    return this.buf.Write(data)
+}
+
+func (this *bufferWriter) WriteString(s string) (int, error) {
+    return this.Write([]byte(s))
+}
+
+// 流式响应直接输出
+func (this *bufferWriter) Flush() {
+    if !this.passthrough {
+        this.startPassthrough()
+    }
+
+    this.ResponseWriter.Flush()
+}
+
+// 输出缓存并改为直接输出
+func (this *bufferWriter) startPassthrough() error {
+    this.passthrough = true
+
+    if this.status != 0 {
+        this.ResponseWriter.WriteHeader(this.status)
+    }
+
+    if this.buf.Len() == 0 {
+        return nil
+    }
+
+    _, err := this.ResponseWriter.Write(this.buf.Bytes())
+    this.buf = bytes.Buffer{}
+
+    return err
+}
+
+// 请求结束
+func (this *bufferWriter) finish(req *http.Request) {
+    if !this.Written() {
+        return
+    }
+
+    status := this.status
+    if status == 0 {
+        status = http.StatusOK
+    }
+
+    header := this.Header()
+
+    if status == http.StatusOK {
+        if header.Get("ETag") == "" {
+            header.Set("ETag", WeakETag(this.buf.Bytes()))
+        }
+
+        if NotModified(req, header) {
+            // 304 响应不带内容相关的响应头
+            for _, name := range []string{"Content-Type", "Content-Length", "Content-Encoding"} {
+                header.Del(name)
+            }
+
+            this.ResponseWriter.WriteHeader(http.StatusNotModified)
+            this.ResponseWriter.WriteHeaderNow()
+            return
+        }
+    }
+
+    this.ResponseWriter.WriteHeader(status)
+    if this.buf.Len() > 0 {
+        this.ResponseWriter.Write(this.buf.Bytes())
+    } else {
+        this.ResponseWriter.WriteHeaderNow()
+    }
+}
@@ -0,0 +1,142 @@
+package conditional
+
+import (
+    "os"
+    "time"
+    "strings"
+    "reflect"
+    "testing"
+    "net/http"
+    "path/filepath"
+    "net/http/httptest"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/http/response"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func newEngine(file string) *router.Engine {
+    router.SetMode(router.ReleaseMode)
+
+    r := router.New()
+    r.Use(Handler(Options{
+        MaxSize: 64,
+    }))
+    r.GET("/list", func(ctx *router.Context) {
+        ctx.JSON(http.StatusOK, router.H{"list": []int{1, 2, 3}})
+    })
+    r.GET("/large", func(ctx *router.Context) {
+        ctx.String(http.StatusOK, strings.Repeat("a", 100))
+    })
+    r.GET("/stream", func(ctx *router.Context) {
+        ctx.Writer.WriteString("chunk")
+        ctx.Writer.Flush()
+    })
+    r.GET("/modified", func(ctx *router.Context) {
+        ctx.Header("Last-Modified", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).Format(http.TimeFormat))
+        ctx.String(http.StatusOK, "data")
+    })
+    r.GET("/download", func(ctx *router.Context) {
+        response.New().WithContext(ctx).Download(file, "test.txt")
+    })
+    r.POST("/list", func(ctx *router.Context) {
+        ctx.String(http.StatusOK, "created")
+    })
+
+    return r
+}
+
+func request(r *router.Engine, method string, path string, headers ...string) *httptest.ResponseRecorder {
+    req := httptest.NewRequest(method, path, nil)
+    for i := 0; i + 1 < len(headers); i += 2 {
+        req.Header.Set(headers[i], headers[i + 1])
+    }
+
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+
+    return w
+}
+
+func Test_ETag(t *testing.T) {
+    eq := assertEqualT(t)
+
+    r := newEngine("")
+
+    w := request(r, "GET", "/list")
+    etag := w.Header().Get("ETag")
+
+    eq(w.Code, http.StatusOK, "first Code")
+    eq(strings.HasPrefix(etag, `W/"`), true, "weak ETag")
+    eq(w.Body.String(), `{"list":[1,2,3]}`, "first Body")
+
+    w = request(r, "GET", "/list", "If-None-Match", etag)
+    eq(w.Code, http.StatusNotModified, "not modified Code")
+    eq(w.Body.Len(), 0, "not modified Body")
+    eq(w.Header().Get("Content-Type"), "", "not modified Content-Type")
+
+    // 弱比较
+    w = request(r, "GET", "/list", "If-None-Match", `"other", ` + strings.TrimPrefix(etag, "W/"))
+    eq(w.Code, http.StatusNotModified, "weak compare")
+
+    w = request(r, "GET", "/list", "If-None-Match", `"other"`)
+    eq(w.Code, http.StatusOK, "changed")
+
+    w = request(r, "POST", "/list", "If-None-Match", etag)
+    eq(w.Code, http.StatusOK, "POST")
+    eq(w.Header().Get("ETag"), "", "POST ETag")
+}
+
+func Test_Passthrough(t *testing.T) {
+    eq := assertEqualT(t)
+
+    r := newEngine("")
+
+    w := request(r, "GET", "/large")
+    eq(w.Header().Get("ETag"), "", "large ETag")
+    eq(w.Body.Len(), 100, "large Body")
+
+    w = request(r, "GET", "/stream")
+    eq(w.Header().Get("ETag"), "", "stream ETag")
+    eq(w.Body.String(), "chunk", "stream Body")
+    eq(w.Flushed, true, "stream Flushed")
+}
+
+func Test_IfModifiedSince(t *testing.T) {
+    eq := assertEqualT(t)
+
+    r := newEngine("")
+
+    w := request(r, "GET", "/modified", "If-Modified-Since", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC).Format(http.TimeFormat))
+    eq(w.Code, http.StatusNotModified, "not modified")
+
+    w = request(r, "GET", "/modified", "If-Modified-Since", time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC).Format(http.TimeFormat))
+    eq(w.Code, http.StatusOK, "modified")
+}
+
+func Test_Download(t *testing.T) {
+    eq := assertEqualT(t)
+
+    file := filepath.Join(t.TempDir(), "test.txt")
+    os.WriteFile(file, []byte("file data"), 0644)
+
+    r := newEngine(file)
+
+    w := request(r, "GET", "/download")
+    etag := w.Header().Get("ETag")
+
+    eq(w.Code, http.StatusOK, "download Code")
+    eq(w.Body.String(), "file data", "download Body")
+    eq(strings.HasPrefix(etag, `W/"`), true, "download ETag")
+
+    w = request(r, "GET", "/download", "If-None-Match", etag)
+    eq(w.Code, http.StatusNotModified, "download not modified")
+    eq(w.Body.Len(), 0, "download not modified Body")
+}
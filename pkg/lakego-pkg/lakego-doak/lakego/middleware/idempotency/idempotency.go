@@ -0,0 +1,434 @@
+package idempotency
+
+import (
+    "io"
+    "fmt"
+    "time"
+    "bytes"
+    "errors"
+    "mime"
+    "net/http"
+    "crypto/sha256"
+    "encoding/hex"
+    "mime/multipart"
+
+    "github.com/deatil/lakego-doak/lakego/cache"
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+// 默认请求头
+const HeaderName = "Idempotency-Key"
+
+// 重放响应标识
+const ReplayedHeader = "Idempotent-Replayed"
+
+// key 最大长度
+const maxKeyLength = 255
+
+// 默认请求内容最大长度
+const DefaultMaxBodySize = 1 << 20
+
+// 默认记录的响应内容最大长度
+const DefaultMaxResponseSize = 1 << 20
+
+var (
+    // 相同 key 的请求正在处理
+    ErrInFlight = errors.New("idempotency: request with the same key is in flight")
+
+    // 相同 key 的请求数据不一致
+    ErrMismatch = errors.New("idempotency: request payload does not match the key")
+
+    // key 格式错误
+    ErrInvalidKey = errors.New("idempotency: invalid key")
+)
+
+// 请求内容超过最大长度
+var errBodyTooLarge = errors.New("idempotency: request body too large")
+
+// 不记录的响应头
+var skipHeaders = map[string]bool{
+    "Set-Cookie":     true,
+    "Date":           true,
+    "Content-Length": true,
+}
+
+// 管理员 ID 在上下文中的名称
+var AdminIDKey = "admin_id"
+
+/**
+ * 记录的响应
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Record struct {
+    // 请求指纹
+    Fingerprint string `json:"fingerprint"`
+
+    // 状态码
+    Status int `json:"status"`
+
+    // 响应头
+    Header map[string][]string `json:"header"`
+
+    // 响应内容
+    Body []byte `json:"body"`
+}
+
+/**
+ * 设置
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Options struct {
+    // 请求头，默认为 Idempotency-Key
+    Header string
+
+    // 响应保存时间，默认 24 小时
+    TTL time.Duration
+
+    // 请求处理锁时间，默认 1 分钟
+    LockTTL time.Duration
+
+    // 请求内容最大长度，超过时不处理，默认 1M
+    MaxBodySize int64
+
+    // 记录的响应内容最大长度，超过时不记录，默认 1M
+    MaxResponseSize int64
+
+    // 冲突时的响应，需自行设置 409 或 400 状态码
+    OnConflict func(*router.Context, error)
+
+    // 缓存出错时的处理，出错时请求放行
+    OnError func(*router.Context, error)
+}
+
+// 默认冲突响应
+func conflict(ctx *router.Context, err error) {
+    status := http.StatusConflict
+    if errors.Is(err, ErrInvalidKey) {
+        status = http.StatusBadRequest
+    }
+
+    ctx.AbortWithStatusJSON(status, router.H{
+        "success": false,
+        "message": err.Error(),
+    })
+}
+
+/**
+ * 幂等中间件，POST/PUT/PATCH/DELETE 请求带有 Idempotency-Key 时，
+ * 保存首次响应，重试时直接返回
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Handler(c *cache.Cache, opts Options) router.HandlerFunc {
+    header := opts.Header
+    if header == "" {
+        header = HeaderName
+    }
+
+    ttl := opts.TTL
+    if ttl <= 0 {
+        ttl = 24 * time.Hour
+    }
+
+    lockTTL := opts.LockTTL
+    if lockTTL <= 0 {
+        lockTTL = time.Minute
+    }
+
+    maxBodySize := opts.MaxBodySize
+    if maxBodySize <= 0 {
+        maxBodySize = DefaultMaxBodySize
+    }
+
+    maxResponseSize := opts.MaxResponseSize
+    if maxResponseSize <= 0 {
+        maxResponseSize = DefaultMaxResponseSize
+    }
+
+    onConflict := opts.OnConflict
+    if onConflict == nil {
+        onConflict = conflict
+    }
+
+    onError := func(ctx *router.Context, err error) {
+        if opts.OnError != nil {
+            opts.OnError(ctx, err)
+        }
+
+        ctx.Next()
+    }
+
+    return func(ctx *router.Context) {
+        key := ctx.GetHeader(header)
+        if key == "" || !mutating(ctx.Request.Method) {
+            ctx.Next()
+            return
+        }
+
+        if len(key) > maxKeyLength {
+            onConflict(ctx, ErrInvalidKey)
+            ctx.Abort()
+            return
+        }
+
+        fingerprint, err := requestFingerprint(ctx, maxBodySize)
+        if err != nil {
+            // 大文件上传等请求不读取到内存中，直接处理
+            if errors.Is(err, errBodyTooLarge) {
+                ctx.Next()
+                return
+            }
+
+            onError(ctx, err)
+            return
+        }
+
+        cacheKey := "idempotency:" + hash(scope(ctx) + "|" + key)
+
+        // 缓存操作记录到请求链路
+        reqCache := c.WithContext(ctx.Request.Context())
+
+        // 已有记录时重放
+        if replayed := replay(ctx, reqCache, cacheKey, fingerprint, onConflict); replayed {
+            return
+        }
+
+        lock := reqCache.Lock(cacheKey + ":lock", seconds(lockTTL))
+
+        ok, err := lock.Get()
+        if err != nil {
+            onError(ctx, err)
+            return
+        }
+
+        if !ok {
+            onConflict(ctx, ErrInFlight)
+            ctx.Abort()
+            return
+        }
+
+        defer lock.Release()
+
+        // 获取锁前其他请求已完成
+        if replayed := replay(ctx, reqCache, cacheKey, fingerprint, onConflict); replayed {
+            return
+        }
+
+        writer := &bodyWriter{
+            ResponseWriter: ctx.Writer,
+            max:            maxResponseSize,
+        }
+        ctx.Writer = writer
+
+        ctx.Next()
+
+        if !storable(writer.Status()) || writer.overflow {
+            return
+        }
+
+        record := Record{
+            Fingerprint: fingerprint,
+            Status:      writer.Status(),
+            Header:      make(map[string][]string),
+            Body:        writer.body.Bytes(),
+        }
+
+        for name, values := range writer.Header() {
+            if !skipHeaders[http.CanonicalHeaderKey(name)] {
+                record.Header[name] = values
+            }
+        }
+
+        if err := cache.PutAs(reqCache, cacheKey, record, seconds(ttl)); err != nil && opts.OnError != nil {
+            opts.OnError(ctx, err)
+        }
+    }
+}
+
+// 重放记录的响应
+func replay(
+    ctx *router.Context,
+    c *cache.Cache,
+    cacheKey string,
+    fingerprint string,
+    onConflict func(*router.Context, error),
+) bool {
+    record, err := cache.GetAs[Record](c, cacheKey)
+    if err != nil {
+        return false
+    }
+
+    if record.Fingerprint != fingerprint {
+        onConflict(ctx, ErrMismatch)
+        ctx.Abort()
+        return true
+    }
+
+    for name, values := range record.Header {
+        for _, value := range values {
+            ctx.Writer.Header().Add(name, value)
+        }
+    }
+
+    ctx.Header(ReplayedHeader, "true")
+    ctx.Writer.WriteHeader(record.Status)
+    ctx.Writer.Write(record.Body)
+    ctx.Abort()
+
+    return true
+}
+
+// 需要处理的请求方式
+func mutating(method string) bool {
+    switch method {
+        case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+            return true
+    }
+
+    return false
+}
+
+// 只保存成功和客户端错误的响应，冲突、限流和服务端错误允许重试
+func storable(status int) bool {
+    switch status {
+        case http.StatusConflict, http.StatusTooManyRequests:
+            return false
+    }
+
+    return status < http.StatusInternalServerError
+}
+
+// key 的作用范围，登录后为管理员 ID，否则为客户端 IP
+func scope(ctx *router.Context) string {
+    if id, ok := ctx.Get(AdminIDKey); ok && fmt.Sprint(id) != "" {
+        return "admin=" + fmt.Sprint(id)
+    }
+
+    return "ip=" + router.GetRequestIp(ctx)
+}
+
+// 请求指纹，包括请求方式、路径和请求内容，请求内容超过最大长度时返回 errBodyTooLarge
+func requestFingerprint(ctx *router.Context, maxBodySize int64) (string, error) {
+    var body []byte
+    if ctx.Request.Body != nil {
+        if ctx.Request.ContentLength > maxBodySize {
+            return "", errBodyTooLarge
+        }
+
+        data, err := io.ReadAll(io.LimitReader(ctx.Request.Body, maxBodySize + 1))
+        if err != nil {
+            return "", err
+        }
+
+        // 未知长度的请求读取后超过最大长度，已读取的内容放回
+        if int64(len(data)) > maxBodySize {
+            ctx.Request.Body = struct {
+                io.Reader
+                io.Closer
+            }{io.MultiReader(bytes.NewReader(data), ctx.Request.Body), ctx.Request.Body}
+
+            return "", errBodyTooLarge
+        }
+
+        body = data
+        ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+    }
+
+    h := sha256.New()
+    h.Write([]byte(ctx.Request.Method + " " + ctx.Request.URL.RequestURI() + "\n"))
+
+    // multipart 的 boundary 每次提交都不同，只记录各部分内容
+    mediaType, params, err := mime.ParseMediaType(ctx.GetHeader("Content-Type"))
+    if err == nil && mediaType == "multipart/form-data" && params["boundary"] != "" {
+        parts := sha256.New()
+        if err := hashMultipart(parts, body, params["boundary"]); err == nil {
+            h.Write([]byte(mediaType + "\n"))
+            h.Write(parts.Sum(nil))
+
+            return hex.EncodeToString(h.Sum(nil)), nil
+        }
+    }
+
+    h.Write([]byte(ctx.GetHeader("Content-Type") + "\n"))
+    h.Write(body)
+
+    return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// multipart 各部分的摘要，包括字段信息和内容
+func hashMultipart(h io.Writer, body []byte, boundary string) error {
+    reader := multipart.NewReader(bytes.NewReader(body), boundary)
+
+    for {
+        part, err := reader.NextPart()
+        if err == io.EOF {
+            return nil
+        }
+        if err != nil {
+            return err
+        }
+
+        h.Write([]byte(part.Header.Get("Content-Disposition") + "\n"))
+        h.Write([]byte(part.Header.Get("Content-Type") + "\n"))
+
+        n, err := io.Copy(h, part)
+        if err != nil {
+            return err
+        }
+
+        h.Write([]byte(fmt.Sprintf("\n%d\n", n)))
+    }
+}
+
+func hash(data string) string {
+    sum := sha256.Sum256([]byte(data))
+    return hex.EncodeToString(sum[:])
+}
+
+// 秒数，最少 1 秒
+func seconds(d time.Duration) int64 {
+    s := int64(d / time.Second)
+    if s < 1 {
+        s = 1
+    }
+
+    return s
+}
+
+// 记录响应内容，超过最大长度时不再记录
+type bodyWriter struct {
+    router.ResponseWriter
+
+    body     bytes.Buffer
+    max      int64
+    overflow bool
+}
+
+func (this *bodyWriter) Write(data []byte) (int, error) {
+    this.record(data)
+    return this.ResponseWriter.Write(data)
+}
+
+func (this *bodyWriter) WriteString(s string) (int, error) {
+    this.record([]byte(s))
+    return this.ResponseWriter.WriteString(s)
+}
+
+func (this *bodyWriter) record(data []byte) {
+    if this.overflow {
+        return
+    }
+
+    if int64(this.body.Len() + len(data)) > this.max {
+        this.overflow = true
+        this.body.Reset()
+        return
+    }
+
+    this.body.Write(data)
+}
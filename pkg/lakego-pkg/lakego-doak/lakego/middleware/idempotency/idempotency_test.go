@@ -0,0 +1,356 @@
+package idempotency
+
+import (
+    "io"
+    "bytes"
+    "errors"
+    "reflect"
+    "strings"
+    "testing"
+    "net/http"
+    "mime/multipart"
+    "net/http/httptest"
+
+    "github.com/deatil/lakego-doak/lakego/cache"
+    "github.com/deatil/lakego-doak/lakego/trace"
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/cache/driver/memory"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func newCache() *cache.Cache {
+    return cache.New(memory.New(memory.Config{}), cache.Config{"type": "memory"}).
+        WithPrefix("test")
+}
+
+func newEngine(c *cache.Cache, handler router.HandlerFunc) *router.Engine {
+    router.SetMode(router.ReleaseMode)
+
+    r := router.New()
+    r.Use(func(ctx *router.Context) {
+        if id := ctx.GetHeader("X-Admin"); id != "" {
+            ctx.Set(AdminIDKey, id)
+        }
+    })
+    r.Use(Handler(c, Options{}))
+    r.POST("/admin", handler)
+    r.GET("/admin", handler)
+
+    return r
+}
+
+func request(r *router.Engine, method string, body string, headers ...string) *httptest.ResponseRecorder {
+    req := httptest.NewRequest(method, "/admin", strings.NewReader(body))
+    req.Header.Set("Content-Type", "application/json")
+    for i := 0; i + 1 < len(headers); i += 2 {
+        req.Header.Set(headers[i], headers[i + 1])
+    }
+
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+
+    return w
+}
+
+func Test_Replay(t *testing.T) {
+    eq := assertEqualT(t)
+
+    calls := 0
+    r := newEngine(newCache(), func(ctx *router.Context) {
+        calls++
+
+        ctx.Header("X-Created", "1")
+        ctx.String(http.StatusCreated, "created %d", calls)
+    })
+
+    w := request(r, "POST", `{"name":"a"}`, HeaderName, "key-1", "X-Admin", "1")
+    eq(w.Code, http.StatusCreated, "first Code")
+    eq(w.Body.String(), "created 1", "first Body")
+    eq(w.Header().Get(ReplayedHeader), "", "first Replayed")
+
+    w = request(r, "POST", `{"name":"a"}`, HeaderName, "key-1", "X-Admin", "1")
+    eq(w.Code, http.StatusCreated, "replay Code")
+    eq(w.Body.String(), "created 1", "replay Body")
+    eq(w.Header().Get("X-Created"), "1", "replay Header")
+    eq(w.Header().Get(ReplayedHeader), "true", "replay Replayed")
+    eq(calls, 1, "replay calls")
+
+    // 不同管理员使用相同 key
+    w = request(r, "POST", `{"name":"a"}`, HeaderName, "key-1", "X-Admin", "2")
+    eq(w.Body.String(), "created 2", "other admin Body")
+
+    // 没有 key 或非修改请求不处理
+    request(r, "POST", `{"name":"a"}`)
+    request(r, "GET", "", HeaderName, "key-1", "X-Admin", "1")
+    eq(calls, 4, "without key calls")
+}
+
+func Test_Mismatch(t *testing.T) {
+    eq := assertEqualT(t)
+
+    r := newEngine(newCache(), func(ctx *router.Context) {
+        ctx.String(http.StatusOK, "ok")
+    })
+
+    request(r, "POST", `{"name":"a"}`, HeaderName, "key-1")
+
+    w := request(r, "POST", `{"name":"b"}`, HeaderName, "key-1")
+    eq(w.Code, http.StatusConflict, "mismatch Code")
+
+    w = request(r, "POST", "", HeaderName, strings.Repeat("k", 300))
+    eq(w.Code, http.StatusBadRequest, "invalid key Code")
+}
+
+func Test_InFlight(t *testing.T) {
+    eq := assertEqualT(t)
+
+    c := newCache()
+
+    var inner *httptest.ResponseRecorder
+
+    var r *router.Engine
+    r = newEngine(c, func(ctx *router.Context) {
+        // 处理中再次提交
+        if inner == nil {
+            inner = request(r, "POST", `{}`, HeaderName, "key-1")
+        }
+
+        ctx.String(http.StatusOK, "ok")
+    })
+
+    w := request(r, "POST", `{}`, HeaderName, "key-1")
+    eq(w.Code, http.StatusOK, "first Code")
+    eq(inner.Code, http.StatusConflict, "in flight Code")
+
+    w = request(r, "POST", `{}`, HeaderName, "key-1")
+    eq(w.Header().Get(ReplayedHeader), "true", "after in flight Replayed")
+}
+
+func Test_ServerErrorNotStored(t *testing.T) {
+    eq := assertEqualT(t)
+
+    calls := 0
+    r := newEngine(newCache(), func(ctx *router.Context) {
+        calls++
+        ctx.String(http.StatusInternalServerError, "error")
+    })
+
+    request(r, "POST", `{}`, HeaderName, "key-1")
+    request(r, "POST", `{}`, HeaderName, "key-1")
+    eq(calls, 2, "retry after server error")
+}
+
+func Test_OnConflict(t *testing.T) {
+    eq := assertEqualT(t)
+
+    var got error
+
+    router.SetMode(router.ReleaseMode)
+
+    r := router.New()
+    r.Use(Handler(newCache(), Options{
+        OnConflict: func(ctx *router.Context, err error) {
+            got = err
+            ctx.String(http.StatusConflict, "conflict")
+        },
+    }))
+    r.POST("/admin", func(ctx *router.Context) {
+        ctx.String(http.StatusOK, "ok")
+    })
+
+    request(r, "POST", `{"a":1}`, HeaderName, "key-1")
+    w := request(r, "POST", `{"a":2}`, HeaderName, "key-1")
+
+    eq(w.Body.String(), "conflict", "OnConflict Body")
+    eq(errors.Is(got, ErrMismatch), true, "OnConflict error")
+}
+
+func Test_TraceSpans(t *testing.T) {
+    eq := assertEqualT(t)
+
+    exporter := trace.NewMemoryExporter()
+    trace.SetDefault(trace.NewTracer(trace.NewSimpleProcessor(exporter)))
+    defer trace.SetDefault(nil)
+
+    router.SetMode(router.ReleaseMode)
+
+    r := router.New()
+    r.Use(func(ctx *router.Context) {
+        reqCtx, span := trace.Start(ctx.Request.Context(), "request")
+        ctx.Request = ctx.Request.WithContext(reqCtx)
+
+        ctx.Next()
+
+        span.End()
+    })
+    r.Use(Handler(newCache(), Options{}))
+    r.POST("/admin", func(ctx *router.Context) {
+        ctx.String(http.StatusCreated, "created")
+    })
+
+    w := request(r, "POST", `{"name":"a"}`, HeaderName, "key-1")
+    eq(w.Code, http.StatusCreated, "Code")
+
+    root, ok := exporter.FindSpan("request")
+    eq(ok, true, "request span")
+
+    // 缓存操作记录在请求链路下
+    span, ok := exporter.FindSpan("cache.put")
+    eq(ok, true, "cache span")
+    eq(span.ParentSpanID, root.SpanContext.SpanID, "cache span parent")
+    eq(span.SpanContext.TraceID, root.SpanContext.TraceID, "cache span trace")
+}
+
+// 生成 multipart 请求内容，每次使用随机 boundary
+func multipartBody(t *testing.T, content string) (string, string) {
+    var buf bytes.Buffer
+
+    w := multipart.NewWriter(&buf)
+    w.WriteField("name", "a")
+
+    part, err := w.CreateFormFile("file", "a.txt")
+    if err != nil {
+        t.Fatal(err)
+    }
+    part.Write([]byte(content))
+    w.Close()
+
+    return buf.String(), w.FormDataContentType()
+}
+
+func Test_MultipartReplay(t *testing.T) {
+    eq := assertEqualT(t)
+
+    calls := 0
+    r := newEngine(newCache(), func(ctx *router.Context) {
+        calls++
+
+        file, err := ctx.FormFile("file")
+        if err != nil {
+            ctx.String(http.StatusBadRequest, err.Error())
+            return
+        }
+
+        ctx.String(http.StatusOK, "uploaded %s %d", file.Filename, calls)
+    })
+
+    body, contentType := multipartBody(t, "file data")
+    w := request(r, "POST", body, "Content-Type", contentType, HeaderName, "upload-1")
+    eq(w.Body.String(), "uploaded a.txt 1", "first Body")
+
+    // 重试时 boundary 不同
+    retry, retryType := multipartBody(t, "file data")
+    eq(retryType != contentType, true, "boundary changed")
+
+    w = request(r, "POST", retry, "Content-Type", retryType, HeaderName, "upload-1")
+    eq(w.Body.String(), "uploaded a.txt 1", "retry Body")
+    eq(w.Header().Get(ReplayedHeader), "true", "retry Replayed")
+
+    other, otherType := multipartBody(t, "other data")
+    w = request(r, "POST", other, "Content-Type", otherType, HeaderName, "upload-1")
+    eq(w.Code, http.StatusConflict, "other file Code")
+    eq(calls, 1, "calls")
+}
+
+func Test_MaxBodySize(t *testing.T) {
+    eq := assertEqualT(t)
+
+    router.SetMode(router.ReleaseMode)
+
+    var bodies []string
+
+    r := router.New()
+    r.Use(Handler(newCache(), Options{
+        MaxBodySize:     8,
+        MaxResponseSize: 8,
+    }))
+    r.POST("/admin", func(ctx *router.Context) {
+        data, _ := io.ReadAll(ctx.Request.Body)
+        bodies = append(bodies, string(data))
+
+        if ctx.Query("large") != "" {
+            ctx.String(http.StatusOK, "large response")
+            return
+        }
+
+        ctx.String(http.StatusOK, "ok")
+    })
+
+    // 超过最大长度时不处理，请求内容完整
+    request(r, "POST", "0123456789", HeaderName, "key-1")
+    w := request(r, "POST", "0123456789", HeaderName, "key-1")
+    eq(w.Header().Get(ReplayedHeader), "", "large body not replayed")
+    eq(bodies, []string{"0123456789", "0123456789"}, "large body")
+
+    // 未知长度的请求
+    req := httptest.NewRequest("POST", "/admin", io.MultiReader(strings.NewReader("01234"), strings.NewReader("56789")))
+    req.ContentLength = -1
+    req.Header.Set(HeaderName, "key-2")
+    r.ServeHTTP(httptest.NewRecorder(), req)
+    eq(bodies[2], "0123456789", "unknown length body")
+
+    // 超过最大长度的响应不记录
+    request(r, "POST", "{}", HeaderName, "key-3")
+    w = request(r, "POST", "{}", HeaderName, "key-3")
+    eq(w.Header().Get(ReplayedHeader), "true", "small response replayed")
+
+    req = httptest.NewRequest("POST", "/admin?large=1", strings.NewReader("{}"))
+    req.Header.Set(HeaderName, "key-4")
+    r.ServeHTTP(httptest.NewRecorder(), req)
+
+    req = httptest.NewRequest("POST", "/admin?large=1", strings.NewReader("{}"))
+    req.Header.Set(HeaderName, "key-4")
+    w = httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+    eq(w.Header().Get(ReplayedHeader), "", "large response not replayed")
+    eq(w.Body.String(), "large response", "large response Body")
+}
+
+func Test_TrustedProxyScope(t *testing.T) {
+    eq := assertEqualT(t)
+
+    router.SetMode(router.ReleaseMode)
+
+    calls := 0
+
+    r := router.New()
+    r.SetTrustedProxies([]string{"127.0.0.1"})
+    r.Use(Handler(newCache(), Options{}))
+    r.POST("/admin", func(ctx *router.Context) {
+        calls++
+        ctx.String(http.StatusOK, "ok")
+    })
+
+    send := func(remote string, forwarded string) *httptest.ResponseRecorder {
+        req := httptest.NewRequest("POST", "/admin", strings.NewReader("{}"))
+        req.RemoteAddr = remote + ":1234"
+        req.Header.Set("X-Forwarded-For", forwarded)
+        req.Header.Set(HeaderName, "key-1")
+
+        w := httptest.NewRecorder()
+        r.ServeHTTP(w, req)
+
+        return w
+    }
+
+    // 经过信任代理的不同客户端使用各自的 key
+    send("127.0.0.1", "1.2.3.4")
+    w := send("127.0.0.1", "5.6.7.8")
+    eq(w.Header().Get(ReplayedHeader), "", "other client")
+
+    w = send("127.0.0.1", "1.2.3.4")
+    eq(w.Header().Get(ReplayedHeader), "true", "same client")
+
+    // 不信任的地址伪造请求头无效
+    send("10.0.0.1", "1.2.3.4")
+    w = send("10.0.0.1", "9.9.9.9")
+    eq(w.Header().Get(ReplayedHeader), "true", "spoofed header")
+    eq(calls, 3, "calls")
+}
@@ -0,0 +1,71 @@
+package locale
+
+import (
+    "github.com/deatil/lakego-doak/lakego/i18n"
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/view/data"
+)
+
+/**
+ * 语言设置
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Options struct {
+    // 查询参数名称，为空时不使用
+    Query string
+
+    // cookie 名称，为空时不使用
+    Cookie string
+
+    // 自定义获取语言，优先使用
+    Resolver func(*router.Context) string
+}
+
+/**
+ * 请求语言中间件
+ *
+ * 依次使用 自定义获取、查询参数、cookie、Accept-Language 匹配已有语言，
+ * 都没有时使用默认语言
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Handler(translator *i18n.Translator, opts Options) router.HandlerFunc {
+    return func(ctx *router.Context) {
+        var locale string
+
+        if opts.Resolver != nil {
+            locale = opts.Resolver(ctx)
+        }
+
+        if locale == "" && opts.Query != "" {
+            locale = ctx.Query(opts.Query)
+        }
+
+        if locale == "" && opts.Cookie != "" {
+            locale, _ = ctx.Cookie(opts.Cookie)
+        }
+
+        if locale == "" {
+            locale = ctx.GetHeader("Accept-Language")
+        }
+
+        ctx.Writer.Header().Add("Vary", "Accept-Language")
+
+        Set(ctx, translator.Match(locale))
+
+        ctx.Next()
+    }
+}
+
+// 设置请求语言，同时设置到模板数据及响应头
+func Set(ctx *router.Context, locale string) {
+    i18n.SetLocale(ctx, locale)
+
+    locale = i18n.Locale(ctx)
+
+    data.Share(ctx, i18n.ContextKey, locale)
+    ctx.Header("Content-Language", locale)
+}
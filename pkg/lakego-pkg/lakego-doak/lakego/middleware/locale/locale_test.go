@@ -0,0 +1,84 @@
+package locale
+
+import (
+    "reflect"
+    "testing"
+    "net/http"
+    "net/http/httptest"
+
+    "github.com/deatil/lakego-doak/lakego/i18n"
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/view/data"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func newEngine() *router.Engine {
+    router.SetMode(router.ReleaseMode)
+
+    translator := i18n.New().
+        WithFallback("zh-CN").
+        AddMessages("zh-CN", "", map[string]any{"hello": "你好"}).
+        AddMessages("en", "", map[string]any{"hello": "Hello"})
+
+    i18n.SetDefault(translator)
+
+    r := router.New()
+    r.Use(Handler(translator, Options{
+        Query:  "lang",
+        Cookie: "lang",
+        Resolver: func(ctx *router.Context) string {
+            return ctx.GetHeader("X-Profile-Lang")
+        },
+    }))
+    r.GET("/", func(ctx *router.Context) {
+        ctx.String(http.StatusOK, i18n.Trans(ctx, "hello") + "|" + data.All(ctx)["locale"].(string))
+    })
+
+    return r
+}
+
+func request(r *router.Engine, path string, headers ...string) *httptest.ResponseRecorder {
+    req := httptest.NewRequest("GET", path, nil)
+    for i := 0; i + 1 < len(headers); i += 2 {
+        req.Header.Set(headers[i], headers[i + 1])
+    }
+
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+
+    return w
+}
+
+func Test_Handler(t *testing.T) {
+    eq := assertEqualT(t)
+
+    r := newEngine()
+    defer i18n.SetDefault(i18n.New())
+
+    w := request(r, "/")
+    eq(w.Body.String(), "你好|zh-CN", "default")
+    eq(w.Header().Get("Content-Language"), "zh-CN", "Content-Language")
+    eq(w.Header().Get("Vary"), "Accept-Language", "Vary")
+
+    w = request(r, "/", "Accept-Language", "en-US,en;q=0.9")
+    eq(w.Body.String(), "Hello|en", "Accept-Language")
+
+    w = request(r, "/?lang=en", "Accept-Language", "zh-CN")
+    eq(w.Body.String(), "Hello|en", "query")
+
+    w = request(r, "/", "Cookie", "lang=en")
+    eq(w.Body.String(), "Hello|en", "cookie")
+
+    w = request(r, "/?lang=en", "X-Profile-Lang", "zh-CN")
+    eq(w.Body.String(), "你好|zh-CN", "resolver")
+
+    w = request(r, "/?lang=de")
+    eq(w.Body.String(), "你好|zh-CN", "unsupported")
+}
@@ -0,0 +1,79 @@
+package metrics
+
+import (
+    "net"
+    "strings"
+    "net/http"
+    "crypto/subtle"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/facade"
+)
+
+/**
+ * 指标访问限制，支持 IP 白名单和 token
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Guard() router.HandlerFunc {
+    return func(ctx *router.Context) {
+        conf := facade.Config("metrics")
+
+        allowIps := conf.GetStringSlice("allow-ips")
+        token := conf.GetString("token")
+
+        if len(allowIps) == 0 && token == "" {
+            ctx.Next()
+            return
+        }
+
+        // 只在信任的代理时读取 X-Forwarded-For，避免伪造请求头绕过白名单
+        if len(allowIps) > 0 && matchIP(router.GetRequestIp(ctx), allowIps) {
+            ctx.Next()
+            return
+        }
+
+        if token != "" && checkToken(ctx, token) {
+            ctx.Next()
+            return
+        }
+
+        ctx.AbortWithStatus(http.StatusForbidden)
+    }
+}
+
+// 检测 token，支持 Bearer 和 query 参数
+func checkToken(ctx *router.Context, token string) bool {
+    reqToken := ctx.Query("token")
+
+    auth := ctx.GetHeader("Authorization")
+    if strings.HasPrefix(auth, "Bearer ") {
+        reqToken = strings.TrimPrefix(auth, "Bearer ")
+    }
+
+    if reqToken == "" {
+        return false
+    }
+
+    return subtle.ConstantTimeCompare([]byte(reqToken), []byte(token)) == 1
+}
+
+// IP 匹配，支持 CIDR
+func matchIP(ip string, allowIps []string) bool {
+    reqIP := net.ParseIP(ip)
+
+    for _, allow := range allowIps {
+        if allow == ip || allow == "*" {
+            return true
+        }
+
+        if strings.Contains(allow, "/") && reqIP != nil {
+            if _, ipNet, err := net.ParseCIDR(allow); err == nil && ipNet.Contains(reqIP) {
+                return true
+            }
+        }
+    }
+
+    return false
+}
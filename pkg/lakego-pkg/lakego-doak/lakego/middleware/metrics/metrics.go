@@ -0,0 +1,66 @@
+package metrics
+
+import (
+    "time"
+    "strconv"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/metrics"
+)
+
+var (
+    // 请求数
+    httpRequests = metrics.NewCounterVec(
+        "lakego_http_requests_total",
+        "Total number of HTTP requests by method, route and status.",
+        "method", "route", "status",
+    )
+
+    // 请求耗时
+    httpDuration = metrics.NewHistogramVec(
+        "lakego_http_request_duration_seconds",
+        "HTTP request latency in seconds by method, route and status.",
+        nil,
+        "method", "route", "status",
+    )
+
+    // 处理中的请求
+    httpInFlight = metrics.NewGaugeVec(
+        "lakego_http_requests_in_flight",
+        "Number of HTTP requests currently being served.",
+    )
+)
+
+func init() {
+    metrics.MustRegister(httpRequests, httpDuration, httpInFlight)
+}
+
+/**
+ * 请求指标记录
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Handler() router.HandlerFunc {
+    return func(ctx *router.Context) {
+        start := time.Now()
+
+        inFlight := httpInFlight.With()
+        inFlight.Inc()
+        defer inFlight.Dec()
+
+        ctx.Next()
+
+        // 使用路由模板，避免 label 过多
+        route := ctx.FullPath()
+        if route == "" {
+            route = "unmatched"
+        }
+
+        method := ctx.Request.Method
+        status := strconv.Itoa(ctx.Writer.Status())
+
+        httpRequests.With(method, route, status).Inc()
+        httpDuration.With(method, route, status).ObserveSince(start)
+    }
+}
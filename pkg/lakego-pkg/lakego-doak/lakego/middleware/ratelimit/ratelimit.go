@@ -0,0 +1,164 @@
+package ratelimit
+
+import (
+    "fmt"
+    "math"
+    "time"
+    "strings"
+    "net/http"
+    "crypto/sha256"
+    "encoding/hex"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/ratelimit"
+)
+
+// key 类型
+const (
+    // 客户端 IP，只在信任的代理时读取 X-Forwarded-For 等请求头
+    KeyIP = "ip"
+
+    // 管理员 ID，未登录时使用 IP
+    KeyAdmin = "admin"
+
+    // 路由
+    KeyRoute = "route"
+
+    // 已验证的 token，未验证时使用 IP
+    KeyToken = "token"
+)
+
+// 管理员 ID 在上下文中的名称
+var AdminIDKey = "admin_id"
+
+// 已验证 token 在上下文中的名称，由登录验证中间件设置
+var TokenKey = "access_token"
+
+// 默认被限流时的响应
+var limitedHandler router.HandlerFunc = func(ctx *router.Context) {
+    ctx.AbortWithStatusJSON(http.StatusTooManyRequests, router.H{
+        "success": false,
+        "message": "请求过于频繁，请稍后再试",
+    })
+}
+
+// 设置默认被限流时的响应
+func SetLimitedHandler(handler router.HandlerFunc) {
+    limitedHandler = handler
+}
+
+/**
+ * 限流设置
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Options struct {
+    // 名称，区分不同规则的额度
+    Name string
+
+    // 规则
+    Rule ratelimit.Rule
+
+    // key 类型组合，默认为 ip
+    Keys []string
+
+    // 被限流时的响应，默认为 SetLimitedHandler 设置的响应，需自行设置 429 状态码
+    OnLimited router.HandlerFunc
+
+    // 存储出错时的处理，出错时请求放行
+    OnError func(*router.Context, error)
+}
+
+/**
+ * 限流中间件
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Handler(limiter *ratelimit.Limiter, opts Options) router.HandlerFunc {
+    keys := opts.Keys
+    if len(keys) == 0 {
+        keys = []string{KeyIP}
+    }
+
+    policy := opts.Rule.Policy()
+
+    return func(ctx *router.Context) {
+        key := opts.Name + ":" + Key(ctx, keys)
+
+        res, err := limiter.Allow(ctx.Request.Context(), key, opts.Rule)
+        if err != nil {
+            if opts.OnError != nil {
+                opts.OnError(ctx, err)
+            }
+
+            ctx.Next()
+            return
+        }
+
+        ctx.Header("RateLimit-Limit", fmt.Sprintf("%d", res.Limit))
+        ctx.Header("RateLimit-Remaining", fmt.Sprintf("%d", res.Remaining))
+        ctx.Header("RateLimit-Reset", fmt.Sprintf("%d", seconds(res.Reset)))
+        ctx.Header("RateLimit-Policy", policy)
+
+        if !res.Allowed {
+            ctx.Header("Retry-After", fmt.Sprintf("%d", seconds(res.RetryAfter)))
+
+            if opts.OnLimited != nil {
+                opts.OnLimited(ctx)
+            } else {
+                limitedHandler(ctx)
+            }
+
+            ctx.Abort()
+            return
+        }
+
+        ctx.Next()
+    }
+}
+
+// 生成限流 key
+func Key(ctx *router.Context, keys []string) string {
+    parts := make([]string, 0, len(keys))
+
+    for _, key := range keys {
+        switch key {
+            case KeyAdmin:
+                if id, ok := ctx.Get(AdminIDKey); ok && fmt.Sprint(id) != "" {
+                    parts = append(parts, "admin=" + fmt.Sprint(id))
+                } else {
+                    parts = append(parts, "ip=" + router.GetRequestIp(ctx))
+                }
+            case KeyRoute:
+                route := ctx.FullPath()
+                if route == "" {
+                    route = ctx.Request.URL.Path
+                }
+
+                parts = append(parts, "route=" + ctx.Request.Method + " " + route)
+            case KeyToken:
+                // 只使用验证通过的 token，避免随意传入的 token 绕过 IP 限流
+                if token := ctx.GetString(TokenKey); token != "" {
+                    sum := sha256.Sum256([]byte(token))
+                    parts = append(parts, "token=" + hex.EncodeToString(sum[:]))
+                } else {
+                    parts = append(parts, "ip=" + router.GetRequestIp(ctx))
+                }
+            default:
+                parts = append(parts, "ip=" + router.GetRequestIp(ctx))
+        }
+    }
+
+    return strings.Join(parts, "|")
+}
+
+// 向上取整的秒数
+func seconds(d time.Duration) int64 {
+    if d <= 0 {
+        return 0
+    }
+
+    return int64(math.Ceil(d.Seconds()))
+}
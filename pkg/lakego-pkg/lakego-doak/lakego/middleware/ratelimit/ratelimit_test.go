@@ -0,0 +1,195 @@
+package ratelimit
+
+import (
+    "errors"
+    "context"
+    "reflect"
+    "testing"
+    "time"
+    "net/http"
+    "net/http/httptest"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/ratelimit"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+// 出错的存储
+type errorStore struct{}
+
+func (this errorStore) Take(ctx context.Context, key string, rule ratelimit.Rule) (ratelimit.Result, error) {
+    return ratelimit.Result{}, errors.New("store down")
+}
+
+func (this errorStore) Reset(ctx context.Context, key string, rule ratelimit.Rule) error {
+    return nil
+}
+
+func newEngine(handler router.HandlerFunc, before ...router.HandlerFunc) *router.Engine {
+    router.SetMode(router.ReleaseMode)
+
+    r := router.New()
+
+    // 与默认配置一致，只信任本机代理
+    r.SetTrustedProxies([]string{"127.0.0.1"})
+
+    r.Use(before...)
+    r.Use(handler)
+    r.GET("/upload/:id", func(ctx *router.Context) {
+        ctx.String(http.StatusOK, "ok")
+    })
+
+    return r
+}
+
+func request(r *router.Engine, ip string, header ...string) *httptest.ResponseRecorder {
+    req := httptest.NewRequest("GET", "/upload/1", nil)
+    req.RemoteAddr = ip + ":1234"
+    if len(header) > 1 {
+        req.Header.Set(header[0], header[1])
+    }
+
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+
+    return w
+}
+
+func Test_Handler(t *testing.T) {
+    eq := assertEqualT(t)
+
+    limiter := ratelimit.New(ratelimit.NewMemoryStore())
+    r := newEngine(Handler(limiter, Options{
+        Name: "upload",
+        Rule: ratelimit.Rule{Limit: 2, Period: time.Minute},
+    }))
+
+    w := request(r, "10.0.0.1")
+    eq(w.Code, http.StatusOK, "first Code")
+    eq(w.Header().Get("RateLimit-Limit"), "2", "first Limit")
+    eq(w.Header().Get("RateLimit-Remaining"), "1", "first Remaining")
+    eq(w.Header().Get("RateLimit-Reset"), "30", "first Reset")
+    eq(w.Header().Get("RateLimit-Policy"), "2;w=60", "first Policy")
+
+    request(r, "10.0.0.1")
+
+    w = request(r, "10.0.0.1")
+    eq(w.Code, http.StatusTooManyRequests, "limited Code")
+    eq(w.Header().Get("RateLimit-Remaining"), "0", "limited Remaining")
+    eq(w.Header().Get("Retry-After"), "30", "limited Retry-After")
+
+    // 其他 IP 不受影响
+    w = request(r, "10.0.0.2")
+    eq(w.Code, http.StatusOK, "other ip Code")
+}
+
+func Test_HandlerOnLimited(t *testing.T) {
+    eq := assertEqualT(t)
+
+    limiter := ratelimit.New(ratelimit.NewMemoryStore())
+    r := newEngine(Handler(limiter, Options{
+        Name: "captcha",
+        Rule: ratelimit.Rule{Algorithm: ratelimit.SlidingWindow, Limit: 1, Period: time.Minute},
+        OnLimited: func(ctx *router.Context) {
+            ctx.String(http.StatusTooManyRequests, "slow down")
+        },
+    }))
+
+    request(r, "10.0.0.1")
+
+    w := request(r, "10.0.0.1")
+    eq(w.Code, http.StatusTooManyRequests, "Code")
+    eq(w.Body.String(), "slow down", "Body")
+}
+
+func Test_HandlerOnError(t *testing.T) {
+    eq := assertEqualT(t)
+
+    var storeErr error
+
+    r := newEngine(Handler(ratelimit.New(errorStore{}), Options{
+        Name: "upload",
+        Rule: ratelimit.Rule{Limit: 1, Period: time.Minute},
+        OnError: func(ctx *router.Context, err error) {
+            storeErr = err
+        },
+    }))
+
+    w := request(r, "10.0.0.1")
+    eq(w.Code, http.StatusOK, "fail open Code")
+    eq(storeErr != nil, true, "OnError called")
+}
+
+func Test_Key(t *testing.T) {
+    eq := assertEqualT(t)
+
+    var keys []string
+
+    r := newEngine(func(ctx *router.Context) {
+        keys = []string{
+            Key(ctx, []string{KeyIP}),
+            Key(ctx, []string{KeyAdmin, KeyRoute}),
+            Key(ctx, []string{KeyToken}),
+        }
+    }, func(ctx *router.Context) {
+        if ctx.GetHeader("X-Admin") != "" {
+            ctx.Set(AdminIDKey, ctx.GetHeader("X-Admin"))
+        }
+
+        // 模拟登录验证通过后设置 token
+        if ctx.GetHeader("X-Token") != "" {
+            ctx.Set(TokenKey, ctx.GetHeader("X-Token"))
+        }
+    })
+
+    request(r, "10.0.0.1")
+    eq(keys[0], "ip=10.0.0.1", "ip")
+    eq(keys[1], "ip=10.0.0.1|route=GET /upload/:id", "admin fallback")
+    eq(keys[2], "ip=10.0.0.1", "token fallback")
+
+    request(r, "10.0.0.1", "X-Admin", "42")
+    eq(keys[1], "admin=42|route=GET /upload/:id", "admin")
+
+    // 未验证的 token 使用 IP
+    request(r, "10.0.0.1", "Authorization", "Bearer abc")
+    eq(keys[2], "ip=10.0.0.1", "unverified token")
+
+    request(r, "10.0.0.1", "X-Token", "abc")
+    eq(keys[2], "token=ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad", "token")
+
+    // 不使用伪造的代理请求头
+    request(r, "10.0.0.1", "X-Forwarded-For", "1.2.3.4")
+    eq(keys[0], "ip=10.0.0.1", "spoofed X-Forwarded-For")
+
+    // 信任的代理传入的客户端 IP
+    request(r, "127.0.0.1", "X-Forwarded-For", "1.2.3.4")
+    eq(keys[0], "ip=1.2.3.4", "trusted proxy")
+    eq(keys[2], "ip=1.2.3.4", "trusted proxy token fallback")
+}
+
+func Test_HandlerTrustedProxy(t *testing.T) {
+    eq := assertEqualT(t)
+
+    limiter := ratelimit.New(ratelimit.NewMemoryStore())
+    r := newEngine(Handler(limiter, Options{
+        Name: "admin-login",
+        Rule: ratelimit.Rule{Limit: 1, Period: time.Minute},
+    }))
+
+    // 经过代理的不同客户端分别限流
+    w := request(r, "127.0.0.1", "X-Forwarded-For", "1.2.3.4")
+    eq(w.Code, http.StatusOK, "first client Code")
+
+    w = request(r, "127.0.0.1", "X-Forwarded-For", "1.2.3.4")
+    eq(w.Code, http.StatusTooManyRequests, "first client limited")
+
+    w = request(r, "127.0.0.1", "X-Forwarded-For", "5.6.7.8")
+    eq(w.Code, http.StatusOK, "second client Code")
+}
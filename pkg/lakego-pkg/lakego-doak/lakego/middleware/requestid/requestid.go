@@ -0,0 +1,75 @@
+package requestid
+
+import (
+    "github.com/deatil/lakego-doak/lakego/uuid"
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/logger"
+)
+
+// 默认请求头
+const HeaderName = "X-Request-ID"
+
+// 请求 ID 最大长度
+const maxLength = 128
+
+/**
+ * 请求 ID，用于关联请求和日志
+ *
+ * header 为空时使用 X-Request-ID，trust 为 true 时使用请求传入的 ID
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Handler(header string, trust bool) router.HandlerFunc {
+    if header == "" {
+        header = HeaderName
+    }
+
+    return func(ctx *router.Context) {
+        id := ""
+        if trust {
+            id = ctx.GetHeader(header)
+        }
+
+        if !valid(id) {
+            id = uuid.ToUUIDString()
+        }
+
+        fields := logger.Fields{
+            logger.RequestIDKey: id,
+        }
+
+        ctx.Set(logger.RequestIDKey, id)
+        if route := ctx.FullPath(); route != "" {
+            ctx.Set(logger.RouteKey, route)
+            fields[logger.RouteKey] = route
+        }
+
+        // 请求上下文，用于只传递 context.Context 的场景
+        ctx.Request = ctx.Request.WithContext(logger.NewContext(ctx.Request.Context(), fields))
+
+        ctx.Header(header, id)
+
+        ctx.Next()
+    }
+}
+
+// 获取请求 ID
+func Get(ctx *router.Context) string {
+    return ctx.GetString(logger.RequestIDKey)
+}
+
+// 请求 ID 只允许可见 ASCII 字符
+func valid(id string) bool {
+    if id == "" || len(id) > maxLength {
+        return false
+    }
+
+    for i := 0; i < len(id); i++ {
+        if id[i] <= ' ' || id[i] > '~' {
+            return false
+        }
+    }
+
+    return true
+}
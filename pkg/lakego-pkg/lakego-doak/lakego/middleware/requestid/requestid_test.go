@@ -0,0 +1,72 @@
+package requestid
+
+import (
+    "reflect"
+    "testing"
+    "net/http"
+    "net/http/httptest"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/logger"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func serve(trust bool, header string) (*httptest.ResponseRecorder, logger.Fields) {
+    router.SetMode(router.ReleaseMode)
+
+    var fields logger.Fields
+
+    r := router.New()
+    r.Use(Handler("", trust))
+    r.GET("/user/:id", func(ctx *router.Context) {
+        fields = logger.FromContext(ctx)
+        fields["from_request"] = logger.FromContext(ctx.Request.Context())[logger.RequestIDKey]
+
+        ctx.String(http.StatusOK, Get(ctx))
+    })
+
+    req := httptest.NewRequest("GET", "/user/1", nil)
+    if header != "" {
+        req.Header.Set(HeaderName, header)
+    }
+
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+
+    return w, fields
+}
+
+func Test_Handler(t *testing.T) {
+    eq := assertEqualT(t)
+
+    {
+        w, fields := serve(true, "abc-123")
+
+        eq(w.Body.String(), "abc-123", "trust body")
+        eq(w.Header().Get(HeaderName), "abc-123", "trust header")
+        eq(fields[logger.RequestIDKey], "abc-123", "trust fields")
+        eq(fields[logger.RouteKey], "/user/:id", "trust route")
+        eq(fields["from_request"], "abc-123", "trust request context")
+    }
+
+    {
+        w, _ := serve(false, "abc-123")
+
+        id := w.Header().Get(HeaderName)
+        eq(id != "abc-123" && len(id) == 36, true, "untrusted header")
+        eq(w.Body.String(), id, "untrusted body")
+    }
+
+    {
+        w, _ := serve(true, "bad id\n")
+
+        eq(len(w.Header().Get(HeaderName)), 36, "invalid header")
+    }
+}
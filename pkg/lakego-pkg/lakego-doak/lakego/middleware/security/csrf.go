@@ -0,0 +1,386 @@
+package security
+
+import (
+    "time"
+    "errors"
+    "strings"
+    "net/http"
+    "html/template"
+    "crypto/hmac"
+    "crypto/sha256"
+    "crypto/subtle"
+    "encoding/hex"
+    "encoding/base64"
+
+    "github.com/deatil/lakego-doak/lakego/cache"
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/view/data"
+)
+
+// CSRF 模式
+const (
+    // 双重提交，token 存在 cookie 中并签名
+    CSRFDoubleSubmit = "double-submit"
+
+    // 同步令牌，token 存在缓存中，cookie 只保存会话标识
+    CSRFSynchronizer = "synchronizer"
+)
+
+// 上下文及模板中的名称
+const (
+    // token
+    CSRFTokenKey = "csrf_token"
+
+    // 隐藏表单字段，模板中使用 {{ csrf_field|safe }}
+    CSRFFieldKey = "csrf_field"
+
+    // 表单字段名称
+    CSRFFieldNameKey = "csrf_field_name"
+
+    // 请求头名称
+    CSRFHeaderNameKey = "csrf_header_name"
+)
+
+var (
+    // 请求没有 token
+    ErrCSRFTokenMissing = errors.New("csrf: token missing")
+
+    // token 不匹配
+    ErrCSRFTokenInvalid = errors.New("csrf: token invalid")
+
+    // 模式不支持
+    ErrCSRFModeInvalid = errors.New("csrf: invalid mode")
+
+    // 同步令牌模式没有设置缓存
+    ErrCSRFCacheRequired = errors.New("csrf: cache is required")
+)
+
+/**
+ * CSRF 设置
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type CSRFOptions struct {
+    // 模式 double-submit, synchronizer，默认为 double-submit
+    Mode string
+
+    // 双重提交模式的签名密钥
+    Secret []byte
+
+    // 同步令牌模式的缓存
+    Cache *cache.Cache
+
+    // cookie 名称
+    CookieName string
+
+    // cookie 路径
+    CookiePath string
+
+    // cookie 域名
+    CookieDomain string
+
+    // 只在 https 中发送 cookie
+    Secure bool
+
+    // SameSite
+    SameSite http.SameSite
+
+    // 有效时间，默认 2 小时
+    TTL time.Duration
+
+    // 请求头名称，默认为 X-CSRF-Token
+    HeaderName string
+
+    // 表单字段名称，默认为 _token
+    FieldName string
+
+    // 不检测的路径前缀
+    Except []string
+
+    // 检测失败时的响应，需自行设置 403 状态码
+    OnError func(*router.Context, error)
+}
+
+// 默认检测失败响应
+func csrfError(ctx *router.Context, err error) {
+    ctx.AbortWithStatusJSON(http.StatusForbidden, router.H{
+        "success": false,
+        "message": "CSRF token 验证失败",
+    })
+}
+
+/**
+ * CSRF 中间件
+ *
+ * GET/HEAD/OPTIONS/TRACE 请求生成 token，其他请求需在请求头或表单字段中提交 token
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func CSRF(opts CSRFOptions) (router.HandlerFunc, error) {
+    opts = csrfDefaults(opts)
+
+    var store csrfStore
+    switch opts.Mode {
+        case CSRFDoubleSubmit:
+            if len(opts.Secret) == 0 {
+                opts.Secret = []byte(randomString(32))
+            }
+
+            store = &doubleSubmitStore{opts: opts}
+        case CSRFSynchronizer:
+            if opts.Cache == nil {
+                return nil, ErrCSRFCacheRequired
+            }
+
+            store = &synchronizerStore{opts: opts}
+        default:
+            return nil, ErrCSRFModeInvalid
+    }
+
+    return func(ctx *router.Context) {
+        if excepted(ctx.Request.URL.Path, opts.Except) {
+            ctx.Next()
+            return
+        }
+
+        token, err := store.get(ctx)
+
+        if safeMethod(ctx.Request.Method) {
+            if err == nil && token == "" {
+                token, err = store.create(ctx)
+            }
+
+            // 缓存出错时不生成 token，提交时验证失败
+            if err == nil {
+                shareCSRF(ctx, opts, token)
+            }
+
+            ctx.Next()
+            return
+        }
+
+        sent := ctx.GetHeader(opts.HeaderName)
+        if sent == "" {
+            sent = ctx.PostForm(opts.FieldName)
+        }
+
+        switch {
+            case sent == "":
+                err = ErrCSRFTokenMissing
+            case err != nil || token == "" || !tokenEqual(sent, token):
+                err = ErrCSRFTokenInvalid
+        }
+
+        if err != nil {
+            opts.OnError(ctx, err)
+            ctx.Abort()
+            return
+        }
+
+        shareCSRF(ctx, opts, token)
+
+        ctx.Next()
+    }, nil
+}
+
+// 获取当前请求的 token
+func CSRFToken(ctx *router.Context) string {
+    return ctx.GetString(CSRFTokenKey)
+}
+
+// 默认设置
+func csrfDefaults(opts CSRFOptions) CSRFOptions {
+    if opts.Mode == "" {
+        opts.Mode = CSRFDoubleSubmit
+    }
+
+    if opts.CookieName == "" {
+        opts.CookieName = "lakego_csrf"
+    }
+
+    if opts.CookiePath == "" {
+        opts.CookiePath = "/"
+    }
+
+    if opts.SameSite == 0 {
+        opts.SameSite = http.SameSiteLaxMode
+    }
+
+    if opts.TTL <= 0 {
+        opts.TTL = 2 * time.Hour
+    }
+
+    if opts.HeaderName == "" {
+        opts.HeaderName = "X-CSRF-Token"
+    }
+
+    if opts.FieldName == "" {
+        opts.FieldName = "_token"
+    }
+
+    if opts.OnError == nil {
+        opts.OnError = csrfError
+    }
+
+    return opts
+}
+
+// 添加到上下文和模板数据
+func shareCSRF(ctx *router.Context, opts CSRFOptions, token string) {
+    field := `<input type="hidden" name="` + template.HTMLEscapeString(opts.FieldName) +
+        `" value="` + template.HTMLEscapeString(token) + `">`
+
+    ctx.Set(CSRFTokenKey, token)
+
+    data.Share(ctx, CSRFTokenKey, token)
+    data.Share(ctx, CSRFFieldKey, field)
+    data.Share(ctx, CSRFFieldNameKey, opts.FieldName)
+    data.Share(ctx, CSRFHeaderNameKey, opts.HeaderName)
+}
+
+// 设置 cookie
+func setCookie(ctx *router.Context, opts CSRFOptions, name string, value string, httpOnly bool) {
+    http.SetCookie(ctx.Writer, &http.Cookie{
+        Name:     name,
+        Value:    value,
+        Path:     opts.CookiePath,
+        Domain:   opts.CookieDomain,
+        MaxAge:   int(opts.TTL / time.Second),
+        Secure:   opts.Secure,
+        HttpOnly: httpOnly,
+        SameSite: opts.SameSite,
+    })
+}
+
+// 不需要检测的请求方式
+func safeMethod(method string) bool {
+    switch method {
+        case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+            return true
+    }
+
+    return false
+}
+
+// 是否为不检测的路径
+func excepted(path string, except []string) bool {
+    for _, prefix := range except {
+        if prefix != "" && strings.HasPrefix(path, prefix) {
+            return true
+        }
+    }
+
+    return false
+}
+
+// 比较 token
+func tokenEqual(a string, b string) bool {
+    return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// token 存储
+type csrfStore interface {
+    // 获取当前 token，不存在时返回空
+    get(ctx *router.Context) (string, error)
+
+    // 生成 token
+    create(ctx *router.Context) (string, error)
+}
+
+/**
+ * 双重提交，cookie 中的 token 带签名，防止子域名写入伪造的 cookie
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type doubleSubmitStore struct {
+    opts CSRFOptions
+}
+
+func (this *doubleSubmitStore) get(ctx *router.Context) (string, error) {
+    value, err := ctx.Cookie(this.opts.CookieName)
+    if err != nil || !this.verify(value) {
+        return "", nil
+    }
+
+    return value, nil
+}
+
+func (this *doubleSubmitStore) create(ctx *router.Context) (string, error) {
+    random := randomString(32)
+    token := random + "." + this.sign(random)
+
+    // 前端需读取 cookie 放入请求头
+    setCookie(ctx, this.opts, this.opts.CookieName, token, false)
+
+    return token, nil
+}
+
+func (this *doubleSubmitStore) sign(value string) string {
+    h := hmac.New(sha256.New, this.opts.Secret)
+    h.Write([]byte(value))
+
+    return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
+
+func (this *doubleSubmitStore) verify(token string) bool {
+    parts := strings.SplitN(token, ".", 2)
+    if len(parts) != 2 {
+        return false
+    }
+
+    return hmac.Equal([]byte(parts[1]), []byte(this.sign(parts[0])))
+}
+
+/**
+ * 同步令牌，token 存在缓存中
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type synchronizerStore struct {
+    opts CSRFOptions
+}
+
+func (this *synchronizerStore) get(ctx *router.Context) (string, error) {
+    sid, err := ctx.Cookie(this.opts.CookieName)
+    if err != nil || sid == "" {
+        return "", nil
+    }
+
+    token, err := cache.GetAs[string](this.opts.Cache, this.key(sid))
+    if err != nil {
+        if cache.IsMiss(err) {
+            return "", nil
+        }
+
+        return "", err
+    }
+
+    return token, nil
+}
+
+func (this *synchronizerStore) create(ctx *router.Context) (string, error) {
+    sid, err := ctx.Cookie(this.opts.CookieName)
+    if err != nil || sid == "" {
+        sid = randomString(32)
+    }
+
+    token := randomString(32)
+
+    err = this.opts.Cache.Put(this.key(sid), token, int64(this.opts.TTL / time.Second))
+    if err != nil {
+        return "", err
+    }
+
+    setCookie(ctx, this.opts, this.opts.CookieName, sid, true)
+
+    return token, nil
+}
+
+func (this *synchronizerStore) key(sid string) string {
+    sum := sha256.Sum256([]byte(sid))
+    return "csrf:" + hex.EncodeToString(sum[:])
+}
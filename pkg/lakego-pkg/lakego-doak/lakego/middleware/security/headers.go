@@ -0,0 +1,164 @@
+package security
+
+import (
+    "fmt"
+    "time"
+    "strings"
+    "crypto/rand"
+    "encoding/base64"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/view/data"
+)
+
+// CSP 中 nonce 的占位符
+const NoncePlaceholder = "{nonce}"
+
+// 上下文及模板中 nonce 的名称
+const NonceKey = "csp_nonce"
+
+/**
+ * 安全响应头设置
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type HeadersOptions struct {
+    // CSP 策略，可使用 {nonce} 占位符，如 script-src 'self' 'nonce-{nonce}'
+    ContentSecurityPolicy string
+
+    // 只报告不拦截
+    CSPReportOnly bool
+
+    // HSTS 有效时间，0 为不设置，只在 https 请求中设置
+    HSTSMaxAge time.Duration
+
+    // HSTS 包含子域名
+    HSTSIncludeSubdomains bool
+
+    // HSTS 预加载
+    HSTSPreload bool
+
+    // X-Frame-Options，如 DENY, SAMEORIGIN
+    FrameOptions string
+
+    // Referrer-Policy
+    ReferrerPolicy string
+
+    // 设置 X-Content-Type-Options: nosniff
+    ContentTypeNosniff bool
+
+    // Permissions-Policy
+    PermissionsPolicy string
+
+    // Cross-Origin-Opener-Policy
+    CrossOriginOpenerPolicy string
+}
+
+/**
+ * 安全响应头中间件
+ *
+ * CSP 包含 {nonce} 时每个请求生成 nonce，可在模板中使用 {{ csp_nonce }}
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Headers(opts HeadersOptions) router.HandlerFunc {
+    cspHeader := "Content-Security-Policy"
+    if opts.CSPReportOnly {
+        cspHeader = "Content-Security-Policy-Report-Only"
+    }
+
+    useNonce := strings.Contains(opts.ContentSecurityPolicy, NoncePlaceholder)
+    hsts := hstsValue(opts)
+
+    return func(ctx *router.Context) {
+        if opts.ContentSecurityPolicy != "" {
+            policy := opts.ContentSecurityPolicy
+
+            if useNonce {
+                nonce := newNonce()
+
+                ctx.Set(NonceKey, nonce)
+                data.Share(ctx, NonceKey, nonce)
+
+                policy = strings.ReplaceAll(policy, NoncePlaceholder, nonce)
+            }
+
+            ctx.Header(cspHeader, policy)
+        }
+
+        if hsts != "" && isHTTPS(ctx) {
+            ctx.Header("Strict-Transport-Security", hsts)
+        }
+
+        if opts.FrameOptions != "" {
+            ctx.Header("X-Frame-Options", opts.FrameOptions)
+        }
+
+        if opts.ReferrerPolicy != "" {
+            ctx.Header("Referrer-Policy", opts.ReferrerPolicy)
+        }
+
+        if opts.ContentTypeNosniff {
+            ctx.Header("X-Content-Type-Options", "nosniff")
+        }
+
+        if opts.PermissionsPolicy != "" {
+            ctx.Header("Permissions-Policy", opts.PermissionsPolicy)
+        }
+
+        if opts.CrossOriginOpenerPolicy != "" {
+            ctx.Header("Cross-Origin-Opener-Policy", opts.CrossOriginOpenerPolicy)
+        }
+
+        ctx.Next()
+    }
+}
+
+// 获取当前请求的 nonce
+func Nonce(ctx *router.Context) string {
+    return ctx.GetString(NonceKey)
+}
+
+// HSTS 响应头
+func hstsValue(opts HeadersOptions) string {
+    if opts.HSTSMaxAge <= 0 {
+        return ""
+    }
+
+    value := fmt.Sprintf("max-age=%d", int64(opts.HSTSMaxAge / time.Second))
+    if opts.HSTSIncludeSubdomains {
+        value += "; includeSubDomains"
+    }
+
+    if opts.HSTSPreload {
+        value += "; preload"
+    }
+
+    return value
+}
+
+// 是否为 https 请求，包括代理转发的请求
+func isHTTPS(ctx *router.Context) bool {
+    if ctx.Request.TLS != nil {
+        return true
+    }
+
+    return strings.EqualFold(ctx.GetHeader("X-Forwarded-Proto"), "https")
+}
+
+// 生成 nonce
+func newNonce() string {
+    return randomString(16)
+}
+
+// 随机字符
+func randomString(size int) string {
+    b := make([]byte, size)
+    if _, err := rand.Read(b); err != nil {
+        panic(err)
+    }
+
+    return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,194 @@
+package security
+
+import (
+    "time"
+    "strings"
+    "reflect"
+    "testing"
+    "net/http"
+    "net/url"
+    "net/http/httptest"
+
+    "github.com/deatil/lakego-doak/lakego/cache"
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/view/data"
+    "github.com/deatil/lakego-doak/lakego/cache/driver/memory"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func Test_Headers(t *testing.T) {
+    eq := assertEqualT(t)
+
+    router.SetMode(router.ReleaseMode)
+
+    var shared map[string]any
+
+    r := router.New()
+    r.Use(Headers(HeadersOptions{
+        ContentSecurityPolicy: "default-src 'self'; script-src 'self' 'nonce-{nonce}'",
+        HSTSMaxAge:            365 * 24 * time.Hour,
+        HSTSIncludeSubdomains: true,
+        FrameOptions:          "DENY",
+        ReferrerPolicy:        "strict-origin-when-cross-origin",
+        ContentTypeNosniff:    true,
+    }))
+    r.GET("/", func(ctx *router.Context) {
+        shared = data.All(ctx)
+        ctx.String(http.StatusOK, Nonce(ctx))
+    })
+
+    req := httptest.NewRequest("GET", "/", nil)
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+
+    nonce := w.Body.String()
+    eq(len(nonce) > 0, true, "nonce")
+    eq(shared[NonceKey], nonce, "nonce shared")
+    eq(w.Header().Get("Content-Security-Policy"), "default-src 'self'; script-src 'self' 'nonce-" + nonce + "'", "CSP")
+    eq(w.Header().Get("Strict-Transport-Security"), "", "HSTS on http")
+    eq(w.Header().Get("X-Frame-Options"), "DENY", "X-Frame-Options")
+    eq(w.Header().Get("Referrer-Policy"), "strict-origin-when-cross-origin", "Referrer-Policy")
+    eq(w.Header().Get("X-Content-Type-Options"), "nosniff", "X-Content-Type-Options")
+
+    req = httptest.NewRequest("GET", "/", nil)
+    req.Header.Set("X-Forwarded-Proto", "https")
+    w2 := httptest.NewRecorder()
+    r.ServeHTTP(w2, req)
+
+    eq(w2.Header().Get("Strict-Transport-Security"), "max-age=31536000; includeSubDomains", "HSTS on https")
+    eq(w2.Body.String() != nonce, true, "nonce per request")
+}
+
+func newCSRFEngine(t *testing.T, opts CSRFOptions) *router.Engine {
+    router.SetMode(router.ReleaseMode)
+
+    handler, err := CSRF(opts)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    r := router.New()
+    r.Use(handler)
+    r.GET("/form", func(ctx *router.Context) {
+        ctx.String(http.StatusOK, CSRFToken(ctx))
+    })
+    r.POST("/form", func(ctx *router.Context) {
+        ctx.String(http.StatusOK, "saved")
+    })
+    r.POST("/api/hook", func(ctx *router.Context) {
+        ctx.String(http.StatusOK, "hook")
+    })
+
+    return r
+}
+
+// 获取 token 和 cookie
+func fetchToken(r *router.Engine) (string, *http.Cookie) {
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, httptest.NewRequest("GET", "/form", nil))
+
+    cookies := w.Result().Cookies()
+    if len(cookies) == 0 {
+        return w.Body.String(), nil
+    }
+
+    return w.Body.String(), cookies[0]
+}
+
+func postForm(r *router.Engine, path string, cookie *http.Cookie, field string, header string) *httptest.ResponseRecorder {
+    form := url.Values{}
+    if field != "" {
+        form.Set("_token", field)
+    }
+
+    req := httptest.NewRequest("POST", path, strings.NewReader(form.Encode()))
+    req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+    if header != "" {
+        req.Header.Set("X-CSRF-Token", header)
+    }
+    if cookie != nil {
+        req.AddCookie(cookie)
+    }
+
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+
+    return w
+}
+
+func Test_CSRFDoubleSubmit(t *testing.T) {
+    eq := assertEqualT(t)
+
+    r := newCSRFEngine(t, CSRFOptions{
+        Secret: []byte("secret"),
+        Except: []string{"/api/"},
+    })
+
+    token, cookie := fetchToken(r)
+    eq(cookie != nil && cookie.Value == token, true, "cookie token")
+    eq(cookie.HttpOnly, false, "cookie readable")
+
+    eq(postForm(r, "/form", cookie, token, "").Code, http.StatusOK, "form field")
+    eq(postForm(r, "/form", cookie, "", token).Code, http.StatusOK, "header")
+    eq(postForm(r, "/form", cookie, "", "").Code, http.StatusForbidden, "missing")
+    eq(postForm(r, "/form", nil, token, "").Code, http.StatusForbidden, "without cookie")
+    eq(postForm(r, "/api/hook", nil, "", "").Code, http.StatusOK, "except")
+
+    // 伪造的 cookie 签名无效
+    forged := &http.Cookie{Name: cookie.Name, Value: "abc.def"}
+    eq(postForm(r, "/form", forged, "abc.def", "").Code, http.StatusForbidden, "forged")
+}
+
+func Test_CSRFSynchronizer(t *testing.T) {
+    eq := assertEqualT(t)
+
+    c := cache.New(memory.New(memory.Config{}), cache.Config{"type": "memory"})
+    r := newCSRFEngine(t, CSRFOptions{
+        Mode:  CSRFSynchronizer,
+        Cache: c,
+    })
+
+    token, cookie := fetchToken(r)
+    eq(cookie != nil && cookie.Value != token, true, "cookie is session id")
+    eq(cookie.HttpOnly, true, "cookie http only")
+
+    eq(postForm(r, "/form", cookie, token, "").Code, http.StatusOK, "valid")
+    eq(postForm(r, "/form", cookie, "wrong", "").Code, http.StatusForbidden, "wrong token")
+    eq(postForm(r, "/form", nil, token, "").Code, http.StatusForbidden, "without session")
+
+    _, err := CSRF(CSRFOptions{Mode: CSRFSynchronizer})
+    eq(err, ErrCSRFCacheRequired, "cache required")
+
+    _, err = CSRF(CSRFOptions{Mode: "other"})
+    eq(err, ErrCSRFModeInvalid, "mode invalid")
+}
+
+func Test_ViewData(t *testing.T) {
+    eq := assertEqualT(t)
+
+    router.SetMode(router.ReleaseMode)
+
+    var merged any
+
+    handler, _ := CSRF(CSRFOptions{Secret: []byte("secret")})
+
+    r := router.New()
+    r.Use(handler)
+    r.GET("/", func(ctx *router.Context) {
+        merged = data.Merge(ctx, router.H{"msg": "hello"})
+    })
+
+    r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+
+    m := merged.(map[string]any)
+    eq(m["msg"], "hello", "keep data")
+    eq(m[CSRFFieldNameKey], "_token", "field name")
+    eq(strings.HasPrefix(m[CSRFFieldKey].(string), `<input type="hidden" name="_token" value="`), true, "field")
+}
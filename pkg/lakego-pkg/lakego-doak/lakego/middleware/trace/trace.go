@@ -0,0 +1,106 @@
+package trace
+
+import (
+    "net/http"
+
+    "github.com/deatil/lakego-doak/lakego/trace"
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/logger"
+    "github.com/deatil/lakego-doak/lakego/middleware/requestid"
+)
+
+/**
+ * 请求链路追踪，支持 W3C traceparent 传递
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Handler() router.HandlerFunc {
+    return func(ctx *router.Context) {
+        tracer := trace.Default()
+        if tracer == nil {
+            ctx.Next()
+            return
+        }
+
+        req := ctx.Request
+
+        // 使用路由模板作为名称，避免名称过多
+        name := req.Method
+        route := ctx.FullPath()
+        if route != "" {
+            name += " " + route
+        }
+
+        attributes := map[string]any{
+            "http.method":         req.Method,
+            "http.target":         req.URL.Path,
+            "http.scheme":         scheme(req),
+            "net.host.name":       req.Host,
+            "net.sock.peer.addr":  router.GetRequestIp(ctx),
+            "user_agent.original": req.UserAgent(),
+        }
+
+        if route != "" {
+            attributes["http.route"] = route
+        }
+
+        if id := requestid.Get(ctx); id != "" {
+            attributes["http.request_id"] = id
+        }
+
+        reqCtx := trace.Extract(req.Context(), req.Header)
+        reqCtx, span := tracer.Start(reqCtx, name,
+            trace.WithSpanKind(trace.SpanKindServer),
+            trace.WithSpanAttributes(attributes),
+        )
+
+        // 日志记录链路 ID
+        traceID := span.SpanContext().TraceID.String()
+        ctx.Set(logger.TraceIDKey, traceID)
+        reqCtx = logger.NewContext(reqCtx, logger.Fields{
+            logger.TraceIDKey: traceID,
+        })
+
+        ctx.Request = req.WithContext(reqCtx)
+
+        defer func() {
+            // 异常由外层的 recovery 处理
+            if r := recover(); r != nil {
+                span.SetAttribute("http.status_code", http.StatusInternalServerError)
+                span.SetStatus(trace.StatusError, "panic")
+                span.End()
+
+                panic(r)
+            }
+        }()
+
+        ctx.Next()
+
+        status := ctx.Writer.Status()
+        span.SetAttribute("http.status_code", status)
+
+        if len(ctx.Errors) > 0 {
+            span.RecordError(ctx.Errors.Last())
+        }
+
+        if status >= http.StatusInternalServerError {
+            span.SetStatus(trace.StatusError, http.StatusText(status))
+        }
+
+        span.End()
+    }
+}
+
+// 请求协议
+func scheme(req *http.Request) string {
+    if req.TLS != nil {
+        return "https"
+    }
+
+    if proto := req.Header.Get("X-Forwarded-Proto"); proto != "" {
+        return proto
+    }
+
+    return "http"
+}
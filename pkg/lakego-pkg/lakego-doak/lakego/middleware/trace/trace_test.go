@@ -0,0 +1,84 @@
+package trace
+
+import (
+    "reflect"
+    "testing"
+    "net/http"
+    "net/http/httptest"
+
+    "github.com/deatil/lakego-doak/lakego/trace"
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/logger"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func Test_Handler(t *testing.T) {
+    eq := assertEqualT(t)
+
+    exporter := trace.NewMemoryExporter()
+    trace.SetDefault(trace.NewTracer(trace.NewSimpleProcessor(exporter)))
+    defer trace.SetDefault(nil)
+
+    router.SetMode(router.TestMode)
+
+    var fields logger.Fields
+    var childParent trace.SpanID
+
+    r := router.New()
+    r.Use(Handler())
+    r.GET("/user/:id", func(ctx *router.Context) {
+        fields = logger.FromContext(ctx)
+
+        _, child := trace.StartChild(ctx.Request.Context(), "child")
+        childParent = trace.SpanContextFromContext(ctx.Request.Context()).SpanID
+        child.End()
+
+        ctx.String(http.StatusInternalServerError, "error")
+    })
+
+    req := httptest.NewRequest("GET", "/user/1", nil)
+    req.Header.Set(trace.TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+
+    span, ok := exporter.FindSpan("GET /user/:id")
+    eq(ok, true, "server span")
+    eq(span.Kind, trace.SpanKindServer, "kind")
+    eq(span.SpanContext.TraceID.String(), "4bf92f3577b34da6a3ce929d0e0e4736", "trace id")
+    eq(span.ParentSpanID.String(), "00f067aa0ba902b7", "remote parent")
+    eq(span.Attributes["http.route"], "/user/:id", "route")
+    eq(span.Attributes["http.status_code"], 500, "status code")
+    eq(span.StatusCode, trace.StatusError, "status")
+
+    child, ok := exporter.FindSpan("child")
+    eq(ok, true, "child span")
+    eq(child.ParentSpanID, childParent, "child parent")
+    eq(childParent, span.SpanContext.SpanID, "child of server span")
+
+    eq(fields[logger.TraceIDKey], "4bf92f3577b34da6a3ce929d0e0e4736", "logger trace id")
+}
+
+func Test_HandlerDisabled(t *testing.T) {
+    eq := assertEqualT(t)
+
+    router.SetMode(router.TestMode)
+
+    r := router.New()
+    r.Use(Handler())
+    r.GET("/", func(ctx *router.Context) {
+        ctx.String(http.StatusOK, "ok")
+    })
+
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+    eq(w.Code, http.StatusOK, "status")
+}
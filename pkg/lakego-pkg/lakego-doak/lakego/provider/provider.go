@@ -1,11 +1,14 @@
 package provider
 
 import (
+    "time"
     "path/filepath"
 
     "github.com/deatil/lakego-filesystem/filesystem"
 
+    "github.com/deatil/lakego-doak/lakego/i18n"
     "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/health"
     "github.com/deatil/lakego-doak/lakego/publish"
     "github.com/deatil/lakego-doak/lakego/command"
     "github.com/deatil/lakego-doak/lakego/facade"
@@ -163,6 +166,25 @@ func (this *ServiceProvider) LoadViewsFrom(path string, namespace string) {
     viewFinder.AddNamespace(namespace, []string{path})
 }
 
+// 注册语言包，目录下文件名为语言，如 en.yml、zh-CN.json
+func (this *ServiceProvider) LoadTranslationsFrom(path string, namespace string) {
+    translator := i18n.Default()
+
+    if err := translator.LoadDir(namespace, path_tool.FormatPath(path)); err != nil {
+        facade.Logger.Error("i18n: " + err.Error())
+    }
+
+    // 应用中的语言包覆盖包的语言包
+    langPath := facade.Config("i18n").GetString("path")
+    if langPath != "" {
+        appPath := path_tool.FormatPath(langPath) + "/pkg/" + namespace
+
+        if err := translator.LoadDir(namespace, appPath); err != nil {
+            facade.Logger.Error("i18n: " + err.Error())
+        }
+    }
+}
+
 // 添加视图用方法
 func (this *ServiceProvider) AddViewFunc(name string, fn any) {
     view_func.AddFunc(name, fn)
@@ -173,6 +195,11 @@ func (this *ServiceProvider) Publishes(obj any, paths map[string]string, group s
     publish.Instance().Publish(obj, paths, group)
 }
 
+// 添加健康检测
+func (this *ServiceProvider) AddHealthChecker(checker health.Checker, timeout ...time.Duration) {
+    health.Register(checker, timeout...)
+}
+
 // 注册
 func (this *ServiceProvider) Register() {
     // 注册
@@ -0,0 +1,68 @@
+package queue
+
+import (
+    "time"
+    "errors"
+)
+
+// 重试间隔，attempts 为已执行次数
+type Backoff func(attempts int) time.Duration
+
+// 固定间隔
+func ConstantBackoff(delay time.Duration) Backoff {
+    return func(attempts int) time.Duration {
+        return delay
+    }
+}
+
+// 指数增长的间隔，base * 2^(attempts-1)，不超过 max
+func ExponentialBackoff(base time.Duration, max time.Duration) Backoff {
+    return func(attempts int) time.Duration {
+        if attempts < 1 {
+            attempts = 1
+        }
+
+        delay := base
+        for i := 1; i < attempts; i++ {
+            delay *= 2
+
+            if max > 0 && delay >= max {
+                return max
+            }
+        }
+
+        if max > 0 && delay > max {
+            return max
+        }
+
+        return delay
+    }
+}
+
+// 不再重试的错误
+type permanentError struct {
+    err error
+}
+
+func (this *permanentError) Error() string {
+    return this.err.Error()
+}
+
+func (this *permanentError) Unwrap() error {
+    return this.err
+}
+
+// 标记错误不再重试，任务直接移入失败列表
+func Permanent(err error) error {
+    if err == nil {
+        return nil
+    }
+
+    return &permanentError{err}
+}
+
+// 判断是否为不再重试的错误
+func IsPermanent(err error) bool {
+    var perr *permanentError
+    return errors.As(err, &perr)
+}
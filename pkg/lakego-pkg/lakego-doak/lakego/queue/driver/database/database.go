@@ -0,0 +1,408 @@
+package database
+
+import (
+    "time"
+    "errors"
+    "strconv"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/schema"
+
+    "github.com/deatil/lakego-doak/lakego/queue/driver"
+    "github.com/deatil/lakego-doak/lakego/queue/interfaces"
+)
+
+// 配置
+type Config struct {
+    // 数据库连接
+    DB *gorm.DB
+
+    // 任务表，不含前缀，前缀使用数据库连接配置的前缀
+    Table string
+
+    // 失败任务表，不含前缀
+    FailedTable string
+
+    // 保留超时时间，超时未完成的任务重新放回队列
+    RetryAfter time.Duration
+}
+
+// 任务数据
+type JobModel struct {
+    ID          uint64 `gorm:"column:id;primaryKey;autoIncrement;"`
+    Queue       string `gorm:"column:queue;size:191;not null;index:idx_queue_available,priority:1;"`
+    Name        string `gorm:"column:name;size:191;not null;"`
+    Payload     []byte `gorm:"column:payload;"`
+    Attempts    int    `gorm:"column:attempts;not null;default:0;"`
+    MaxAttempts int    `gorm:"column:max_attempts;not null;default:0;"`
+    Timeout     int64  `gorm:"column:timeout;not null;default:0;"`
+    Error       string `gorm:"column:error;type:text;"`
+    ReservedAt  int64  `gorm:"column:reserved_at;not null;default:0;"`
+    AvailableAt int64  `gorm:"column:available_at;not null;default:0;index:idx_queue_available,priority:2;"`
+    CreatedAt   int64  `gorm:"column:created_at;not null;default:0;autoCreateTime:false;"`
+}
+
+// 失败任务数据
+type FailedJobModel struct {
+    ID          uint64 `gorm:"column:id;primaryKey;autoIncrement;"`
+    Queue       string `gorm:"column:queue;size:191;not null;index;"`
+    Name        string `gorm:"column:name;size:191;not null;"`
+    Payload     []byte `gorm:"column:payload;"`
+    Attempts    int    `gorm:"column:attempts;not null;default:0;"`
+    MaxAttempts int    `gorm:"column:max_attempts;not null;default:0;"`
+    Timeout     int64  `gorm:"column:timeout;not null;default:0;"`
+    Error       string `gorm:"column:error;type:text;"`
+    CreatedAt   int64  `gorm:"column:created_at;not null;default:0;autoCreateTime:false;"`
+    FailedAt    int64  `gorm:"column:failed_at;not null;default:0;"`
+}
+
+/**
+ * 数据库队列
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Database struct {
+    // 数据库
+    db *gorm.DB
+
+    // 任务表
+    table string
+
+    // 失败任务表
+    failedTable string
+
+    // 保留超时时间
+    retryAfter time.Duration
+
+    // 当前时间，测试用
+    now func() time.Time
+}
+
+// 构造函数
+func New(config Config) *Database {
+    table := config.Table
+    if table == "" {
+        table = "jobs"
+    }
+
+    failedTable := config.FailedTable
+    if failedTable == "" {
+        failedTable = "failed_jobs"
+    }
+
+    retryAfter := config.RetryAfter
+    if retryAfter <= 0 {
+        retryAfter = 90 * time.Second
+    }
+
+    prefix := tablePrefix(config.DB)
+
+    return &Database{
+        db:          config.DB,
+        table:       prefix + table,
+        failedTable: prefix + failedTable,
+        retryAfter:  retryAfter,
+        now:         time.Now,
+    }
+}
+
+// 创建数据表，由服务提供者或 lakego:queue-work 调用
+func (this *Database) Migrate() error {
+    if err := this.db.Table(this.table).AutoMigrate(&JobModel{}); err != nil {
+        return err
+    }
+
+    return this.db.Table(this.failedTable).AutoMigrate(&FailedJobModel{})
+}
+
+// 推送任务
+func (this *Database) Push(job *interfaces.Job) error {
+    model := JobModel{
+        Queue:       job.Queue,
+        Name:        job.Name,
+        Payload:     job.Payload,
+        Attempts:    job.Attempts,
+        MaxAttempts: job.MaxAttempts,
+        Timeout:     int64(job.Timeout),
+        Error:       job.Error,
+        AvailableAt: job.AvailableAt.UnixNano(),
+        CreatedAt:   job.CreatedAt.UnixNano(),
+    }
+
+    if err := this.db.Table(this.table).Create(&model).Error; err != nil {
+        return err
+    }
+
+    job.ID = formatID(model.ID)
+
+    return nil
+}
+
+// 取出任务
+// 使用保留时间做乐观锁，多个进程同时取出时只有一个成功
+func (this *Database) Pop(queue string) (*interfaces.Job, error) {
+    for i := 0; i < 5; i++ {
+        now := this.now()
+
+        var model JobModel
+        err := this.db.Table(this.table).
+            Where("queue = ?", queue).
+            Where(
+                this.db.Where("reserved_at = 0 AND available_at <= ?", now.UnixNano()).
+                    Or("reserved_at > 0 AND reserved_at <= ?", now.Add(-this.retryAfter).UnixNano()),
+            ).
+            Order("available_at ASC, id ASC").
+            Take(&model).
+            Error
+        if err != nil {
+            if errors.Is(err, gorm.ErrRecordNotFound) {
+                return nil, nil
+            }
+
+            return nil, err
+        }
+
+        res := this.db.Table(this.table).
+            Where("id = ? AND reserved_at = ?", model.ID, model.ReservedAt).
+            Updates(map[string]any{
+                "reserved_at": now.UnixNano(),
+                "attempts":    gorm.Expr("attempts + 1"),
+            })
+        if res.Error != nil {
+            return nil, res.Error
+        }
+
+        if res.RowsAffected == 1 {
+            model.Attempts++
+
+            return toJob(model), nil
+        }
+    }
+
+    return nil, nil
+}
+
+// 删除任务
+func (this *Database) Delete(job *interfaces.Job) error {
+    id, err := parseID(job.ID)
+    if err != nil {
+        return err
+    }
+
+    return this.db.Table(this.table).
+        Where("id = ?", id).
+        Delete(&JobModel{}).
+        Error
+}
+
+// 放回队列
+func (this *Database) Release(job *interfaces.Job, delay time.Duration) error {
+    id, err := parseID(job.ID)
+    if err != nil {
+        return err
+    }
+
+    return this.db.Table(this.table).
+        Where("id = ?", id).
+        Updates(map[string]any{
+            "reserved_at":  0,
+            "available_at": this.now().Add(delay).UnixNano(),
+            "error":        job.Error,
+        }).
+        Error
+}
+
+// 移入失败列表
+func (this *Database) Fail(job *interfaces.Job) error {
+    id, err := parseID(job.ID)
+    if err != nil {
+        return err
+    }
+
+    failedAt := job.FailedAt
+    if failedAt.IsZero() {
+        failedAt = this.now()
+    }
+
+    return this.db.Transaction(func(tx *gorm.DB) error {
+        failed := FailedJobModel{
+            Queue:       job.Queue,
+            Name:        job.Name,
+            Payload:     job.Payload,
+            Attempts:    job.Attempts,
+            MaxAttempts: job.MaxAttempts,
+            Timeout:     int64(job.Timeout),
+            Error:       job.Error,
+            CreatedAt:   job.CreatedAt.UnixNano(),
+            FailedAt:    failedAt.UnixNano(),
+        }
+
+        if err := tx.Table(this.failedTable).Create(&failed).Error; err != nil {
+            return err
+        }
+
+        return tx.Table(this.table).
+            Where("id = ?", id).
+            Delete(&JobModel{}).
+            Error
+    })
+}
+
+// 任务数量
+func (this *Database) Size(queue string) (int64, error) {
+    var count int64
+
+    err := this.db.Table(this.table).
+        Where("queue = ?", queue).
+        Count(&count).
+        Error
+
+    return count, err
+}
+
+// 失败列表
+func (this *Database) Failed(queue string) ([]*interfaces.Job, error) {
+    query := this.db.Table(this.failedTable)
+    if queue != "" {
+        query = query.Where("queue = ?", queue)
+    }
+
+    var models []FailedJobModel
+    err := query.Order("id DESC").Find(&models).Error
+    if err != nil {
+        return nil, err
+    }
+
+    jobs := make([]*interfaces.Job, 0, len(models))
+    for _, model := range models {
+        jobs = append(jobs, toFailedJob(model))
+    }
+
+    return jobs, nil
+}
+
+// 重试失败任务
+func (this *Database) Retry(id string) error {
+    failedID, err := parseID(id)
+    if err != nil {
+        return driver.ErrNotFound
+    }
+
+    return this.db.Transaction(func(tx *gorm.DB) error {
+        var failed FailedJobModel
+
+        err := tx.Table(this.failedTable).
+            Where("id = ?", failedID).
+            Take(&failed).
+            Error
+        if err != nil {
+            if errors.Is(err, gorm.ErrRecordNotFound) {
+                return driver.ErrNotFound
+            }
+
+            return err
+        }
+
+        model := JobModel{
+            Queue:       failed.Queue,
+            Name:        failed.Name,
+            Payload:     failed.Payload,
+            MaxAttempts: failed.MaxAttempts,
+            Timeout:     failed.Timeout,
+            AvailableAt: this.now().UnixNano(),
+            CreatedAt:   failed.CreatedAt,
+        }
+
+        if err := tx.Table(this.table).Create(&model).Error; err != nil {
+            return err
+        }
+
+        return tx.Table(this.failedTable).
+            Where("id = ?", failedID).
+            Delete(&FailedJobModel{}).
+            Error
+    })
+}
+
+// 删除失败任务
+func (this *Database) Forget(id string) error {
+    failedID, err := parseID(id)
+    if err != nil {
+        return driver.ErrNotFound
+    }
+
+    res := this.db.Table(this.failedTable).
+        Where("id = ?", failedID).
+        Delete(&FailedJobModel{})
+    if res.Error != nil {
+        return res.Error
+    }
+
+    if res.RowsAffected == 0 {
+        return driver.ErrNotFound
+    }
+
+    return nil
+}
+
+// 任务表名
+func (this *Database) GetTable() string {
+    return this.table
+}
+
+// 失败任务表名
+func (this *Database) GetFailedTable() string {
+    return this.failedTable
+}
+
+// 数据库连接配置的表前缀
+func tablePrefix(db *gorm.DB) string {
+    if db == nil {
+        return ""
+    }
+
+    if naming, ok := db.NamingStrategy.(schema.NamingStrategy); ok {
+        return naming.TablePrefix
+    }
+
+    return ""
+}
+
+func toJob(model JobModel) *interfaces.Job {
+    return &interfaces.Job{
+        ID:          formatID(model.ID),
+        Queue:       model.Queue,
+        Name:        model.Name,
+        Payload:     model.Payload,
+        Attempts:    model.Attempts,
+        MaxAttempts: model.MaxAttempts,
+        Timeout:     time.Duration(model.Timeout),
+        Error:       model.Error,
+        AvailableAt: time.Unix(0, model.AvailableAt),
+        CreatedAt:   time.Unix(0, model.CreatedAt),
+    }
+}
+
+func toFailedJob(model FailedJobModel) *interfaces.Job {
+    return &interfaces.Job{
+        ID:          formatID(model.ID),
+        Queue:       model.Queue,
+        Name:        model.Name,
+        Payload:     model.Payload,
+        Attempts:    model.Attempts,
+        MaxAttempts: model.MaxAttempts,
+        Timeout:     time.Duration(model.Timeout),
+        Error:       model.Error,
+        CreatedAt:   time.Unix(0, model.CreatedAt),
+        FailedAt:    time.Unix(0, model.FailedAt),
+    }
+}
+
+func formatID(id uint64) string {
+    return strconv.FormatUint(id, 10)
+}
+
+func parseID(id string) (uint64, error) {
+    return strconv.ParseUint(id, 10, 64)
+}
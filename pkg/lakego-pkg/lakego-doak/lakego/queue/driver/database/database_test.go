@@ -0,0 +1,267 @@
+package database
+
+import (
+    "io"
+    "sync"
+    "context"
+    "errors"
+    "reflect"
+    "strings"
+    "testing"
+    "database/sql"
+    sqlDriver "database/sql/driver"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/logger"
+    "gorm.io/gorm/schema"
+    "gorm.io/driver/mysql"
+
+    "github.com/deatil/lakego-doak/lakego/queue/driver"
+    "github.com/deatil/lakego-doak/lakego/queue/interfaces"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+var errCreateTable = errors.New("create table denied")
+
+// 记录执行的语句，不连接真实数据库
+type recorder struct {
+    mu    sync.Mutex
+    stmts []string
+}
+
+func (this *recorder) add(query string) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.stmts = append(this.stmts, query)
+}
+
+func (this *recorder) all() []string {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    return append([]string{}, this.stmts...)
+}
+
+type fakeConn struct {
+    rec *recorder
+}
+
+func (this fakeConn) Prepare(query string) (sqlDriver.Stmt, error) {
+    return fakeStmt{this.rec, query}, nil
+}
+
+func (this fakeConn) Close() error {
+    return nil
+}
+
+func (this fakeConn) Begin() (sqlDriver.Tx, error) {
+    this.rec.add("BEGIN")
+    return fakeTx{this.rec}, nil
+}
+
+type fakeTx struct {
+    rec *recorder
+}
+
+func (this fakeTx) Commit() error {
+    this.rec.add("COMMIT")
+    return nil
+}
+
+func (this fakeTx) Rollback() error {
+    this.rec.add("ROLLBACK")
+    return nil
+}
+
+type fakeStmt struct {
+    rec   *recorder
+    query string
+}
+
+func (this fakeStmt) Close() error {
+    return nil
+}
+
+func (this fakeStmt) NumInput() int {
+    return -1
+}
+
+func (this fakeStmt) Exec(args []sqlDriver.Value) (sqlDriver.Result, error) {
+    this.rec.add(this.query)
+
+    if strings.HasPrefix(this.query, "CREATE TABLE") {
+        return nil, errCreateTable
+    }
+
+    return fakeResult{}, nil
+}
+
+func (this fakeStmt) Query(args []sqlDriver.Value) (sqlDriver.Rows, error) {
+    this.rec.add(this.query)
+
+    return fakeRows{}, nil
+}
+
+type fakeResult struct{}
+
+func (fakeResult) LastInsertId() (int64, error) {
+    return 1, nil
+}
+
+func (fakeResult) RowsAffected() (int64, error) {
+    return 1, nil
+}
+
+type fakeRows struct{}
+
+func (fakeRows) Columns() []string {
+    return []string{}
+}
+
+func (fakeRows) Close() error {
+    return nil
+}
+
+func (fakeRows) Next(dest []sqlDriver.Value) error {
+    return io.EOF
+}
+
+type fakeDriver struct {
+    rec *recorder
+}
+
+func (this fakeDriver) Open(name string) (sqlDriver.Conn, error) {
+    return fakeConn{this.rec}, nil
+}
+
+type driverConnector struct {
+    driver fakeDriver
+}
+
+func (this driverConnector) Connect(_ context.Context) (sqlDriver.Conn, error) {
+    return this.driver.Open("")
+}
+
+func (this driverConnector) Driver() sqlDriver.Driver {
+    return this.driver
+}
+
+func newTestDB(t *testing.T, prefix string) (*gorm.DB, *recorder) {
+    rec := &recorder{}
+
+    conn := sql.OpenDB(driverConnector{fakeDriver{rec}})
+    t.Cleanup(func() {
+        conn.Close()
+    })
+
+    db, err := gorm.Open(mysql.New(mysql.Config{
+        Conn:                      conn,
+        SkipInitializeWithVersion: true,
+    }), &gorm.Config{
+        SkipDefaultTransaction: true,
+        Logger:                 logger.Default.LogMode(logger.Silent),
+        NamingStrategy: schema.NamingStrategy{
+            SingularTable: true,
+            TablePrefix:   prefix,
+        },
+    })
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    return db, rec
+}
+
+func Test_NewWithoutMigrate(t *testing.T) {
+    assertEqual := assertEqualT(t)
+
+    db, rec := newTestDB(t, "lakego_")
+
+    d := New(Config{
+        DB: db,
+    })
+
+    assertEqual(len(rec.all()), 0, "New run no sql")
+    assertEqual(d.GetTable(), "lakego_jobs", "GetTable")
+    assertEqual(d.GetFailedTable(), "lakego_failed_jobs", "GetFailedTable")
+
+    d2 := New(Config{
+        DB:          db,
+        Table:       "queue_jobs",
+        FailedTable: "queue_failed",
+    })
+    assertEqual(d2.GetTable(), "lakego_queue_jobs", "GetTable custom")
+    assertEqual(d2.GetFailedTable(), "lakego_queue_failed", "GetFailedTable custom")
+}
+
+func Test_MigrateError(t *testing.T) {
+    db, rec := newTestDB(t, "lakego_")
+
+    err := New(Config{DB: db}).Migrate()
+    if !errors.Is(err, errCreateTable) {
+        t.Fatalf("Migrate error: %v", err)
+    }
+
+    found := false
+    for _, stmt := range rec.all() {
+        if strings.HasPrefix(stmt, "CREATE TABLE `lakego_jobs`") {
+            found = true
+        }
+    }
+
+    if !found {
+        t.Errorf("Migrate should create prefixed table, sql: %v", rec.all())
+    }
+}
+
+func Test_PrefixedQueries(t *testing.T) {
+    assertEqual := assertEqualT(t)
+
+    db, rec := newTestDB(t, "lakego_")
+
+    d := New(Config{DB: db})
+
+    job, err := d.Pop("default")
+    assertEqual(err, nil, "Pop error")
+    assertEqual(job == nil, true, "Pop empty")
+
+    size, err := d.Size("default")
+    assertEqual(err, nil, "Size error")
+    assertEqual(size, int64(0), "Size")
+
+    err = d.Fail(&interfaces.Job{ID: "1", Queue: "default", Name: "mail"})
+    assertEqual(err, nil, "Fail error")
+
+    stmts := rec.all()
+    if len(stmts) == 0 {
+        t.Fatal("no sql run")
+    }
+
+    for _, stmt := range stmts {
+        if stmt == "BEGIN" || stmt == "COMMIT" || stmt == "ROLLBACK" {
+            continue
+        }
+
+        if !strings.Contains(stmt, "`lakego_jobs`") && !strings.Contains(stmt, "`lakego_failed_jobs`") {
+            t.Errorf("sql without table prefix: %s", stmt)
+        }
+    }
+
+    // 失败任务在事务中移动
+    assertEqual(stmts[len(stmts) - 1], "COMMIT", "Fail commit")
+
+    pushed := &interfaces.Job{Queue: "default", Name: "mail"}
+    assertEqual(d.Push(pushed), nil, "Push error")
+    assertEqual(pushed.ID, "1", "Push id")
+
+    assertEqual(d.Retry("abc"), driver.ErrNotFound, "Retry bad id")
+    assertEqual(d.Forget("abc"), driver.ErrNotFound, "Forget bad id")
+}
@@ -0,0 +1,24 @@
+package driver
+
+import (
+    "errors"
+
+    "github.com/deatil/lakego-doak/lakego/queue/interfaces"
+)
+
+var (
+    // 任务不存在
+    ErrNotFound = errors.New("queue: job not found")
+)
+
+// 复制任务，避免驱动内数据被外部修改
+func Clone(job *interfaces.Job) *interfaces.Job {
+    if job == nil {
+        return nil
+    }
+
+    newJob := *job
+    newJob.Payload = append([]byte(nil), job.Payload...)
+
+    return &newJob
+}
@@ -0,0 +1,251 @@
+package memory
+
+import (
+    "sort"
+    "sync"
+    "time"
+    "strconv"
+
+    "github.com/deatil/lakego-doak/lakego/queue/driver"
+    "github.com/deatil/lakego-doak/lakego/queue/interfaces"
+)
+
+// 配置
+type Config struct {
+    // 保留超时时间，超时未完成的任务重新放回队列
+    RetryAfter time.Duration
+}
+
+// 队列中的任务
+type item struct {
+    job *interfaces.Job
+
+    // 保留时间，零值为未保留
+    reservedAt time.Time
+}
+
+/**
+ * 内存队列，只在当前进程有效
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Memory struct {
+    // 锁定
+    mu sync.Mutex
+
+    // 任务 ID
+    id uint64
+
+    // 任务列表
+    items map[string]*item
+
+    // 失败列表
+    failed map[string]*interfaces.Job
+
+    // 保留超时时间
+    retryAfter time.Duration
+
+    // 当前时间，测试用
+    now func() time.Time
+}
+
+// 构造函数
+func New(config ...Config) *Memory {
+    var conf Config
+    if len(config) > 0 {
+        conf = config[0]
+    }
+
+    return &Memory{
+        items:      make(map[string]*item),
+        failed:     make(map[string]*interfaces.Job),
+        retryAfter: conf.RetryAfter,
+        now:        time.Now,
+    }
+}
+
+// 设置当前时间
+func (this *Memory) WithNow(fn func() time.Time) *Memory {
+    this.now = fn
+
+    return this
+}
+
+// 推送任务
+func (this *Memory) Push(job *interfaces.Job) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.id++
+    job.ID = strconv.FormatUint(this.id, 10)
+
+    this.items[job.ID] = &item{
+        job: driver.Clone(job),
+    }
+
+    return nil
+}
+
+// 取出任务
+func (this *Memory) Pop(queue string) (*interfaces.Job, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    now := this.now()
+
+    var found *item
+    for _, it := range this.items {
+        if it.job.Queue != queue || !this.available(it, now) {
+            continue
+        }
+
+        if found == nil || before(it.job, found.job) {
+            found = it
+        }
+    }
+
+    if found == nil {
+        return nil, nil
+    }
+
+    found.reservedAt = now
+    found.job.Attempts++
+
+    return driver.Clone(found.job), nil
+}
+
+// 删除任务
+func (this *Memory) Delete(job *interfaces.Job) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    delete(this.items, job.ID)
+
+    return nil
+}
+
+// 放回队列
+func (this *Memory) Release(job *interfaces.Job, delay time.Duration) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    newJob := driver.Clone(job)
+    newJob.AvailableAt = this.now().Add(delay)
+
+    this.items[job.ID] = &item{
+        job: newJob,
+    }
+
+    return nil
+}
+
+// 移入失败列表
+func (this *Memory) Fail(job *interfaces.Job) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    delete(this.items, job.ID)
+
+    failed := driver.Clone(job)
+    if failed.FailedAt.IsZero() {
+        failed.FailedAt = this.now()
+    }
+
+    this.failed[job.ID] = failed
+
+    return nil
+}
+
+// 任务数量
+func (this *Memory) Size(queue string) (int64, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    var size int64
+    for _, it := range this.items {
+        if it.job.Queue == queue {
+            size++
+        }
+    }
+
+    return size, nil
+}
+
+// 失败列表，按失败时间倒序
+func (this *Memory) Failed(queue string) ([]*interfaces.Job, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    jobs := make([]*interfaces.Job, 0)
+    for _, job := range this.failed {
+        if queue == "" || job.Queue == queue {
+            jobs = append(jobs, driver.Clone(job))
+        }
+    }
+
+    sort.Slice(jobs, func(i, j int) bool {
+        return jobs[i].FailedAt.After(jobs[j].FailedAt)
+    })
+
+    return jobs, nil
+}
+
+// 重试失败任务
+func (this *Memory) Retry(id string) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    job, ok := this.failed[id]
+    if !ok {
+        return driver.ErrNotFound
+    }
+
+    delete(this.failed, id)
+
+    job.Attempts = 0
+    job.Error = ""
+    job.FailedAt = time.Time{}
+    job.AvailableAt = this.now()
+
+    this.items[id] = &item{
+        job: job,
+    }
+
+    return nil
+}
+
+// 删除失败任务
+func (this *Memory) Forget(id string) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if _, ok := this.failed[id]; !ok {
+        return driver.ErrNotFound
+    }
+
+    delete(this.failed, id)
+
+    return nil
+}
+
+// 是否可取出
+func (this *Memory) available(it *item, now time.Time) bool {
+    if !it.reservedAt.IsZero() {
+        return this.retryAfter > 0 && !now.Before(it.reservedAt.Add(this.retryAfter))
+    }
+
+    return !now.Before(it.job.AvailableAt)
+}
+
+// 按可执行时间和推送顺序排序
+func before(a, b *interfaces.Job) bool {
+    if !a.AvailableAt.Equal(b.AvailableAt) {
+        return a.AvailableAt.Before(b.AvailableAt)
+    }
+
+    ai, _ := strconv.ParseUint(a.ID, 10, 64)
+    bi, _ := strconv.ParseUint(b.ID, 10, 64)
+
+    return ai < bi
+}
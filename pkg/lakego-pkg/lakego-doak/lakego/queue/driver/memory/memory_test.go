@@ -0,0 +1,72 @@
+package memory
+
+import (
+    "time"
+    "reflect"
+    "testing"
+
+    "github.com/deatil/lakego-doak/lakego/queue/driver"
+    "github.com/deatil/lakego-doak/lakego/queue/interfaces"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func Test_Memory(t *testing.T) {
+    assertEqual := assertEqualT(t)
+
+    now := time.Unix(1700000000, 0)
+    m := New(Config{
+        RetryAfter: time.Minute,
+    }).WithNow(func() time.Time {
+        return now
+    })
+
+    m.Push(&interfaces.Job{Queue: "a", Name: "first", AvailableAt: now})
+    m.Push(&interfaces.Job{Queue: "a", Name: "second", AvailableAt: now})
+    m.Push(&interfaces.Job{Queue: "b", Name: "other", AvailableAt: now})
+
+    job, _ := m.Pop("a")
+    assertEqual(job.Name, "first", "Pop order")
+    assertEqual(job.Attempts, 1, "Pop attempts")
+
+    // 修改取出的任务不影响队列数据
+    job.Name = "changed"
+
+    second, _ := m.Pop("a")
+    assertEqual(second.Name, "second", "Pop second")
+
+    empty, _ := m.Pop("a")
+    assertEqual(empty == nil, true, "Pop reserved")
+
+    // 保留超时后重新取出
+    now = now.Add(time.Minute)
+    job, _ = m.Pop("a")
+    assertEqual(job.Name, "first", "Pop after retry-after")
+    assertEqual(job.Attempts, 2, "Pop attempts after retry-after")
+
+    size, _ := m.Size("a")
+    assertEqual(size, int64(2), "Size")
+
+    m.Delete(job)
+    size, _ = m.Size("a")
+    assertEqual(size, int64(1), "Size after Delete")
+
+    assertEqual(m.Retry("none"), driver.ErrNotFound, "Retry not found")
+    assertEqual(m.Forget("none"), driver.ErrNotFound, "Forget not found")
+
+    other, _ := m.Pop("b")
+    m.Fail(other)
+
+    failed, _ := m.Failed("b")
+    assertEqual(len(failed), 1, "Failed")
+    assertEqual(failed[0].FailedAt, now, "Failed at")
+
+    failed, _ = m.Failed("a")
+    assertEqual(len(failed), 0, "Failed other queue")
+}
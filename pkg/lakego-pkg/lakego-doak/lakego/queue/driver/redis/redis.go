@@ -0,0 +1,366 @@
+package redis
+
+import (
+    "sort"
+    "sync"
+    "time"
+    "context"
+    "encoding/json"
+
+    "github.com/go-redis/redis/v8"
+
+    "github.com/deatil/lakego-doak/lakego/uuid"
+    "github.com/deatil/lakego-doak/lakego/queue/driver"
+    "github.com/deatil/lakego-doak/lakego/queue/interfaces"
+)
+
+// 取出任务脚本
+// 先将到期的延迟任务和超时的保留任务移回队列，再取出任务放入保留列表
+// KEYS: 队列, 延迟列表, 保留列表, 执行次数
+// ARGV: 当前时间, 保留超时时间
+var popScript = redis.NewScript(`
+local function migrate(from, to, now)
+    local jobs = redis.call('zrangebyscore', from, '-inf', now)
+    for i, job in ipairs(jobs) do
+        redis.call('zrem', from, job)
+        redis.call('rpush', to, job)
+    end
+end
+
+migrate(KEYS[2], KEYS[1], ARGV[1])
+migrate(KEYS[3], KEYS[1], ARGV[1])
+
+local job = redis.call('lpop', KEYS[1])
+if not job then
+    return false
+end
+
+redis.call('zadd', KEYS[3], ARGV[2], job)
+
+local id = cjson.decode(job)['id']
+local attempts = redis.call('hincrby', KEYS[4], id, 1)
+
+return {job, attempts}
+`)
+
+// 放回队列脚本
+// KEYS: 保留列表, 延迟列表
+// ARGV: 保留的任务, 新任务, 可执行时间
+var releaseScript = redis.NewScript(`
+redis.call('zrem', KEYS[1], ARGV[1])
+redis.call('zadd', KEYS[2], ARGV[3], ARGV[2])
+return 1
+`)
+
+// 配置
+type Config struct {
+    // 客户端
+    Client *redis.Client
+
+    // 前缀
+    Prefix string
+
+    // 保留超时时间，超时未完成的任务重新放回队列
+    RetryAfter time.Duration
+}
+
+/**
+ * redis 队列
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Redis struct {
+    // 锁定
+    mu sync.Mutex
+
+    // 上下文
+    ctx context.Context
+
+    // 客户端
+    client *redis.Client
+
+    // 前缀
+    prefix string
+
+    // 保留超时时间
+    retryAfter time.Duration
+
+    // 保留中的任务原始数据，用于从保留列表中删除
+    reserved map[string]string
+
+    // 当前时间，测试用
+    now func() time.Time
+}
+
+// 构造函数
+func New(config Config) *Redis {
+    prefix := config.Prefix
+    if prefix == "" {
+        prefix = "lakego-queue"
+    }
+
+    retryAfter := config.RetryAfter
+    if retryAfter <= 0 {
+        retryAfter = 90 * time.Second
+    }
+
+    return &Redis{
+        ctx:        context.Background(),
+        client:     config.Client,
+        prefix:     prefix,
+        retryAfter: retryAfter,
+        reserved:   make(map[string]string),
+        now:        time.Now,
+    }
+}
+
+// 推送任务
+func (this *Redis) Push(job *interfaces.Job) error {
+    job.ID = uuid.ToUUIDString()
+
+    data, err := json.Marshal(job)
+    if err != nil {
+        return err
+    }
+
+    if job.AvailableAt.After(this.now()) {
+        return this.client.ZAdd(this.ctx, this.delayedKey(job.Queue), &redis.Z{
+            Score:  score(job.AvailableAt),
+            Member: string(data),
+        }).Err()
+    }
+
+    return this.client.RPush(this.ctx, this.queueKey(job.Queue), string(data)).Err()
+}
+
+// 取出任务
+func (this *Redis) Pop(queue string) (*interfaces.Job, error) {
+    now := this.now()
+
+    res, err := popScript.Run(this.ctx, this.client,
+        []string{
+            this.queueKey(queue),
+            this.delayedKey(queue),
+            this.reservedKey(queue),
+            this.attemptsKey(),
+        },
+        score(now),
+        score(now.Add(this.retryAfter)),
+    ).Result()
+    if err == redis.Nil {
+        return nil, nil
+    } else if err != nil {
+        return nil, err
+    }
+
+    values, ok := res.([]any)
+    if !ok || len(values) != 2 {
+        return nil, nil
+    }
+
+    raw, _ := values[0].(string)
+    attempts, _ := values[1].(int64)
+
+    var job interfaces.Job
+    if err := json.Unmarshal([]byte(raw), &job); err != nil {
+        return nil, err
+    }
+
+    job.Attempts = int(attempts)
+
+    this.setReserved(job.ID, raw)
+
+    return &job, nil
+}
+
+// 删除任务
+func (this *Redis) Delete(job *interfaces.Job) error {
+    raw := this.takeReserved(job.ID)
+
+    pipe := this.client.TxPipeline()
+    pipe.ZRem(this.ctx, this.reservedKey(job.Queue), raw)
+    pipe.HDel(this.ctx, this.attemptsKey(), job.ID)
+
+    _, err := pipe.Exec(this.ctx)
+    return err
+}
+
+// 放回队列
+func (this *Redis) Release(job *interfaces.Job, delay time.Duration) error {
+    raw := this.takeReserved(job.ID)
+
+    newJob := driver.Clone(job)
+    newJob.AvailableAt = this.now().Add(delay)
+
+    data, err := json.Marshal(newJob)
+    if err != nil {
+        return err
+    }
+
+    return releaseScript.Run(this.ctx, this.client,
+        []string{
+            this.reservedKey(job.Queue),
+            this.delayedKey(job.Queue),
+        },
+        raw,
+        string(data),
+        score(newJob.AvailableAt),
+    ).Err()
+}
+
+// 移入失败列表
+func (this *Redis) Fail(job *interfaces.Job) error {
+    raw := this.takeReserved(job.ID)
+
+    failed := driver.Clone(job)
+    if failed.FailedAt.IsZero() {
+        failed.FailedAt = this.now()
+    }
+
+    data, err := json.Marshal(failed)
+    if err != nil {
+        return err
+    }
+
+    pipe := this.client.TxPipeline()
+    pipe.ZRem(this.ctx, this.reservedKey(job.Queue), raw)
+    pipe.HDel(this.ctx, this.attemptsKey(), job.ID)
+    pipe.HSet(this.ctx, this.failedKey(), job.ID, string(data))
+
+    _, err = pipe.Exec(this.ctx)
+    return err
+}
+
+// 任务数量
+func (this *Redis) Size(queue string) (int64, error) {
+    pipe := this.client.Pipeline()
+    ready := pipe.LLen(this.ctx, this.queueKey(queue))
+    delayed := pipe.ZCard(this.ctx, this.delayedKey(queue))
+    reserved := pipe.ZCard(this.ctx, this.reservedKey(queue))
+
+    if _, err := pipe.Exec(this.ctx); err != nil {
+        return 0, err
+    }
+
+    return ready.Val() + delayed.Val() + reserved.Val(), nil
+}
+
+// 失败列表
+func (this *Redis) Failed(queue string) ([]*interfaces.Job, error) {
+    values, err := this.client.HGetAll(this.ctx, this.failedKey()).Result()
+    if err != nil {
+        return nil, err
+    }
+
+    jobs := make([]*interfaces.Job, 0, len(values))
+    for _, value := range values {
+        var job interfaces.Job
+        if err := json.Unmarshal([]byte(value), &job); err != nil {
+            continue
+        }
+
+        if queue == "" || job.Queue == queue {
+            jobs = append(jobs, &job)
+        }
+    }
+
+    sort.Slice(jobs, func(i, j int) bool {
+        return jobs[i].FailedAt.After(jobs[j].FailedAt)
+    })
+
+    return jobs, nil
+}
+
+// 重试失败任务
+func (this *Redis) Retry(id string) error {
+    value, err := this.client.HGet(this.ctx, this.failedKey(), id).Result()
+    if err == redis.Nil {
+        return driver.ErrNotFound
+    } else if err != nil {
+        return err
+    }
+
+    var job interfaces.Job
+    if err := json.Unmarshal([]byte(value), &job); err != nil {
+        return err
+    }
+
+    job.Attempts = 0
+    job.Error = ""
+    job.FailedAt = time.Time{}
+    job.AvailableAt = this.now()
+
+    data, err := json.Marshal(job)
+    if err != nil {
+        return err
+    }
+
+    pipe := this.client.TxPipeline()
+    pipe.HDel(this.ctx, this.failedKey(), id)
+    pipe.RPush(this.ctx, this.queueKey(job.Queue), string(data))
+
+    _, err = pipe.Exec(this.ctx)
+    return err
+}
+
+// 删除失败任务
+func (this *Redis) Forget(id string) error {
+    n, err := this.client.HDel(this.ctx, this.failedKey(), id).Result()
+    if err != nil {
+        return err
+    }
+
+    if n == 0 {
+        return driver.ErrNotFound
+    }
+
+    return nil
+}
+
+// 获取客户端
+func (this *Redis) GetClient() *redis.Client {
+    return this.client
+}
+
+func (this *Redis) setReserved(id string, raw string) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.reserved[id] = raw
+}
+
+func (this *Redis) takeReserved(id string) string {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    raw := this.reserved[id]
+    delete(this.reserved, id)
+
+    return raw
+}
+
+func (this *Redis) queueKey(queue string) string {
+    return this.prefix + ":queues:" + queue
+}
+
+func (this *Redis) delayedKey(queue string) string {
+    return this.queueKey(queue) + ":delayed"
+}
+
+func (this *Redis) reservedKey(queue string) string {
+    return this.queueKey(queue) + ":reserved"
+}
+
+func (this *Redis) attemptsKey() string {
+    return this.prefix + ":attempts"
+}
+
+func (this *Redis) failedKey() string {
+    return this.prefix + ":failed"
+}
+
+// 分数使用毫秒时间戳
+func score(t time.Time) float64 {
+    return float64(t.UnixMilli())
+}
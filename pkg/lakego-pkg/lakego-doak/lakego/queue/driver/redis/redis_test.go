@@ -0,0 +1,152 @@
+package redis
+
+import (
+    "time"
+    "reflect"
+    "testing"
+
+    "github.com/go-redis/redis/v8"
+    "github.com/alicebob/miniredis/v2"
+
+    "github.com/deatil/lakego-doak/lakego/queue/driver"
+    "github.com/deatil/lakego-doak/lakego/queue/interfaces"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func newTestRedis(t *testing.T, now *time.Time) *Redis {
+    server := miniredis.RunT(t)
+
+    client := redis.NewClient(&redis.Options{
+        Addr: server.Addr(),
+    })
+    t.Cleanup(func() {
+        client.Close()
+    })
+
+    r := New(Config{
+        Client:     client,
+        Prefix:     "test-queue",
+        RetryAfter: time.Minute,
+    })
+    r.now = func() time.Time {
+        return *now
+    }
+
+    return r
+}
+
+func Test_Redis(t *testing.T) {
+    assertEqual := assertEqualT(t)
+
+    now := time.Unix(1700000000, 0)
+    r := newTestRedis(t, &now)
+
+    r.Push(&interfaces.Job{Queue: "a", Name: "first", AvailableAt: now})
+    r.Push(&interfaces.Job{Queue: "a", Name: "second", AvailableAt: now})
+    r.Push(&interfaces.Job{Queue: "b", Name: "other", AvailableAt: now})
+
+    job, err := r.Pop("a")
+    assertEqual(err, nil, "Pop error")
+    assertEqual(job.Name, "first", "Pop order")
+    assertEqual(job.Attempts, 1, "Pop attempts")
+
+    now = now.Add(10 * time.Second)
+    second, _ := r.Pop("a")
+    assertEqual(second.Name, "second", "Pop second")
+
+    empty, err := r.Pop("a")
+    assertEqual(err, nil, "Pop empty error")
+    assertEqual(empty == nil, true, "Pop reserved")
+
+    size, _ := r.Size("a")
+    assertEqual(size, int64(2), "Size with reserved")
+
+    // 保留超时后重新取出
+    now = now.Add(50 * time.Second)
+    job, _ = r.Pop("a")
+    assertEqual(job.Name, "first", "Pop after retry-after")
+    assertEqual(job.Attempts, 2, "Pop attempts after retry-after")
+
+    empty, _ = r.Pop("a")
+    assertEqual(empty == nil, true, "Pop second still reserved")
+
+    assertEqual(r.Delete(job), nil, "Delete")
+    size, _ = r.Size("a")
+    assertEqual(size, int64(1), "Size after Delete")
+
+    size, _ = r.Size("b")
+    assertEqual(size, int64(1), "Size other queue")
+}
+
+func Test_Delay(t *testing.T) {
+    assertEqual := assertEqualT(t)
+
+    now := time.Unix(1700000000, 0)
+    r := newTestRedis(t, &now)
+
+    r.Push(&interfaces.Job{Queue: "a", Name: "delayed", AvailableAt: now.Add(time.Minute)})
+
+    job, _ := r.Pop("a")
+    assertEqual(job == nil, true, "Pop before available")
+
+    now = now.Add(time.Minute)
+    job, _ = r.Pop("a")
+    assertEqual(job.Name, "delayed", "Pop after available")
+
+    // 放回队列后延迟执行
+    assertEqual(r.Release(job, 30 * time.Second), nil, "Release")
+
+    empty, _ := r.Pop("a")
+    assertEqual(empty == nil, true, "Pop before release delay")
+
+    now = now.Add(30 * time.Second)
+    job, _ = r.Pop("a")
+    assertEqual(job.Name, "delayed", "Pop after release delay")
+    assertEqual(job.Attempts, 2, "Pop attempts after release")
+}
+
+func Test_Failed(t *testing.T) {
+    assertEqual := assertEqualT(t)
+
+    now := time.Unix(1700000000, 0)
+    r := newTestRedis(t, &now)
+
+    r.Push(&interfaces.Job{Queue: "a", Name: "fail", AvailableAt: now})
+
+    job, _ := r.Pop("a")
+    job.Error = "boom"
+    assertEqual(r.Fail(job), nil, "Fail")
+
+    size, _ := r.Size("a")
+    assertEqual(size, int64(0), "Size after Fail")
+
+    failed, _ := r.Failed("a")
+    assertEqual(len(failed), 1, "Failed")
+    assertEqual(failed[0].Error, "boom", "Failed error")
+    assertEqual(failed[0].FailedAt.Equal(now), true, "Failed at")
+
+    others, _ := r.Failed("b")
+    assertEqual(len(others), 0, "Failed other queue")
+
+    assertEqual(r.Retry("none"), driver.ErrNotFound, "Retry not found")
+    assertEqual(r.Retry(job.ID), nil, "Retry")
+
+    job, _ = r.Pop("a")
+    assertEqual(job.Name, "fail", "Pop after Retry")
+    assertEqual(job.Attempts, 1, "Attempts after Retry")
+    assertEqual(job.Error, "", "Error after Retry")
+
+    r.Fail(job)
+    assertEqual(r.Forget(job.ID), nil, "Forget")
+    assertEqual(r.Forget(job.ID), driver.ErrNotFound, "Forget again")
+
+    failed, _ = r.Failed("")
+    assertEqual(len(failed), 0, "Failed after Forget")
+}
@@ -0,0 +1,82 @@
+package interfaces
+
+import (
+    "time"
+)
+
+/**
+ * 队列任务
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Job struct {
+    // 任务 ID，由驱动生成
+    ID string `json:"id"`
+
+    // 队列名称
+    Queue string `json:"queue"`
+
+    // 任务名称，对应注册的处理函数
+    Name string `json:"name"`
+
+    // 任务数据，JSON 格式
+    Payload []byte `json:"payload"`
+
+    // 已执行次数，每次取出任务时加 1
+    Attempts int `json:"attempts"`
+
+    // 最大执行次数
+    MaxAttempts int `json:"max_attempts"`
+
+    // 执行超时时间
+    Timeout time.Duration `json:"timeout"`
+
+    // 最后一次执行的错误信息
+    Error string `json:"error"`
+
+    // 可执行时间
+    AvailableAt time.Time `json:"available_at"`
+
+    // 创建时间
+    CreatedAt time.Time `json:"created_at"`
+
+    // 失败时间，只在失败列表中有值
+    FailedAt time.Time `json:"failed_at"`
+}
+
+/**
+ * 队列驱动接口
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Driver interface {
+    // 推送任务
+    Push(job *Job) error
+
+    // 取出一个可执行的任务，没有任务时返回 nil
+    // 取出的任务在 Delete、Release 或 Fail 前处于保留状态
+    Pop(queue string) (*Job, error)
+
+    // 执行成功后删除任务
+    Delete(job *Job) error
+
+    // 放回队列，延迟后重新执行
+    Release(job *Job, delay time.Duration) error
+
+    // 移入失败列表
+    Fail(job *Job) error
+
+    // 队列中的任务数量，包括延迟和保留中的任务
+    Size(queue string) (int64, error)
+
+    // 失败列表，queue 为空时返回全部
+    Failed(queue string) ([]*Job, error)
+
+    // 将失败任务重新放回队列
+    Retry(id string) error
+
+    // 删除失败任务
+    Forget(id string) error
+}
@@ -0,0 +1,344 @@
+package queue
+
+import (
+    "fmt"
+    "sync"
+    "time"
+    "errors"
+    "context"
+    "encoding/json"
+
+    "github.com/deatil/lakego-doak/lakego/queue/interfaces"
+)
+
+type (
+    // 任务
+    Job = interfaces.Job
+
+    // 驱动
+    Driver = interfaces.Driver
+)
+
+// 默认队列名称
+const DefaultQueue = "default"
+
+var (
+    // 处理函数不存在
+    ErrHandlerNotFound = errors.New("queue: handler not found")
+
+    // 任务名称为空
+    ErrNameEmpty = errors.New("queue: job name is empty")
+)
+
+// 任务处理函数
+type Handler func(ctx context.Context, job *Job) error
+
+// 推送设置
+type Option func(*Job)
+
+// 设置队列
+func OnQueue(name string) Option {
+    return func(job *Job) {
+        job.Queue = name
+    }
+}
+
+// 延迟执行
+func Delay(delay time.Duration) Option {
+    return func(job *Job) {
+        job.AvailableAt = job.AvailableAt.Add(delay)
+    }
+}
+
+// 指定时间执行
+func At(t time.Time) Option {
+    return func(job *Job) {
+        job.AvailableAt = t
+    }
+}
+
+// 最大执行次数
+func Tries(tries int) Option {
+    return func(job *Job) {
+        job.MaxAttempts = tries
+    }
+}
+
+// 执行超时时间
+func Timeout(timeout time.Duration) Option {
+    return func(job *Job) {
+        job.Timeout = timeout
+    }
+}
+
+/**
+ * 任务队列
+ *
+ * q := queue.New(memory.New())
+ * queue.Register(q, "send-mail", func(ctx context.Context, mail Mail) error {
+ *     return nil
+ * })
+ * q.Dispatch("send-mail", Mail{To: "a@example.com"}, queue.Delay(time.Minute))
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Queue struct {
+    // 锁定
+    mu sync.RWMutex
+
+    // 驱动
+    driver Driver
+
+    // 处理函数
+    handlers map[string]Handler
+
+    // 默认队列
+    queue string
+
+    // 默认最大执行次数
+    maxAttempts int
+
+    // 默认超时时间
+    timeout time.Duration
+
+    // 重试间隔
+    backoff Backoff
+
+    // 当前时间，测试用
+    now func() time.Time
+}
+
+// 构造函数
+func New(driver Driver) *Queue {
+    return &Queue{
+        driver:      driver,
+        handlers:    make(map[string]Handler),
+        queue:       DefaultQueue,
+        maxAttempts: 3,
+        timeout:     time.Minute,
+        backoff:     ExponentialBackoff(time.Second, 10 * time.Minute),
+        now:         time.Now,
+    }
+}
+
+// 设置驱动
+func (this *Queue) WithDriver(driver Driver) *Queue {
+    this.driver = driver
+
+    return this
+}
+
+// 获取驱动
+func (this *Queue) GetDriver() Driver {
+    return this.driver
+}
+
+// 设置默认队列
+func (this *Queue) WithQueue(name string) *Queue {
+    if name != "" {
+        this.queue = name
+    }
+
+    return this
+}
+
+// 获取默认队列
+func (this *Queue) GetQueue() string {
+    return this.queue
+}
+
+// 设置默认最大执行次数
+func (this *Queue) WithMaxAttempts(attempts int) *Queue {
+    if attempts > 0 {
+        this.maxAttempts = attempts
+    }
+
+    return this
+}
+
+// 设置默认超时时间
+func (this *Queue) WithTimeout(timeout time.Duration) *Queue {
+    if timeout > 0 {
+        this.timeout = timeout
+    }
+
+    return this
+}
+
+// 设置重试间隔
+func (this *Queue) WithBackoff(backoff Backoff) *Queue {
+    if backoff != nil {
+        this.backoff = backoff
+    }
+
+    return this
+}
+
+// 设置当前时间
+func (this *Queue) WithNow(fn func() time.Time) *Queue {
+    this.now = fn
+
+    return this
+}
+
+// 注册处理函数
+func (this *Queue) Handle(name string, handler Handler) *Queue {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.handlers[name] = handler
+
+    return this
+}
+
+// 获取处理函数
+func (this *Queue) GetHandler(name string) (Handler, bool) {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    handler, ok := this.handlers[name]
+
+    return handler, ok
+}
+
+// 推送任务，payload 使用 JSON 编码
+func (this *Queue) Dispatch(name string, payload any, opts ...Option) (*Job, error) {
+    if name == "" {
+        return nil, ErrNameEmpty
+    }
+
+    data, err := json.Marshal(payload)
+    if err != nil {
+        return nil, fmt.Errorf("queue: encode payload fail, error: %w", err)
+    }
+
+    now := this.now()
+
+    job := &Job{
+        Queue:       this.queue,
+        Name:        name,
+        Payload:     data,
+        MaxAttempts: this.maxAttempts,
+        Timeout:     this.timeout,
+        AvailableAt: now,
+        CreatedAt:   now,
+    }
+
+    for _, opt := range opts {
+        opt(job)
+    }
+
+    if err := this.driver.Push(job); err != nil {
+        return nil, err
+    }
+
+    return job, nil
+}
+
+// 队列任务数量
+func (this *Queue) Size(queue ...string) (int64, error) {
+    name := this.queue
+    if len(queue) > 0 {
+        name = queue[0]
+    }
+
+    return this.driver.Size(name)
+}
+
+// 失败列表
+func (this *Queue) Failed(queue ...string) ([]*Job, error) {
+    name := ""
+    if len(queue) > 0 {
+        name = queue[0]
+    }
+
+    return this.driver.Failed(name)
+}
+
+// 重试失败任务
+func (this *Queue) RetryFailed(id string) error {
+    return this.driver.Retry(id)
+}
+
+// 删除失败任务
+func (this *Queue) ForgetFailed(id string) error {
+    return this.driver.Forget(id)
+}
+
+// 执行任务，根据结果删除、重试或移入失败列表
+func (this *Queue) Process(ctx context.Context, job *Job) error {
+    err := this.call(ctx, job)
+    if err == nil {
+        return this.driver.Delete(job)
+    }
+
+    job.Error = err.Error()
+
+    // 停止运行时中断的任务直接放回队列
+    if ctx.Err() != nil {
+        if rerr := this.driver.Release(job, 0); rerr != nil {
+            return rerr
+        }
+
+        return err
+    }
+
+    if IsPermanent(err) || errors.Is(err, ErrHandlerNotFound) || job.Attempts >= job.MaxAttempts {
+        job.FailedAt = this.now()
+
+        if ferr := this.driver.Fail(job); ferr != nil {
+            return ferr
+        }
+
+        return err
+    }
+
+    if rerr := this.driver.Release(job, this.backoff(job.Attempts)); rerr != nil {
+        return rerr
+    }
+
+    return err
+}
+
+// 调用处理函数，捕获异常并设置超时
+func (this *Queue) call(ctx context.Context, job *Job) (err error) {
+    handler, ok := this.GetHandler(job.Name)
+    if !ok {
+        return fmt.Errorf("%w: %s", ErrHandlerNotFound, job.Name)
+    }
+
+    if job.Timeout > 0 {
+        var cancel context.CancelFunc
+        ctx, cancel = context.WithTimeout(ctx, job.Timeout)
+        defer cancel()
+    }
+
+    defer func() {
+        if r := recover(); r != nil {
+            err = fmt.Errorf("queue: job %s panic: %v", job.Name, r)
+        }
+    }()
+
+    return handler(ctx, job)
+}
+
+// 注册指定数据类型的处理函数
+func Register[T any](q *Queue, name string, fn func(ctx context.Context, payload T) error) {
+    q.Handle(name, func(ctx context.Context, job *Job) error {
+        var payload T
+        if err := json.Unmarshal(job.Payload, &payload); err != nil {
+            return Permanent(fmt.Errorf("queue: decode payload fail, error: %w", err))
+        }
+
+        return fn(ctx, payload)
+    })
+}
+
+// 解析任务数据
+func Decode[T any](job *Job) (T, error) {
+    var payload T
+    err := json.Unmarshal(job.Payload, &payload)
+
+    return payload, err
+}
@@ -0,0 +1,299 @@
+package queue
+
+import (
+    "sync"
+    "time"
+    "errors"
+    "reflect"
+    "testing"
+    "context"
+    "sync/atomic"
+
+    "github.com/deatil/lakego-doak/lakego/queue/driver/memory"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func assertErrorT(t *testing.T) func(error, string) {
+    return func(err error, msg string) {
+        if err != nil {
+            t.Errorf("Failed %s: error: %+v", msg, err)
+        }
+    }
+}
+
+type testMail struct {
+    To    string
+    Title string
+}
+
+// 测试时间
+type testClock struct {
+    mu  sync.Mutex
+    now time.Time
+}
+
+func (this *testClock) Now() time.Time {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    return this.now
+}
+
+func (this *testClock) Add(d time.Duration) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.now = this.now.Add(d)
+}
+
+func newTestQueue() (*Queue, *memory.Memory, *testClock) {
+    clock := &testClock{now: time.Unix(1700000000, 0)}
+
+    driver := memory.New().WithNow(clock.Now)
+    q := New(driver).WithNow(clock.Now)
+
+    return q, driver, clock
+}
+
+func Test_Dispatch(t *testing.T) {
+    assertEqual := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    q, _, _ := newTestQueue()
+
+    var got testMail
+    Register(q, "send-mail", func(ctx context.Context, mail testMail) error {
+        got = mail
+        return nil
+    })
+
+    job, err := q.Dispatch("send-mail", testMail{To: "a@example.com", Title: "hi"})
+    assertError(err, "Dispatch")
+    assertEqual(job.Queue, DefaultQueue, "Dispatch queue")
+    assertEqual(job.MaxAttempts, 3, "Dispatch tries")
+
+    size, _ := q.Size()
+    assertEqual(size, int64(1), "Size")
+
+    w := NewWorker(q, nil)
+    assertEqual(w.RunNext(context.Background(), DefaultQueue), true, "RunNext")
+    assertEqual(got, testMail{To: "a@example.com", Title: "hi"}, "handled payload")
+
+    size, _ = q.Size()
+    assertEqual(size, int64(0), "Size after run")
+
+    assertEqual(w.RunNext(context.Background(), DefaultQueue), false, "RunNext empty")
+
+    _, err = q.Dispatch("", nil)
+    assertEqual(err, ErrNameEmpty, "Dispatch empty name")
+}
+
+func Test_DelayAndQueue(t *testing.T) {
+    assertEqual := assertEqualT(t)
+
+    q, _, clock := newTestQueue()
+
+    var handled []string
+    Register(q, "echo", func(ctx context.Context, s string) error {
+        handled = append(handled, s)
+        return nil
+    })
+
+    q.Dispatch("echo", "later", Delay(time.Minute))
+    q.Dispatch("echo", "now")
+    q.Dispatch("echo", "mail", OnQueue("mail"))
+
+    w := NewWorker(q, nil)
+
+    w.RunNext(context.Background(), DefaultQueue)
+    assertEqual(w.RunNext(context.Background(), DefaultQueue), false, "delayed not ready")
+    assertEqual(handled, []string{"now"}, "handled now")
+
+    clock.Add(time.Minute)
+    w.RunNext(context.Background(), DefaultQueue)
+    assertEqual(handled, []string{"now", "later"}, "handled later")
+
+    w.RunNext(context.Background(), "mail")
+    assertEqual(handled, []string{"now", "later", "mail"}, "handled mail")
+}
+
+func Test_RetryAndFail(t *testing.T) {
+    assertEqual := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    q, driver, clock := newTestQueue()
+    q.WithBackoff(ConstantBackoff(10 * time.Second))
+
+    calls := 0
+    q.Handle("flaky", func(ctx context.Context, job *Job) error {
+        calls++
+        return errors.New("boom")
+    })
+
+    q.Dispatch("flaky", nil, Tries(2))
+
+    w := NewWorker(q, nil)
+    w.RunNext(context.Background(), DefaultQueue)
+    assertEqual(calls, 1, "first attempt")
+
+    // 重试间隔内不执行
+    assertEqual(w.RunNext(context.Background(), DefaultQueue), false, "backoff")
+
+    clock.Add(10 * time.Second)
+    w.RunNext(context.Background(), DefaultQueue)
+    assertEqual(calls, 2, "second attempt")
+
+    size, _ := q.Size()
+    assertEqual(size, int64(0), "removed from queue")
+
+    failed, err := q.Failed()
+    assertError(err, "Failed")
+    assertEqual(len(failed), 1, "Failed count")
+    assertEqual(failed[0].Attempts, 2, "Failed attempts")
+    assertEqual(failed[0].Error, "boom", "Failed error")
+
+    // 重新放回队列
+    assertError(q.RetryFailed(failed[0].ID), "RetryFailed")
+    size, _ = driver.Size(DefaultQueue)
+    assertEqual(size, int64(1), "Size after RetryFailed")
+
+    // 执行次数已重置
+    w.RunNext(context.Background(), DefaultQueue)
+    assertEqual(calls, 3, "attempt after RetryFailed")
+
+    failed, _ = q.Failed()
+    assertEqual(len(failed), 0, "Failed after RetryFailed")
+
+    clock.Add(10 * time.Second)
+    w.RunNext(context.Background(), DefaultQueue)
+
+    failed, _ = q.Failed()
+    assertError(q.ForgetFailed(failed[0].ID), "ForgetFailed")
+
+    failed, _ = q.Failed()
+    assertEqual(len(failed), 0, "Failed after ForgetFailed")
+}
+
+func Test_PermanentAndPanic(t *testing.T) {
+    assertEqual := assertEqualT(t)
+
+    q, _, _ := newTestQueue()
+
+    q.Handle("permanent", func(ctx context.Context, job *Job) error {
+        return Permanent(errors.New("bad input"))
+    })
+    q.Handle("panic", func(ctx context.Context, job *Job) error {
+        panic("oops")
+    })
+
+    q.Dispatch("permanent", nil)
+    q.Dispatch("panic", nil, Tries(1))
+    q.Dispatch("missing", nil)
+
+    w := NewWorker(q, nil)
+    for w.RunNext(context.Background(), DefaultQueue) {
+    }
+
+    failed, _ := q.Failed(DefaultQueue)
+    assertEqual(len(failed), 3, "all failed")
+
+    errs := map[string]string{}
+    for _, job := range failed {
+        errs[job.Name] = job.Error
+    }
+
+    assertEqual(errs["permanent"], "bad input", "permanent error")
+    assertEqual(errs["panic"], "queue: job panic panic: oops", "panic error")
+    assertEqual(errs["missing"], "queue: handler not found: missing", "missing handler")
+}
+
+func Test_Timeout(t *testing.T) {
+    assertEqual := assertEqualT(t)
+
+    q, _, _ := newTestQueue()
+
+    q.Handle("slow", func(ctx context.Context, job *Job) error {
+        <-ctx.Done()
+        return ctx.Err()
+    })
+
+    q.Dispatch("slow", nil, Timeout(10 * time.Millisecond), Tries(1))
+
+    NewWorker(q, nil).RunNext(context.Background(), DefaultQueue)
+
+    failed, _ := q.Failed()
+    assertEqual(len(failed), 1, "timeout failed")
+    assertEqual(failed[0].Error, context.DeadlineExceeded.Error(), "timeout error")
+}
+
+func Test_WorkerStop(t *testing.T) {
+    assertEqual := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    q := New(memory.New())
+
+    var done int32
+    started := make(chan struct{}, 10)
+    Register(q, "work", func(ctx context.Context, n int) error {
+        started <- struct{}{}
+
+        select {
+            case <-time.After(50 * time.Millisecond):
+                atomic.AddInt32(&done, 1)
+                return nil
+            case <-ctx.Done():
+                return ctx.Err()
+        }
+    })
+
+    for i := 0; i < 2; i++ {
+        q.Dispatch("work", i)
+    }
+
+    w := NewWorker(q, map[string]int{DefaultQueue: 2}).WithSleep(5 * time.Millisecond)
+    assertError(w.Start(), "Start")
+    assertEqual(w.Start(), ErrWorkerRunning, "Start twice")
+
+    <-started
+    <-started
+
+    // 等待执行中的任务完成
+    assertError(w.Stop(context.Background()), "Stop")
+    assertEqual(atomic.LoadInt32(&done), int32(2), "finished before stop")
+    assertEqual(w.Running(), false, "Running")
+
+    // 超时时中断任务并放回队列
+    q.Dispatch("work", 3)
+    w.Start()
+    <-started
+
+    ctx, cancel := context.WithTimeout(context.Background(), 5 * time.Millisecond)
+    defer cancel()
+
+    assertEqual(w.Stop(ctx), context.DeadlineExceeded, "Stop timeout")
+
+    size, _ := q.Size()
+    assertEqual(size, int64(1), "interrupted job released")
+
+    failed, _ := q.Failed()
+    assertEqual(len(failed), 0, "interrupted job not failed")
+}
+
+func Test_ExponentialBackoff(t *testing.T) {
+    assertEqual := assertEqualT(t)
+
+    backoff := ExponentialBackoff(time.Second, 10 * time.Second)
+
+    assertEqual(backoff(1), time.Second, "attempt 1")
+    assertEqual(backoff(2), 2 * time.Second, "attempt 2")
+    assertEqual(backoff(4), 8 * time.Second, "attempt 4")
+    assertEqual(backoff(5), 10 * time.Second, "attempt 5")
+    assertEqual(backoff(100), 10 * time.Second, "attempt 100")
+}
@@ -0,0 +1,206 @@
+package queue
+
+import (
+    "sync"
+    "time"
+    "errors"
+    "context"
+)
+
+var (
+    // 已在运行
+    ErrWorkerRunning = errors.New("queue: worker is running")
+)
+
+// 日志接口
+type iLogger interface {
+    Errorf(template string, args ...any)
+}
+
+/**
+ * 队列执行者
+ * 每个队列按设置的并发数启动协程取出任务执行
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Worker struct {
+    // 锁定
+    mu sync.Mutex
+
+    // 队列
+    queue *Queue
+
+    // 队列及并发数
+    queues map[string]int
+
+    // 没有任务时的等待时间
+    sleep time.Duration
+
+    // 日志
+    logger iLogger
+
+    // 停止取出新任务
+    stop chan struct{}
+
+    // 中断执行中的任务
+    ctx    context.Context
+    cancel context.CancelFunc
+
+    // 执行中的协程
+    wg sync.WaitGroup
+
+    // 是否运行中
+    running bool
+}
+
+// 构造函数
+func NewWorker(queue *Queue, queues map[string]int) *Worker {
+    if len(queues) == 0 {
+        queues = map[string]int{
+            queue.GetQueue(): 1,
+        }
+    }
+
+    return &Worker{
+        queue:  queue,
+        queues: queues,
+        sleep:  time.Second,
+    }
+}
+
+// 设置空闲等待时间
+func (this *Worker) WithSleep(sleep time.Duration) *Worker {
+    if sleep > 0 {
+        this.sleep = sleep
+    }
+
+    return this
+}
+
+// 设置日志
+func (this *Worker) WithLogger(logger iLogger) *Worker {
+    this.logger = logger
+
+    return this
+}
+
+// 队列及并发数
+func (this *Worker) GetQueues() map[string]int {
+    return this.queues
+}
+
+// 是否运行中
+func (this *Worker) Running() bool {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    return this.running
+}
+
+// 开始执行，不阻塞
+func (this *Worker) Start() error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if this.running {
+        return ErrWorkerRunning
+    }
+
+    this.running = true
+    this.stop = make(chan struct{})
+    this.ctx, this.cancel = context.WithCancel(context.Background())
+
+    for name, concurrency := range this.queues {
+        if concurrency < 1 {
+            concurrency = 1
+        }
+
+        for i := 0; i < concurrency; i++ {
+            this.wg.Add(1)
+            go this.loop(name)
+        }
+    }
+
+    return nil
+}
+
+// 停止执行
+// 不再取出新任务并等待执行中的任务完成，ctx 结束时中断执行中的任务
+func (this *Worker) Stop(ctx context.Context) error {
+    this.mu.Lock()
+    if !this.running {
+        this.mu.Unlock()
+        return nil
+    }
+
+    this.running = false
+    close(this.stop)
+    this.mu.Unlock()
+
+    done := make(chan struct{})
+    go func() {
+        this.wg.Wait()
+        close(done)
+    }()
+
+    select {
+        case <-done:
+            this.cancel()
+            return nil
+        case <-ctx.Done():
+            // 中断的任务会被放回队列
+            this.cancel()
+            <-done
+
+            return ctx.Err()
+    }
+}
+
+// 取出任务执行
+func (this *Worker) loop(name string) {
+    defer this.wg.Done()
+
+    for {
+        select {
+            case <-this.stop:
+                return
+            default:
+        }
+
+        if this.RunNext(this.ctx, name) {
+            continue
+        }
+
+        select {
+            case <-this.stop:
+                return
+            case <-time.After(this.sleep):
+        }
+    }
+}
+
+// 执行一个任务，没有任务或取出失败时返回 false
+func (this *Worker) RunNext(ctx context.Context, name string) bool {
+    job, err := this.queue.GetDriver().Pop(name)
+    if err != nil {
+        this.errorf("queue: pop [%s] fail, error: %s", name, err.Error())
+        return false
+    }
+
+    if job == nil {
+        return false
+    }
+
+    if err := this.queue.Process(ctx, job); err != nil {
+        this.errorf("queue: job [%s:%s] attempt %d fail, error: %s", job.Name, job.ID, job.Attempts, err.Error())
+    }
+
+    return true
+}
+
+func (this *Worker) errorf(template string, args ...any) {
+    if this.logger != nil {
+        this.logger.Errorf(template, args...)
+    }
+}
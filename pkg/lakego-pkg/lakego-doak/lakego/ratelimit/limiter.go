@@ -0,0 +1,51 @@
+package ratelimit
+
+import (
+    "context"
+)
+
+/**
+ * 限流器
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Limiter struct {
+    // 存储
+    store Store
+
+    // key 前缀
+    prefix string
+}
+
+// 构造函数
+func New(store Store) *Limiter {
+    if store == nil {
+        store = NewMemoryStore()
+    }
+
+    return &Limiter{
+        store: store,
+    }
+}
+
+// 设置 key 前缀
+func (this *Limiter) WithPrefix(prefix string) *Limiter {
+    this.prefix = prefix
+    return this
+}
+
+// 存储
+func (this *Limiter) GetStore() Store {
+    return this.store
+}
+
+// 消耗一次额度
+func (this *Limiter) Allow(ctx context.Context, key string, rule Rule) (Result, error) {
+    return this.store.Take(ctx, this.prefix + key, rule)
+}
+
+// 重置额度
+func (this *Limiter) Reset(ctx context.Context, key string, rule Rule) error {
+    return this.store.Reset(ctx, this.prefix + key, rule)
+}
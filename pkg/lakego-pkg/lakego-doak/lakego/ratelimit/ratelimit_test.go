@@ -0,0 +1,197 @@
+package ratelimit
+
+import (
+    "time"
+    "errors"
+    "reflect"
+    "testing"
+    "context"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func assertErrorT(t *testing.T) func(error, string) {
+    return func(err error, msg string) {
+        if err != nil {
+            t.Errorf("Failed %s: error: %+v", msg, err)
+        }
+    }
+}
+
+// 测试时钟，从整分钟开始
+type testClock struct {
+    now time.Time
+}
+
+func newTestClock() *testClock {
+    return &testClock{
+        now: time.Unix(1699999980, 0),
+    }
+}
+
+func (this *testClock) Now() time.Time {
+    return this.now
+}
+
+func (this *testClock) Add(d time.Duration) {
+    this.now = this.now.Add(d)
+}
+
+func Test_Rule(t *testing.T) {
+    eq := assertEqualT(t)
+
+    eq(Rule{Limit: 10, Period: time.Minute}.Validate(), nil, "Validate")
+    eq(Rule{Limit: 0, Period: time.Minute}.Validate(), ErrInvalidRule, "Validate Limit")
+    eq(Rule{Limit: 10}.Validate(), ErrInvalidRule, "Validate Period")
+    eq(Rule{Algorithm: "fixed", Limit: 10, Period: time.Minute}.Validate(), ErrInvalidAlgorithm, "Validate Algorithm")
+
+    eq(Rule{Limit: 10, Period: time.Minute}.Policy(), "10;w=60", "Policy")
+    eq(Rule{Limit: 10, Period: time.Minute, Burst: 20}.Policy(), "10;w=60;burst=20", "Policy burst")
+    eq(Rule{Algorithm: SlidingWindow, Limit: 5, Period: time.Second}.Policy(), "5;w=1", "Policy sliding")
+}
+
+func Test_TokenBucket(t *testing.T) {
+    eq := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    clock := newTestClock()
+    store := NewMemoryStore().WithClock(clock.Now)
+    rule := Rule{Limit: 2, Period: time.Minute}
+
+    ctx := context.Background()
+
+    res, err := store.Take(ctx, "ip:1", rule)
+    assertError(err, "Take 1")
+    eq(res.Allowed, true, "Take 1 Allowed")
+    eq(res.Limit, 2, "Take 1 Limit")
+    eq(res.Remaining, 1, "Take 1 Remaining")
+    eq(res.Reset, 30*time.Second, "Take 1 Reset")
+
+    res, _ = store.Take(ctx, "ip:1", rule)
+    eq(res.Allowed, true, "Take 2 Allowed")
+    eq(res.Remaining, 0, "Take 2 Remaining")
+
+    res, _ = store.Take(ctx, "ip:1", rule)
+    eq(res.Allowed, false, "Take 3 Allowed")
+    eq(res.RetryAfter, 30*time.Second, "Take 3 RetryAfter")
+
+    // 其他 key 不受影响
+    res, _ = store.Take(ctx, "ip:2", rule)
+    eq(res.Allowed, true, "Take other key")
+
+    clock.Add(30 * time.Second)
+
+    res, _ = store.Take(ctx, "ip:1", rule)
+    eq(res.Allowed, true, "Take after refill")
+    eq(res.Remaining, 0, "Take after refill Remaining")
+}
+
+func Test_TokenBucketBurst(t *testing.T) {
+    eq := assertEqualT(t)
+
+    store := NewMemoryStore().WithClock(newTestClock().Now)
+    rule := Rule{Limit: 1, Period: time.Minute, Burst: 3}
+
+    for i := 0; i < 3; i++ {
+        res, _ := store.Take(context.Background(), "burst", rule)
+        eq(res.Allowed, true, "Take burst")
+    }
+
+    res, _ := store.Take(context.Background(), "burst", rule)
+    eq(res.Allowed, false, "Take over burst")
+    eq(res.Limit, 3, "Take over burst Limit")
+    eq(res.RetryAfter, time.Minute, "Take over burst RetryAfter")
+}
+
+func Test_SlidingWindow(t *testing.T) {
+    eq := assertEqualT(t)
+
+    clock := newTestClock()
+    store := NewMemoryStore().WithClock(clock.Now)
+    rule := Rule{Algorithm: SlidingWindow, Limit: 3, Period: time.Minute}
+
+    ctx := context.Background()
+
+    for i := 0; i < 3; i++ {
+        res, _ := store.Take(ctx, "admin:1", rule)
+        eq(res.Allowed, true, "Take in window")
+        eq(res.Remaining, 2 - i, "Take in window Remaining")
+    }
+
+    res, _ := store.Take(ctx, "admin:1", rule)
+    eq(res.Allowed, false, "Take over window")
+    eq(res.Remaining, 0, "Take over window Remaining")
+    eq(res.RetryAfter, time.Minute, "Take over window RetryAfter")
+
+    // 下一窗口过半，上一窗口权重为 0.5
+    clock.Add(90 * time.Second)
+
+    res, _ = store.Take(ctx, "admin:1", rule)
+    eq(res.Allowed, true, "Take next window")
+    eq(res.Remaining, 0, "Take next window Remaining")
+
+    res, _ = store.Take(ctx, "admin:1", rule)
+    eq(res.Allowed, false, "Take next window over")
+    eq(res.RetryAfter, 10*time.Second, "Take next window RetryAfter")
+
+    // 间隔多个窗口后清零
+    clock.Add(5 * time.Minute)
+
+    res, _ = store.Take(ctx, "admin:1", rule)
+    eq(res.Allowed, true, "Take after windows")
+    eq(res.Remaining, 2, "Take after windows Remaining")
+}
+
+func Test_MemoryStoreSweep(t *testing.T) {
+    eq := assertEqualT(t)
+
+    clock := newTestClock()
+    store := NewMemoryStore().WithClock(clock.Now)
+    rule := Rule{Algorithm: SlidingWindow, Limit: 3, Period: time.Second}
+
+    store.Take(context.Background(), "a", rule)
+    store.Take(context.Background(), "b", rule)
+    eq(store.Len(), 2, "Len")
+
+    clock.Add(2 * time.Minute)
+
+    store.Take(context.Background(), "c", rule)
+    eq(store.Len(), 1, "Len after sweep")
+}
+
+func Test_Limiter(t *testing.T) {
+    eq := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    store := NewMemoryStore().WithClock(newTestClock().Now)
+    limiter := New(store).WithPrefix("login:")
+    rule := Rule{Limit: 1, Period: time.Minute}
+
+    ctx := context.Background()
+
+    res, _ := limiter.Allow(ctx, "ip:1", rule)
+    eq(res.Allowed, true, "Allow")
+
+    res, _ = limiter.Allow(ctx, "ip:1", rule)
+    eq(res.Allowed, false, "Allow over")
+
+    // 不同前缀的限流器互不影响
+    res, _ = New(store).WithPrefix("upload:").Allow(ctx, "ip:1", rule)
+    eq(res.Allowed, true, "Allow other prefix")
+
+    assertError(limiter.Reset(ctx, "ip:1", rule), "Reset")
+
+    res, _ = limiter.Allow(ctx, "ip:1", rule)
+    eq(res.Allowed, true, "Allow after reset")
+
+    _, err := limiter.Allow(ctx, "ip:1", Rule{})
+    if !errors.Is(err, ErrInvalidRule) {
+        t.Errorf("Allow invalid rule error got %v", err)
+    }
+}
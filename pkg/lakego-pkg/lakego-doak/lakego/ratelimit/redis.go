@@ -0,0 +1,181 @@
+package ratelimit
+
+import (
+    "time"
+    "context"
+    "strconv"
+
+    "github.com/go-redis/redis/v8"
+)
+
+// 令牌桶脚本
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local ttl = tonumber(ARGV[4])
+
+local data = redis.call("HMGET", KEYS[1], "tokens", "last")
+local tokens = tonumber(data[1])
+local last = tonumber(data[2])
+if tokens == nil or last == nil then
+    tokens = burst
+    last = now
+end
+
+if now > last then
+    tokens = math.min(burst, tokens + (now - last) * rate)
+    last = now
+end
+
+local allowed = 0
+if tokens >= 1 then
+    tokens = tokens - 1
+    allowed = 1
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "last", tostring(last))
+redis.call("PEXPIRE", KEYS[1], ttl)
+
+return {allowed, tostring(tokens)}
+`)
+
+// 滑动窗口脚本
+var slidingWindowScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local weight = tonumber(ARGV[2])
+local ttl = tonumber(ARGV[3])
+
+local current = tonumber(redis.call("GET", KEYS[1]) or "0")
+local previous = tonumber(redis.call("GET", KEYS[2]) or "0")
+
+local allowed = 0
+if previous * weight + current + 1 <= limit then
+    current = redis.call("INCR", KEYS[1])
+    redis.call("PEXPIRE", KEYS[1], ttl)
+    allowed = 1
+end
+
+return {allowed, previous, current}
+`)
+
+/**
+ * redis 存储，多个服务共享额度
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type RedisStore struct {
+    // 客户端
+    client *redis.Client
+
+    // 前缀
+    prefix string
+}
+
+// 构造函数
+func NewRedisStore(client *redis.Client, prefix string) *RedisStore {
+    return &RedisStore{
+        client: client,
+        prefix: prefix,
+    }
+}
+
+// 消耗一次额度
+func (this *RedisStore) Take(ctx context.Context, key string, rule Rule) (Result, error) {
+    if err := rule.Validate(); err != nil {
+        return Result{}, err
+    }
+
+    if ctx == nil {
+        ctx = context.Background()
+    }
+
+    now := time.Now()
+
+    if rule.algorithm() == SlidingWindow {
+        return this.takeWindow(ctx, key, rule, now)
+    }
+
+    return this.takeBucket(ctx, key, rule, now)
+}
+
+// 令牌桶
+func (this *RedisStore) takeBucket(ctx context.Context, key string, rule Rule, now time.Time) (Result, error) {
+    // 令牌补满后过期
+    ttl := time.Duration(float64(rule.burst()) / rule.rate()) + time.Second
+
+    res, err := tokenBucketScript.Run(ctx, this.client, []string{this.bucketKey(key)},
+        strconv.FormatFloat(rule.rate() * float64(time.Millisecond), 'f', -1, 64),
+        rule.burst(),
+        now.UnixMilli(),
+        ttl.Milliseconds(),
+    ).Slice()
+    if err != nil {
+        return Result{}, err
+    }
+
+    allowed, _ := res[0].(int64)
+    text, _ := res[1].(string)
+
+    tokens, err := strconv.ParseFloat(text, 64)
+    if err != nil {
+        return Result{}, err
+    }
+
+    return tokenBucketResult(rule, allowed == 1, tokens), nil
+}
+
+// 滑动窗口
+func (this *RedisStore) takeWindow(ctx context.Context, key string, rule Rule, now time.Time) (Result, error) {
+    start := windowStart(rule, now)
+    weight := previousWeight(rule, now)
+
+    keys := []string{
+        this.windowKey(key, start),
+        this.windowKey(key, start.Add(-rule.Period)),
+    }
+
+    res, err := slidingWindowScript.Run(ctx, this.client, keys,
+        rule.Limit,
+        strconv.FormatFloat(weight, 'f', -1, 64),
+        (2 * rule.Period).Milliseconds(),
+    ).Slice()
+    if err != nil {
+        return Result{}, err
+    }
+
+    allowed, _ := res[0].(int64)
+    previous, _ := res[1].(int64)
+    current, _ := res[2].(int64)
+
+    return slidingWindowResult(rule, now, allowed == 1, int(previous), int(current)), nil
+}
+
+// 重置额度
+func (this *RedisStore) Reset(ctx context.Context, key string, rule Rule) error {
+    if ctx == nil {
+        ctx = context.Background()
+    }
+
+    if rule.algorithm() == SlidingWindow {
+        start := windowStart(rule, time.Now())
+
+        return this.client.Del(ctx,
+            this.windowKey(key, start),
+            this.windowKey(key, start.Add(-rule.Period)),
+        ).Err()
+    }
+
+    return this.client.Del(ctx, this.bucketKey(key)).Err()
+}
+
+// 令牌桶 key
+func (this *RedisStore) bucketKey(key string) string {
+    return this.prefix + "{" + key + "}:bucket"
+}
+
+// 窗口 key，同一限流 key 使用相同 hash tag 以支持集群
+func (this *RedisStore) windowKey(key string, start time.Time) string {
+    return this.prefix + "{" + key + "}:" + strconv.FormatInt(start.UnixMilli(), 10)
+}
@@ -0,0 +1,198 @@
+package ratelimit
+
+import (
+    "fmt"
+    "math"
+    "time"
+    "errors"
+)
+
+// 算法
+const (
+    // 令牌桶，允许短时突发
+    TokenBucket = "token-bucket"
+
+    // 滑动窗口
+    SlidingWindow = "sliding-window"
+)
+
+var (
+    // 规则无效
+    ErrInvalidRule = errors.New("ratelimit: invalid rule")
+
+    // 算法不支持
+    ErrInvalidAlgorithm = errors.New("ratelimit: invalid algorithm")
+)
+
+/**
+ * 限流规则，每 Period 时间内允许 Limit 次请求
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Rule struct {
+    // 算法，默认为令牌桶
+    Algorithm string
+
+    // 次数
+    Limit int
+
+    // 时间段
+    Period time.Duration
+
+    // 令牌桶容量，默认为 Limit
+    Burst int
+}
+
+// 检测规则
+func (this Rule) Validate() error {
+    if this.Limit <= 0 || this.Period <= 0 || this.Burst < 0 {
+        return ErrInvalidRule
+    }
+
+    switch this.algorithm() {
+        case TokenBucket, SlidingWindow:
+            return nil
+    }
+
+    return ErrInvalidAlgorithm
+}
+
+// 策略描述，用于 RateLimit-Policy 响应头
+func (this Rule) Policy() string {
+    seconds := int64(math.Ceil(this.Period.Seconds()))
+
+    if this.algorithm() == TokenBucket && this.burst() != this.Limit {
+        return fmt.Sprintf("%d;w=%d;burst=%d", this.Limit, seconds, this.burst())
+    }
+
+    return fmt.Sprintf("%d;w=%d", this.Limit, seconds)
+}
+
+// 算法
+func (this Rule) algorithm() string {
+    if this.Algorithm == "" {
+        return TokenBucket
+    }
+
+    return this.Algorithm
+}
+
+// 令牌桶容量
+func (this Rule) burst() int {
+    if this.Burst > 0 {
+        return this.Burst
+    }
+
+    return this.Limit
+}
+
+// 每纳秒生成的令牌数
+func (this Rule) rate() float64 {
+    return float64(this.Limit) / float64(this.Period)
+}
+
+/**
+ * 限流结果
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Result struct {
+    // 是否允许
+    Allowed bool
+
+    // 次数上限
+    Limit int
+
+    // 剩余次数
+    Remaining int
+
+    // 额度完全恢复需要的时间
+    Reset time.Duration
+
+    // 被拒绝时需要等待的时间
+    RetryAfter time.Duration
+}
+
+// 令牌桶结果
+func tokenBucketResult(rule Rule, allowed bool, tokens float64) Result {
+    burst := float64(rule.burst())
+    rate := rule.rate()
+
+    result := Result{
+        Allowed:   allowed,
+        Limit:     rule.burst(),
+        Remaining: int(math.Floor(tokens)),
+        Reset:     time.Duration(math.Ceil((burst - tokens) / rate)),
+    }
+
+    if !allowed {
+        result.RetryAfter = time.Duration(math.Ceil((1 - tokens) / rate))
+    }
+
+    return result
+}
+
+// 令牌桶补充令牌后的数量
+func refillTokens(rule Rule, tokens float64, elapsed time.Duration) float64 {
+    if elapsed <= 0 {
+        return tokens
+    }
+
+    return math.Min(float64(rule.burst()), tokens + float64(elapsed) * rule.rate())
+}
+
+// 滑动窗口开始时间
+func windowStart(rule Rule, now time.Time) time.Time {
+    return now.Truncate(rule.Period)
+}
+
+// 上一窗口的权重
+func previousWeight(rule Rule, now time.Time) float64 {
+    elapsed := now.Sub(windowStart(rule, now))
+
+    return 1 - float64(elapsed) / float64(rule.Period)
+}
+
+// 滑动窗口结果，current 为本次请求计入后的当前窗口次数
+func slidingWindowResult(rule Rule, now time.Time, allowed bool, previous int, current int) Result {
+    start := windowStart(rule, now)
+    end := start.Add(rule.Period)
+    weight := previousWeight(rule, now)
+
+    used := int(math.Ceil(float64(previous) * weight)) + current
+
+    remaining := rule.Limit - used
+    if remaining < 0 {
+        remaining = 0
+    }
+
+    // 上一窗口的请求全部移出后额度恢复
+    reset := end.Sub(now)
+    if current == 0 {
+        reset = time.Duration(float64(rule.Period) * weight)
+    }
+
+    result := Result{
+        Allowed:   allowed,
+        Limit:     rule.Limit,
+        Remaining: remaining,
+        Reset:     reset,
+    }
+
+    if !allowed {
+        result.RetryAfter = end.Sub(now)
+
+        // 当前窗口未满时，等待上一窗口的请求移出
+        if previous > 0 && current + 1 <= rule.Limit {
+            need := 1 - float64(rule.Limit - current - 1) / float64(previous)
+            wait := time.Duration((need - (1 - weight)) * float64(rule.Period))
+            if wait > 0 && wait < result.RetryAfter {
+                result.RetryAfter = wait
+            }
+        }
+    }
+
+    return result
+}
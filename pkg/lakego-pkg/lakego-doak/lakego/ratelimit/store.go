@@ -0,0 +1,178 @@
+package ratelimit
+
+import (
+    "sync"
+    "time"
+    "context"
+)
+
+// 存储接口
+type Store interface {
+    // 消耗一次额度
+    Take(ctx context.Context, key string, rule Rule) (Result, error)
+
+    // 重置额度
+    Reset(ctx context.Context, key string, rule Rule) error
+}
+
+// 过期记录清理间隔
+const sweepInterval = time.Minute
+
+// 内存记录
+type memoryEntry struct {
+    // 令牌数
+    tokens float64
+
+    // 上次补充令牌时间
+    last time.Time
+
+    // 当前窗口开始时间
+    window time.Time
+
+    // 当前窗口次数
+    current int
+
+    // 上一窗口次数
+    previous int
+
+    // 过期时间
+    expires time.Time
+}
+
+/**
+ * 内存存储，只在单个服务内生效
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type MemoryStore struct {
+    // 锁定
+    mu sync.Mutex
+
+    // 记录
+    entries map[string]*memoryEntry
+
+    // 下次清理时间
+    nextSweep time.Time
+
+    // 当前时间
+    now func() time.Time
+}
+
+// 构造函数
+func NewMemoryStore() *MemoryStore {
+    return &MemoryStore{
+        entries: make(map[string]*memoryEntry),
+        now:     time.Now,
+    }
+}
+
+// 设置时间函数
+func (this *MemoryStore) WithClock(now func() time.Time) *MemoryStore {
+    this.now = now
+    return this
+}
+
+// 记录数量
+func (this *MemoryStore) Len() int {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    return len(this.entries)
+}
+
+// 消耗一次额度
+func (this *MemoryStore) Take(ctx context.Context, key string, rule Rule) (Result, error) {
+    if err := rule.Validate(); err != nil {
+        return Result{}, err
+    }
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    now := this.now()
+    this.sweep(now)
+
+    entry, ok := this.entries[key]
+    if !ok || now.After(entry.expires) {
+        entry = &memoryEntry{
+            tokens: float64(rule.burst()),
+            last:   now,
+            window: windowStart(rule, now),
+        }
+        this.entries[key] = entry
+    }
+
+    if rule.algorithm() == SlidingWindow {
+        return this.takeWindow(entry, rule, now), nil
+    }
+
+    return this.takeBucket(entry, rule, now), nil
+}
+
+// 令牌桶
+func (this *MemoryStore) takeBucket(entry *memoryEntry, rule Rule, now time.Time) Result {
+    entry.tokens = refillTokens(rule, entry.tokens, now.Sub(entry.last))
+    entry.last = now
+
+    allowed := entry.tokens >= 1
+    if allowed {
+        entry.tokens--
+    }
+
+    result := tokenBucketResult(rule, allowed, entry.tokens)
+    entry.expires = now.Add(result.Reset)
+
+    return result
+}
+
+// 滑动窗口
+func (this *MemoryStore) takeWindow(entry *memoryEntry, rule Rule, now time.Time) Result {
+    start := windowStart(rule, now)
+    if !start.Equal(entry.window) {
+        if start.Sub(entry.window) == rule.Period {
+            entry.previous = entry.current
+        } else {
+            entry.previous = 0
+        }
+
+        entry.current = 0
+        entry.window = start
+    }
+
+    weighted := float64(entry.previous) * previousWeight(rule, now) + float64(entry.current)
+
+    allowed := weighted + 1 <= float64(rule.Limit)
+    if allowed {
+        entry.current++
+    }
+
+    entry.expires = start.Add(2 * rule.Period)
+
+    return slidingWindowResult(rule, now, allowed, entry.previous, entry.current)
+}
+
+// 重置额度
+func (this *MemoryStore) Reset(ctx context.Context, key string, rule Rule) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    delete(this.entries, key)
+
+    return nil
+}
+
+// 清理过期记录
+func (this *MemoryStore) sweep(now time.Time) {
+    if now.Before(this.nextSweep) {
+        return
+    }
+
+    for key, entry := range this.entries {
+        if now.After(entry.expires) {
+            delete(this.entries, key)
+        }
+    }
+
+    this.nextSweep = now.Add(sweepInterval)
+}
@@ -9,7 +9,8 @@ import (
     "github.com/deatil/lakego-doak/lakego/array"
 )
 
-// 请求 IP
+// 请求 IP，只在连接来自 trusted-proxies 设置的代理时读取 X-Forwarded-For 等请求头，
+// 限流、访问限制等需要 IP 的地方统一使用
 func GetRequestIp(ctx *Context) string {
     ip := ctx.ClientIP()
 
@@ -36,6 +36,17 @@ var (
     VerbosePrintfLogger = cron.VerbosePrintfLogger
 )
 
+// 解析选项
+const (
+    Second     = cron.Second
+    Minute     = cron.Minute
+    Hour       = cron.Hour
+    Dom        = cron.Dom
+    Month      = cron.Month
+    Dow        = cron.Dow
+    Descriptor = cron.Descriptor
+)
+
 // 结构体
 type (
     Cron         = cron.Cron
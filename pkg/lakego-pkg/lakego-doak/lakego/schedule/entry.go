@@ -1,6 +1,9 @@
 package schedule
 
 import (
+    "fmt"
+    "time"
+    "context"
     "strings"
 )
 
@@ -22,6 +25,18 @@ type Entry struct {
 
     // 当前任务名称
     Name string
+
+    // 上次运行未结束时跳过
+    SkipOverlapping bool
+
+    // 不重叠运行的锁过期时间
+    OverlappingExpires time.Duration
+
+    // 多台服务器时只在一台运行
+    OneServer bool
+
+    // 运行超时时间
+    Timeout time.Duration
 }
 
 // 构造函数
@@ -59,6 +74,19 @@ func (this *Entry) WithName(name string) *Entry {
     return this
 }
 
+// 任务名称，未设置名称时使用计划时间
+func (this *Entry) DisplayName() string {
+    if this.Name != "" {
+        return this.Name
+    }
+
+    if this.Spec != "" {
+        return this.Spec
+    }
+
+    return fmt.Sprintf("%v", this.Schedule)
+}
+
 // 函数
 func (this *Entry) AddFunc(cmd func()) *Entry {
     return this.WithCmd(cmd)
@@ -76,6 +104,37 @@ func (this *Entry) AddSchedule(schedule ISchedule, cmd IJob) *Entry {
     return this.WithCmd(cmd)
 }
 
+// 上次运行未结束时跳过本次运行
+// 同时设置 OnOneServer 时使用分布式锁，expires 为锁的过期时间，默认 24 小时
+func (this *Entry) WithoutOverlapping(expires ...time.Duration) *Entry {
+    this.SkipOverlapping = true
+
+    if len(expires) > 0 {
+        this.OverlappingExpires = expires[0]
+    }
+
+    return this
+}
+
+// 多台服务器时只在一台运行，需设置锁
+func (this *Entry) OnOneServer() *Entry {
+    this.OneServer = true
+
+    return this
+}
+
+// 设置超时时间，超时后取消任务的 context
+func (this *Entry) WithTimeout(timeout time.Duration) *Entry {
+    this.Timeout = timeout
+
+    return this
+}
+
+// 带 context 的函数
+func (this *Entry) AddContextFunc(cmd func(context.Context) error) *Entry {
+    return this.WithCmd(cmd)
+}
+
 // Yearly
 func (this *Entry) CronYearly() *Entry {
     this.Spec = "@yearly"
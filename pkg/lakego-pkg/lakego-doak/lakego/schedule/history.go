@@ -0,0 +1,214 @@
+package schedule
+
+import (
+    "sync"
+    "time"
+
+    "gorm.io/gorm"
+)
+
+// 运行结果
+const (
+    RunSuccess = "success"
+    RunFailed  = "failed"
+    RunTimeout = "timeout"
+)
+
+/**
+ * 运行记录
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Run struct {
+    // 任务名称
+    Name string `json:"name"`
+
+    // 计划时间
+    Spec string `json:"spec"`
+
+    // 开始时间
+    Start time.Time `json:"start"`
+
+    // 结束时间
+    End time.Time `json:"end"`
+
+    // 耗时
+    Duration time.Duration `json:"duration"`
+
+    // 结果
+    Outcome string `json:"outcome"`
+
+    // 错误信息
+    Error string `json:"error"`
+
+    // 运行的服务器
+    Host string `json:"host"`
+}
+
+// 运行记录存储接口
+type History interface {
+    // 记录
+    Record(run Run) error
+
+    // 最近的记录，name 为空时返回全部任务的记录
+    Recent(name string, limit int) ([]Run, error)
+}
+
+/**
+ * 内存运行记录，只保留最近的记录
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type MemoryHistory struct {
+    // 锁定
+    mu sync.RWMutex
+
+    // 记录
+    runs []Run
+
+    // 最大数量
+    size int
+}
+
+// 构造函数
+func NewMemoryHistory(size int) *MemoryHistory {
+    if size <= 0 {
+        size = 1000
+    }
+
+    return &MemoryHistory{
+        runs: make([]Run, 0),
+        size: size,
+    }
+}
+
+// 记录
+func (this *MemoryHistory) Record(run Run) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.runs = append(this.runs, run)
+    if len(this.runs) > this.size {
+        this.runs = this.runs[len(this.runs) - this.size:]
+    }
+
+    return nil
+}
+
+// 最近的记录，按开始时间倒序
+func (this *MemoryHistory) Recent(name string, limit int) ([]Run, error) {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    runs := make([]Run, 0)
+    for i := len(this.runs) - 1; i >= 0; i-- {
+        if limit > 0 && len(runs) >= limit {
+            break
+        }
+
+        if name == "" || this.runs[i].Name == name {
+            runs = append(runs, this.runs[i])
+        }
+    }
+
+    return runs, nil
+}
+
+// 数据库运行记录
+type RunModel struct {
+    ID       uint64 `gorm:"column:id;primaryKey;autoIncrement;"`
+    Name     string `gorm:"column:name;size:191;not null;index;"`
+    Spec     string `gorm:"column:spec;size:191;"`
+    Start    int64  `gorm:"column:started_at;not null;default:0;"`
+    End      int64  `gorm:"column:ended_at;not null;default:0;"`
+    Duration int64  `gorm:"column:duration;not null;default:0;"`
+    Outcome  string `gorm:"column:outcome;size:20;"`
+    Error    string `gorm:"column:error;type:text;"`
+    Host     string `gorm:"column:host;size:191;"`
+}
+
+/**
+ * 数据库运行记录
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type DatabaseHistory struct {
+    // 数据库
+    db *gorm.DB
+
+    // 数据表
+    table string
+}
+
+// 构造函数
+func NewDatabaseHistory(db *gorm.DB, table string, autoMigrate bool) *DatabaseHistory {
+    if table == "" {
+        table = "schedule_runs"
+    }
+
+    h := &DatabaseHistory{
+        db:    db,
+        table: table,
+    }
+
+    if autoMigrate {
+        h.Migrate()
+    }
+
+    return h
+}
+
+// 创建数据表
+func (this *DatabaseHistory) Migrate() error {
+    return this.db.Table(this.table).AutoMigrate(&RunModel{})
+}
+
+// 记录
+func (this *DatabaseHistory) Record(run Run) error {
+    return this.db.Table(this.table).Create(&RunModel{
+        Name:     run.Name,
+        Spec:     run.Spec,
+        Start:    run.Start.UnixNano(),
+        End:      run.End.UnixNano(),
+        Duration: int64(run.Duration),
+        Outcome:  run.Outcome,
+        Error:    run.Error,
+        Host:     run.Host,
+    }).Error
+}
+
+// 最近的记录
+func (this *DatabaseHistory) Recent(name string, limit int) ([]Run, error) {
+    query := this.db.Table(this.table)
+    if name != "" {
+        query = query.Where("name = ?", name)
+    }
+
+    if limit > 0 {
+        query = query.Limit(limit)
+    }
+
+    var models []RunModel
+    if err := query.Order("id DESC").Find(&models).Error; err != nil {
+        return nil, err
+    }
+
+    runs := make([]Run, 0, len(models))
+    for _, model := range models {
+        runs = append(runs, Run{
+            Name:     model.Name,
+            Spec:     model.Spec,
+            Start:    time.Unix(0, model.Start),
+            End:      time.Unix(0, model.End),
+            Duration: time.Duration(model.Duration),
+            Outcome:  model.Outcome,
+            Error:    model.Error,
+            Host:     model.Host,
+        })
+    }
+
+    return runs, nil
+}
@@ -0,0 +1,29 @@
+package schedule
+
+import (
+    "testing"
+)
+
+func Test_MemoryHistory(t *testing.T) {
+    assertEqual := assertEqualT(t)
+
+    h := NewMemoryHistory(3)
+
+    h.Record(Run{Name: "a", Outcome: RunSuccess})
+    h.Record(Run{Name: "b", Outcome: RunFailed})
+    h.Record(Run{Name: "a", Outcome: RunTimeout})
+    h.Record(Run{Name: "a", Outcome: RunFailed})
+
+    // 只保留最近的记录
+    runs, _ := h.Recent("", 0)
+    assertEqual(len(runs), 3, "size limit")
+    assertEqual(runs[0].Outcome, RunFailed, "newest first")
+    assertEqual(runs[2].Name, "b", "oldest kept")
+
+    runs, _ = h.Recent("a", 0)
+    assertEqual(len(runs), 2, "filter by name")
+
+    runs, _ = h.Recent("a", 1)
+    assertEqual(len(runs), 1, "limit")
+    assertEqual(runs[0].Outcome, RunFailed, "limit newest")
+}
@@ -0,0 +1,49 @@
+package schedule
+
+import (
+    "time"
+
+    "github.com/deatil/lakego-doak/lakego/cache"
+)
+
+// 锁接口
+type Locker interface {
+    // 获取锁，成功时返回释放函数
+    Acquire(key string, ttl time.Duration) (func(), bool, error)
+}
+
+/**
+ * 缓存锁，缓存驱动需支持锁
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type CacheLocker struct {
+    cache *cache.Cache
+}
+
+// 构造函数
+func NewCacheLocker(c *cache.Cache) *CacheLocker {
+    return &CacheLocker{
+        cache: c,
+    }
+}
+
+// 获取锁
+func (this *CacheLocker) Acquire(key string, ttl time.Duration) (func(), bool, error) {
+    seconds := int64(ttl / time.Second)
+    if seconds < 1 {
+        seconds = 1
+    }
+
+    lock := this.cache.Lock(key, seconds)
+
+    ok, err := lock.Get()
+    if err != nil || !ok {
+        return nil, false, err
+    }
+
+    return func() {
+        lock.Release()
+    }, true, nil
+}
@@ -0,0 +1,27 @@
+package schedule
+
+import (
+    "time"
+    "testing"
+)
+
+func Test_CacheLocker(t *testing.T) {
+    assertEqual := assertEqualT(t)
+
+    locker := NewCacheLocker(newTestCache())
+
+    release, ok, err := locker.Acquire("job", time.Minute)
+    assertEqual(err, nil, "Acquire err")
+    assertEqual(ok, true, "Acquire")
+
+    _, ok, _ = locker.Acquire("job", time.Minute)
+    assertEqual(ok, false, "Acquire locked")
+
+    _, ok, _ = locker.Acquire("other", time.Minute)
+    assertEqual(ok, true, "Acquire other key")
+
+    release()
+
+    _, ok, _ = locker.Acquire("job", time.Minute)
+    assertEqual(ok, true, "Acquire after release")
+}
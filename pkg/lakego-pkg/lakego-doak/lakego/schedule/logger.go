@@ -1,9 +1,19 @@
 package schedule
 
 import (
-    "github.com/deatil/lakego-doak/lakego/facade"
+    "log"
 )
 
+// 日志输出，默认使用标准库 log
+var logPrintf = log.Printf
+
+// 设置日志输出
+func SetLogPrintf(fn func(msg string, v ...any)) {
+    if fn != nil {
+        logPrintf = fn
+    }
+}
+
 /**
  * 日志
  *
@@ -21,5 +31,5 @@ func NewLogger() Logger {
 func (this Logger) Printf(msg string, v ...any) {
     msg = "schedule: " + msg
 
-    facade.Logger.Errorf(msg, v...)
+    logPrintf(msg, v...)
 }
@@ -0,0 +1,33 @@
+package schedule
+
+import (
+    "github.com/deatil/lakego-doak/lakego/metrics"
+)
+
+var (
+    // 运行次数
+    jobRuns = metrics.NewCounterVec(
+        "lakego_schedule_job_runs_total",
+        "Total number of scheduled job runs by job.",
+        "job",
+    )
+
+    // 失败次数
+    jobFailures = metrics.NewCounterVec(
+        "lakego_schedule_job_failures_total",
+        "Total number of failed scheduled job runs by job.",
+        "job",
+    )
+
+    // 运行耗时
+    jobDuration = metrics.NewHistogramVec(
+        "lakego_schedule_job_duration_seconds",
+        "Scheduled job run duration in seconds by job.",
+        []float64{.01, .1, .5, 1, 5, 10, 30, 60, 300, 900},
+        "job",
+    )
+)
+
+func init() {
+    metrics.MustRegister(jobRuns, jobFailures, jobDuration)
+}
@@ -0,0 +1,281 @@
+package schedule
+
+import (
+    "os"
+    "fmt"
+    "time"
+    "strconv"
+    "context"
+    "sync/atomic"
+
+    "github.com/deatil/lakego-doak/lakego/trace"
+)
+
+// 带 context 的任务接口
+type IContextJob interface {
+    RunContext(ctx context.Context) error
+}
+
+// 运行包装
+// 处理不重叠运行、单机运行、超时、异常捕获，并记录指标、链路和运行记录
+type entryJob struct {
+    // 计划任务
+    schedule *Schedule
+
+    // 任务数据
+    entry *Entry
+
+    // 任务名称
+    name string
+
+    // 执行
+    run func(ctx context.Context) error
+
+    // 运行中
+    running int32
+}
+
+// 创建运行包装
+func newEntryJob(schedule *Schedule, entry *Entry) *entryJob {
+    var run func(ctx context.Context) error
+
+    switch cmd := entry.Cmd.(type) {
+        // 方法
+        case func():
+            run = func(context.Context) error {
+                cmd()
+                return nil
+            }
+
+        // 带 context 的方法
+        case func(context.Context) error:
+            run = cmd
+
+        // 带 context 的 job 结构体
+        case IContextJob:
+            run = cmd.RunContext
+
+        // job 结构体
+        case IJob:
+            run = func(context.Context) error {
+                cmd.Run()
+                return nil
+            }
+
+        default:
+            return nil
+    }
+
+    return &entryJob{
+        schedule: schedule,
+        entry:    entry,
+        name:     entry.DisplayName(),
+        run:      run,
+    }
+}
+
+// 计划运行，已暂停时跳过
+func (this *entryJob) Run() {
+    if this.schedule.IsPaused(this.name) {
+        return
+    }
+
+    this.start(time.Now(), true)
+}
+
+// 立即运行，不检测暂停状态
+// 手动运行不是计划时间点，不获取单机运行锁，只检测不重叠运行
+func (this *entryJob) RunNow() {
+    this.start(time.Now(), false)
+}
+
+// 开始运行，scheduled 为计划运行
+func (this *entryJob) start(now time.Time, scheduled bool) {
+    // 本机上次运行未结束
+    if this.entry.SkipOverlapping {
+        if !atomic.CompareAndSwapInt32(&this.running, 0, 1) {
+            return
+        }
+    }
+
+    releases := []func(){}
+    finish := func() {
+        for _, release := range releases {
+            release()
+        }
+
+        if this.entry.SkipOverlapping {
+            atomic.StoreInt32(&this.running, 0)
+        }
+    }
+
+    if this.entry.OneServer {
+        if locker := this.schedule.GetLocker(); locker != nil {
+            // 同一计划时间点只有一台服务器获取到锁
+            if scheduled {
+                at, interval := this.scheduledTime(now)
+
+                key := "schedule:" + this.name + ":" + strconv.FormatInt(at.Unix(), 10)
+                if _, ok, err := locker.Acquire(key, lockTTL(interval)); !ok {
+                    this.logError(err)
+                    finish()
+                    return
+                }
+            }
+
+            // 其他服务器上次运行未结束
+            if this.entry.SkipOverlapping {
+                expires := this.entry.OverlappingExpires
+                if expires <= 0 {
+                    expires = 24 * time.Hour
+                }
+
+                release, ok, err := locker.Acquire("schedule:overlapping:" + this.name, expires)
+                if !ok {
+                    this.logError(err)
+                    finish()
+                    return
+                }
+
+                releases = append(releases, release)
+            }
+        }
+    }
+
+    this.execute(finish)
+}
+
+// 本次运行的计划时间及计划间隔
+// 取 now 附近的计划时间点，服务器之间的时间误差小于间隔的一半时得到相同的时间
+func (this *entryJob) scheduledTime(now time.Time) (time.Time, time.Duration) {
+    schedule := this.entry.Schedule
+    if schedule == nil {
+        var err error
+        schedule, err = secondParser.Parse(this.entry.Spec)
+        if err != nil {
+            return now.Truncate(time.Second), time.Second
+        }
+    }
+
+    // 固定间隔的任务没有统一的时间点，按间隔取整
+    if every, ok := schedule.(ConstantDelaySchedule); ok {
+        return now.Round(every.Delay), every.Delay
+    }
+
+    now = now.In(this.schedule.CronLocation())
+
+    next := schedule.Next(now)
+    interval := schedule.Next(next).Sub(next)
+    if interval <= 0 {
+        return next, time.Second
+    }
+
+    return schedule.Next(now.Add(-interval / 2)), interval
+}
+
+// 单机运行锁的过期时间，需大于服务器之间的时间误差
+func lockTTL(interval time.Duration) time.Duration {
+    if interval < time.Second {
+        return time.Second
+    }
+
+    if interval > time.Hour {
+        return time.Hour
+    }
+
+    return interval
+}
+
+// 执行并记录
+func (this *entryJob) execute(finish func()) {
+    start := time.Now()
+
+    ctx := context.Background()
+    cancel := func() {}
+    if this.entry.Timeout > 0 {
+        ctx, cancel = context.WithTimeout(ctx, this.entry.Timeout)
+    }
+    defer cancel()
+
+    // 每次运行为一条链路
+    ctx, span := trace.Start(ctx, "schedule " + this.name,
+        trace.WithSpanKind(trace.SpanKindInternal),
+        trace.WithSpanAttributes(map[string]any{
+            "schedule.job":  this.name,
+            "schedule.spec": this.entry.Spec,
+        }),
+    )
+    defer span.End()
+
+    done := make(chan error, 1)
+    go func() {
+        defer finish()
+
+        done <- this.call(ctx)
+    }()
+
+    var err error
+    outcome := RunSuccess
+
+    select {
+        case err = <-done:
+            if err != nil {
+                outcome = RunFailed
+            }
+        case <-ctx.Done():
+            // 超时后不再等待，任务结束后才释放锁
+            err = ctx.Err()
+            outcome = RunTimeout
+    }
+
+    end := time.Now()
+
+    jobRuns.With(this.name).Inc()
+    jobDuration.With(this.name).Observe(end.Sub(start).Seconds())
+
+    run := Run{
+        Name:     this.name,
+        Spec:     this.entry.Spec,
+        Start:    start,
+        End:      end,
+        Duration: end.Sub(start),
+        Outcome:  outcome,
+        Host:     hostname,
+    }
+
+    span.SetAttribute("schedule.outcome", outcome)
+
+    if err != nil {
+        jobFailures.With(this.name).Inc()
+        span.RecordError(err)
+
+        run.Error = err.Error()
+        this.logError(fmt.Errorf("job %s %s: %w", this.name, outcome, err))
+    }
+
+    if history := this.schedule.GetHistory(); history != nil {
+        if herr := history.Record(run); herr != nil {
+            this.logError(herr)
+        }
+    }
+}
+
+// 调用任务，捕获异常
+func (this *entryJob) call(ctx context.Context) (err error) {
+    defer func() {
+        if r := recover(); r != nil {
+            err = fmt.Errorf("panic: %v", r)
+        }
+    }()
+
+    return this.run(ctx)
+}
+
+func (this *entryJob) logError(err error) {
+    if err != nil {
+        NewLogger().Printf("%s", err.Error())
+    }
+}
+
+// 当前服务器名称
+var hostname, _ = os.Hostname()
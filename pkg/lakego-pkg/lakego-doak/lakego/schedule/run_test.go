@@ -0,0 +1,253 @@
+package schedule
+
+import (
+    "time"
+    "errors"
+    "context"
+    "reflect"
+    "testing"
+    "sync/atomic"
+
+    "github.com/deatil/lakego-doak/lakego/cache"
+    "github.com/deatil/lakego-doak/lakego/cache/driver/memory"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func newTestCache() *cache.Cache {
+    return cache.New(memory.New(memory.Config{}), cache.Config{"type": "memory"}).
+        WithPrefix("test")
+}
+
+func newTestSchedule() *Schedule {
+    SetLogPrintf(func(string, ...any) {})
+
+    return New().WithHistory(NewMemoryHistory(10))
+}
+
+func newTestJob(s *Schedule, entry *Entry) *entryJob {
+    s.AddEntry(entry)
+
+    return s.entryJob(entry)
+}
+
+func Test_RunHistory(t *testing.T) {
+    assertEqual := assertEqualT(t)
+
+    s := newTestSchedule()
+
+    newTestJob(s, NewEntry().WithName("ok").AddFunc(func() {})).RunNow()
+    newTestJob(s, NewEntry().WithName("fail").AddContextFunc(func(context.Context) error {
+        return errors.New("fail")
+    })).RunNow()
+    newTestJob(s, NewEntry().WithName("panic").AddFunc(func() {
+        panic("boom")
+    })).RunNow()
+
+    runs, _ := s.GetHistory().Recent("", 0)
+    assertEqual(len(runs), 3, "history count")
+
+    assertEqual(runs[2].Name, "ok", "success name")
+    assertEqual(runs[2].Outcome, RunSuccess, "success outcome")
+    assertEqual(runs[2].Error, "", "success error")
+
+    assertEqual(runs[1].Outcome, RunFailed, "failed outcome")
+    assertEqual(runs[1].Error, "fail", "failed error")
+
+    // 异常被捕获并记录
+    assertEqual(runs[0].Outcome, RunFailed, "panic outcome")
+    assertEqual(runs[0].Error, "panic: boom", "panic error")
+}
+
+func Test_RunTimeout(t *testing.T) {
+    assertEqual := assertEqualT(t)
+
+    s := newTestSchedule()
+
+    release := make(chan struct{})
+    done := make(chan struct{})
+
+    var calls int32
+    job := newTestJob(s, NewEntry().
+        WithName("slow").
+        WithTimeout(20 * time.Millisecond).
+        WithoutOverlapping().
+        AddContextFunc(func(ctx context.Context) error {
+            atomic.AddInt32(&calls, 1)
+            defer close(done)
+
+            <-ctx.Done()
+            <-release
+
+            return ctx.Err()
+        }))
+
+    job.RunNow()
+
+    runs, _ := s.GetHistory().Recent("slow", 0)
+    assertEqual(len(runs), 1, "timeout recorded")
+    assertEqual(runs[0].Outcome, RunTimeout, "timeout outcome")
+    assertEqual(runs[0].Error, context.DeadlineExceeded.Error(), "timeout error")
+
+    // 超时后任务未结束，不重叠运行时跳过
+    job.RunNow()
+    assertEqual(atomic.LoadInt32(&calls), int32(1), "skip while timed out job running")
+
+    close(release)
+    <-done
+
+    waitFor(t, func() bool {
+        return atomic.LoadInt32(&job.running) == 0
+    })
+
+    job.RunNow()
+    assertEqual(atomic.LoadInt32(&calls), int32(2), "run after job finished")
+}
+
+func Test_RunOverlapping(t *testing.T) {
+    assertEqual := assertEqualT(t)
+
+    s := newTestSchedule()
+
+    started := make(chan struct{}, 2)
+    release := make(chan struct{})
+
+    var calls int32
+    job := newTestJob(s, NewEntry().
+        WithName("overlap").
+        WithoutOverlapping().
+        AddFunc(func() {
+            atomic.AddInt32(&calls, 1)
+            started <- struct{}{}
+            <-release
+        }))
+
+    finished := make(chan struct{})
+    go func() {
+        job.RunNow()
+        close(finished)
+    }()
+
+    <-started
+
+    job.RunNow()
+    assertEqual(atomic.LoadInt32(&calls), int32(1), "skip overlapping")
+
+    close(release)
+    <-finished
+
+    waitFor(t, func() bool {
+        return atomic.LoadInt32(&job.running) == 0
+    })
+
+    job.RunNow()
+    assertEqual(atomic.LoadInt32(&calls), int32(2), "run after finished")
+}
+
+func Test_RunOneServer(t *testing.T) {
+    assertEqual := assertEqualT(t)
+
+    // 两台服务器共用缓存锁
+    locker := NewCacheLocker(newTestCache())
+
+    var calls int32
+    newServerJob := func() *entryJob {
+        s := newTestSchedule().WithLocker(locker)
+
+        return newTestJob(s, NewEntry().
+            WithName("one").
+            WithSpec("0 */5 * * * *").
+            OnOneServer().
+            AddFunc(func() {
+                atomic.AddInt32(&calls, 1)
+            }))
+    }
+
+    job1 := newServerJob()
+    job2 := newServerJob()
+
+    at := time.Date(2026, 10, 19, 12, 5, 0, 0, time.Local)
+
+    // 同一计划时间点，服务器之间有延迟
+    job1.start(at.Add(10 * time.Millisecond), true)
+    job2.start(at.Add(1500 * time.Millisecond), true)
+    assertEqual(atomic.LoadInt32(&calls), int32(1), "one server per tick")
+
+    // 下一个计划时间点
+    job2.start(at.Add(5 * time.Minute + 20 * time.Millisecond), true)
+    job1.start(at.Add(5 * time.Minute + 900 * time.Millisecond), true)
+    assertEqual(atomic.LoadInt32(&calls), int32(2), "one server next tick")
+
+    // 手动运行不获取计划时间点的锁
+    job1.RunNow()
+    job2.RunNow()
+    assertEqual(atomic.LoadInt32(&calls), int32(4), "run now")
+}
+
+func Test_ScheduledTime(t *testing.T) {
+    assertEqual := assertEqualT(t)
+
+    s := newTestSchedule()
+
+    job := newTestJob(s, NewEntry().WithName("spec").WithSpec("0 */5 * * * *").AddFunc(func() {}))
+
+    at := time.Date(2026, 10, 19, 12, 5, 0, 0, time.Local)
+
+    tick, interval := job.scheduledTime(at.Add(3 * time.Second))
+    assertEqual(tick.Equal(at), true, "after tick")
+    assertEqual(interval, 5 * time.Minute, "interval")
+
+    tick, _ = job.scheduledTime(at.Add(-time.Second))
+    assertEqual(tick.Equal(at), true, "before tick")
+
+    tick, _ = job.scheduledTime(at.Add(3 * time.Minute))
+    assertEqual(tick.Equal(at.Add(5 * time.Minute)), true, "near next tick")
+
+    every := newTestJob(s, NewEntry().WithName("every").AddSchedule(Every(30 * time.Second), IFuncJob(func() {})))
+
+    tick, interval = every.scheduledTime(at.Add(100 * time.Millisecond))
+    assertEqual(tick.Equal(at), true, "every tick")
+    assertEqual(interval, 30 * time.Second, "every interval")
+}
+
+func Test_RunPaused(t *testing.T) {
+    assertEqual := assertEqualT(t)
+
+    s := newTestSchedule()
+
+    var calls int32
+    job := newTestJob(s, NewEntry().WithName("paused").AddFunc(func() {
+        atomic.AddInt32(&calls, 1)
+    }))
+
+    assertEqual(s.Pause("paused"), nil, "Pause")
+
+    job.Run()
+    assertEqual(atomic.LoadInt32(&calls), int32(0), "Run paused")
+
+    // 手动运行不检测暂停
+    job.RunNow()
+    assertEqual(atomic.LoadInt32(&calls), int32(1), "RunNow paused")
+
+    assertEqual(s.Resume("paused"), nil, "Resume")
+
+    job.Run()
+    assertEqual(atomic.LoadInt32(&calls), int32(2), "Run resumed")
+}
+
+func waitFor(t *testing.T, fn func() bool) {
+    deadline := time.Now().Add(time.Second)
+    for !fn() {
+        if time.Now().After(deadline) {
+            t.Fatal("wait timeout")
+        }
+
+        time.Sleep(time.Millisecond)
+    }
+}
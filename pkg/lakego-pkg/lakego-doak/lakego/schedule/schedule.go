@@ -4,6 +4,7 @@ import (
     "fmt"
     "time"
     "sync"
+    "errors"
     "context"
 )
 
@@ -18,6 +19,14 @@ const (
     SATURDAY  = "6";
 )
 
+var (
+    // 任务不存在
+    ErrEntryNotFound = errors.New("schedule: entry not found")
+
+    // 任务不能运行
+    ErrEntryInvalid = errors.New("schedule: entry is invalid")
+)
+
 /**
  * 计划任务
  *
@@ -39,8 +48,26 @@ type Schedule struct {
 
     // 已停止的计划任务
     stoped map[string]CronEntry
+
+    // 是否运行中
+    running bool
+
+    // 单机运行使用的锁
+    locker Locker
+
+    // 运行记录
+    history History
+
+    // 暂停状态
+    state State
+
+    // 运行包装
+    jobs map[*Entry]*entryJob
 }
 
+// 带秒的计划时间解析，与 WithSeconds 一致
+var secondParser = NewParser(Second | Minute | Hour | Dom | Month | Dow | Descriptor)
+
 // 构造函数
 func New() *Schedule {
     logger := PrintfLogger(NewLogger())
@@ -55,6 +82,8 @@ func New() *Schedule {
         entries: make([]*Entry, 0),
         cronIDs: make(map[string]CronEntryID),
         stoped:  make(map[string]CronEntry),
+        state:   NewMemoryState(),
+        jobs:    make(map[*Entry]*entryJob),
     }
 
     return schedule
@@ -67,15 +96,86 @@ func (this *Schedule) WithCron(c *Cron) *Schedule {
     return this
 }
 
+// 设置锁，用于 OnOneServer
+func (this *Schedule) WithLocker(locker Locker) *Schedule {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.locker = locker
+
+    return this
+}
+
+// 获取锁
+func (this *Schedule) GetLocker() Locker {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    return this.locker
+}
+
+// 设置运行记录
+func (this *Schedule) WithHistory(history History) *Schedule {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.history = history
+
+    return this
+}
+
+// 获取运行记录
+func (this *Schedule) GetHistory() History {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    return this.history
+}
+
+// 设置暂停状态存储
+func (this *Schedule) WithState(state State) *Schedule {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.state = state
+
+    return this
+}
+
+// 获取暂停状态存储
+func (this *Schedule) GetState() State {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    return this.state
+}
+
 // 添加数据
 func (this *Schedule) WithEntry(entry *Entry) *Schedule {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
     this.entries = append(this.entries, entry)
 
     return this
 }
 
+// 添加数据，运行中时直接添加到计划任务
+func (this *Schedule) AddEntry(entry *Entry) *Schedule {
+    this.WithEntry(entry)
+
+    if this.IsRunning() {
+        this.addEntry(entry)
+    }
+
+    return this
+}
+
 // 清空数据
 func (this *Schedule) ClearEntries() *Schedule {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
     this.entries = make([]*Entry, 0)
 
     return this
@@ -99,31 +199,160 @@ func (this *Schedule) SetLocation(loc string) *Schedule {
 
 // 获取数据
 func (this *Schedule) GetEntry(name string) *Entry {
+    if entry, ok := this.FindEntry(name); ok {
+        return entry
+    }
+
+    return &Entry{}
+}
+
+// 查找数据
+func (this *Schedule) FindEntry(name string) (*Entry, bool) {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
     for _, entry := range this.entries {
         if entry.Name == name {
-            return entry
+            return entry, true
         }
     }
 
-    return &Entry{}
+    return nil, false
 }
 
-// 移除数据
+// 移除数据，已添加到计划任务的同时移除
 func (this *Schedule) RemoveEntry(name string) {
     var entries []*Entry
 
+    this.mu.Lock()
+
     for _, entry := range this.entries {
         if entry.Name != name {
             entries = append(entries, entry)
+        } else {
+            delete(this.jobs, entry)
         }
     }
 
     this.entries = entries
+
+    this.mu.Unlock()
+
+    this.CronRemove(name)
 }
 
 // 全部任务数据
 func (this *Schedule) Entries() []*Entry {
-    return this.entries
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    entries := make([]*Entry, len(this.entries))
+    copy(entries, this.entries)
+
+    return entries
+}
+
+// 暂停任务，设置共享状态存储时对全部进程生效
+func (this *Schedule) Pause(name string) error {
+    if _, ok := this.FindEntry(name); !ok {
+        return ErrEntryNotFound
+    }
+
+    if state := this.GetState(); state != nil {
+        if err := state.Pause(name); err != nil {
+            return err
+        }
+    }
+
+    this.CronStop(name)
+
+    return nil
+}
+
+// 恢复任务
+func (this *Schedule) Resume(name string) error {
+    if _, ok := this.FindEntry(name); !ok {
+        return ErrEntryNotFound
+    }
+
+    if state := this.GetState(); state != nil {
+        if err := state.Resume(name); err != nil {
+            return err
+        }
+    }
+
+    this.CronStart(name)
+
+    return nil
+}
+
+// 任务是否已暂停
+func (this *Schedule) IsPaused(name string) bool {
+    if state := this.GetState(); state != nil {
+        return state.IsPaused(name)
+    }
+
+    return false
+}
+
+// 立即运行任务，不等待运行结束
+func (this *Schedule) RunNow(name string) error {
+    entry, ok := this.FindEntry(name)
+    if !ok {
+        return ErrEntryNotFound
+    }
+
+    job := this.entryJob(entry)
+    if job == nil {
+        return ErrEntryInvalid
+    }
+
+    go job.RunNow()
+
+    return nil
+}
+
+// 获取任务运行包装，同一任务共用不重叠运行状态
+func (this *Schedule) entryJob(entry *Entry) *entryJob {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if job, ok := this.jobs[entry]; ok {
+        return job
+    }
+
+    job := newEntryJob(this, entry)
+    if job != nil {
+        this.jobs[entry] = job
+    }
+
+    return job
+}
+
+// 任务接下来的运行时间
+func (this *Schedule) NextRuns(entry *Entry, from time.Time, n int) ([]time.Time, error) {
+    schedule := entry.Schedule
+    if schedule == nil {
+        var err error
+        schedule, err = secondParser.Parse(entry.Spec)
+        if err != nil {
+            return nil, err
+        }
+    }
+
+    next := from.In(this.CronLocation())
+
+    runs := make([]time.Time, 0, n)
+    for i := 0; i < n; i++ {
+        next = schedule.Next(next)
+        if next.IsZero() {
+            break
+        }
+
+        runs = append(runs, next)
+    }
+
+    return runs, nil
 }
 
 // 设置日志
@@ -143,7 +372,16 @@ func (this *Schedule) SetShowLogInfo(logInfo bool) *Schedule {
 func (this *Schedule) AddFunc(cmd func()) *Entry {
     entry := NewEntry().AddFunc(cmd)
 
-    this.entries = append(this.entries, entry)
+    this.WithEntry(entry)
+
+    return entry
+}
+
+// AddContextFunc
+func (this *Schedule) AddContextFunc(cmd func(context.Context) error) *Entry {
+    entry := NewEntry().AddContextFunc(cmd)
+
+    this.WithEntry(entry)
 
     return entry
 }
@@ -152,7 +390,7 @@ func (this *Schedule) AddFunc(cmd func()) *Entry {
 func (this *Schedule) AddJob(cmd IJob) *Entry {
     entry := NewEntry().AddJob(cmd)
 
-    this.entries = append(this.entries, entry)
+    this.WithEntry(entry)
 
     return entry
 }
@@ -161,7 +399,7 @@ func (this *Schedule) AddJob(cmd IJob) *Entry {
 func (this *Schedule) AddSchedule(schedule ISchedule, cmd IJob) *Entry {
     entry := NewEntry().AddSchedule(schedule, cmd)
 
-    this.entries = append(this.entries, entry)
+    this.WithEntry(entry)
 
     return entry
 }
@@ -170,6 +408,8 @@ func (this *Schedule) AddSchedule(schedule ISchedule, cmd IJob) *Entry {
 func (this *Schedule) Start() {
     this.addEntries()
 
+    this.setRunning(true)
+
     this.Cron.Start()
 }
 
@@ -177,12 +417,31 @@ func (this *Schedule) Start() {
 func (this *Schedule) Run() {
     this.addEntries()
 
+    this.setRunning(true)
+    defer this.setRunning(false)
+
     this.Cron.Run()
 }
 
+// 是否运行中
+func (this *Schedule) IsRunning() bool {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    return this.running
+}
+
+// 设置运行状态
+func (this *Schedule) setRunning(running bool) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.running = running
+}
+
 // 添加全部任务数据
 func (this *Schedule) addEntries() {
-    for _, entry := range this.entries {
+    for _, entry := range this.Entries() {
         this.addEntry(entry)
     }
 }
@@ -197,20 +456,18 @@ func (this *Schedule) addEntry(entry *Entry) {
     var entryID CronEntryID
     var err error
 
-    switch cmd := entry.Cmd.(type) {
-        // 方法
-        case func():
-            entryID, err = this.Cron.AddFunc(entry.Spec, cmd)
-
-        // job 结构体
-        case IJob:
-            if entry.Spec != "" {
-                // 字符
-                entryID, err = this.Cron.AddJob(entry.Spec, cmd)
-            } else if entry.Schedule != nil {
-                // Schedule 结构体
-                entryID = this.Cron.Schedule(entry.Schedule, cmd)
-            }
+    // 运行包装
+    job := this.entryJob(entry)
+    if job == nil {
+        return
+    }
+
+    if entry.Spec != "" {
+        // 字符
+        entryID, err = this.Cron.AddJob(entry.Spec, job)
+    } else if entry.Schedule != nil {
+        // Schedule 结构体
+        entryID = this.Cron.Schedule(entry.Schedule, job)
     }
 
     if err == nil {
@@ -226,12 +483,20 @@ func (this *Schedule) addEntry(entry *Entry) {
     }
 
     if err != nil {
-        fmt.Println(err.Error())
+        NewLogger().Printf("add entry %s fail, error: %s", entry.DisplayName(), err.Error())
+        return
+    }
+
+    // 已暂停的任务不加入计划
+    if entry.Name != "" && this.IsPaused(entry.Name) {
+        this.CronStop(entry.Name)
     }
 }
 
 // 停止
 func (this *Schedule) Stop() context.Context {
+    this.setRunning(false)
+
     return this.Cron.Stop()
 }
 
@@ -259,7 +524,7 @@ func (this *Schedule) CronEntry(name string) CronEntry {
 
 // 任务删除
 func (this *Schedule) CronRemove(name string) {
-    if id, ok := this.cronIDs[name]; ok {
+    if id := this.CronID(name); id > 0 {
         this.Cron.Remove(id)
     }
 
@@ -273,7 +538,11 @@ func (this *Schedule) CronRemove(name string) {
 
 // 任务开启
 func (this *Schedule) CronStart(name string) {
-    if entry, ok := this.stoped[name]; ok {
+    this.mu.RLock()
+    entry, ok := this.stoped[name]
+    this.mu.RUnlock()
+
+    if ok {
         if entry.Valid() {
             // 添加计划任务
             entryID := this.Cron.Schedule(entry.Schedule, entry.Job)
@@ -290,7 +559,7 @@ func (this *Schedule) CronStart(name string) {
 
 // 任务停止
 func (this *Schedule) CronStop(name string) {
-    if id, ok := this.cronIDs[name]; ok {
+    if id := this.CronID(name); id > 0 {
         entry := this.Cron.Entry(id)
         if entry.Valid() {
             this.Cron.Remove(id)
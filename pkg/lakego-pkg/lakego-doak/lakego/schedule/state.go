@@ -0,0 +1,110 @@
+package schedule
+
+import (
+    "sync"
+
+    "github.com/deatil/lakego-doak/lakego/cache"
+)
+
+// 暂停状态存储接口，多个进程共享时暂停对全部进程生效
+type State interface {
+    // 是否已暂停
+    IsPaused(name string) bool
+
+    // 暂停
+    Pause(name string) error
+
+    // 恢复
+    Resume(name string) error
+}
+
+/**
+ * 内存暂停状态，只在当前进程有效
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type MemoryState struct {
+    // 锁定
+    mu sync.RWMutex
+
+    // 已暂停的任务
+    paused map[string]bool
+}
+
+// 构造函数
+func NewMemoryState() *MemoryState {
+    return &MemoryState{
+        paused: make(map[string]bool),
+    }
+}
+
+// 是否已暂停
+func (this *MemoryState) IsPaused(name string) bool {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    return this.paused[name]
+}
+
+// 暂停
+func (this *MemoryState) Pause(name string) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.paused[name] = true
+
+    return nil
+}
+
+// 恢复
+func (this *MemoryState) Resume(name string) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    delete(this.paused, name)
+
+    return nil
+}
+
+/**
+ * 缓存暂停状态，使用共享缓存时对全部服务器生效
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type CacheState struct {
+    cache *cache.Cache
+}
+
+// 构造函数
+func NewCacheState(c *cache.Cache) *CacheState {
+    return &CacheState{
+        cache: c,
+    }
+}
+
+// 是否已暂停
+func (this *CacheState) IsPaused(name string) bool {
+    return this.cache.Has(this.key(name))
+}
+
+// 暂停
+func (this *CacheState) Pause(name string) error {
+    return this.cache.Forever(this.key(name), 1)
+}
+
+// 恢复
+func (this *CacheState) Resume(name string) error {
+    if !this.IsPaused(name) {
+        return nil
+    }
+
+    _, err := this.cache.Forget(this.key(name))
+
+    return err
+}
+
+func (this *CacheState) key(name string) string {
+    return "schedule:paused:" + name
+}
@@ -0,0 +1,28 @@
+package schedule
+
+import (
+    "testing"
+)
+
+func Test_MemoryState(t *testing.T) {
+    testState(t, NewMemoryState())
+}
+
+func Test_CacheState(t *testing.T) {
+    testState(t, NewCacheState(newTestCache()))
+}
+
+func testState(t *testing.T, state State) {
+    assertEqual := assertEqualT(t)
+
+    assertEqual(state.IsPaused("job"), false, "IsPaused default")
+
+    assertEqual(state.Pause("job"), nil, "Pause")
+    assertEqual(state.IsPaused("job"), true, "IsPaused")
+    assertEqual(state.IsPaused("other"), false, "IsPaused other")
+
+    assertEqual(state.Resume("job"), nil, "Resume")
+    assertEqual(state.IsPaused("job"), false, "IsPaused after Resume")
+
+    assertEqual(state.Resume("job"), nil, "Resume not paused")
+}
@@ -1,16 +1,48 @@
 package service_provider
 
 import (
+    "fmt"
+    "time"
+    "context"
+    "strings"
+    "net/http"
+
+    "github.com/deatil/lakego-doak/lakego/i18n"
+    "github.com/deatil/lakego-doak/lakego/path"
+    "github.com/deatil/lakego-doak/lakego/trace"
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/storage"
+    "github.com/deatil/lakego-doak/lakego/health"
+    "github.com/deatil/lakego-doak/lakego/metrics"
     "github.com/deatil/lakego-doak/lakego/schedule"
     "github.com/deatil/lakego-doak/lakego/provider"
+    "github.com/deatil/lakego-doak/lakego/ratelimit"
+    "github.com/deatil/lakego-doak/lakego/kernel/shutdown"
+
+    // 中间件
+    traceMiddleware "github.com/deatil/lakego-doak/lakego/middleware/trace"
+    metricsMiddleware "github.com/deatil/lakego-doak/lakego/middleware/metrics"
+    compressMiddleware "github.com/deatil/lakego-doak/lakego/middleware/compress"
+    conditionalMiddleware "github.com/deatil/lakego-doak/lakego/middleware/conditional"
+    ratelimitMiddleware "github.com/deatil/lakego-doak/lakego/middleware/ratelimit"
+    securityMiddleware "github.com/deatil/lakego-doak/lakego/middleware/security"
+    localeMiddleware "github.com/deatil/lakego-doak/lakego/middleware/locale"
+
+    // 健康检测
+    healthChecker "github.com/deatil/lakego-doak/lakego/health/checker"
 
     // 脚本
     publishCmd "github.com/deatil/lakego-doak/lakego/console/publish"
     storageCmd "github.com/deatil/lakego-doak/lakego/console/storage"
     scheduleCmd "github.com/deatil/lakego-doak/lakego/console/schedule"
+    queueCmd "github.com/deatil/lakego-doak/lakego/console/queue"
 
     // 视图
     "github.com/deatil/lakego-doak/lakego/facade"
+    facade_redis "github.com/deatil/lakego-doak/lakego/facade/redis"
+    facade_queue "github.com/deatil/lakego-doak/lakego/facade/queue"
+    facade_cache "github.com/deatil/lakego-doak/lakego/facade/cache"
+    facade_database "github.com/deatil/lakego-doak/lakego/facade/database"
 )
 
 /**
@@ -28,6 +60,30 @@ func NewLakego() *Lakego {
     return &Lakego{}
 }
 
+// 注册
+func (this *Lakego) Register() {
+    // 请求指标
+    this.loadMetricsMiddleware()
+
+    // 链路追踪
+    this.loadTrace()
+
+    // 限流
+    this.loadRateLimit()
+
+    // 安全响应头及 CSRF
+    this.loadSecurity()
+
+    // 响应压缩及条件请求
+    this.loadCompress()
+
+    // 关闭操作
+    this.loadShutdown()
+
+    // 多语言
+    this.loadI18n()
+}
+
 // 引导
 func (this *Lakego) Boot() {
     // 脚本
@@ -35,6 +91,18 @@ func (this *Lakego) Boot() {
 
     // 模板渲染
     this.loadHtmlRender()
+
+    // 指标路由
+    this.loadMetricsRoute()
+
+    // 健康检测
+    this.loadHealth()
+
+    // 队列
+    this.loadQueueWorker()
+
+    // 计划任务
+    this.loadScheduleRunner()
 }
 
 /**
@@ -46,12 +114,44 @@ func (this *Lakego) loadCommand() {
 
     // 创建软连接
     this.AddCommand(storageCmd.StorageLinkCmd)
+
+    // 执行队列任务
+    this.AddCommand(queueCmd.QueueWorkCmd)
 }
 
 // 计划任务
 func (this *Lakego) Schedule(s *schedule.Schedule) {
+    conf := facade.Config("schedule")
+
+    // 单机运行锁
+    lockCache := facade.Cache
+    if name := conf.GetString("lock-cache"); name != "" {
+        lockCache = facade_cache.Cache(name)
+    }
+
+    s.WithLocker(schedule.NewCacheLocker(lockCache))
+
+    // 暂停状态，与锁使用同一缓存
+    s.WithState(schedule.NewCacheState(lockCache))
+
+    // 运行记录
+    switch conf.GetString("history.driver") {
+        case "database":
+            db := facade.DB
+            if conn := conf.GetString("history.connection"); conn != "" {
+                db = facade_database.Database(conn)
+            }
+
+            s.WithHistory(schedule.NewDatabaseHistory(db, conf.GetString("history.table"), conf.GetBool("history.auto-migrate")))
+        case "memory":
+            s.WithHistory(schedule.NewMemoryHistory(conf.GetInt("history.size")))
+    }
+
     // 计划任务命令
     this.AddCommand(scheduleCmd.NewScheduleCmd(s))
+
+    // 计划任务列表
+    this.AddCommand(scheduleCmd.NewScheduleListCmd(s))
 }
 
 /**
@@ -60,3 +160,398 @@ func (this *Lakego) Schedule(s *schedule.Schedule) {
 func (this *Lakego) loadHtmlRender() {
     this.GetRoute().HTMLRender = facade.ViewHtml.GetRender()
 }
+
+/**
+ * 导入请求指标中间件
+ */
+func (this *Lakego) loadMetricsMiddleware() {
+    if !facade.Config("metrics").GetBool("open") {
+        return
+    }
+
+    // 需在其他服务提供者添加路由前设置
+    this.GetRoute().Use(metricsMiddleware.Handler())
+}
+
+/**
+ * 导入链路追踪
+ */
+func (this *Lakego) loadTrace() {
+    conf := facade.Config("trace")
+    if !conf.GetBool("open") {
+        return
+    }
+
+    var processor trace.Processor
+    switch conf.GetString("exporter") {
+        case "memory":
+            processor = trace.NewSimpleProcessor(trace.NewMemoryExporter())
+
+        default:
+            exporter := trace.NewOTLPExporter(conf.GetString("otlp.endpoint")).
+                WithHeaders(conf.GetStringMapString("otlp.headers"))
+
+            if timeout := conf.GetDuration("otlp.timeout"); timeout > 0 {
+                exporter.WithClient(&http.Client{
+                    Timeout: timeout,
+                })
+            }
+
+            processor = trace.NewBatchProcessor(exporter, trace.BatchOptions{
+                QueueSize: conf.GetInt("batch.queue-size"),
+                BatchSize: conf.GetInt("batch.size"),
+                Interval:  conf.GetDuration("batch.interval"),
+            }).WithErrorHandler(func(err error) {
+                facade.Logger.Error(err.Error())
+            })
+    }
+
+    tracer := trace.NewTracer(processor).
+        WithServiceName(conf.GetString("service-name")).
+        WithSampleRatio(conf.GetFloat64("sample-ratio"))
+
+    trace.SetDefault(tracer)
+
+    // 需在其他服务提供者添加路由前设置
+    this.GetRoute().Use(traceMiddleware.Handler())
+}
+
+/**
+ * 导入限流中间件
+ */
+func (this *Lakego) loadRateLimit() {
+    conf := facade.Config("ratelimit")
+    if !conf.GetBool("open") {
+        return
+    }
+
+    var store ratelimit.Store
+    switch conf.GetString("store") {
+        case "redis":
+            client := facade_redis.Default
+            if name := conf.GetString("redis-connection"); name != "" {
+                client = facade_redis.Connect(name)
+            }
+
+            store = ratelimit.NewRedisStore(client.GetClient(), conf.GetString("prefix"))
+        default:
+            store = ratelimit.NewMemoryStore()
+    }
+
+    limiter := ratelimit.New(store)
+
+    handler := func(name string, key string) (router.HandlerFunc, bool) {
+        rule := ratelimit.Rule{
+            Algorithm: conf.GetString(key + ".algorithm"),
+            Limit:     conf.GetInt(key + ".limit"),
+            Period:    conf.GetDuration(key + ".period"),
+            Burst:     conf.GetInt(key + ".burst"),
+        }
+
+        if rule.Limit <= 0 {
+            return nil, false
+        }
+
+        if err := rule.Validate(); err != nil {
+            facade.Logger.Error("ratelimit " + name + ": " + err.Error())
+            return nil, false
+        }
+
+        return ratelimitMiddleware.Handler(limiter, ratelimitMiddleware.Options{
+            Name: name,
+            Rule: rule,
+            Keys: conf.GetStringSlice(key + ".keys"),
+            OnError: func(ctx *router.Context, err error) {
+                facade.Logger.Error("ratelimit " + name + ": " + err.Error())
+            },
+        }), true
+    }
+
+    // 路由规则，使用 ratelimit:名称 别名
+    for name := range conf.GetStringMap("rules") {
+        if h, ok := handler(name, "rules." + name); ok {
+            router.AliasMiddleware("ratelimit:" + name, h)
+        }
+    }
+
+    // 全局规则，需在其他服务提供者添加路由前设置
+    if h, ok := handler("global", "global"); ok {
+        this.GetRoute().Use(h)
+    }
+}
+
+/**
+ * 导入安全响应头及 CSRF 中间件
+ */
+func (this *Lakego) loadSecurity() {
+    conf := facade.Config("security")
+
+    // 需在其他服务提供者添加路由前设置
+    if conf.GetBool("headers.open") {
+        this.GetRoute().Use(securityMiddleware.Headers(securityMiddleware.HeadersOptions{
+            ContentSecurityPolicy:   conf.GetString("headers.content-security-policy"),
+            CSPReportOnly:           conf.GetBool("headers.csp-report-only"),
+            HSTSMaxAge:              conf.GetDuration("headers.hsts-max-age"),
+            HSTSIncludeSubdomains:   conf.GetBool("headers.hsts-include-subdomains"),
+            HSTSPreload:             conf.GetBool("headers.hsts-preload"),
+            FrameOptions:            conf.GetString("headers.frame-options"),
+            ReferrerPolicy:          conf.GetString("headers.referrer-policy"),
+            ContentTypeNosniff:      conf.GetBool("headers.content-type-nosniff"),
+            PermissionsPolicy:       conf.GetString("headers.permissions-policy"),
+            CrossOriginOpenerPolicy: conf.GetString("headers.cross-origin-opener-policy"),
+        }))
+    }
+
+    opts := securityMiddleware.CSRFOptions{
+        Mode:         conf.GetString("csrf.mode"),
+        Secret:       []byte(conf.GetString("csrf.secret")),
+        CookieName:   conf.GetString("csrf.cookie-name"),
+        CookiePath:   conf.GetString("csrf.cookie-path"),
+        CookieDomain: conf.GetString("csrf.cookie-domain"),
+        Secure:       conf.GetBool("csrf.secure"),
+        TTL:          conf.GetDuration("csrf.ttl"),
+        HeaderName:   conf.GetString("csrf.header-name"),
+        FieldName:    conf.GetString("csrf.field-name"),
+        Except:       conf.GetStringSlice("csrf.except"),
+    }
+
+    switch strings.ToLower(conf.GetString("csrf.same-site")) {
+        case "strict":
+            opts.SameSite = http.SameSiteStrictMode
+        case "none":
+            opts.SameSite = http.SameSiteNoneMode
+        default:
+            opts.SameSite = http.SameSiteLaxMode
+    }
+
+    if opts.Mode == securityMiddleware.CSRFSynchronizer {
+        opts.Cache = facade.Cache
+        if name := conf.GetString("csrf.cache"); name != "" {
+            opts.Cache = facade_cache.Cache(name)
+        }
+    }
+
+    csrf, err := securityMiddleware.CSRF(opts)
+    if err != nil {
+        facade.Logger.Error(err.Error())
+        return
+    }
+
+    router.AliasMiddleware("csrf", csrf)
+}
+
+/**
+ * 导入响应压缩及条件请求中间件
+ */
+func (this *Lakego) loadCompress() {
+    conf := facade.Config("compress")
+
+    // 需在其他服务提供者添加路由前设置，压缩需在条件请求之前
+    if conf.GetBool("compress.open") {
+        this.GetRoute().Use(compressMiddleware.Handler(compressMiddleware.Options{
+            Level:        conf.GetInt("compress.level"),
+            MinLength:    conf.GetInt("compress.min-length"),
+            Encodings:    conf.GetStringSlice("compress.encodings"),
+            ContentTypes: conf.GetStringSlice("compress.content-types"),
+            ExcludePaths: conf.GetStringSlice("compress.exclude-paths"),
+        }))
+    }
+
+    if conf.GetBool("conditional.open") {
+        this.GetRoute().Use(conditionalMiddleware.Handler(conditionalMiddleware.Options{
+            MaxSize:      conf.GetInt("conditional.max-size"),
+            ExcludePaths: conf.GetStringSlice("conditional.exclude-paths"),
+        }))
+    }
+}
+
+/**
+ * 导入指标路由
+ */
+func (this *Lakego) loadMetricsRoute() {
+    conf := facade.Config("metrics")
+    if !conf.GetBool("open") {
+        return
+    }
+
+    path := conf.GetString("path")
+    if path == "" {
+        path = "/metrics"
+    }
+
+    this.AddRoute(func(engine *router.Engine) {
+        engine.GET(path, metricsMiddleware.Guard(), router.WrapH(metrics.Handler()))
+    })
+}
+
+/**
+ * 导入健康检测
+ */
+func (this *Lakego) loadHealth() {
+    conf := facade.Config("health")
+    if !conf.GetBool("open") {
+        return
+    }
+
+    health.Default().
+        WithTimeout(conf.GetDuration("timeout")).
+        WithCacheTTL(conf.GetDuration("cache-ttl"))
+
+    // 内置检测
+    if conf.GetBool("checkers.database") {
+        this.AddHealthChecker(healthChecker.Database("database", facade.DB))
+    }
+
+    if conf.GetBool("checkers.redis") {
+        this.AddHealthChecker(healthChecker.Redis("redis", facade_redis.Default.GetClient()))
+    }
+
+    // 只检测指定的磁盘，磁盘在检测时才创建
+    for _, name := range conf.GetStringSlice("checkers.storage") {
+        this.AddHealthChecker(healthChecker.Storage("storage:" + name, resolveStorage(name)))
+    }
+
+    if conf.GetBool("checkers.schedule") && this.App != nil {
+        this.AddHealthChecker(healthChecker.Schedule("schedule", this.App.GetSchedule()))
+    }
+
+    prefix := conf.GetString("path-prefix")
+    if prefix == "" {
+        prefix = "/health"
+    }
+
+    this.AddRoute(func(engine *router.Engine) {
+        engine.GET(prefix + "/live", health.LiveHandler(health.Default()))
+        engine.GET(prefix + "/ready", health.ReadyHandler(health.Default()))
+    })
+}
+
+// 创建磁盘，配置错误时返回错误
+func resolveStorage(name string) func() (*storage.Storage, error) {
+    return func() (disk *storage.Storage, err error) {
+        defer func() {
+            if r := recover(); r != nil {
+                err = fmt.Errorf("storage %s: %v", name, r)
+            }
+        }()
+
+        return facade.TryStorageWithDisk(name)
+    }
+}
+
+/**
+ * http 服务中创建队列数据表及执行队列任务
+ */
+func (this *Lakego) loadQueueWorker() {
+    if this.App == nil || this.App.RunningInConsole() {
+        return
+    }
+
+    // 创建数据表
+    if err := facade_queue.Migrate(); err != nil {
+        facade.Logger.Error("queue: migrate fail, error: " + err.Error())
+        return
+    }
+
+    if !facade.Config("queue").GetBool("worker.run-in-server") {
+        return
+    }
+
+    if err := facade_queue.NewWorker(nil).Start(); err != nil {
+        facade.Logger.Error("queue: " + err.Error())
+    }
+}
+
+/**
+ * http 服务中执行计划任务
+ */
+func (this *Lakego) loadScheduleRunner() {
+    if this.App == nil || this.App.RunningInConsole() {
+        return
+    }
+
+    if !facade.Config("schedule").GetBool("run-in-server") {
+        return
+    }
+
+    if s := this.App.GetSchedule(); s != nil && !s.IsRunning() {
+        s.Start()
+
+        // 关闭时停止调度新任务，等待运行中的任务完成
+        timeout := facade.Config("server").GetDuration("shutdown.schedule-timeout")
+        shutdown.Register("schedule", shutdown.PrioritySchedule, timeout, func(ctx context.Context) error {
+            select {
+                case <-s.Stop().Done():
+                    return nil
+                case <-ctx.Done():
+                    return ctx.Err()
+            }
+        })
+    }
+}
+
+/**
+ * 导入多语言
+ */
+func (this *Lakego) loadI18n() {
+    conf := facade.Config("i18n")
+
+    translator := i18n.Default()
+    if fallback := conf.GetString("fallback"); fallback != "" {
+        translator.WithFallback(fallback)
+    }
+
+    // 应用语言包为默认命名空间
+    if langPath := conf.GetString("path"); langPath != "" {
+        if err := translator.LoadDir("", path.FormatPath(langPath)); err != nil {
+            facade.Logger.Error("i18n: " + err.Error())
+        }
+    }
+
+    // 模板过滤器，{{ "admin::key"|trans:locale }}
+    if err := i18n.RegisterFilter("trans", translator); err != nil {
+        facade.Logger.Error("i18n: " + err.Error())
+    }
+
+    if !conf.GetBool("locale.open") {
+        return
+    }
+
+    this.GetRoute().Use(localeMiddleware.Handler(translator, localeMiddleware.Options{
+        Query:  conf.GetString("locale.query"),
+        Cookie: conf.GetString("locale.cookie"),
+    }))
+}
+
+/**
+ * 导入关闭操作
+ */
+func (this *Lakego) loadShutdown() {
+    // 停止队列执行者，等待执行中的任务完成
+    shutdown.Register("queue", shutdown.PriorityQueue, facade.Config("queue").GetDuration("worker.stop-timeout"), facade_queue.Shutdown)
+
+    // 导出未导出的链路数据
+    shutdown.Register("trace", shutdown.PriorityTrace, 5 * time.Second, trace.Shutdown)
+
+    // 关闭连接
+    shutdown.Register("database", shutdown.PriorityConnection, 0, func(context.Context) error {
+        if facade.DB == nil {
+            return nil
+        }
+
+        db, err := facade.DB.DB()
+        if err != nil {
+            return err
+        }
+
+        return db.Close()
+    })
+
+    shutdown.Register("redis", shutdown.PriorityConnection, 0, func(context.Context) error {
+        if facade_redis.Default.GetClient() == nil {
+            return nil
+        }
+
+        return facade_redis.Default.Close()
+    })
+}
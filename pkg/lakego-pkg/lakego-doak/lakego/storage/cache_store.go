@@ -0,0 +1,45 @@
+package storage
+
+import (
+    "time"
+
+    "github.com/deatil/lakego-doak/lakego/cache"
+)
+
+/**
+ * 文件信息缓存存储，用于 go-filesystem 的缓存适配器
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type CacheStore struct {
+    // 缓存
+    cache *cache.Cache
+}
+
+// 文件信息缓存存储
+func NewCacheStore(c *cache.Cache) *CacheStore {
+    return &CacheStore{
+        cache: c,
+    }
+}
+
+// 获取
+func (this *CacheStore) Get(key string) (any, error) {
+    return this.cache.Get(key)
+}
+
+// 存储，缓存时间不足 1 秒时按 1 秒处理
+func (this *CacheStore) Put(key string, value any, ttl time.Duration) error {
+    seconds := int64(ttl / time.Second)
+    if seconds < 1 {
+        seconds = 1
+    }
+
+    return this.cache.Put(key, value, seconds)
+}
+
+// 删除
+func (this *CacheStore) Forget(key string) (bool, error) {
+    return this.cache.Forget(key)
+}
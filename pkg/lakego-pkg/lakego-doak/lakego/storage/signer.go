@@ -0,0 +1,227 @@
+package storage
+
+import (
+    "mime"
+    "time"
+    "errors"
+    "strconv"
+    "net/url"
+    "crypto/hmac"
+
+    "github.com/deatil/go-hash/hash"
+)
+
+// 链接参数名称
+const (
+    signKeyDisk        = "disk"
+    signKeyPath        = "path"
+    signKeyExpires     = "expires"
+    signKeyFilename    = "filename"
+    signKeyDisposition = "disposition"
+    signKeyBindIP      = "bind-ip"
+    signKeySignature   = "signature"
+)
+
+// 参与签名的参数
+var signKeys = []string{
+    signKeyDisk,
+    signKeyPath,
+    signKeyExpires,
+    signKeyFilename,
+    signKeyDisposition,
+    signKeyBindIP,
+}
+
+var (
+    // 链接签名错误
+    ErrUrlSignature = errors.New("storage: url signature invalid")
+
+    // 链接已过期
+    ErrUrlExpired = errors.New("storage: url expired")
+
+    // 访问 IP 不匹配
+    ErrUrlIP = errors.New("storage: url ip not match")
+
+    // 签名密钥为空
+    ErrUrlSignKeyEmpty = errors.New("storage: url sign key is empty")
+)
+
+/**
+ * 临时链接选项
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type TemporaryUrlOptions struct {
+    // 下载文件名，为空时使用路径中的文件名
+    Filename string
+
+    // 响应方式，inline 或 attachment，为空时不设置
+    Disposition string
+
+    // 限定访问 IP，为空时不限定
+    IP string
+}
+
+// 生成 Content-Disposition 响应头
+func (this TemporaryUrlOptions) ContentDisposition() string {
+    return ContentDisposition(this.Disposition, this.Filename)
+}
+
+// 生成 Content-Disposition 响应头
+// 文件名包含非 ASCII 字符时使用 RFC 2231 编码
+func ContentDisposition(disposition string, filename string) string {
+    if disposition == "" {
+        return ""
+    }
+
+    params := map[string]string{}
+    if filename != "" {
+        params["filename"] = filename
+    }
+
+    return mime.FormatMediaType(disposition, params)
+}
+
+/**
+ * 临时链接数据
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type SignedUrl struct {
+    // 磁盘
+    Disk string
+
+    // 文件路径
+    Path string
+
+    // 过期时间
+    Expires time.Time
+
+    // 下载文件名
+    Filename string
+
+    // 响应方式
+    Disposition string
+}
+
+/**
+ * 临时链接签名，使用 HMAC-SHA256
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type UrlSigner struct {
+    // 签名密钥
+    key []byte
+
+    // 当前时间，测试时可替换
+    now func() time.Time
+}
+
+// 临时链接签名
+func NewUrlSigner(key string) *UrlSigner {
+    return &UrlSigner{
+        key: []byte(key),
+        now: time.Now,
+    }
+}
+
+// 设置当前时间函数
+func (this *UrlSigner) WithNow(now func() time.Time) *UrlSigner {
+    this.now = now
+
+    return this
+}
+
+// 生成签名后的链接参数
+func (this *UrlSigner) Sign(disk string, path string, expiry time.Duration, opts TemporaryUrlOptions) (url.Values, error) {
+    if len(this.key) == 0 {
+        return nil, ErrUrlSignKeyEmpty
+    }
+
+    query := url.Values{}
+    query.Set(signKeyDisk, disk)
+    query.Set(signKeyPath, path)
+    query.Set(signKeyExpires, strconv.FormatInt(this.now().Add(expiry).Unix(), 10))
+
+    if opts.Filename != "" {
+        query.Set(signKeyFilename, opts.Filename)
+    }
+    if opts.Disposition != "" {
+        query.Set(signKeyDisposition, opts.Disposition)
+    }
+
+    // IP 只参与签名，不出现在链接中
+    if opts.IP != "" {
+        query.Set(signKeyBindIP, "1")
+    }
+
+    query.Set(signKeySignature, this.signature(query, opts.IP))
+
+    return query, nil
+}
+
+// 验证链接参数，ip 为当前访问者 IP
+func (this *UrlSigner) Verify(query url.Values, ip string) (*SignedUrl, error) {
+    if len(this.key) == 0 {
+        return nil, ErrUrlSignKeyEmpty
+    }
+
+    signature := query.Get(signKeySignature)
+    if signature == "" {
+        return nil, ErrUrlSignature
+    }
+
+    // 只取签名相关参数，忽略链接中的其他参数
+    data := url.Values{}
+    for _, k := range signKeys {
+        if v, ok := query[k]; ok {
+            data[k] = v
+        }
+    }
+
+    bindIP := ""
+    if data.Get(signKeyBindIP) != "" {
+        bindIP = ip
+    }
+
+    if !hmac.Equal([]byte(this.signature(data, bindIP)), []byte(signature)) {
+        // 绑定 IP 的链接校验失败时按 IP 不匹配处理
+        if bindIP != "" {
+            return nil, ErrUrlIP
+        }
+
+        return nil, ErrUrlSignature
+    }
+
+    expires, err := strconv.ParseInt(data.Get(signKeyExpires), 10, 64)
+    if err != nil {
+        return nil, ErrUrlSignature
+    }
+
+    if this.now().Unix() > expires {
+        return nil, ErrUrlExpired
+    }
+
+    return &SignedUrl{
+        Disk:        data.Get(signKeyDisk),
+        Path:        data.Get(signKeyPath),
+        Expires:     time.Unix(expires, 0),
+        Filename:    data.Get(signKeyFilename),
+        Disposition: data.Get(signKeyDisposition),
+    }, nil
+}
+
+// 计算签名，参数按名称排序
+func (this *UrlSigner) signature(query url.Values, ip string) string {
+    data := query.Encode()
+    if ip != "" {
+        data += "\n" + ip
+    }
+
+    return hash.FromString(data).
+        Hmac(hash.HmacSHA256.New, this.key).
+        ToHexString()
+}
@@ -2,10 +2,16 @@ package storage
 
 import(
     "io"
+    "time"
+    "errors"
     "strings"
+    "net/url"
+    "path/filepath"
 
     "github.com/deatil/go-filesystem/filesystem"
     "github.com/deatil/go-filesystem/filesystem/interfaces"
+    "github.com/deatil/go-filesystem/filesystem/adapter/cached"
+    "github.com/deatil/go-filesystem/filesystem/adapter/readonly"
 )
 
 /**
@@ -16,8 +22,25 @@ import(
  */
 type Storage struct {
     *filesystem.Filesystem
+
+    // 磁盘名称
+    disk string
+
+    // 临时链接验证地址
+    temporaryUrl string
+
+    // 临时链接签名
+    signer *UrlSigner
 }
 
+// 支持预签名链接的适配器
+type PresignedAdapter interface {
+    PresignedUrlWithQuery(path string, expires time.Duration, query url.Values, method ...string) (string, error)
+}
+
+// 预签名链接不支持绑定 IP
+var ErrUrlIPNotSupported = errors.New("storage: presigned url does not support ip binding")
+
 // new 文件管理器
 func New(adapters interfaces.Adapter, conf ...map[string]any) *Storage {
     fs := &filesystem.Filesystem{}
@@ -33,7 +56,40 @@ func New(adapters interfaces.Adapter, conf ...map[string]any) *Storage {
 
 // new 文件管理器
 func NewWithFilesystem(fs *filesystem.Filesystem) *Storage {
-    return &Storage{fs}
+    return &Storage{
+        Filesystem: fs,
+    }
+}
+
+// 设置磁盘名称
+func (this *Storage) WithDisk(disk string) *Storage {
+    this.disk = disk
+
+    return this
+}
+
+// 磁盘名称
+func (this *Storage) GetDisk() string {
+    return this.disk
+}
+
+// 设置临时链接验证地址
+func (this *Storage) WithTemporaryUrl(url string) *Storage {
+    this.temporaryUrl = url
+
+    return this
+}
+
+// 设置临时链接签名
+func (this *Storage) WithUrlSigner(signer *UrlSigner) *Storage {
+    this.signer = signer
+
+    return this
+}
+
+// 临时链接签名
+func (this *Storage) GetUrlSigner() *UrlSigner {
+    return this.signer
 }
 
 // 判断
@@ -128,3 +184,68 @@ func (this *Storage) Url(url string) string {
 func (this *Storage) ConcatPathToUrl(url string, path string) string {
     return strings.TrimSuffix(url, "/") + "/" + strings.TrimPrefix(path, "/")
 }
+
+// 临时链接
+// 适配器支持预签名时使用预签名链接，否则生成由验证路由处理的签名链接
+func (this *Storage) TemporaryUrl(path string, expiry time.Duration, opts ...TemporaryUrlOptions) (string, error) {
+    var opt TemporaryUrlOptions
+    if len(opts) > 0 {
+        opt = opts[0]
+    }
+
+    path = strings.Trim(path, "/")
+
+    if opt.Disposition != "" && opt.Filename == "" {
+        opt.Filename = filepath.Base(path)
+    }
+
+    if adapter, ok := presignedAdapter(this.GetAdapter()); ok {
+        if opt.IP != "" {
+            return "", ErrUrlIPNotSupported
+        }
+
+        query := url.Values{}
+        if disposition := opt.ContentDisposition(); disposition != "" {
+            query.Set("response-content-disposition", disposition)
+        }
+
+        return adapter.PresignedUrlWithQuery(path, expiry, query)
+    }
+
+    if this.signer == nil {
+        return "", ErrUrlSignKeyEmpty
+    }
+
+    query, err := this.signer.Sign(this.disk, path, expiry, opt)
+    if err != nil {
+        return "", err
+    }
+
+    separator := "?"
+    if strings.Contains(this.temporaryUrl, "?") {
+        separator = "&"
+    }
+
+    return this.temporaryUrl + separator + query.Encode(), nil
+}
+
+// 获取支持预签名的适配器
+// 缓存、只读及链路追踪适配器向内查找，加密适配器保存的是密文，不使用预签名
+func presignedAdapter(adapter interfaces.Adapter) (PresignedAdapter, bool) {
+    for {
+        if presigned, ok := adapter.(PresignedAdapter); ok {
+            return presigned, true
+        }
+
+        switch wrapper := adapter.(type) {
+            case *cached.Cached:
+                adapter = wrapper.GetAdapter()
+            case *readonly.ReadOnly:
+                adapter = wrapper.GetAdapter()
+            case *TraceAdapter:
+                adapter = wrapper.GetAdapter()
+            default:
+                return nil, false
+        }
+    }
+}
@@ -0,0 +1,301 @@
+package storage
+
+import (
+    "time"
+    "context"
+    "strings"
+    "reflect"
+    "testing"
+    "net/url"
+    "net/http"
+    "net/http/httptest"
+
+    "github.com/deatil/go-filesystem/filesystem/config"
+    cached_adapter "github.com/deatil/go-filesystem/filesystem/adapter/cached"
+    readonly_adapter "github.com/deatil/go-filesystem/filesystem/adapter/readonly"
+    memory_adapter "github.com/deatil/go-filesystem/filesystem/adapter/memory"
+
+    "github.com/deatil/lakego-doak/lakego/trace"
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/cache"
+    "github.com/deatil/lakego-doak/lakego/cache/driver/memory"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func assertErrorT(t *testing.T) func(error, string) {
+    return func(err error, msg string) {
+        if err != nil {
+            t.Errorf("Failed %s: error: %+v", msg, err)
+        }
+    }
+}
+
+// 预签名测试适配器
+type testPresignedAdapter struct {
+    *memory_adapter.Memory
+
+    query url.Values
+}
+
+func (this *testPresignedAdapter) PresignedUrlWithQuery(path string, expires time.Duration, query url.Values, method ...string) (string, error) {
+    this.query = query
+
+    return "https://bucket.example.com/" + path + "?presigned=1", nil
+}
+
+func Test_UrlSigner(t *testing.T) {
+    assertEqual := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    now := time.Unix(1700000000, 0)
+    signer := NewUrlSigner("test-key").WithNow(func() time.Time {
+        return now
+    })
+
+    query, err := signer.Sign("local", "a/b.txt", time.Minute, TemporaryUrlOptions{
+        Filename:    "下载.txt",
+        Disposition: "attachment",
+    })
+    assertError(err, "Sign")
+
+    // 链接中的其他参数不影响验证
+    query.Set("utm", "x")
+
+    data, err := signer.Verify(query, "")
+    assertError(err, "Verify")
+    assertEqual(data, &SignedUrl{
+        Disk:        "local",
+        Path:        "a/b.txt",
+        Expires:     now.Add(time.Minute),
+        Filename:    "下载.txt",
+        Disposition: "attachment",
+    }, "Verify data")
+
+    changed := url.Values{}
+    for k, v := range query {
+        changed[k] = v
+    }
+    changed.Set("path", "a/c.txt")
+
+    _, err = signer.Verify(changed, "")
+    assertEqual(err, ErrUrlSignature, "Verify changed path")
+
+    _, err = NewUrlSigner("other-key").Verify(query, "")
+    assertEqual(err, ErrUrlSignature, "Verify other key")
+
+    now = now.Add(2 * time.Minute)
+    _, err = signer.Verify(query, "")
+    assertEqual(err, ErrUrlExpired, "Verify expired")
+
+    _, err = NewUrlSigner("").Sign("local", "a.txt", time.Minute, TemporaryUrlOptions{})
+    assertEqual(err, ErrUrlSignKeyEmpty, "Sign empty key")
+}
+
+func Test_UrlSignerIP(t *testing.T) {
+    assertEqual := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    signer := NewUrlSigner("test-key")
+
+    query, err := signer.Sign("local", "a.txt", time.Minute, TemporaryUrlOptions{
+        IP: "10.0.0.1",
+    })
+    assertError(err, "Sign")
+    assertEqual(strings.Contains(query.Encode(), "10.0.0.1"), false, "IP not in url")
+
+    _, err = signer.Verify(query, "10.0.0.1")
+    assertError(err, "Verify ip")
+
+    _, err = signer.Verify(query, "10.0.0.2")
+    assertEqual(err, ErrUrlIP, "Verify other ip")
+
+    // 去掉 IP 绑定参数后签名失效
+    query.Del("bind-ip")
+    _, err = signer.Verify(query, "10.0.0.2")
+    assertEqual(err, ErrUrlSignature, "Verify without bind")
+}
+
+func Test_UrlSignerTrustedProxy(t *testing.T) {
+    assertEqual := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    signer := NewUrlSigner("test-key")
+
+    query, err := signer.Sign("local", "a.txt", time.Minute, TemporaryUrlOptions{
+        IP: "1.2.3.4",
+    })
+    assertError(err, "Sign")
+
+    router.SetMode(router.ReleaseMode)
+
+    // 与默认配置一致，只信任本机代理
+    r := router.New()
+    r.SetTrustedProxies([]string{"127.0.0.1"})
+    r.GET("/temporary", func(ctx *router.Context) {
+        if _, err := signer.Verify(ctx.Request.URL.Query(), router.GetRequestIp(ctx)); err != nil {
+            ctx.Status(http.StatusForbidden)
+            return
+        }
+
+        ctx.Status(http.StatusOK)
+    })
+
+    request := func(remote string, forwarded string) int {
+        req := httptest.NewRequest("GET", "/temporary?" + query.Encode(), nil)
+        req.RemoteAddr = remote + ":1234"
+        if forwarded != "" {
+            req.Header.Set("X-Forwarded-For", forwarded)
+        }
+
+        w := httptest.NewRecorder()
+        r.ServeHTTP(w, req)
+
+        return w.Code
+    }
+
+    // 经过代理访问时使用代理传入的客户端 IP
+    assertEqual(request("127.0.0.1", "1.2.3.4"), http.StatusOK, "bound client through proxy")
+    assertEqual(request("127.0.0.1", "5.6.7.8"), http.StatusForbidden, "other client through proxy")
+
+    // 不信任的地址伪造请求头无效
+    assertEqual(request("10.0.0.1", "1.2.3.4"), http.StatusForbidden, "spoofed header")
+    assertEqual(request("1.2.3.4", ""), http.StatusOK, "bound client direct")
+}
+
+func Test_TemporaryUrl(t *testing.T) {
+    assertEqual := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    signer := NewUrlSigner("test-key")
+
+    fs := New(memory_adapter.New()).
+        WithDisk("local").
+        WithTemporaryUrl("http://127.0.0.1/storage/temporary").
+        WithUrlSigner(signer)
+
+    link, err := fs.TemporaryUrl("/a/b.txt", time.Minute, TemporaryUrlOptions{
+        Disposition: "attachment",
+    })
+    assertError(err, "TemporaryUrl")
+    assertEqual(strings.HasPrefix(link, "http://127.0.0.1/storage/temporary?"), true, "TemporaryUrl base")
+
+    u, _ := url.Parse(link)
+    data, err := signer.Verify(u.Query(), "")
+    assertError(err, "TemporaryUrl Verify")
+    assertEqual(data.Disk, "local", "TemporaryUrl disk")
+    assertEqual(data.Path, "a/b.txt", "TemporaryUrl path")
+    assertEqual(data.Filename, "b.txt", "TemporaryUrl filename")
+
+    _, err = New(memory_adapter.New()).TemporaryUrl("a.txt", time.Minute)
+    assertEqual(err, ErrUrlSignKeyEmpty, "TemporaryUrl without signer")
+}
+
+func Test_TemporaryUrlPresigned(t *testing.T) {
+    assertEqual := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    adapter := &testPresignedAdapter{
+        Memory: memory_adapter.New(),
+    }
+
+    fs := New(adapter)
+
+    link, err := fs.TemporaryUrl("a.txt", time.Minute, TemporaryUrlOptions{
+        Filename:    "中文.txt",
+        Disposition: "inline",
+    })
+    assertError(err, "TemporaryUrl")
+    assertEqual(link, "https://bucket.example.com/a.txt?presigned=1", "TemporaryUrl presigned")
+    assertEqual(adapter.query.Get("response-content-disposition"), "inline; filename*=utf-8''%E4%B8%AD%E6%96%87.txt", "TemporaryUrl disposition")
+
+    _, err = fs.TemporaryUrl("a.txt", time.Minute, TemporaryUrlOptions{
+        IP: "10.0.0.1",
+    })
+    assertEqual(err, ErrUrlIPNotSupported, "TemporaryUrl presigned ip")
+
+    // 包装后仍使用预签名
+    wrapped := New(readonly_adapter.New(adapter))
+
+    link, err = wrapped.TemporaryUrl("a.txt", time.Minute)
+    assertError(err, "TemporaryUrl wrapped")
+    assertEqual(link, "https://bucket.example.com/a.txt?presigned=1", "TemporaryUrl wrapped presigned")
+}
+
+func Test_CacheStore(t *testing.T) {
+    assertEqual := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    c := cache.New(memory.New(memory.Config{}), cache.Config{"type": "memory"}).
+        WithPrefix("test")
+
+    adapter := memory_adapter.New()
+    fs := New(cached_adapter.New(adapter, NewCacheStore(c), "storage:", time.Minute))
+
+    _, err := fs.Put("a.txt", []byte("aaa"))
+    assertError(err, "Put")
+
+    size, err := fs.GetSize("a.txt")
+    assertError(err, "GetSize")
+    assertEqual(size, int64(3), "GetSize")
+    assertEqual(c.Has("storage:metadata:a.txt"), true, "cached")
+
+    // 直接修改底层数据后仍返回缓存
+    adapter.Update("a.txt", []byte("aaaaa"), config.New(map[string]any{}))
+
+    size, _ = fs.GetSize("a.txt")
+    assertEqual(size, int64(3), "GetSize cached")
+
+    fs.Put("a.txt", []byte("aaaaa"))
+
+    size, _ = fs.GetSize("a.txt")
+    assertEqual(size, int64(5), "GetSize after Put")
+}
+
+func Test_WithContext(t *testing.T) {
+    assertEqual := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    exporter := trace.NewMemoryExporter()
+    trace.SetDefault(trace.NewTracer(trace.NewSimpleProcessor(exporter)))
+    defer trace.SetDefault(nil)
+
+    fs := New(memory_adapter.New()).WithDisk("memory")
+
+    ctx, root := trace.Start(context.Background(), "root")
+
+    tfs := fs.WithContext(ctx)
+    assertEqual(tfs.GetDisk(), "memory", "GetDisk")
+
+    _, err := tfs.Put("a.txt", []byte("aaa"))
+    assertError(err, "Put")
+
+    _, err = tfs.Read("none.txt")
+    assertEqual(err != nil, true, "Read none")
+
+    root.End()
+
+    span, ok := exporter.FindSpan("storage.write")
+    assertEqual(ok, true, "write span")
+    assertEqual(span.Attributes["storage.disk"], "memory", "disk")
+    assertEqual(span.Attributes["storage.path"], "a.txt", "path")
+    assertEqual(span.ParentSpanID, root.SpanContext().SpanID, "parent")
+
+    span, ok = exporter.FindSpan("storage.read")
+    assertEqual(ok, true, "read span")
+    assertEqual(span.StatusCode, trace.StatusError, "read error")
+
+    // 原文件管理器不记录
+    exporter.Reset()
+    fs.Has("a.txt")
+    assertEqual(len(exporter.Spans()), 0, "original storage")
+
+    _, ok = presignedAdapter(NewTraceAdapter(ctx, "memory", &testPresignedAdapter{}))
+    assertEqual(ok, true, "presignedAdapter unwrap")
+}
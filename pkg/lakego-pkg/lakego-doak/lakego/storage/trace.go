@@ -0,0 +1,261 @@
+package storage
+
+import (
+    "io"
+    "context"
+
+    "github.com/deatil/go-filesystem/filesystem"
+    "github.com/deatil/go-filesystem/filesystem/interfaces"
+
+    "github.com/deatil/lakego-doak/lakego/trace"
+)
+
+// 带上下文的文件管理器，上下文中有链路时记录文件操作
+func (this *Storage) WithContext(ctx context.Context) *Storage {
+    fs := filesystem.New(NewTraceAdapter(ctx, this.disk, this.GetAdapter()))
+    fs.WithConfig(this.GetConfig())
+
+    storage := *this
+    storage.Filesystem = fs
+
+    return &storage
+}
+
+/**
+ * 链路追踪适配器
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type TraceAdapter struct {
+    // 被包装的适配器
+    interfaces.Adapter
+
+    // 上下文
+    ctx context.Context
+
+    // 磁盘名称
+    disk string
+}
+
+// 构造函数
+func NewTraceAdapter(ctx context.Context, disk string, adapter interfaces.Adapter) *TraceAdapter {
+    return &TraceAdapter{
+        Adapter: adapter,
+        ctx:     ctx,
+        disk:    disk,
+    }
+}
+
+// 被包装的适配器
+func (this *TraceAdapter) GetAdapter() interfaces.Adapter {
+    return this.Adapter
+}
+
+// 判断
+func (this *TraceAdapter) Has(path string) bool {
+    span := this.startSpan("has", path)
+    defer span.End()
+
+    return this.Adapter.Has(path)
+}
+
+// 上传
+func (this *TraceAdapter) Write(path string, contents []byte, conf interfaces.Config) (map[string]any, error) {
+    span := this.startSpan("write", path)
+
+    data, err := this.Adapter.Write(path, contents, conf)
+    this.endSpan(span, err)
+
+    return data, err
+}
+
+// 上传 Stream 文件类型
+func (this *TraceAdapter) WriteStream(path string, stream io.Reader, conf interfaces.Config) (map[string]any, error) {
+    span := this.startSpan("write_stream", path)
+
+    data, err := this.Adapter.WriteStream(path, stream, conf)
+    this.endSpan(span, err)
+
+    return data, err
+}
+
+// 更新
+func (this *TraceAdapter) Update(path string, contents []byte, conf interfaces.Config) (map[string]any, error) {
+    span := this.startSpan("update", path)
+
+    data, err := this.Adapter.Update(path, contents, conf)
+    this.endSpan(span, err)
+
+    return data, err
+}
+
+// 更新
+func (this *TraceAdapter) UpdateStream(path string, stream io.Reader, conf interfaces.Config) (map[string]any, error) {
+    span := this.startSpan("update_stream", path)
+
+    data, err := this.Adapter.UpdateStream(path, stream, conf)
+    this.endSpan(span, err)
+
+    return data, err
+}
+
+// 读取
+func (this *TraceAdapter) Read(path string) (map[string]any, error) {
+    span := this.startSpan("read", path)
+
+    data, err := this.Adapter.Read(path)
+    this.endSpan(span, err)
+
+    return data, err
+}
+
+// 读取文件为数据流
+func (this *TraceAdapter) ReadStream(path string) (map[string]any, error) {
+    span := this.startSpan("read_stream", path)
+
+    data, err := this.Adapter.ReadStream(path)
+    this.endSpan(span, err)
+
+    return data, err
+}
+
+// 重命名
+func (this *TraceAdapter) Rename(path string, newpath string) error {
+    span := this.startSpan("rename", path)
+
+    err := this.Adapter.Rename(path, newpath)
+    this.endSpan(span, err)
+
+    return err
+}
+
+// 复制
+func (this *TraceAdapter) Copy(path string, newpath string) error {
+    span := this.startSpan("copy", path)
+
+    err := this.Adapter.Copy(path, newpath)
+    this.endSpan(span, err)
+
+    return err
+}
+
+// 删除
+func (this *TraceAdapter) Delete(path string) error {
+    span := this.startSpan("delete", path)
+
+    err := this.Adapter.Delete(path)
+    this.endSpan(span, err)
+
+    return err
+}
+
+// 删除文件夹
+func (this *TraceAdapter) DeleteDir(dirname string) error {
+    span := this.startSpan("delete_dir", dirname)
+
+    err := this.Adapter.DeleteDir(dirname)
+    this.endSpan(span, err)
+
+    return err
+}
+
+// 创建文件夹
+func (this *TraceAdapter) CreateDir(dirname string, conf interfaces.Config) (map[string]string, error) {
+    span := this.startSpan("create_dir", dirname)
+
+    data, err := this.Adapter.CreateDir(dirname, conf)
+    this.endSpan(span, err)
+
+    return data, err
+}
+
+// 列出内容
+func (this *TraceAdapter) ListContents(directory string, recursive ...bool) ([]map[string]any, error) {
+    span := this.startSpan("list_contents", directory)
+
+    data, err := this.Adapter.ListContents(directory, recursive...)
+    this.endSpan(span, err)
+
+    return data, err
+}
+
+// 文件信息
+func (this *TraceAdapter) GetMetadata(path string) (map[string]any, error) {
+    span := this.startSpan("get_metadata", path)
+
+    data, err := this.Adapter.GetMetadata(path)
+    this.endSpan(span, err)
+
+    return data, err
+}
+
+// 文件大小
+func (this *TraceAdapter) GetSize(path string) (map[string]any, error) {
+    span := this.startSpan("get_size", path)
+
+    data, err := this.Adapter.GetSize(path)
+    this.endSpan(span, err)
+
+    return data, err
+}
+
+// 类型
+func (this *TraceAdapter) GetMimetype(path string) (map[string]any, error) {
+    span := this.startSpan("get_mimetype", path)
+
+    data, err := this.Adapter.GetMimetype(path)
+    this.endSpan(span, err)
+
+    return data, err
+}
+
+// 获取时间戳
+func (this *TraceAdapter) GetTimestamp(path string) (map[string]any, error) {
+    span := this.startSpan("get_timestamp", path)
+
+    data, err := this.Adapter.GetTimestamp(path)
+    this.endSpan(span, err)
+
+    return data, err
+}
+
+// 获取文件的权限
+func (this *TraceAdapter) GetVisibility(path string) (map[string]string, error) {
+    span := this.startSpan("get_visibility", path)
+
+    data, err := this.Adapter.GetVisibility(path)
+    this.endSpan(span, err)
+
+    return data, err
+}
+
+// 设置文件的权限
+func (this *TraceAdapter) SetVisibility(path string, visibility string) (map[string]string, error) {
+    span := this.startSpan("set_visibility", path)
+
+    data, err := this.Adapter.SetVisibility(path, visibility)
+    this.endSpan(span, err)
+
+    return data, err
+}
+
+// 开始记录
+func (this *TraceAdapter) startSpan(operation string, path string) *trace.Span {
+    _, span := trace.StartChild(this.ctx, "storage." + operation,
+        trace.WithSpanKind(trace.SpanKindClient),
+        trace.WithSpanAttributes(map[string]any{
+            "storage.disk":      this.disk,
+            "storage.operation": operation,
+            "storage.path":      path,
+        }),
+    )
+
+    return span
+}
+
+// 结束记录
+func (this *TraceAdapter) endSpan(span *trace.Span, err error) {
+    span.RecordError(err)
+    span.End()
+}
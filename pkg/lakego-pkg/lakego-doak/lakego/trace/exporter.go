@@ -0,0 +1,86 @@
+package trace
+
+import (
+    "sync"
+    "context"
+)
+
+/**
+ * 节点导出
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Exporter interface {
+    // 导出
+    ExportSpans(context.Context, []SpanData) error
+
+    // 关闭
+    Shutdown(context.Context) error
+}
+
+/**
+ * 内存导出，用于测试
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type MemoryExporter struct {
+    // 锁定
+    mu sync.Mutex
+
+    // 已导出的节点
+    spans []SpanData
+}
+
+// 构造函数
+func NewMemoryExporter() *MemoryExporter {
+    return &MemoryExporter{
+        spans: make([]SpanData, 0),
+    }
+}
+
+// 导出
+func (this *MemoryExporter) ExportSpans(ctx context.Context, spans []SpanData) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.spans = append(this.spans, spans...)
+
+    return nil
+}
+
+// 关闭
+func (this *MemoryExporter) Shutdown(ctx context.Context) error {
+    return nil
+}
+
+// 已导出的节点
+func (this *MemoryExporter) Spans() []SpanData {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    spans := make([]SpanData, len(this.spans))
+    copy(spans, this.spans)
+
+    return spans
+}
+
+// 按名称查找
+func (this *MemoryExporter) FindSpan(name string) (SpanData, bool) {
+    for _, span := range this.Spans() {
+        if span.Name == name {
+            return span, true
+        }
+    }
+
+    return SpanData{}, false
+}
+
+// 清空
+func (this *MemoryExporter) Reset() {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.spans = make([]SpanData, 0)
+}
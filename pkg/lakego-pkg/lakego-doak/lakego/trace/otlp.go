@@ -0,0 +1,238 @@
+package trace
+
+import (
+    "io"
+    "fmt"
+    "sort"
+    "time"
+    "bytes"
+    "context"
+    "strconv"
+    "net/http"
+    "encoding/json"
+)
+
+// 默认 OTLP/HTTP 地址
+const DefaultOTLPEndpoint = "http://127.0.0.1:4318/v1/traces"
+
+// 导出的 scope 名称
+const scopeName = "github.com/deatil/lakego-doak/lakego/trace"
+
+/**
+ * OTLP/HTTP 导出，使用 json 编码
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type OTLPExporter struct {
+    // 地址
+    endpoint string
+
+    // 自定义请求头
+    headers map[string]string
+
+    // 请求
+    client *http.Client
+}
+
+// 构造函数
+func NewOTLPExporter(endpoint string) *OTLPExporter {
+    if endpoint == "" {
+        endpoint = DefaultOTLPEndpoint
+    }
+
+    return &OTLPExporter{
+        endpoint: endpoint,
+        headers:  make(map[string]string),
+        client:   &http.Client{
+            Timeout: 10 * time.Second,
+        },
+    }
+}
+
+// 设置请求头，如认证信息
+func (this *OTLPExporter) WithHeaders(headers map[string]string) *OTLPExporter {
+    for k, v := range headers {
+        this.headers[k] = v
+    }
+
+    return this
+}
+
+// 设置请求
+func (this *OTLPExporter) WithClient(client *http.Client) *OTLPExporter {
+    this.client = client
+
+    return this
+}
+
+// 导出
+func (this *OTLPExporter) ExportSpans(ctx context.Context, spans []SpanData) error {
+    if len(spans) == 0 {
+        return nil
+    }
+
+    body, err := json.Marshal(otlpRequest(spans))
+    if err != nil {
+        return err
+    }
+
+    req, err := http.NewRequestWithContext(ctx, http.MethodPost, this.endpoint, bytes.NewReader(body))
+    if err != nil {
+        return err
+    }
+
+    req.Header.Set("Content-Type", "application/json")
+    for k, v := range this.headers {
+        req.Header.Set(k, v)
+    }
+
+    resp, err := this.client.Do(req)
+    if err != nil {
+        return err
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode < 200 || resp.StatusCode > 299 {
+        msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+        return fmt.Errorf("trace: otlp export failed with status %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+    }
+
+    io.Copy(io.Discard, resp.Body)
+
+    return nil
+}
+
+// 关闭
+func (this *OTLPExporter) Shutdown(ctx context.Context) error {
+    this.client.CloseIdleConnections()
+
+    return nil
+}
+
+// 生成请求数据，按服务信息分组
+func otlpRequest(spans []SpanData) map[string]any {
+    groups := make([]map[string]any, 0)
+    groupSpans := make(map[string][]any)
+    groupOrder := make([]string, 0)
+
+    for _, span := range spans {
+        key := fmt.Sprint(span.Resource[ServiceNameKey])
+        if _, ok := groupSpans[key]; !ok {
+            groupOrder = append(groupOrder, key)
+
+            groups = append(groups, map[string]any{
+                "resource": map[string]any{
+                    "attributes": otlpAttributes(span.Resource),
+                },
+            })
+        }
+
+        groupSpans[key] = append(groupSpans[key], otlpSpan(span))
+    }
+
+    for i, key := range groupOrder {
+        groups[i]["scopeSpans"] = []any{
+            map[string]any{
+                "scope": map[string]any{
+                    "name": scopeName,
+                },
+                "spans": groupSpans[key],
+            },
+        }
+    }
+
+    return map[string]any{
+        "resourceSpans": groups,
+    }
+}
+
+// 节点数据
+func otlpSpan(span SpanData) map[string]any {
+    data := map[string]any{
+        "traceId":           span.SpanContext.TraceID.String(),
+        "spanId":            span.SpanContext.SpanID.String(),
+        "name":              span.Name,
+        "kind":              int(span.Kind),
+        "startTimeUnixNano": strconv.FormatInt(span.StartTime.UnixNano(), 10),
+        "endTimeUnixNano":   strconv.FormatInt(span.EndTime.UnixNano(), 10),
+        "attributes":        otlpAttributes(span.Attributes),
+        "status":            map[string]any{
+            "code":    int(span.StatusCode),
+            "message": span.StatusMessage,
+        },
+    }
+
+    if span.ParentSpanID.IsValid() {
+        data["parentSpanId"] = span.ParentSpanID.String()
+    }
+
+    if len(span.Events) > 0 {
+        events := make([]any, 0, len(span.Events))
+        for _, event := range span.Events {
+            events = append(events, map[string]any{
+                "timeUnixNano": strconv.FormatInt(event.Time.UnixNano(), 10),
+                "name":         event.Name,
+                "attributes":   otlpAttributes(event.Attributes),
+            })
+        }
+
+        data["events"] = events
+    }
+
+    return data
+}
+
+// 属性列表，按名称排序
+func otlpAttributes(attributes map[string]any) []any {
+    keys := make([]string, 0, len(attributes))
+    for k := range attributes {
+        keys = append(keys, k)
+    }
+
+    sort.Strings(keys)
+
+    list := make([]any, 0, len(keys))
+    for _, k := range keys {
+        list = append(list, map[string]any{
+            "key":   k,
+            "value": otlpValue(attributes[k]),
+        })
+    }
+
+    return list
+}
+
+// 属性值
+func otlpValue(value any) map[string]any {
+    switch v := value.(type) {
+        case string:
+            return map[string]any{"stringValue": v}
+        case bool:
+            return map[string]any{"boolValue": v}
+        case int:
+            return map[string]any{"intValue": strconv.FormatInt(int64(v), 10)}
+        case int32:
+            return map[string]any{"intValue": strconv.FormatInt(int64(v), 10)}
+        case int64:
+            return map[string]any{"intValue": strconv.FormatInt(v, 10)}
+        case uint:
+            return map[string]any{"intValue": strconv.FormatUint(uint64(v), 10)}
+        case uint32:
+            return map[string]any{"intValue": strconv.FormatUint(uint64(v), 10)}
+        case uint64:
+            return map[string]any{"intValue": strconv.FormatUint(v, 10)}
+        case float32:
+            return map[string]any{"doubleValue": float64(v)}
+        case float64:
+            return map[string]any{"doubleValue": v}
+        case time.Duration:
+            return map[string]any{"intValue": strconv.FormatInt(int64(v), 10)}
+        case error:
+            return map[string]any{"stringValue": v.Error()}
+        case fmt.Stringer:
+            return map[string]any{"stringValue": v.String()}
+    }
+
+    return map[string]any{"stringValue": fmt.Sprint(value)}
+}
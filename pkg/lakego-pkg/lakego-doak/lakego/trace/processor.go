@@ -0,0 +1,275 @@
+package trace
+
+import (
+    "sync"
+    "time"
+    "context"
+    "sync/atomic"
+)
+
+/**
+ * 节点处理器
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Processor interface {
+    // 节点结束
+    OnEnd(SpanData)
+
+    // 导出未导出的节点
+    ForceFlush(context.Context) error
+
+    // 关闭
+    Shutdown(context.Context) error
+}
+
+/**
+ * 同步导出，用于测试及内存导出
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type SimpleProcessor struct {
+    // 导出
+    exporter Exporter
+}
+
+// 构造函数
+func NewSimpleProcessor(exporter Exporter) *SimpleProcessor {
+    return &SimpleProcessor{
+        exporter: exporter,
+    }
+}
+
+// 节点结束
+func (this *SimpleProcessor) OnEnd(data SpanData) {
+    this.exporter.ExportSpans(context.Background(), []SpanData{data})
+}
+
+// 导出未导出的节点
+func (this *SimpleProcessor) ForceFlush(ctx context.Context) error {
+    return nil
+}
+
+// 关闭
+func (this *SimpleProcessor) Shutdown(ctx context.Context) error {
+    return this.exporter.Shutdown(ctx)
+}
+
+/**
+ * 批量导出设置
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type BatchOptions struct {
+    // 队列长度，队列满时丢弃节点
+    QueueSize int
+
+    // 每批数量
+    BatchSize int
+
+    // 导出间隔
+    Interval time.Duration
+
+    // 单次导出超时
+    Timeout time.Duration
+}
+
+/**
+ * 批量导出
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type BatchProcessor struct {
+    // 导出
+    exporter Exporter
+
+    // 设置
+    options BatchOptions
+
+    // 队列
+    queue chan SpanData
+
+    // 立即导出
+    flush chan chan struct{}
+
+    // 停止
+    stop chan struct{}
+
+    // 已停止
+    done chan struct{}
+
+    // 只关闭一次
+    stopOnce sync.Once
+
+    // 丢弃数量
+    dropped int64
+
+    // 导出错误回调
+    onError func(error)
+}
+
+// 构造函数
+func NewBatchProcessor(exporter Exporter, options BatchOptions) *BatchProcessor {
+    if options.QueueSize <= 0 {
+        options.QueueSize = 2048
+    }
+
+    if options.BatchSize <= 0 {
+        options.BatchSize = 512
+    }
+
+    if options.BatchSize > options.QueueSize {
+        options.BatchSize = options.QueueSize
+    }
+
+    if options.Interval <= 0 {
+        options.Interval = 5 * time.Second
+    }
+
+    if options.Timeout <= 0 {
+        options.Timeout = 30 * time.Second
+    }
+
+    processor := &BatchProcessor{
+        exporter: exporter,
+        options:  options,
+        queue:    make(chan SpanData, options.QueueSize),
+        flush:    make(chan chan struct{}),
+        stop:     make(chan struct{}),
+        done:     make(chan struct{}),
+        onError:  func(error) {},
+    }
+
+    go processor.run()
+
+    return processor
+}
+
+// 设置导出错误回调
+func (this *BatchProcessor) WithErrorHandler(fn func(error)) *BatchProcessor {
+    this.onError = fn
+
+    return this
+}
+
+// 丢弃数量
+func (this *BatchProcessor) Dropped() int64 {
+    return atomic.LoadInt64(&this.dropped)
+}
+
+// 节点结束
+func (this *BatchProcessor) OnEnd(data SpanData) {
+    select {
+        case <-this.stop:
+            atomic.AddInt64(&this.dropped, 1)
+            return
+        default:
+    }
+
+    select {
+        case this.queue <- data:
+        default:
+            atomic.AddInt64(&this.dropped, 1)
+    }
+}
+
+// 导出未导出的节点
+func (this *BatchProcessor) ForceFlush(ctx context.Context) error {
+    finished := make(chan struct{})
+
+    select {
+        case this.flush <- finished:
+        case <-this.done:
+            return nil
+        case <-ctx.Done():
+            return ctx.Err()
+    }
+
+    select {
+        case <-finished:
+            return nil
+        case <-ctx.Done():
+            return ctx.Err()
+    }
+}
+
+// 关闭，导出队列中的节点
+func (this *BatchProcessor) Shutdown(ctx context.Context) error {
+    this.stopOnce.Do(func() {
+        close(this.stop)
+    })
+
+    select {
+        case <-this.done:
+        case <-ctx.Done():
+            return ctx.Err()
+    }
+
+    return this.exporter.Shutdown(ctx)
+}
+
+// 后台导出
+func (this *BatchProcessor) run() {
+    defer close(this.done)
+
+    ticker := time.NewTicker(this.options.Interval)
+    defer ticker.Stop()
+
+    batch := make([]SpanData, 0, this.options.BatchSize)
+
+    export := func() {
+        if len(batch) == 0 {
+            return
+        }
+
+        ctx, cancel := context.WithTimeout(context.Background(), this.options.Timeout)
+        defer cancel()
+
+        if err := this.exporter.ExportSpans(ctx, batch); err != nil {
+            this.onError(err)
+        }
+
+        batch = make([]SpanData, 0, this.options.BatchSize)
+    }
+
+    // 取出队列中的全部节点
+    drain := func() {
+        for {
+            select {
+                case data := <-this.queue:
+                    batch = append(batch, data)
+                    if len(batch) >= this.options.BatchSize {
+                        export()
+                    }
+                default:
+                    export()
+                    return
+            }
+        }
+    }
+
+    for {
+        select {
+            case data := <-this.queue:
+                batch = append(batch, data)
+                if len(batch) >= this.options.BatchSize {
+                    export()
+                }
+
+            case <-ticker.C:
+                export()
+
+            case finished := <-this.flush:
+                drain()
+                close(finished)
+
+            case <-this.stop:
+                drain()
+                return
+        }
+    }
+}
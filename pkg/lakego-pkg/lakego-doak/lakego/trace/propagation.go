@@ -0,0 +1,111 @@
+package trace
+
+import (
+    "errors"
+    "context"
+    "net/http"
+    "encoding/hex"
+)
+
+// W3C Trace Context 请求头
+const (
+    TraceparentHeader = "traceparent"
+    TracestateHeader  = "tracestate"
+)
+
+var (
+    // traceparent 格式错误
+    ErrInvalidTraceparent = errors.New("trace: invalid traceparent")
+)
+
+// 解析 traceparent，格式为 version-traceid-spanid-flags
+func ParseTraceparent(value string) (SpanContext, error) {
+    var sc SpanContext
+
+    // 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+    if len(value) < 55 || value[2] != '-' || value[35] != '-' || value[52] != '-' {
+        return sc, ErrInvalidTraceparent
+    }
+
+    version, err := decodeHex(value[0:2])
+    if err != nil || version[0] == 0xff {
+        return sc, ErrInvalidTraceparent
+    }
+
+    // 当前版本长度固定，后续版本可追加字段
+    if version[0] == 0 && len(value) != 55 {
+        return sc, ErrInvalidTraceparent
+    }
+
+    if version[0] > 0 && len(value) > 55 && value[55] != '-' {
+        return sc, ErrInvalidTraceparent
+    }
+
+    traceID, err := decodeHex(value[3:35])
+    if err != nil {
+        return sc, ErrInvalidTraceparent
+    }
+
+    spanID, err := decodeHex(value[36:52])
+    if err != nil {
+        return sc, ErrInvalidTraceparent
+    }
+
+    flags, err := decodeHex(value[53:55])
+    if err != nil {
+        return sc, ErrInvalidTraceparent
+    }
+
+    copy(sc.TraceID[:], traceID)
+    copy(sc.SpanID[:], spanID)
+    sc.Sampled = flags[0] & 0x01 == 0x01
+    sc.Remote = true
+
+    if !sc.IsValid() {
+        return SpanContext{}, ErrInvalidTraceparent
+    }
+
+    return sc, nil
+}
+
+// 生成 traceparent
+func FormatTraceparent(sc SpanContext) string {
+    flags := "00"
+    if sc.Sampled {
+        flags = "01"
+    }
+
+    return "00-" + sc.TraceID.String() + "-" + sc.SpanID.String() + "-" + flags
+}
+
+// 从请求头读取上游节点上下文
+func Extract(ctx context.Context, header http.Header) context.Context {
+    sc, err := ParseTraceparent(header.Get(TraceparentHeader))
+    if err != nil {
+        return ctx
+    }
+
+    return ContextWithRemoteSpanContext(ctx, sc)
+}
+
+// 写入节点上下文到请求头，用于调用下游服务
+func Inject(ctx context.Context, header http.Header) {
+    sc := SpanContextFromContext(ctx)
+    if !sc.IsValid() {
+        return
+    }
+
+    header.Set(TraceparentHeader, FormatTraceparent(sc))
+}
+
+// 只允许小写十六进制
+func decodeHex(value string) ([]byte, error) {
+    for i := 0; i < len(value); i++ {
+        c := value[i]
+        if !(c >= '0' && c <= '9') && !(c >= 'a' && c <= 'f') {
+            return nil, ErrInvalidTraceparent
+        }
+    }
+
+    return hex.DecodeString(value)
+}
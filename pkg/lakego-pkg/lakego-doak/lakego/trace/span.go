@@ -0,0 +1,310 @@
+package trace
+
+import (
+    "sync"
+    "time"
+    "crypto/rand"
+    "encoding/hex"
+)
+
+// 链路 ID
+type TraceID [16]byte
+
+// 是否有效
+func (this TraceID) IsValid() bool {
+    return this != TraceID{}
+}
+
+// 十六进制字符
+func (this TraceID) String() string {
+    return hex.EncodeToString(this[:])
+}
+
+// 节点 ID
+type SpanID [8]byte
+
+// 是否有效
+func (this SpanID) IsValid() bool {
+    return this != SpanID{}
+}
+
+// 十六进制字符
+func (this SpanID) String() string {
+    return hex.EncodeToString(this[:])
+}
+
+// 节点类型，数值与 OTLP 一致
+type SpanKind int
+
+const (
+    SpanKindInternal SpanKind = 1
+    SpanKindServer   SpanKind = 2
+    SpanKindClient   SpanKind = 3
+    SpanKindProducer SpanKind = 4
+    SpanKindConsumer SpanKind = 5
+)
+
+// 状态，数值与 OTLP 一致
+type StatusCode int
+
+const (
+    StatusUnset StatusCode = 0
+    StatusOk    StatusCode = 1
+    StatusError StatusCode = 2
+)
+
+/**
+ * 节点上下文，跨服务传递
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type SpanContext struct {
+    // 链路 ID
+    TraceID TraceID
+
+    // 节点 ID
+    SpanID SpanID
+
+    // 是否采样
+    Sampled bool
+
+    // 是否来自上游服务
+    Remote bool
+}
+
+// 是否有效
+func (this SpanContext) IsValid() bool {
+    return this.TraceID.IsValid() && this.SpanID.IsValid()
+}
+
+/**
+ * 节点事件
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Event struct {
+    // 名称
+    Name string
+
+    // 时间
+    Time time.Time
+
+    // 属性
+    Attributes map[string]any
+}
+
+/**
+ * 已结束的节点数据，用于导出
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type SpanData struct {
+    // 名称
+    Name string
+
+    // 类型
+    Kind SpanKind
+
+    // 节点上下文
+    SpanContext SpanContext
+
+    // 父节点 ID
+    ParentSpanID SpanID
+
+    // 开始时间
+    StartTime time.Time
+
+    // 结束时间
+    EndTime time.Time
+
+    // 属性
+    Attributes map[string]any
+
+    // 事件
+    Events []Event
+
+    // 状态
+    StatusCode StatusCode
+
+    // 状态说明
+    StatusMessage string
+
+    // 服务信息
+    Resource map[string]any
+}
+
+// 耗时
+func (this SpanData) Duration() time.Duration {
+    return this.EndTime.Sub(this.StartTime)
+}
+
+/**
+ * 节点，nil 时所有方法为空操作
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Span struct {
+    // 锁定
+    mu sync.Mutex
+
+    // 链路
+    tracer *Tracer
+
+    // 数据
+    data SpanData
+
+    // 是否记录，未采样时只传递上下文
+    recording bool
+
+    // 已结束
+    ended bool
+}
+
+// 节点上下文
+func (this *Span) SpanContext() SpanContext {
+    if this == nil {
+        return SpanContext{}
+    }
+
+    return this.data.SpanContext
+}
+
+// 是否记录
+func (this *Span) IsRecording() bool {
+    if this == nil {
+        return false
+    }
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    return this.recording && !this.ended
+}
+
+// 设置名称
+func (this *Span) SetName(name string) {
+    this.update(func() {
+        this.data.Name = name
+    })
+}
+
+// 设置属性
+func (this *Span) SetAttribute(key string, value any) {
+    this.update(func() {
+        this.data.Attributes[key] = value
+    })
+}
+
+// 批量设置属性
+func (this *Span) SetAttributes(attributes map[string]any) {
+    this.update(func() {
+        for k, v := range attributes {
+            this.data.Attributes[k] = v
+        }
+    })
+}
+
+// 添加事件
+func (this *Span) AddEvent(name string, attributes ...map[string]any) {
+    this.update(func() {
+        event := Event{
+            Name: name,
+            Time: time.Now(),
+        }
+
+        if len(attributes) > 0 {
+            event.Attributes = attributes[0]
+        }
+
+        this.data.Events = append(this.data.Events, event)
+    })
+}
+
+// 记录错误，并设置为错误状态
+func (this *Span) RecordError(err error) {
+    if err == nil {
+        return
+    }
+
+    this.AddEvent("exception", map[string]any{
+        "exception.message": err.Error(),
+    })
+
+    this.SetStatus(StatusError, err.Error())
+}
+
+// 设置状态，已设置为 Ok 时不再修改
+func (this *Span) SetStatus(code StatusCode, message string) {
+    this.update(func() {
+        if this.data.StatusCode == StatusOk {
+            return
+        }
+
+        this.data.StatusCode = code
+        if code == StatusError {
+            this.data.StatusMessage = message
+        }
+    })
+}
+
+// 结束
+func (this *Span) End() {
+    if this == nil {
+        return
+    }
+
+    this.mu.Lock()
+    if this.ended || !this.recording {
+        this.ended = true
+        this.mu.Unlock()
+        return
+    }
+
+    this.ended = true
+    this.data.EndTime = time.Now()
+
+    data := this.data
+    this.mu.Unlock()
+
+    this.tracer.export(data)
+}
+
+// 修改数据
+func (this *Span) update(fn func()) {
+    if this == nil {
+        return
+    }
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if !this.recording || this.ended {
+        return
+    }
+
+    fn()
+}
+
+// 生成链路 ID
+func newTraceID() TraceID {
+    var id TraceID
+    for !id.IsValid() {
+        rand.Read(id[:])
+    }
+
+    return id
+}
+
+// 生成节点 ID
+func newSpanID() SpanID {
+    var id SpanID
+    for !id.IsValid() {
+        rand.Read(id[:])
+    }
+
+    return id
+}
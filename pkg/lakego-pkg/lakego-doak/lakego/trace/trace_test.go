@@ -0,0 +1,262 @@
+package trace
+
+import (
+    "time"
+    "errors"
+    "context"
+    "reflect"
+    "testing"
+    "net/http"
+    "encoding/json"
+    "net/http/httptest"
+)
+
+func assertEqualT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func assertErrorT(t *testing.T) func(error, string) {
+    return func(err error, msg string) {
+        if err != nil {
+            t.Errorf("Failed %s: error: %+v", msg, err)
+        }
+    }
+}
+
+func Test_Start(t *testing.T) {
+    eq := assertEqualT(t)
+
+    exporter := NewMemoryExporter()
+    tracer := NewTracer(NewSimpleProcessor(exporter)).
+        WithServiceName("test")
+
+    ctx, root := tracer.Start(context.Background(), "root", WithSpanKind(SpanKindServer))
+    _, child := tracer.Start(ctx, "child", WithSpanAttributes(map[string]any{
+        "k": "v",
+    }))
+
+    child.RecordError(errors.New("failed"))
+    child.End()
+    root.End()
+    root.End()
+
+    spans := exporter.Spans()
+    eq(len(spans), 2, "spans len")
+
+    childData, _ := exporter.FindSpan("child")
+    rootData, _ := exporter.FindSpan("root")
+
+    eq(childData.SpanContext.TraceID, rootData.SpanContext.TraceID, "same trace")
+    eq(childData.ParentSpanID, rootData.SpanContext.SpanID, "parent")
+    eq(rootData.ParentSpanID.IsValid(), false, "root parent")
+    eq(rootData.Kind, SpanKindServer, "kind")
+    eq(childData.Attributes["k"], "v", "attributes")
+    eq(childData.StatusCode, StatusError, "status")
+    eq(childData.StatusMessage, "failed", "status message")
+    eq(len(childData.Events), 1, "events")
+    eq(rootData.Resource[ServiceNameKey], "test", "service name")
+}
+
+func Test_NilSpan(t *testing.T) {
+    eq := assertEqualT(t)
+
+    var span *Span
+    span.SetAttribute("k", "v")
+    span.RecordError(errors.New("failed"))
+    span.End()
+
+    eq(span.IsRecording(), false, "IsRecording")
+    eq(span.SpanContext().IsValid(), false, "SpanContext")
+}
+
+func Test_Sample(t *testing.T) {
+    eq := assertEqualT(t)
+
+    exporter := NewMemoryExporter()
+    tracer := NewTracer(NewSimpleProcessor(exporter)).
+        WithSampleRatio(0)
+
+    ctx, span := tracer.Start(context.Background(), "root")
+    eq(span.SpanContext().IsValid(), true, "context valid")
+    eq(span.IsRecording(), false, "not recording")
+
+    _, child := tracer.Start(ctx, "child")
+    eq(child.SpanContext().TraceID, span.SpanContext().TraceID, "same trace")
+
+    child.End()
+    span.End()
+
+    eq(len(exporter.Spans()), 0, "not exported")
+
+    // 上游已采样时跟随上游
+    sc, _ := ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+    _, remote := tracer.Start(ContextWithRemoteSpanContext(context.Background(), sc), "remote")
+    remote.End()
+
+    eq(len(exporter.Spans()), 1, "parent sampled")
+}
+
+func Test_StartChild(t *testing.T) {
+    eq := assertEqualT(t)
+
+    exporter := NewMemoryExporter()
+    SetDefault(NewTracer(NewSimpleProcessor(exporter)))
+    defer SetDefault(nil)
+
+    _, span := StartChild(context.Background(), "orphan")
+    eq(span == nil, true, "no parent")
+
+    ctx, root := Start(context.Background(), "root")
+    _, child := StartChild(ctx, "child")
+    eq(child == nil, false, "with parent")
+
+    child.End()
+    root.End()
+
+    eq(len(exporter.Spans()), 2, "spans len")
+}
+
+func Test_Traceparent(t *testing.T) {
+    eq := assertEqualT(t)
+
+    value := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+    sc, err := ParseTraceparent(value)
+    eq(err, nil, "Parse")
+    eq(sc.TraceID.String(), "4bf92f3577b34da6a3ce929d0e0e4736", "TraceID")
+    eq(sc.SpanID.String(), "00f067aa0ba902b7", "SpanID")
+    eq(sc.Sampled, true, "Sampled")
+    eq(FormatTraceparent(sc), value, "Format")
+
+    invalid := []string{
+        "",
+        "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+        "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+        "00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+        "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+        "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+        "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+    }
+    for _, v := range invalid {
+        _, err := ParseTraceparent(v)
+        eq(err, ErrInvalidTraceparent, "invalid " + v)
+    }
+
+    // 后续版本可追加字段
+    _, err = ParseTraceparent("01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra")
+    eq(err, nil, "future version")
+
+    header := http.Header{}
+    header.Set(TraceparentHeader, value)
+
+    ctx := Extract(context.Background(), header)
+    eq(SpanContextFromContext(ctx).TraceID, sc.TraceID, "Extract")
+
+    out := http.Header{}
+    Inject(ctx, out)
+    eq(out.Get(TraceparentHeader), value, "Inject")
+}
+
+func Test_BatchProcessor(t *testing.T) {
+    eq := assertEqualT(t)
+
+    exporter := NewMemoryExporter()
+    processor := NewBatchProcessor(exporter, BatchOptions{
+        BatchSize: 2,
+        Interval:  time.Hour,
+    })
+
+    tracer := NewTracer(processor)
+    for i := 0; i < 3; i++ {
+        _, span := tracer.Start(context.Background(), "span")
+        span.End()
+    }
+
+    err := processor.ForceFlush(context.Background())
+    eq(err, nil, "ForceFlush")
+    eq(len(exporter.Spans()), 3, "flushed")
+
+    _, span := tracer.Start(context.Background(), "last")
+    span.End()
+
+    err = tracer.Shutdown(context.Background())
+    eq(err, nil, "Shutdown")
+    eq(len(exporter.Spans()), 4, "shutdown flushed")
+
+    _, span = tracer.Start(context.Background(), "dropped")
+    span.End()
+    eq(processor.Dropped(), int64(1), "dropped after shutdown")
+}
+
+func Test_OTLPExporter(t *testing.T) {
+    eq := assertEqualT(t)
+    assertError := assertErrorT(t)
+
+    var body map[string]any
+    var header http.Header
+
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        header = r.Header
+        json.NewDecoder(r.Body).Decode(&body)
+    }))
+    defer server.Close()
+
+    exporter := NewOTLPExporter(server.URL).
+        WithHeaders(map[string]string{
+            "Authorization": "Bearer token",
+        })
+
+    mem := NewMemoryExporter()
+    tracer := NewTracer(NewSimpleProcessor(mem)).WithServiceName("lakego-test")
+
+    ctx, root := tracer.Start(context.Background(), "root")
+    _, child := tracer.Start(ctx, "child", WithSpanAttributes(map[string]any{
+        "http.status_code": 200,
+    }))
+    child.End()
+    root.End()
+
+    err := exporter.ExportSpans(context.Background(), mem.Spans())
+    assertError(err, "ExportSpans")
+
+    eq(header.Get("Content-Type"), "application/json", "Content-Type")
+    eq(header.Get("Authorization"), "Bearer token", "Authorization")
+
+    resourceSpans := body["resourceSpans"].([]any)
+    eq(len(resourceSpans), 1, "resourceSpans")
+
+    resource := resourceSpans[0].(map[string]any)["resource"].(map[string]any)
+    attr := resource["attributes"].([]any)[0].(map[string]any)
+    eq(attr["key"], ServiceNameKey, "resource key")
+    eq(attr["value"], map[string]any{"stringValue": "lakego-test"}, "resource value")
+
+    scopeSpans := resourceSpans[0].(map[string]any)["scopeSpans"].([]any)
+    spans := scopeSpans[0].(map[string]any)["spans"].([]any)
+    eq(len(spans), 2, "spans")
+
+    first := spans[0].(map[string]any)
+    eq(first["name"], "child", "name")
+    eq(first["parentSpanId"], root.SpanContext().SpanID.String(), "parentSpanId")
+    eq(first["traceId"], root.SpanContext().TraceID.String(), "traceId")
+    eq(first["attributes"].([]any)[0].(map[string]any)["value"], map[string]any{"intValue": "200"}, "int attribute")
+}
+
+func Test_OTLPExporterError(t *testing.T) {
+    eq := assertEqualT(t)
+
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        w.WriteHeader(http.StatusBadRequest)
+        w.Write([]byte("bad request"))
+    }))
+    defer server.Close()
+
+    err := NewOTLPExporter(server.URL).ExportSpans(context.Background(), []SpanData{
+        {Name: "span"},
+    })
+
+    eq(err != nil, true, "ExportSpans error")
+}
@@ -0,0 +1,266 @@
+package trace
+
+import (
+    "sync"
+    "time"
+    "context"
+    "encoding/binary"
+)
+
+// 服务名称属性
+const ServiceNameKey = "service.name"
+
+/**
+ * 链路追踪
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Tracer struct {
+    // 处理器
+    processor Processor
+
+    // 采样比例
+    ratio float64
+
+    // 服务信息
+    resource map[string]any
+}
+
+// 构造函数
+func NewTracer(processor Processor) *Tracer {
+    return &Tracer{
+        processor: processor,
+        ratio:     1,
+        resource:  map[string]any{
+            ServiceNameKey: "lakego",
+        },
+    }
+}
+
+// 设置服务名称
+func (this *Tracer) WithServiceName(name string) *Tracer {
+    if name != "" {
+        this.resource[ServiceNameKey] = name
+    }
+
+    return this
+}
+
+// 设置服务信息
+func (this *Tracer) WithResource(attributes map[string]any) *Tracer {
+    for k, v := range attributes {
+        this.resource[k] = v
+    }
+
+    return this
+}
+
+// 设置采样比例，0-1，上游已采样时跟随上游
+func (this *Tracer) WithSampleRatio(ratio float64) *Tracer {
+    if ratio < 0 {
+        ratio = 0
+    } else if ratio > 1 {
+        ratio = 1
+    }
+
+    this.ratio = ratio
+
+    return this
+}
+
+// 处理器
+func (this *Tracer) GetProcessor() Processor {
+    return this.processor
+}
+
+// 开始节点，上下文中有节点时作为子节点
+func (this *Tracer) Start(ctx context.Context, name string, opts ...SpanOption) (context.Context, *Span) {
+    if ctx == nil {
+        ctx = context.Background()
+    }
+
+    conf := &spanConfig{
+        kind: SpanKindInternal,
+    }
+    for _, opt := range opts {
+        opt(conf)
+    }
+
+    parent := SpanContextFromContext(ctx)
+
+    sc := SpanContext{
+        SpanID: newSpanID(),
+    }
+
+    if parent.IsValid() {
+        sc.TraceID = parent.TraceID
+        sc.Sampled = parent.Sampled
+    } else {
+        sc.TraceID = newTraceID()
+        sc.Sampled = this.shouldSample(sc.TraceID)
+    }
+
+    attributes := make(map[string]any, len(conf.attributes))
+    for k, v := range conf.attributes {
+        attributes[k] = v
+    }
+
+    span := &Span{
+        tracer:    this,
+        recording: sc.Sampled,
+        data:      SpanData{
+            Name:         name,
+            Kind:         conf.kind,
+            SpanContext:  sc,
+            ParentSpanID: parent.SpanID,
+            StartTime:    time.Now(),
+            Attributes:   attributes,
+            Resource:     this.resource,
+        },
+    }
+
+    return ContextWithSpan(ctx, span), span
+}
+
+// 关闭，导出未导出的节点
+func (this *Tracer) Shutdown(ctx context.Context) error {
+    return this.processor.Shutdown(ctx)
+}
+
+// 导出
+func (this *Tracer) export(data SpanData) {
+    this.processor.OnEnd(data)
+}
+
+// 按链路 ID 采样，同一链路结果一致
+func (this *Tracer) shouldSample(id TraceID) bool {
+    if this.ratio >= 1 {
+        return true
+    }
+
+    if this.ratio <= 0 {
+        return false
+    }
+
+    value := binary.BigEndian.Uint64(id[8:]) >> 1
+
+    return value < uint64(this.ratio * (1 << 63))
+}
+
+// 节点设置
+type spanConfig struct {
+    // 类型
+    kind SpanKind
+
+    // 属性
+    attributes map[string]any
+}
+
+// 节点设置
+type SpanOption func(*spanConfig)
+
+// 设置节点类型
+func WithSpanKind(kind SpanKind) SpanOption {
+    return func(conf *spanConfig) {
+        conf.kind = kind
+    }
+}
+
+// 设置节点属性
+func WithSpanAttributes(attributes map[string]any) SpanOption {
+    return func(conf *spanConfig) {
+        conf.attributes = attributes
+    }
+}
+
+// ========
+
+// 上下文 key
+type spanKey struct{}
+
+// 添加节点到上下文
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+    return context.WithValue(ctx, spanKey{}, span)
+}
+
+// 添加上游节点上下文到上下文
+func ContextWithRemoteSpanContext(ctx context.Context, sc SpanContext) context.Context {
+    sc.Remote = true
+
+    return ContextWithSpan(ctx, &Span{
+        data: SpanData{
+            SpanContext: sc,
+        },
+    })
+}
+
+// 获取上下文中的节点，不存在时返回 nil
+func SpanFromContext(ctx context.Context) *Span {
+    if ctx == nil {
+        return nil
+    }
+
+    span, _ := ctx.Value(spanKey{}).(*Span)
+
+    return span
+}
+
+// 获取上下文中的节点上下文
+func SpanContextFromContext(ctx context.Context) SpanContext {
+    return SpanFromContext(ctx).SpanContext()
+}
+
+// ========
+
+// 默认链路
+var defaultTracer = struct {
+    sync.RWMutex
+    tracer *Tracer
+}{}
+
+// 设置默认链路，为 nil 时关闭追踪
+func SetDefault(tracer *Tracer) {
+    defaultTracer.Lock()
+    defer defaultTracer.Unlock()
+
+    defaultTracer.tracer = tracer
+}
+
+// 默认链路，未设置时返回 nil
+func Default() *Tracer {
+    defaultTracer.RLock()
+    defer defaultTracer.RUnlock()
+
+    return defaultTracer.tracer
+}
+
+// 使用默认链路开始节点，未开启追踪时返回 nil 节点
+func Start(ctx context.Context, name string, opts ...SpanOption) (context.Context, *Span) {
+    tracer := Default()
+    if tracer == nil {
+        return ctx, nil
+    }
+
+    return tracer.Start(ctx, name, opts...)
+}
+
+// 开始子节点，上下文中没有记录中的节点时不创建
+// 用于数据库、缓存等调用，避免产生大量单独的链路
+func StartChild(ctx context.Context, name string, opts ...SpanOption) (context.Context, *Span) {
+    if !SpanFromContext(ctx).IsRecording() {
+        return ctx, nil
+    }
+
+    return Start(ctx, name, opts...)
+}
+
+// 关闭默认链路
+func Shutdown(ctx context.Context) error {
+    tracer := Default()
+    if tracer == nil {
+        return nil
+    }
+
+    return tracer.Shutdown(ctx)
+}
@@ -4,16 +4,27 @@ import (
     "fmt"
     "strings"
 
+    "github.com/go-playground/locales/en"
     "github.com/go-playground/locales/zh"
     "github.com/go-playground/validator/v10"
     ut "github.com/go-playground/universal-translator"
+    en_trans "github.com/go-playground/validator/v10/translations/en"
     zh_trans "github.com/go-playground/validator/v10/translations/zh"
+
+    "github.com/deatil/lakego-doak/lakego/i18n"
+    "github.com/deatil/lakego-doak/lakego/router"
 )
 
 var (
     // 默认翻译
     defaultTrans = "zh"
 
+    // 已支持的翻译
+    defaultTranslations = map[string]func(*validator.Validate, ut.Translator) error{
+        "zh": zh_trans.RegisterDefaultTranslations,
+        "en": en_trans.RegisterDefaultTranslations,
+    }
+
     // 默认
     defaultValidate *validate
 
@@ -34,6 +45,10 @@ func init() {
 // 设置默认翻译
 func SetTrans(trans string) {
     defaultTrans = trans
+
+    if defaultValidate != nil {
+        defaultValidate = defaultValidate.WithLocale(trans)
+    }
 }
 
 // 添加验证器
@@ -87,40 +102,100 @@ func (this Errors) All() map[string]string {
  */
 type validate struct {
     validate *validator.Validate
+    uni      *ut.UniversalTranslator
     trans    ut.Translator
+
+    // 使用的语言，用于翻译 命名空间::键名 形式的错误提示
+    locale   string
 }
 
 // 初始化一个验证器
 func New() (cv *validate, err error) {
     v := validator.New()
     local := zh.New()
-    uniTrans := ut.New(local, local)
+    uniTrans := ut.New(local, local, en.New())
 
-    translator, _ := uniTrans.GetTranslator(defaultTrans)
+    for name, registerFn := range defaultTranslations {
+        translator, _ := uniTrans.GetTranslator(name)
 
-    // 批量注册参数验证表达式
-    for i := range validations {
-        validation := validations[i]
-        err = validation.Register(v, translator)
+        // 批量注册参数验证表达式
+        for i := range validations {
+            validation := validations[i]
+            err = validation.Register(v, translator)
+            if err != nil {
+                return
+            }
+        }
+
+        // 注册默认翻译
+        err = registerFn(v, translator)
         if err != nil {
             return
         }
     }
 
-    // RegisterTranslation chinese as default translators for validate.
-    err = zh_trans.RegisterDefaultTranslations(v, translator)
-    if err != nil {
-        return
-    }
+    translator, _ := uniTrans.GetTranslator(defaultTrans)
 
     cv = &validate{
         validate: v,
+        uni:      uniTrans,
         trans:    translator,
     }
 
     return
 }
 
+// 使用指定语言的错误提示，如 en、zh-CN，没有该语言时使用默认翻译
+func (this *validate) WithLocale(locale string) *validate {
+    cv := &validate{
+        validate: this.validate,
+        uni:      this.uni,
+        trans:    this.trans,
+        locale:   locale,
+    }
+
+    name := strings.Replace(locale, "-", "_", -1)
+    names := []string{name}
+    if index := strings.Index(name, "_"); index > 0 {
+        names = append(names, strings.ToLower(name[:index]))
+    }
+
+    if translator, found := this.uni.FindTranslator(names...); found {
+        cv.trans = translator
+    }
+
+    return cv
+}
+
+// 使用请求语言的错误提示
+func (this *validate) WithContext(ctx *router.Context) *validate {
+    return this.WithLocale(i18n.Locale(ctx))
+}
+
+// 自定义错误提示，命名空间::键名 形式时使用语言包翻译
+func (this *validate) message(str string) string {
+    if !strings.Contains(str, i18n.NamespaceSeparator) {
+        return str
+    }
+
+    locale := this.locale
+    if locale == "" {
+        locale = i18n.Default().GetFallback()
+    }
+
+    return i18n.Default().Trans(locale, str)
+}
+
+// 使用指定语言的验证器
+func Locale(locale string) *validate {
+    return defaultValidate.WithLocale(locale)
+}
+
+// 使用请求语言的验证器
+func WithContext(ctx *router.Context) *validate {
+    return defaultValidate.WithContext(ctx)
+}
+
 // 获取验证器
 func (this *validate) GetValidate() *validator.Validate {
     return this.validate
@@ -160,6 +235,7 @@ func (this *validate) Validate(
             }
 
             if str, ok := message[field + "." + tag]; ok {
+                str = this.message(str)
                 str = strings.Replace(str, ":namespace", namespace, -1)
                 str = strings.Replace(str, ":field", field, -1)
                 str = strings.Replace(str, ":structNamespace", structNamespace, -1)
@@ -243,6 +319,7 @@ func (this *validate) ValidateMap(
                     }
 
                     if str, ok := message[field + "." + tag]; ok {
+                        str = this.message(str)
                         str = strings.Replace(str, ":field", field, -1)
                         str = strings.Replace(str, ":value", value, -1)
                         str = strings.Replace(str, ":tag", tag, -1)
@@ -338,21 +415,27 @@ validation := Validation{
 func registerValidations() {
     validations = append(validations,
         // 国内手机号码
-        ValidationOfRegexp("phone", "^1[0-9]{10}$", "{0} 必须是手机号码"),
+        ValidationOfRegexp("phone", "^1[0-9]{10}$", "{0} 必须是手机号码").
+            WithTranslation("en", "{0} must be a valid phone number"),
 
         // 常规用户名
-        ValidationOfRegexp("username", "^[a-zA-Z][a-zA-Z0-9_]{4,15}$", "{0} 必须只包含大小写字母, 数字, 下划线, 且长度为 4-15"),
+        ValidationOfRegexp("username", "^[a-zA-Z][a-zA-Z0-9_]{4,15}$", "{0} 必须只包含大小写字母, 数字, 下划线, 且长度为 4-15").
+            WithTranslation("en", "{0} must start with a letter and contain only letters, numbers and underscores, 5-16 characters"),
 
         // 标准域名
-        ValidationOfRegexp("domain", "[a-zA-Z0-9][-a-zA-Z0-9]{0,62}(/.[a-zA-Z0-9][-a-zA-Z0-9]{0,62})+/.?", "{0} 必须是标准域名"),
+        ValidationOfRegexp("domain", "[a-zA-Z0-9][-a-zA-Z0-9]{0,62}(/.[a-zA-Z0-9][-a-zA-Z0-9]{0,62})+/.?", "{0} 必须是标准域名").
+            WithTranslation("en", "{0} must be a valid domain"),
 
         // 强密码
-        ValidationOfRegexp("strong_password", "^[a-zA-Z][a-zA-Z0-9_]{8,}$", "{0} 必须包含写字母和数字, 且长度为 8-16"),
+        ValidationOfRegexp("strong_password", "^[a-zA-Z][a-zA-Z0-9_]{8,}$", "{0} 必须包含写字母和数字, 且长度为 8-16").
+            WithTranslation("en", "{0} must contain letters and numbers, 8-16 characters"),
 
         // 中国邮政编码
-        ValidationOfRegexp("cn_postal_code", `[0-8][0-7]\d{4}`, "{0} 必须是中国邮政编码"),
+        ValidationOfRegexp("cn_postal_code", `[0-8][0-7]\d{4}`, "{0} 必须是中国邮政编码").
+            WithTranslation("en", "{0} must be a valid Chinese postal code"),
 
         // 中国大陆身份证号
-        ValidationOfRegexp("cn_id_number", `^\d{15}|\d{18}$`, "{0} 必须是中国身份证号码"),
+        ValidationOfRegexp("cn_id_number", `^\d{15}|\d{18}$`, "{0} 必须是中国身份证号码").
+            WithTranslation("en", "{0} must be a valid Chinese ID number"),
     )
 }
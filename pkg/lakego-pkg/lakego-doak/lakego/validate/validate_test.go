@@ -95,3 +95,36 @@ func Test_ValidateError(t *testing.T) {
         t.Error("Status required should empty")
     }
 }
+
+func Test_Locale(t *testing.T) {
+    data := struct{
+        Title string `validate:"required"`
+        Phone string `validate:"phone"`
+    }{
+        Phone: "13000000000",
+    }
+
+    _, zhErr := ValidateError(data, nil)
+    _, enErr := Locale("en-US").ValidateError(data, nil)
+
+    if enErr != "Title is a required field" {
+        t.Errorf("en error got %s", enErr)
+    }
+
+    if zhErr == enErr {
+        t.Errorf("zh error should not be english, got %s", zhErr)
+    }
+
+    _, unknownErr := Locale("xx").ValidateError(data, nil)
+    if unknownErr != zhErr {
+        t.Errorf("unknown locale should use default, got %s", unknownErr)
+    }
+
+    data.Title = "title"
+    data.Phone = "123"
+
+    _, phoneErr := Locale("en").ValidateError(data, nil)
+    if phoneErr != "Phone must be a valid phone number" {
+        t.Errorf("custom validation en error got %s", phoneErr)
+    }
+}
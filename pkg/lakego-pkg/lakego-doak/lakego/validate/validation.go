@@ -17,6 +17,8 @@ type Validation struct {
     Tag           string
     // 表示该标 Validate 的描述/解释
     Translation   string
+    // 其他语言的描述，键名为语言，如 en
+    Translations  map[string]string
     // 是否覆盖已存在的验证器
     Override      bool
     // 用于验证字段的函数
@@ -46,20 +48,44 @@ func (this *Validation) Register(v *validator.Validate, t ut.Translator) (err er
 
 // 以下方法支持
 func (this *Validation) RegisterTranslation(v *validator.Validate, t ut.Translator) (err error) {
+    translation := this.TranslationFor(t.Locale())
+
     switch {
         case this.TranslationFn != nil && this.RegisterFn != nil:
             err = v.RegisterTranslation(this.Tag, t, this.RegisterFn, this.TranslationFn)
         case this.TranslationFn != nil && this.RegisterFn == nil:
-            err = v.RegisterTranslation(this.Tag, t, registrationFunc(this.Tag, this.Translation, this.Override), this.TranslationFn)
+            err = v.RegisterTranslation(this.Tag, t, registrationFunc(this.Tag, translation, this.Override), this.TranslationFn)
         case this.TranslationFn == nil && this.RegisterFn != nil:
             err = v.RegisterTranslation(this.Tag, t, this.RegisterFn, translateFunc)
         default:
-            err = v.RegisterTranslation(this.Tag, t, registrationFunc(this.Tag, this.Translation, this.Override), translateFunc)
+            err = v.RegisterTranslation(this.Tag, t, registrationFunc(this.Tag, translation, this.Override), translateFunc)
     }
 
     return
 }
 
+// 添加其他语言的描述
+func (this Validation) WithTranslation(locale string, translation string) Validation {
+    translations := make(map[string]string)
+    for k, v := range this.Translations {
+        translations[k] = v
+    }
+
+    translations[locale] = translation
+    this.Translations = translations
+
+    return this
+}
+
+// 语言对应的描述，没有时使用默认描述
+func (this *Validation) TranslationFor(locale string) string {
+    if translation, ok := this.Translations[locale]; ok {
+        return translation
+    }
+
+    return this.Translation
+}
+
 // 创建正则验证器
 func ValidationOfRegexp(tag string, regex string, translation string) Validation {
     re, err := regexp.Compile(regex)
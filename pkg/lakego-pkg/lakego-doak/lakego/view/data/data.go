@@ -0,0 +1,62 @@
+package data
+
+import (
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+// 上下文中的名称
+const contextKey = "lakego.view-data"
+
+// 添加当前请求的模板数据，如 CSP nonce 和 CSRF token
+func Share(ctx *router.Context, key string, value any) {
+    data := All(ctx)
+    data[key] = value
+
+    ctx.Set(contextKey, data)
+}
+
+// 当前请求的模板数据
+func All(ctx *router.Context) map[string]any {
+    data := make(map[string]any)
+
+    if old, ok := ctx.Get(contextKey); ok {
+        if shared, ok := old.(map[string]any); ok {
+            for k, v := range shared {
+                data[k] = v
+            }
+        }
+    }
+
+    return data
+}
+
+// 合并到模板数据，模板数据中已有的值不覆盖
+func Merge(ctx *router.Context, obj any) any {
+    shared := All(ctx)
+    if len(shared) == 0 {
+        return obj
+    }
+
+    var data map[string]any
+    switch v := obj.(type) {
+        case nil:
+            data = make(map[string]any)
+        case map[string]any:
+            data = v
+        case router.H:
+            data = v
+        default:
+            return obj
+    }
+
+    merged := make(map[string]any, len(data) + len(shared))
+    for k, v := range shared {
+        merged[k] = v
+    }
+
+    for k, v := range data {
+        merged[k] = v
+    }
+
+    return merged
+}
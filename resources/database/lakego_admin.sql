@@ -22,6 +22,7 @@ CREATE TABLE `pre__admin` (
   `email` varchar(100) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
   `avatar` char(36) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
   `introduce` mediumtext COLLATE utf8mb4_unicode_ci,
+  `language` varchar(20) COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT '语言',
   `is_root` tinyint(1) DEFAULT NULL,
   `status` tinyint(1) NOT NULL,
   `refresh_time` int(10) NOT NULL DEFAULT '0' COMMENT '刷新时间',
@@ -142,7 +143,60 @@ CREATE TABLE `pre__extension` (
   KEY `name` (`name`)
 ) ENGINE=MyISAM DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=COMPACT COMMENT='已安装模块列表';
 
+DROP TABLE IF EXISTS `pre__schedule_job`;
+CREATE TABLE `pre__schedule_job` (
+  `id` char(36) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `name` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '任务名称',
+  `spec` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '计划时间',
+  `command` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '执行的脚本',
+  `args` varchar(500) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '脚本参数',
+  `without_overlapping` tinyint(1) DEFAULT '0' COMMENT '不重叠运行',
+  `one_server` tinyint(1) DEFAULT '0' COMMENT '只在一台服务器运行',
+  `timeout` int(10) DEFAULT '0' COMMENT '超时时间，单位秒',
+  `description` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT '' COMMENT '描述',
+  `status` tinyint(1) DEFAULT '1' COMMENT '状态',
+  `update_time` int(10) DEFAULT '0' COMMENT '更新时间',
+  `update_ip` varchar(50) COLLATE utf8mb4_unicode_ci DEFAULT '' COMMENT '更新IP',
+  `add_time` int(10) DEFAULT '0' COMMENT '添加时间',
+  `add_ip` varchar(50) COLLATE utf8mb4_unicode_ci DEFAULT '' COMMENT '添加ip',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `name` (`name`)
+) ENGINE=MyISAM DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=COMPACT COMMENT='计划任务';
+
+
+DROP TABLE IF EXISTS `pre__jobs`;
+CREATE TABLE `pre__jobs` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `queue` varchar(191) COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '队列',
+  `name` varchar(191) COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '任务名称',
+  `payload` longblob COMMENT '任务数据',
+  `attempts` bigint(20) NOT NULL DEFAULT '0' COMMENT '执行次数',
+  `max_attempts` bigint(20) NOT NULL DEFAULT '0' COMMENT '最大执行次数',
+  `timeout` bigint(20) NOT NULL DEFAULT '0' COMMENT '超时时间',
+  `error` text COLLATE utf8mb4_unicode_ci COMMENT '错误信息',
+  `reserved_at` bigint(20) NOT NULL DEFAULT '0' COMMENT '保留时间',
+  `available_at` bigint(20) NOT NULL DEFAULT '0' COMMENT '可执行时间',
+  `created_at` bigint(20) NOT NULL DEFAULT '0' COMMENT '添加时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_queue_available` (`queue`,`available_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=COMPACT COMMENT='队列任务';
+
+DROP TABLE IF EXISTS `pre__failed_jobs`;
+CREATE TABLE `pre__failed_jobs` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `queue` varchar(191) COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '队列',
+  `name` varchar(191) COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '任务名称',
+  `payload` longblob COMMENT '任务数据',
+  `attempts` bigint(20) NOT NULL DEFAULT '0' COMMENT '执行次数',
+  `max_attempts` bigint(20) NOT NULL DEFAULT '0' COMMENT '最大执行次数',
+  `timeout` bigint(20) NOT NULL DEFAULT '0' COMMENT '超时时间',
+  `error` text COLLATE utf8mb4_unicode_ci COMMENT '错误信息',
+  `created_at` bigint(20) NOT NULL DEFAULT '0' COMMENT '添加时间',
+  `failed_at` bigint(20) NOT NULL DEFAULT '0' COMMENT '失败时间',
+  PRIMARY KEY (`id`),
+  KEY `queue` (`queue`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=COMPACT COMMENT='队列失败任务';
 
-INSERT INTO `pre__admin` VALUES ('01cabd82-060d-405f-ba47-4d79fc47efcf','lakego','8966aff5289184448a004af81373c8f9','gazqzd','lakego','lakego@admin.com','5acfcd19-3a4c-4a28-8386-ae877952fd11','lakego-admin 是基于 gin、jwt 和 rbac 的 go 后台管理系统',0,1,0,'',1652759635,'127.0.0.1',1652587697,'127.0.0.1',1652545221,'127.0.0.1'),('642eb7b3-91ea-4808-bba6-f5f10938929a','admin','2a9b6b430ebe2f4257639e62ff9321bb','chNI7n','管理员','lakego-admin@admin.com','1f3cd4fb-f7e4-4b41-8663-167ca23ea5ab','lakego-admin 是基于 gin、jwt 和 rbac 的 go 后台管理系统',1,1,0,'',1675937003,'127.0.0.1',1652587697,'127.0.0.1',1652545221,'127.0.0.1');
+INSERT INTO `pre__admin` VALUES ('01cabd82-060d-405f-ba47-4d79fc47efcf','lakego','8966aff5289184448a004af81373c8f9','gazqzd','lakego','lakego@admin.com','5acfcd19-3a4c-4a28-8386-ae877952fd11','lakego-admin 是基于 gin、jwt 和 rbac 的 go 后台管理系统',NULL,0,1,0,'',1652759635,'127.0.0.1',1652587697,'127.0.0.1',1652545221,'127.0.0.1'),('642eb7b3-91ea-4808-bba6-f5f10938929a','admin','2a9b6b430ebe2f4257639e62ff9321bb','chNI7n','管理员','lakego-admin@admin.com','1f3cd4fb-f7e4-4b41-8663-167ca23ea5ab','lakego-admin 是基于 gin、jwt 和 rbac 的 go 后台管理系统',NULL,1,1,0,'',1675937003,'127.0.0.1',1652587697,'127.0.0.1',1652545221,'127.0.0.1');
 INSERT INTO `pre__auth_group` VALUES ('277cbc81-be2c-4fab-9240-5feccb2c024c','0','管理员组','账号管理员组',105,1,1656389180,'127.0.0.1',1621431751,'127.0.0.1'),('bcf40e54-4802-45b4-b3e6-7021ec755083','0','超级管理员组','拥有全部管理权限',95,1,1652586071,'127.0.0.1',1621431751,'127.0.0.1');
 INSERT INTO `pre__auth_group_access` VALUES ('01cabd82-060d-405f-ba47-4d79fc47efcf','277cbc81-be2c-4fab-9240-5feccb2c024c'),('642eb7b3-91ea-4808-bba6-f5f10938929a','277cbc81-be2c-4fab-9240-5feccb2c024c');
@@ -0,0 +1,3 @@
+-- 已安装的系统升级：管理员表添加语言字段
+-- 执行前将 pre__ 替换为配置的数据表前缀
+ALTER TABLE `pre__admin` ADD COLUMN `language` varchar(20) COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT '语言' AFTER `introduce`;